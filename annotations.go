@@ -0,0 +1,67 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// featureAnnotation lets SDK code declare the features it implements, in a
+// comment anywhere in the file:
+//
+//	// qb:feature pagination
+//	/** @qbFeature pagination, retry */
+//	// feature: pagination (the original form, still accepted)
+var featureAnnotation = regexp.MustCompile(`(?m)(?:^[ \t]*(?://|#|/?\*+)[ \t]*(?:qb:feature[ \t]+|feature:[ \t]*)|(?:^|[ \t*])@qbFeature[ \t]+)([A-Za-z0-9][\w-]*(?:[ \t]*,[ \t]*[A-Za-z0-9][\w-]*)*)`)
+
+// featureAnnotations returns the feature names a file declares.
+func featureAnnotations(data []byte) []string {
+	var names []string
+	for _, m := range featureAnnotation.FindAllSubmatch(data, -1) {
+		for _, name := range strings.Split(string(m[1]), ",") {
+			if name = strings.TrimSpace(name); name != "" && !containsString(names, name) {
+				names = append(names, name)
+			}
+		}
+	}
+	return names
+}
+
+// annotateFeatureMap adds annotated files to the feature map. A file joins
+// the entry whose name matches ignoring case, dashes and underscores
+// (qb:feature temp_token is temp-token); names not in the map become new
+// entries. Generated files are skipped.
+func annotateFeatureMap(features map[string]featureEntry) {
+	byKey := map[string]string{}
+	for name := range features {
+		byKey[normalizeTerm(name)] = name
+	}
+	for i, side := range inventorySides() {
+		for _, rel := range side.files {
+			data, err := os.ReadFile(filepath.Join(side.root, rel))
+			if err != nil || isGenerated(data) {
+				continue
+			}
+			for _, name := range featureAnnotations(data) {
+				if existing, ok := byKey[normalizeTerm(name)]; ok {
+					name = existing
+				} else {
+					byKey[normalizeTerm(name)] = name
+					features[name] = featureEntry{source: "annotations"}
+				}
+				entry := features[name]
+				if entry.annotated == nil {
+					entry.annotated = map[string]bool{}
+				}
+				if i == 0 && !containsString(entry.JS, rel) {
+					entry.JS = append(entry.JS, rel)
+				} else if i == 1 && !containsString(entry.Go, rel) {
+					entry.Go = append(entry.Go, rel)
+				}
+				entry.annotated[rel] = true
+				features[name] = entry
+			}
+		}
+	}
+}
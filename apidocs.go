@@ -0,0 +1,359 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"gopkg.in/yaml.v3"
+)
+
+// fileSlug turns a name into a lowercase, dash-separated file name part.
+func fileSlug(name string) string {
+	slug := strings.Map(func(r rune) rune {
+		if r >= 'a' && r <= 'z' || r >= '0' && r <= '9' {
+			return r
+		}
+		return '-'
+	}, strings.ToLower(name))
+	return strings.Trim(slug, "-")
+}
+
+// docCell makes text safe for a markdown table cell.
+func docCell(s string) string {
+	s = strings.Join(strings.Fields(s), " ")
+	return strings.ReplaceAll(s, "|", `\|`)
+}
+
+// snippetCall cuts the call out of a full example program: from the line
+// starting with start up to the one starting with end, dedented. Pages
+// show the client setup once instead.
+func snippetCall(code, start, end string) string {
+	from := strings.Index(code, start)
+	if from < 0 {
+		return code
+	}
+	code = code[from:]
+	if to := strings.Index(code, "\n"+end); to >= 0 {
+		code = code[:to+1]
+	}
+	lines := strings.Split(code, "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimPrefix(line, "\t")
+	}
+	return strings.Join(lines, "\n")
+}
+
+// docPage is one reference page: a tag and its operations.
+type docPage struct {
+	title, description string
+	ops                []specOperation
+}
+
+// docsWriter renders reference pages. Component schemas used on a page are
+// collected as it's written and documented at the end of the page, so
+// every type link resolves within the page.
+type docsWriter struct {
+	e        exampleValue
+	snippets bool
+	jsIndex  map[string]sdkMethod
+	goIndex  map[string]sdkMethod
+	schemas  []string
+	seen     map[string]bool
+}
+
+func (d *docsWriter) use(name string) string {
+	if !d.seen[name] {
+		d.seen[name] = true
+		d.schemas = append(d.schemas, name)
+	}
+	return fmt.Sprintf("[%s](#%s)", name, strings.ToLower(name))
+}
+
+// typeLabel describes a schema's type, linking component schemas.
+func (d *docsWriter) typeLabel(schema *yaml.Node) string {
+	if schema == nil {
+		return "any"
+	}
+	if _, ref := resolveRef(d.e.root, schema); ref != "" {
+		return d.use(ref)
+	}
+	flat, _ := d.e.flatten(schema)
+	switch schemaType(flat) {
+	case "array":
+		return d.typeLabel(mappingValue(flat, "items")) + "[]"
+	case "object":
+		if value := mappingValue(flat, "additionalProperties"); value != nil && value.Kind == yaml.MappingNode && mappingValue(flat, "properties") == nil {
+			return "map of " + d.typeLabel(value)
+		}
+		return "object"
+	case "":
+		return "any"
+	}
+	if f := scalarAt(flat, "format"); f != "" {
+		return fmt.Sprintf("%s (%s)", schemaType(flat), f)
+	}
+	return schemaType(flat)
+}
+
+// fieldNotes is a property's description with its enum and default.
+func (d *docsWriter) fieldNotes(schema *yaml.Node) string {
+	flat, _ := d.e.flatten(schema)
+	notes := []string{strings.TrimSpace(scalarAt(flat, "description"))}
+	if enum := stringList(mappingValue(flat, "enum")); len(enum) > 0 {
+		notes = append(notes, "One of: `"+strings.Join(enum, "`, `")+"`.")
+	}
+	if def := scalarAt(flat, "default"); def != "" {
+		notes = append(notes, "Default: `"+def+"`.")
+	}
+	if scalarAt(flat, "deprecated") == "true" {
+		notes = append(notes, "**Deprecated.**")
+	}
+	return docCell(strings.Join(notes, " "))
+}
+
+// fieldTable documents an object schema's properties, or its type when it
+// has none.
+func (d *docsWriter) fieldTable(b *strings.Builder, schema *yaml.Node) {
+	flat, _ := d.e.flatten(schema)
+	fields := schemaFields(flat)
+	if len(fields) == 0 {
+		b.WriteString(fmt.Sprintf("Type: %s\n\n", d.typeLabel(schema)))
+		return
+	}
+	b.WriteString("| Field | Type | Required | Description |\n|---|---|---|---|\n")
+	for _, f := range fields {
+		required := ""
+		if f.required {
+			required = "yes"
+		}
+		b.WriteString(fmt.Sprintf("| `%s` | %s | %s | %s |\n", f.name, d.typeLabel(f.schema), required, d.fieldNotes(f.schema)))
+	}
+	b.WriteString("\n")
+}
+
+// operation writes one operation's section.
+func (d *docsWriter) operation(b *strings.Builder, op specOperation) {
+	root := d.e.root
+	title := op.OperationID
+	if title == "" {
+		title = op.Method + " " + op.Path
+	}
+	b.WriteString(fmt.Sprintf("## %s\n\n`%s %s`\n\n", title, op.Method, op.Path))
+	if op.Deprecated {
+		b.WriteString("> ⚠️ **Deprecated.**\n\n")
+	} else if why := stability(op); why != "" {
+		b.WriteString(fmt.Sprintf("> 🧪 **Beta** (%s).\n\n", why))
+	}
+	if op.Summary != "" {
+		b.WriteString(op.Summary + "\n\n")
+	}
+	if desc := strings.TrimSpace(scalarAt(op.Node, "description")); desc != "" && desc != op.Summary {
+		b.WriteString(desc + "\n\n")
+	}
+
+	if params := operationParameters(root, op); len(params) > 0 {
+		b.WriteString("### Parameters\n\n| Name | In | Type | Required | Description |\n|---|---|---|---|---|\n")
+		for _, p := range params {
+			required := ""
+			if scalarAt(p, "required") == "true" || scalarAt(p, "in") == "path" {
+				required = "yes"
+			}
+			b.WriteString(fmt.Sprintf("| `%s` | %s | %s | %s | %s |\n", scalarAt(p, "name"), scalarAt(p, "in"), d.typeLabel(mappingValue(p, "schema")), required, docCell(scalarAt(p, "description"))))
+		}
+		b.WriteString("\n")
+	}
+
+	inputs, body := operationInputs(root, op)
+	if body != nil {
+		b.WriteString("### Request body\n\n")
+		if _, ref := resolveRef(root, body); ref != "" {
+			b.WriteString(fmt.Sprintf("%s\n\n", d.use(ref)))
+		} else {
+			d.fieldTable(b, body)
+		}
+	}
+
+	if responses := mappingValue(op.Node, "responses"); responses != nil {
+		b.WriteString("### Responses\n\n| Status | Description | Body |\n|---|---|---|\n")
+		for _, status := range mappingKeys(responses) {
+			resp, _ := resolveRef(root, mappingValue(responses, status))
+			bodyType := "—"
+			if schema := mappingValue(mappingValue(mappingValue(resp, "content"), "application/json"), "schema"); schema != nil {
+				bodyType = d.typeLabel(schema)
+			}
+			b.WriteString(fmt.Sprintf("| %s | %s | %s |\n", status, docCell(scalarAt(resp, "description")), bodyType))
+		}
+		b.WriteString("\n")
+	}
+
+	if d.snippets && op.OperationID != "" {
+		jsMethod, goMethod := lowerFirst(op.OperationID), goFieldName(op.OperationID)
+		if m := lookupOperation(d.jsIndex, op.OperationID); m.found {
+			jsMethod = m.name
+		}
+		if m := lookupOperation(d.goIndex, op.OperationID); m.found {
+			goMethod = m.name
+		}
+		b.WriteString("### Usage\n\n")
+		b.WriteString(fmt.Sprintf("```typescript\n%s```\n\n", snippetCall(jsEndpointExample(d.e, jsMethod, inputs, body, false), "const result", "console.log")))
+		b.WriteString(fmt.Sprintf("```go\n%s```\n\n", snippetCall(goEndpointExample(d.e, op, goMethod, inputs, body, false), "\tresult, err", "\tfmt.Printf")))
+	}
+}
+
+// page renders a tag's reference page, ending with the schemas it uses.
+func (d *docsWriter) page(p docPage) string {
+	d.schemas, d.seen = nil, map[string]bool{}
+	var b strings.Builder
+	b.WriteString(fmt.Sprintf("# %s\n\n", p.title))
+	if p.description != "" {
+		b.WriteString(strings.TrimSpace(p.description) + "\n\n")
+	}
+	for _, op := range p.ops {
+		b.WriteString(fmt.Sprintf("- [%s](#%s) — `%s %s`\n", op.OperationID, strings.ToLower(op.OperationID), op.Method, op.Path))
+	}
+	b.WriteString("\n")
+	if d.snippets {
+		b.WriteString("Usage snippets assume a client set up like this:\n\n")
+		b.WriteString(fmt.Sprintf("```typescript\nimport { createClient } from 'quickbase-js';\n\nconst qb = createClient({\n  realm: '%s',\n  auth: { type: 'user-token', userToken: process.env.%s! },\n});\n```\n\n", d.e.cfg.Realm, d.e.cfg.UserTokenEnv))
+		b.WriteString(fmt.Sprintf("```go\nclient, err := quickbase.New(%q, quickbase.WithUserToken(os.Getenv(%q)))\nif err != nil {\n\tlog.Fatal(err)\n}\nctx := context.Background()\n```\n\n", d.e.cfg.Realm, d.e.cfg.UserTokenEnv))
+	}
+	for _, op := range p.ops {
+		d.operation(&b, op)
+	}
+
+	schemas := mappingValue(mappingValue(d.e.root, "components"), "schemas")
+	if len(d.schemas) > 0 {
+		b.WriteString("## Schemas\n\n")
+	}
+	// Documenting a schema can link (and so queue) more of them
+	for i := 0; i < len(d.schemas); i++ {
+		name := d.schemas[i]
+		schema := mappingValue(schemas, name)
+		b.WriteString(fmt.Sprintf("### %s\n\n", name))
+		if desc := strings.TrimSpace(scalarAt(schema, "description")); desc != "" {
+			b.WriteString(desc + "\n\n")
+		}
+		d.fieldTable(&b, schema)
+	}
+	return strings.TrimRight(b.String(), "\n") + "\n"
+}
+
+// docPages groups operations into pages by their first tag, in the order
+// of the spec's tag list; untagged operations go on an "Other" page.
+func docPages(root *yaml.Node) []docPage {
+	var pages []docPage
+	index := map[string]int{}
+	add := func(title, description string) {
+		index[title] = len(pages)
+		pages = append(pages, docPage{title: title, description: description})
+	}
+	if tags := mappingValue(root, "tags"); tags != nil {
+		for _, t := range tags.Content {
+			add(scalarAt(t, "name"), scalarAt(t, "description"))
+		}
+	}
+	for _, op := range specOperations(root) {
+		tag := "Other"
+		if len(op.Tags) > 0 {
+			tag = op.Tags[0]
+		}
+		if _, ok := index[tag]; !ok {
+			add(tag, "")
+		}
+		pages[index[tag]].ops = append(pages[index[tag]].ops, op)
+	}
+	var kept []docPage
+	for _, p := range pages {
+		if len(p.ops) > 0 {
+			kept = append(kept, p)
+		}
+	}
+	return kept
+}
+
+func (s *QuickBasePersonalMCPServer) handleGenerateAPIDocs(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	var params struct {
+		Tag      string `json:"tag"`
+		Snippets bool   `json:"snippets"`
+		Save     bool   `json:"save"`
+	}
+	argsData, _ := json.Marshal(request.Params.Arguments)
+	if err := json.Unmarshal(argsData, &params); err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Invalid parameters: %v", err)), nil
+	}
+
+	root, err := loadSpec()
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to load spec: %v", err)), nil
+	}
+	cfg, err := loadConfig()
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to load config: %v", err)), nil
+	}
+	pages := docPages(root)
+	if params.Tag != "" {
+		var titles []string
+		var match []docPage
+		for _, p := range pages {
+			titles = append(titles, p.title)
+			if strings.EqualFold(p.title, params.Tag) {
+				match = append(match, p)
+			}
+		}
+		if len(match) == 0 {
+			return mcp.NewToolResultError(fmt.Sprintf("No operations are tagged %q (tags: %s)", params.Tag, strings.Join(titles, ", "))), nil
+		}
+		pages = match
+	}
+
+	d := &docsWriter{e: exampleValue{root: root, cfg: cfg}, snippets: params.Snippets}
+	if params.Snippets {
+		d.jsIndex = sdkMethodIndex(quickbaseJSPath, isJSSource, false)
+		d.goIndex = sdkMethodIndex(quickbaseGoPath, isGoSource, true)
+	}
+	rendered := make([]string, len(pages))
+	for i, p := range pages {
+		rendered[i] = d.page(p)
+	}
+
+	if !params.Save {
+		return mcp.NewToolResultText(strings.Join(rendered, "\n---\n\n")), nil
+	}
+
+	// One file per tag plus an index linking them
+	dir := filepath.Join(os.TempDir(), "quickbase-api-docs")
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to create %s: %v", dir, err)), nil
+	}
+	var index strings.Builder
+	title := scalarAt(mappingValue(root, "info"), "title")
+	if title == "" {
+		title = "API Reference"
+	}
+	index.WriteString(fmt.Sprintf("# %s\n\n", title))
+	if version := scalarAt(mappingValue(root, "info"), "version"); version != "" {
+		index.WriteString(fmt.Sprintf("Version %s\n\n", version))
+	}
+	var results strings.Builder
+	results.WriteString(fmt.Sprintf("# API Docs\n\nWrote %d page(s) to `%s`:\n\n", len(pages), dir))
+	for i, p := range pages {
+		name := fileSlug(p.title) + ".md"
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(rendered[i]), 0o644); err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("Failed to write %s: %v", name, err)), nil
+		}
+		index.WriteString(fmt.Sprintf("- [%s](%s) (%d operation(s))\n", p.title, name, len(p.ops)))
+		results.WriteString(fmt.Sprintf("- %s (%d operation(s))\n", name, len(p.ops)))
+	}
+	if params.Tag == "" {
+		if err := os.WriteFile(filepath.Join(dir, "README.md"), []byte(index.String()), 0o644); err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("Failed to write README.md: %v", err)), nil
+		}
+		results.WriteString("- README.md (index)\n")
+	}
+	return mcp.NewToolResultText(results.String()), nil
+}
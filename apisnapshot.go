@@ -0,0 +1,566 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+const apiSnapshotBucket = "api_snapshots"
+
+// apiSnapshot is an SDK's exported API at one commit: each entry is a
+// kind and qualified name ("func client.NewClient", "field Options.realm")
+// mapped to its shape, with parameter names left out so renaming one
+// isn't a change.
+type apiSnapshot struct {
+	Repo    string            `json:"repo"`
+	Ref     string            `json:"ref"`
+	Commit  string            `json:"commit"`
+	Source  string            `json:"source"`
+	Entries map[string]string `json:"entries"`
+	Taken   time.Time         `json:"taken"`
+}
+
+// goFieldTypes lists a field list's types, once per name, so "a, b int"
+// and "a int, b int" read the same.
+func goFieldTypes(fields *ast.FieldList) []string {
+	if fields == nil {
+		return nil
+	}
+	var out []string
+	for _, f := range fields.List {
+		n := len(f.Names)
+		if n == 0 {
+			n = 1
+		}
+		for i := 0; i < n; i++ {
+			out = append(out, types.ExprString(f.Type))
+		}
+	}
+	return out
+}
+
+// goFuncShape is a function's type parameters, parameter types and result
+// types.
+func goFuncShape(ft *ast.FuncType) string {
+	shape := ""
+	if ft.TypeParams != nil {
+		shape = "[" + strings.Join(goFieldTypes(ft.TypeParams), ", ") + "]"
+	}
+	shape += "(" + strings.Join(goFieldTypes(ft.Params), ", ") + ")"
+	switch results := goFieldTypes(ft.Results); len(results) {
+	case 0:
+	case 1:
+		shape += " " + results[0]
+	default:
+		shape += " (" + strings.Join(results, ", ") + ")"
+	}
+	return shape
+}
+
+// goAPIEntries reads the exported API of a module's importable packages,
+// the way gorelease sees it: functions, methods, types with their struct
+// fields and interface methods, and package-level consts and vars. Names
+// are qualified by package directory.
+func goAPIEntries(dir string) map[string]string {
+	entries := map[string]string{}
+	for _, rel := range listSourceFiles(dir, isGoSource) {
+		p := "/" + path.Dir(rel) + "/"
+		if strings.Contains(p, "/internal/") || strings.Contains(p, "/cmd/") || strings.Contains(p, "/testdata/") {
+			continue
+		}
+		src, err := os.ReadFile(filepath.Join(dir, rel))
+		if err != nil {
+			continue
+		}
+		f, err := parser.ParseFile(token.NewFileSet(), rel, src, parser.SkipObjectResolution)
+		if err != nil || f.Name.Name == "main" {
+			continue
+		}
+		pkg := path.Dir(rel)
+		if pkg == "." {
+			pkg = f.Name.Name
+		}
+		for _, decl := range f.Decls {
+			switch d := decl.(type) {
+			case *ast.FuncDecl:
+				if !d.Name.IsExported() {
+					continue
+				}
+				if d.Recv != nil && len(d.Recv.List) > 0 {
+					recv := receiverName(d.Recv.List[0].Type)
+					if ast.IsExported(recv) {
+						entries["method "+pkg+"."+recv+"."+d.Name.Name] = goFuncShape(d.Type)
+					}
+					continue
+				}
+				entries["func "+pkg+"."+d.Name.Name] = goFuncShape(d.Type)
+			case *ast.GenDecl:
+				for _, spec := range d.Specs {
+					switch s := spec.(type) {
+					case *ast.TypeSpec:
+						if !s.Name.IsExported() {
+							continue
+						}
+						name := pkg + "." + s.Name.Name
+						params := ""
+						if s.TypeParams != nil {
+							params = "[" + strings.Join(goFieldTypes(s.TypeParams), ", ") + "] "
+						}
+						switch t := s.Type.(type) {
+						case *ast.StructType:
+							entries["type "+name] = params + "struct"
+							for _, field := range t.Fields.List {
+								if len(field.Names) == 0 {
+									if embedded := receiverName(field.Type); ast.IsExported(embedded) {
+										entries["field "+name+"."+embedded] = "embedded " + types.ExprString(field.Type)
+									}
+								}
+								for _, n := range field.Names {
+									if n.IsExported() {
+										entries["field "+name+"."+n.Name] = types.ExprString(field.Type)
+									}
+								}
+							}
+						case *ast.InterfaceType:
+							entries["type "+name] = params + "interface"
+							for _, m := range t.Methods.List {
+								if len(m.Names) == 0 {
+									entries["imethod "+name+"."+types.ExprString(m.Type)] = "embedded"
+									continue
+								}
+								if ft, ok := m.Type.(*ast.FuncType); ok {
+									entries["imethod "+name+"."+m.Names[0].Name] = goFuncShape(ft)
+								}
+							}
+						default:
+							shape := types.ExprString(s.Type)
+							if s.Assign.IsValid() {
+								shape = "= " + shape
+							}
+							entries["type "+name] = params + shape
+						}
+					case *ast.ValueSpec:
+						kind := "var"
+						if d.Tok == token.CONST {
+							kind = "const"
+						}
+						shape := ""
+						if s.Type != nil {
+							shape = types.ExprString(s.Type)
+						}
+						for _, n := range s.Names {
+							if n.IsExported() {
+								entries[kind+" "+pkg+"."+n.Name] = shape
+							}
+						}
+					}
+				}
+			}
+		}
+	}
+	return entries
+}
+
+// topLevelColon is the index of the first colon in s that isn't nested
+// in brackets, or -1: where a parameter's name ends and its type begins.
+func topLevelColon(s string) int {
+	depth := 0
+	for i := 0; i < len(s); i++ {
+		switch s[i] {
+		case '(', '{', '[', '<':
+			depth++
+		case ')', '}', ']', '>':
+			if s[i] != '>' || i == 0 || s[i-1] != '=' {
+				depth--
+			}
+		case ':':
+			if depth == 0 {
+				return i
+			}
+		}
+	}
+	return -1
+}
+
+// jsParamShapes reduces a parameter list to its types: "?" marks an
+// optional parameter (or one with a default), "..." a rest parameter.
+func jsParamShapes(params string) []string {
+	var shapes []string
+	for _, p := range splitTopLevel(strings.Join(strings.Fields(params), " ")) {
+		p = strings.TrimSpace(jsParamModifiers.ReplaceAllString(p, ""))
+		name, typ := p, "any"
+		if i := topLevelColon(p); i >= 0 {
+			name, typ = p[:i], strings.TrimSpace(p[i+1:])
+		}
+		// A default value makes the parameter optional
+		if i := strings.Index(typ, " = "); i >= 0 {
+			typ = strings.TrimSpace(typ[:i])
+			name += "="
+		} else if i := strings.Index(name, "="); i >= 0 && typ == "any" {
+			name = name[:i] + "="
+		}
+		shape := typ
+		switch {
+		case strings.HasPrefix(name, "..."):
+			shape = "..." + typ
+		case strings.Contains(name, "?") || strings.Contains(name, "="):
+			shape = "?" + typ
+		}
+		shapes = append(shapes, shape)
+	}
+	return shapes
+}
+
+// jsCallShape is a signature "name(params): ret" without the name and
+// parameter names.
+func jsCallShape(signature string) string {
+	open := strings.Index(signature, "(")
+	if open < 0 {
+		return signature
+	}
+	end := matchClose(signature, open)
+	if end < 0 {
+		return signature
+	}
+	return "(" + strings.Join(jsParamShapes(signature[open+1:end]), ", ") + ")" + signature[end+1:]
+}
+
+// jsAPIEntries reads what a package exports from entry: functions, class
+// methods, types and their fields.
+func jsAPIEntries(dir, entry string) map[string]string {
+	entries := map[string]string{}
+	for name, sym := range jsSurface(dir, entry) {
+		switch sym.kind {
+		case "func", "method":
+			entries[sym.kind+" "+name] = jsCallShape(sym.signature)
+		case "type":
+			kind, _, _ := strings.Cut(sym.signature, " ")
+			entries[kind+" "+name] = ""
+			for _, field := range sym.fields {
+				entries["field "+name+"."+field] = ""
+			}
+		default:
+			entries["export "+name] = ""
+		}
+	}
+	return entries
+}
+
+// takeAPISnapshot reads the API in dir: go/ast for a Go module, and for
+// JS the exports reachable from src/index (both trees of a comparison
+// have source, while dist/ is only built locally), else the package's
+// entry point.
+func takeAPISnapshot(dir string) (map[string]string, string, error) {
+	if fileExists(filepath.Join(dir, "go.mod")) {
+		return goAPIEntries(dir), "exported identifiers of importable packages", nil
+	}
+	entry, via := resolveJSModule(dir, ".", "src/index"), "source"
+	if entry == "" {
+		entry, via = packageEntry(dir)
+	}
+	if entry == "" {
+		return nil, "", fmt.Errorf("no entry point found (looked at src/index and package.json)")
+	}
+	return jsAPIEntries(dir, entry), fmt.Sprintf("exports of %s (%s)", entry, via), nil
+}
+
+// apiSnapshotAt takes repo's API snapshot at ref, or of the working tree
+// when ref is empty. Refs are read from a scratch worktree, and their
+// snapshots are stored so a tag is only read once.
+func (s *QuickBasePersonalMCPServer) apiSnapshotAt(repo gitRepo, ref string) (apiSnapshot, error) {
+	snap := apiSnapshot{Repo: repo.name, Ref: ref, Taken: time.Now()}
+	if ref == "" {
+		snap.Ref = "working tree"
+		snap.Commit, _ = runGit(repo.path, "rev-parse", "--short", "HEAD")
+		var err error
+		snap.Entries, snap.Source, err = takeAPISnapshot(repo.path)
+		if err == nil {
+			err = s.store.put(apiSnapshotBucket, repo.name+"@working", snap)
+		}
+		return snap, err
+	}
+	if err := verifyRef(repo.path, ref); err != nil {
+		return snap, err
+	}
+	commit, err := runGit(repo.path, "rev-parse", "--short", ref+"^{commit}")
+	if err != nil {
+		return snap, err
+	}
+	var stored apiSnapshot
+	if found, _ := s.store.get(apiSnapshotBucket, repo.name+"@"+ref, &stored); found && stored.Commit == commit {
+		return stored, nil
+	}
+	snap.Commit = commit
+	scratch, err := os.MkdirTemp("", "qb-api-")
+	if err != nil {
+		return snap, err
+	}
+	os.Remove(scratch)
+	if _, err := runGit(repo.path, "worktree", "add", "--detach", scratch, ref); err != nil {
+		return snap, err
+	}
+	defer func() {
+		runGit(repo.path, "worktree", "remove", "--force", scratch)
+		os.RemoveAll(scratch)
+	}()
+	if snap.Entries, snap.Source, err = takeAPISnapshot(scratch); err != nil {
+		return snap, err
+	}
+	return snap, s.store.put(apiSnapshotBucket, repo.name+"@"+ref, snap)
+}
+
+// apiChange is one difference between two snapshots.
+type apiChange struct {
+	entry, before, after string
+}
+
+// apiDiff sorts the differences between two snapshots by what they mean
+// for callers. Removals and changed shapes are breaking, as is a method
+// added to an existing Go interface (implementations stop satisfying it).
+// A JS call gaining optional trailing parameters is compatible; a field
+// added to an existing JS type (which has no shape, unlike a Go field) may
+// be required in an input, so it's left to review.
+func apiDiff(before, after apiSnapshot) (breaking, review, added []apiChange) {
+	owner := func(entry string) string {
+		_, name, _ := strings.Cut(entry, " ")
+		return name[:strings.LastIndex(name, ".")+1]
+	}
+	typeExisted := func(entry string) bool {
+		prefix := strings.TrimSuffix(owner(entry), ".")
+		for k := range before.Entries {
+			if _, name, _ := strings.Cut(k, " "); name == prefix && !strings.HasPrefix(k, "field ") {
+				return true
+			}
+		}
+		return false
+	}
+	for _, key := range sortedKeys(before.Entries) {
+		old := before.Entries[key]
+		now, ok := after.Entries[key]
+		switch {
+		case !ok:
+			breaking = append(breaking, apiChange{entry: key, before: old})
+		case now != old && jsOptionalExtension(old, now):
+			added = append(added, apiChange{entry: key, before: old, after: now})
+		case now != old:
+			breaking = append(breaking, apiChange{entry: key, before: old, after: now})
+		}
+	}
+	for _, key := range sortedKeys(after.Entries) {
+		if _, ok := before.Entries[key]; ok {
+			continue
+		}
+		change := apiChange{entry: key, after: after.Entries[key]}
+		switch {
+		case strings.HasPrefix(key, "imethod ") && typeExisted(key):
+			breaking = append(breaking, change)
+		case strings.HasPrefix(key, "field ") && change.after == "" && typeExisted(key):
+			review = append(review, change)
+		default:
+			added = append(added, change)
+		}
+	}
+	return breaking, review, added
+}
+
+// jsOptionalExtension reports whether a JS call shape only gained optional
+// or rest parameters at the end, or made existing ones optional, with the
+// same return type.
+func jsOptionalExtension(before, after string) bool {
+	if !strings.HasPrefix(before, "(") || !strings.HasPrefix(after, "(") {
+		return false
+	}
+	bEnd, aEnd := matchClose(before, 0), matchClose(after, 0)
+	if bEnd < 0 || aEnd < 0 || before[bEnd+1:] != after[aEnd+1:] {
+		return false
+	}
+	old, now := splitTopLevel(before[1:bEnd]), splitTopLevel(after[1:aEnd])
+	if len(now) < len(old) {
+		return false
+	}
+	for i := range now {
+		n := now[i]
+		if i < len(old) {
+			o := old[i]
+			if n != o && n != "?"+o {
+				return false
+			}
+		} else if !strings.HasPrefix(n, "?") && !strings.HasPrefix(n, "...") {
+			return false
+		}
+	}
+	return true
+}
+
+// sdkRepos picks the SDKs for sdk: 'js', 'go' or 'all'.
+func sdkRepos(sdk string) ([]gitRepo, error) {
+	switch sdk {
+	case "", "all":
+		return []gitRepo{{"quickbase-js", quickbaseJSPath}, {"quickbase-go", quickbaseGoPath}}, nil
+	case "js", "quickbase-js":
+		return []gitRepo{{"quickbase-js", quickbaseJSPath}}, nil
+	case "go", "quickbase-go":
+		return []gitRepo{{"quickbase-go", quickbaseGoPath}}, nil
+	}
+	return nil, fmt.Errorf("sdk must be 'js', 'go' or 'all'")
+}
+
+func (s *QuickBasePersonalMCPServer) handleAPISnapshot(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	var params struct {
+		SDK     string `json:"sdk"`
+		Ref     string `json:"ref"`
+		Summary bool   `json:"summary"`
+	}
+	argsData, _ := json.Marshal(request.Params.Arguments)
+	if err := json.Unmarshal(argsData, &params); err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Invalid parameters: %v", err)), nil
+	}
+	repos, err := sdkRepos(params.SDK)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	var results strings.Builder
+	results.WriteString("# API snapshot\n\n")
+	for _, repo := range repos {
+		snap, err := s.apiSnapshotAt(repo, params.Ref)
+		results.WriteString(fmt.Sprintf("## %s at %s (%s)\n\n", repo.name, snap.Ref, snap.Commit))
+		if err != nil {
+			results.WriteString(fmt.Sprintf("⚠️ %v\n\n", err))
+			continue
+		}
+		counts := map[string]int{}
+		for key := range snap.Entries {
+			kind, _, _ := strings.Cut(key, " ")
+			counts[kind]++
+		}
+		var parts []string
+		for _, kind := range sortedKeys(counts) {
+			parts = append(parts, fmt.Sprintf("%d %s", counts[kind], kind))
+		}
+		results.WriteString(fmt.Sprintf("%d entries from the %s: %s\n\n", len(snap.Entries), snap.Source, strings.Join(parts, ", ")))
+		if !params.Summary {
+			var lines []string
+			for _, key := range sortedKeys(snap.Entries) {
+				lines = append(lines, strings.TrimSpace(key+" "+snap.Entries[key]))
+			}
+			text, more := truncateLines(strings.Join(lines, "\n"), 400)
+			results.WriteString(fmt.Sprintf("```\n%s\n```\n", text))
+			if more > 0 {
+				results.WriteString(fmt.Sprintf("… %d more line(s)\n", more))
+			}
+			results.WriteString("\n")
+		}
+	}
+	results.WriteString("Stored; api_breaking_check compares the working tree with the last tag's snapshot.\n")
+	return mcp.NewToolResultText(results.String()), nil
+}
+
+func (s *QuickBasePersonalMCPServer) handleAPIBreakingCheck(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	var params struct {
+		SDK     string `json:"sdk"`
+		Base    string `json:"base"`
+		Version string `json:"version"`
+	}
+	argsData, _ := json.Marshal(request.Params.Arguments)
+	if err := json.Unmarshal(argsData, &params); err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Invalid parameters: %v", err)), nil
+	}
+	repos, err := sdkRepos(params.SDK)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	var results strings.Builder
+	results.WriteString("# API breaking-change check\n\n")
+	for _, repo := range repos {
+		results.WriteString(fmt.Sprintf("## %s\n\n", repo.name))
+		base := params.Base
+		if base == "" {
+			if base, err = previousTag(repo.path, ""); err != nil {
+				results.WriteString("⚠️ No tag to compare against; pass base\n\n")
+				continue
+			}
+		}
+		before, err := s.apiSnapshotAt(repo, base)
+		if err != nil {
+			results.WriteString(fmt.Sprintf("⚠️ %s: %v\n\n", base, err))
+			continue
+		}
+		after, err := s.apiSnapshotAt(repo, "")
+		if err != nil {
+			results.WriteString(fmt.Sprintf("⚠️ Working tree: %v\n\n", err))
+			continue
+		}
+		breaking, review, added := apiDiff(before, after)
+		results.WriteString(fmt.Sprintf("Working tree (%s) against %s (%s): %d breaking, %d to review, %d added\n\n", after.Commit, base, before.Commit, len(breaking), len(review), len(added)))
+
+		// The smallest version the changes allow, and whether the one
+		// planned is enough
+		required, reason := nextVersion(base, len(breaking) > 0, len(added)+len(review) > 0)
+		switch {
+		case params.Version != "" && required != "":
+			if cmp, ok := compareVersions(params.Version, required); ok && cmp < 0 {
+				results.WriteString(fmt.Sprintf("❌ %s isn't enough: %s, so at least %s\n\n", params.Version, reason, required))
+			} else {
+				results.WriteString(fmt.Sprintf("✅ %s is allowed: %s, so at least %s\n\n", params.Version, reason, required))
+			}
+		case required == "":
+			results.WriteString(fmt.Sprintf("➖ %s isn't a version tag, so no version is suggested\n\n", base))
+		case len(breaking) > 0:
+			results.WriteString(fmt.Sprintf("❌ Breaking: the next version must be at least %s\n\n", required))
+		default:
+			results.WriteString(fmt.Sprintf("✅ No breaking changes; %s is enough (%s)\n\n", required, reason))
+		}
+		if repo.name == "quickbase-go" && len(breaking) > 0 && majorVersion(required) >= 2 && majorVersion(required) > majorVersion(base) {
+			results.WriteString(fmt.Sprintf("A new major version also needs the module path to end in /v%d.\n\n", majorVersion(required)))
+		}
+
+		writeChanges := func(title string, changes []apiChange) {
+			if len(changes) == 0 {
+				return
+			}
+			results.WriteString(fmt.Sprintf("### %s (%d)\n\n", title, len(changes)))
+			for _, c := range changes {
+				_, was := before.Entries[c.entry]
+				_, is := after.Entries[c.entry]
+				line := "- `" + c.entry + "`"
+				switch {
+				case !is:
+					line += " removed"
+				case !was:
+					line += " added"
+				default:
+					line += fmt.Sprintf(": `%s` →", dash(c.before))
+				}
+				switch {
+				case !is && c.before != "":
+					line += fmt.Sprintf(" (was `%s`)", c.before)
+				case is && c.after != "":
+					line += fmt.Sprintf(" `%s`", c.after)
+				}
+				results.WriteString(line + "\n")
+			}
+			results.WriteString("\n")
+		}
+		writeChanges("❌ Breaking", breaking)
+		writeChanges("⚠️ Review", review)
+		writeChanges("Added", added)
+		if len(review) > 0 {
+			results.WriteString("Fields added to existing types break callers only if they're required in something callers construct.\n\n")
+		}
+	}
+	return mcp.NewToolResultText(strings.TrimRight(results.String(), "\n") + "\n"), nil
+}
@@ -0,0 +1,307 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+var (
+	jsExportAll   = regexp.MustCompile(`(?m)^\s*export\s+\*\s+from\s+['"]([^'"]+)['"]`)
+	jsExportAllAs = regexp.MustCompile(`(?m)^\s*export\s+\*\s+as\s+([A-Za-z_$][\w$]*)\s+from`)
+	jsExportFrom  = regexp.MustCompile(`(?m)^\s*export\s+(?:type\s+)?\{[^}]*\}\s+from\s+['"]([^'"]+)['"]`)
+)
+
+// packageEntry is the file a JS package exposes, preferring its type
+// declarations since they are exactly the public surface.
+func packageEntry(repoPath string) (string, string) {
+	var pkg struct {
+		Types   string          `json:"types"`
+		Typings string          `json:"typings"`
+		Main    string          `json:"main"`
+		Module  string          `json:"module"`
+		Exports json.RawMessage `json:"exports"`
+	}
+	if data, err := os.ReadFile(filepath.Join(repoPath, "package.json")); err == nil {
+		json.Unmarshal(data, &pkg)
+	}
+	// exports is a string, or {".": string | {types, import, default}}
+	var exportTypes string
+	var root map[string]json.RawMessage
+	if json.Unmarshal(pkg.Exports, &root) == nil {
+		var cond map[string]interface{}
+		if json.Unmarshal(root["."], &cond) == nil {
+			if t, ok := cond["types"].(string); ok {
+				exportTypes = t
+			}
+		}
+	}
+	for _, p := range []string{pkg.Types, pkg.Typings, exportTypes} {
+		if p == "" {
+			continue
+		}
+		if rel := resolveJSModule(repoPath, ".", p); rel != "" {
+			return rel, "type declarations"
+		}
+	}
+	if rel := resolveJSModule(repoPath, ".", "src/index"); rel != "" {
+		return rel, "source"
+	}
+	for _, p := range []string{pkg.Module, pkg.Main} {
+		if p == "" {
+			continue
+		}
+		if rel := resolveJSModule(repoPath, ".", p); rel != "" {
+			return rel, "build output"
+		}
+	}
+	return "", ""
+}
+
+// resolveJSModule resolves an import specifier relative to dir the way
+// TypeScript does: declaration and source extensions, then index files.
+// ".js" specifiers in ESM source also resolve to the .ts file.
+func resolveJSModule(repoPath, dir, spec string) string {
+	base := path.Join(dir, spec)
+	stem := strings.TrimSuffix(base, path.Ext(base))
+	candidates := []string{base}
+	for _, ext := range []string{".d.ts", ".ts", ".tsx", ".js", ".mjs"} {
+		candidates = append(candidates, base+ext, stem+ext)
+	}
+	for _, ext := range []string{".d.ts", ".ts", ".js"} {
+		candidates = append(candidates, base+"/index"+ext)
+	}
+	for _, c := range candidates {
+		if info, err := os.Stat(filepath.Join(repoPath, c)); err == nil && !info.IsDir() {
+			return c
+		}
+	}
+	return ""
+}
+
+// jsSurface collects what a package exports from its entry point, following
+// `export * from` chains. Methods of exported classes are included as
+// Class.method.
+func jsSurface(repoPath, entry string) map[string]apiSymbol {
+	surface := map[string]apiSymbol{}
+	visited := map[string]bool{}
+	var follow func(rel string)
+	follow = func(rel string) {
+		if visited[rel] {
+			return
+		}
+		visited[rel] = true
+		data, err := os.ReadFile(filepath.Join(repoPath, rel))
+		if err != nil {
+			return
+		}
+		// jsSymbols expects plain exports; declaration files add "declare"
+		src := strings.ReplaceAll(string(data), "export declare ", "export ")
+		exported := map[string]bool{}
+		for _, name := range jsExports(src) {
+			exported[name] = true
+		}
+		for _, m := range jsExportAllAs.FindAllStringSubmatch(src, -1) {
+			exported[m[1]] = true
+		}
+		symbols := map[string]apiSymbol{}
+		for _, sym := range jsSymbols(rel, src) {
+			symbols[sym.name] = sym
+		}
+		for name := range exported {
+			sym, ok := symbols[name]
+			if !ok {
+				sym = apiSymbol{name: name, kind: "export", file: rel}
+			}
+			surface[name] = sym
+		}
+		for _, sym := range symbols {
+			if owner, _, ok := strings.Cut(sym.name, "."); ok && exported[owner] && !strings.HasPrefix(sym.name, owner+".#") {
+				surface[sym.name] = sym
+			}
+		}
+		for _, m := range jsExportAll.FindAllStringSubmatch(src, -1) {
+			if target := resolveJSModule(repoPath, path.Dir(rel), m[1]); target != "" {
+				follow(target)
+			}
+		}
+		// Named re-exports declare the name here; the symbol lives there
+		for _, m := range jsExportFrom.FindAllStringSubmatch(src, -1) {
+			target := resolveJSModule(repoPath, path.Dir(rel), m[1])
+			if target == "" || visited[target] {
+				continue
+			}
+			tdata, err := os.ReadFile(filepath.Join(repoPath, target))
+			if err != nil {
+				continue
+			}
+			for _, sym := range jsSymbols(target, strings.ReplaceAll(string(tdata), "export declare ", "export ")) {
+				owner, _, _ := strings.Cut(sym.name, ".")
+				if exported[owner] && !strings.Contains(sym.name, ".#") {
+					surface[sym.name] = sym
+				}
+			}
+		}
+	}
+	follow(entry)
+	return surface
+}
+
+// goSurface is every exported identifier of the module's importable
+// packages: internal, main and example packages are not public API.
+func goSurface(repoPath string) (map[string]apiSymbol, []string) {
+	surface := map[string]apiSymbol{}
+	packages := map[string]bool{}
+	for _, rel := range listSourceFiles(repoPath, isGoSource) {
+		p := "/" + path.Dir(rel) + "/"
+		if strings.Contains(p, "/internal/") || strings.Contains(p, "/cmd/") || strings.Contains(p, "/testdata/") {
+			continue
+		}
+		data, err := os.ReadFile(filepath.Join(repoPath, rel))
+		if err != nil || strings.Contains(string(data), "\npackage main\n") || strings.HasPrefix(string(data), "package main\n") {
+			continue
+		}
+		packages[path.Dir(rel)] = true
+		for _, sym := range goSymbols(rel, data) {
+			surface[sym.name] = sym
+		}
+		// goSymbols covers funcs and types; add exported vars and consts
+		for _, name := range goExports(data) {
+			if _, ok := surface[name]; !ok {
+				surface[name] = apiSymbol{name: name, kind: "value", file: rel}
+			}
+		}
+	}
+	return surface, sortedKeys(packages)
+}
+
+// goInterfaceMethods implement standard interfaces (error, Stringer,
+// errors.Unwrap) rather than SDK API, so they have no JS counterpart.
+var goInterfaceMethods = map[string]bool{"Error": true, "String": true, "Unwrap": true, "Is": true, "As": true, "GoString": true, "Format": true}
+
+// surfaceKeys fold naming conventions: UpsertRecords ↔ upsertRecords,
+// NewClient ↔ Client.constructor. Go's New prefix is also tried bare, since
+// JS factories are often createClient or plain functions.
+func surfaceKeys(name string) []string {
+	keys := []string{symbolKey(name)}
+	if rest, ok := strings.CutPrefix(name, "New"); ok && rest != "" && !strings.Contains(name, ".") {
+		keys = append(keys, normalizeTerm("create"+rest))
+	}
+	if rest, ok := strings.CutPrefix(name, "create"); ok && rest != "" && !strings.Contains(name, ".") {
+		keys = append(keys, normalizeTerm("new"+rest))
+	}
+	return keys
+}
+
+// surfaceDiff is the symbol-level comparison of the two SDKs' public API.
+type surfaceDiff struct {
+	entry, via     string
+	jsAPI, goAPI   map[string]apiSymbol
+	packages       []string
+	matched        [][2]string
+	onlyJS, onlyGo []string
+}
+
+// diffSurfaces collects both public surfaces and pairs them up.
+func diffSurfaces() (surfaceDiff, error) {
+	var d surfaceDiff
+	d.entry, d.via = packageEntry(quickbaseJSPath)
+	if d.entry == "" {
+		return d, fmt.Errorf("no entry point found in %s (looked at package.json types/main and src/index)", quickbaseJSPath)
+	}
+	d.jsAPI = jsSurface(quickbaseJSPath, d.entry)
+	d.goAPI, d.packages = goSurface(quickbaseGoPath)
+
+	goByKey := map[string]string{}
+	for _, name := range sortedKeys(d.goAPI) {
+		for _, key := range surfaceKeys(name) {
+			if _, ok := goByKey[key]; !ok {
+				goByKey[key] = name
+			}
+		}
+	}
+	matchedGo := map[string]bool{}
+	for _, name := range sortedKeys(d.jsAPI) {
+		found := ""
+		for _, key := range surfaceKeys(name) {
+			if g, ok := goByKey[key]; ok && !matchedGo[g] {
+				found = g
+				break
+			}
+		}
+		if found == "" {
+			// A class constructor without a NewX is paired with the struct
+			if class, ok := strings.CutSuffix(name, ".constructor"); ok {
+				if _, ok := d.goAPI[class]; ok {
+					continue
+				}
+			}
+			d.onlyJS = append(d.onlyJS, name)
+			continue
+		}
+		matchedGo[found] = true
+		d.matched = append(d.matched, [2]string{name, found})
+	}
+	for _, name := range sortedKeys(d.goAPI) {
+		_, method, isMethod := strings.Cut(name, ".")
+		if !matchedGo[name] && !(isMethod && goInterfaceMethods[method]) {
+			d.onlyGo = append(d.onlyGo, name)
+		}
+	}
+	return d, nil
+}
+
+func (s *QuickBasePersonalMCPServer) handleCompareAPISurface(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	var params struct {
+		ShowMatched bool `json:"show_matched"`
+	}
+	argsData, _ := json.Marshal(request.Params.Arguments)
+	if err := json.Unmarshal(argsData, &params); err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Invalid parameters: %v", err)), nil
+	}
+
+	d, err := diffSurfaces()
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	var results strings.Builder
+	results.WriteString("# API Surface: quickbase-js vs quickbase-go\n\n")
+	results.WriteString(fmt.Sprintf("- JS: %d symbols exported from %s (%s)\n", len(d.jsAPI), d.entry, d.via))
+	results.WriteString(fmt.Sprintf("- Go: %d exported identifiers in %d package(s): %s\n", len(d.goAPI), len(d.packages), strings.Join(d.packages, ", ")))
+	results.WriteString(fmt.Sprintf("- Matched: %d, JS only: %d, Go only: %d\n\n", len(d.matched), len(d.onlyJS), len(d.onlyGo)))
+	results.WriteString("Names are matched across conventions (upsertRecords ↔ UpsertRecords, class constructors ↔ NewX, createX ↔ NewX).\n\n")
+
+	writeOneSided := func(title string, names []string, api map[string]apiSymbol) {
+		results.WriteString(fmt.Sprintf("## %s (%d)\n\n", title, len(names)))
+		if len(names) == 0 {
+			results.WriteString("None\n\n")
+			return
+		}
+		results.WriteString("| Symbol | Kind | File |\n|---|---|---|\n")
+		for _, name := range names {
+			sym := api[name]
+			results.WriteString(fmt.Sprintf("| %s | %s | %s |\n", name, sym.kind, sym.file))
+		}
+		results.WriteString("\n")
+	}
+	writeOneSided("Only in quickbase-js", d.onlyJS, d.jsAPI)
+	writeOneSided("Only in quickbase-go", d.onlyGo, d.goAPI)
+
+	if params.ShowMatched && len(d.matched) > 0 {
+		results.WriteString(fmt.Sprintf("## Matched (%d)\n\n| JS | Go |\n|---|---|\n", len(d.matched)))
+		for _, m := range d.matched {
+			results.WriteString(fmt.Sprintf("| %s | %s |\n", m[0], m[1]))
+		}
+		results.WriteString("\n")
+	}
+
+	return mcp.NewToolResultText(results.String()), nil
+}
@@ -0,0 +1,167 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// codeExample is a snippet of example code found in an SDK.
+type codeExample struct {
+	source string // file, or file › heading for doc fences
+	kind   string // "example", "docs" or "test setup"
+	code   string
+}
+
+var codeFence = regexp.MustCompile("(?s)```([A-Za-z]*)\\n(.*?)```")
+
+// exampleLineWindow is how many lines around a match are taken from test
+// files, which are too long to return whole.
+const exampleLineWindow = 12
+
+// findAuthExamples collects examples for an auth type from one SDK, best
+// first: files under examples/ (complete and runnable), then code fences
+// in README/docs sections about it, then test setup code using it.
+func findAuthExamples(repoPath string, pattern *regexp.Regexp, pathKey string, isTest func(string) bool, fences []string) []codeExample {
+	var byPath, byContent []codeExample
+	for _, ex := range exampleFiles(repoPath) {
+		data, err := os.ReadFile(filepath.Join(repoPath, ex))
+		if err != nil {
+			continue
+		}
+		switch {
+		case strings.Contains(normalizeTerm(filepath.ToSlash(ex)), pathKey):
+			byPath = append(byPath, codeExample{source: ex, kind: "example", code: string(data)})
+		case pattern.MatchString(string(data)):
+			byContent = append(byContent, codeExample{source: ex, kind: "example", code: string(data)})
+		}
+	}
+	examples := append(byPath, byContent...)
+
+	for _, sec := range readDocSections(repoPath) {
+		if !pattern.MatchString(sec.heading) && !pattern.MatchString(sec.body) {
+			continue
+		}
+		for _, m := range codeFence.FindAllStringSubmatch(sec.body, -1) {
+			lang := strings.ToLower(m[1])
+			if lang != "" && !containsString(fences, lang) {
+				continue
+			}
+			if pattern.MatchString(sec.heading) || pattern.MatchString(m[2]) {
+				examples = append(examples, codeExample{source: fmt.Sprintf("%s › %s", sec.file, sec.heading), kind: "docs", code: m[2]})
+			}
+		}
+	}
+
+	walkRepo(repoPath, func(rel string) error {
+		if !isTest(rel) {
+			return nil
+		}
+		data, err := os.ReadFile(filepath.Join(repoPath, rel))
+		if err != nil {
+			return nil
+		}
+		lines := strings.Split(string(data), "\n")
+		for i, line := range lines {
+			if pattern.MatchString(line) {
+				start, end := max(0, i-exampleLineWindow/2), min(len(lines), i+exampleLineWindow)
+				examples = append(examples, codeExample{source: fmt.Sprintf("%s:%d", rel, start+1), kind: "test setup", code: strings.Join(lines[start:end], "\n")})
+				break
+			}
+		}
+		return nil
+	})
+	return examples
+}
+
+func containsString(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *QuickBasePersonalMCPServer) handleGetAuthExample(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	var params struct {
+		AuthType string `json:"auth_type"`
+		Language string `json:"language"`
+		All      bool   `json:"all"`
+		Diagram  bool   `json:"diagram"`
+	}
+	argsData, _ := json.Marshal(request.Params.Arguments)
+	if err := json.Unmarshal(argsData, &params); err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Invalid parameters: %v", err)), nil
+	}
+	if params.Language == "" {
+		params.Language = "both"
+	}
+
+	cfg, err := loadConfig()
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to load config: %v", err)), nil
+	}
+	auth, ok := cfg.AuthTypes[params.AuthType]
+	if !ok {
+		return mcp.NewToolResultError(fmt.Sprintf("Unknown auth_type: %s (available: %s; add more under auth_types in %s)", params.AuthType, strings.Join(sortedKeys(cfg.AuthTypes), ", "), filepath.Join(configDir, configFileNames[0]))), nil
+	}
+	featureName := auth.Feature
+	features, err := loadFeatureMap()
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to load feature map: %v", err)), nil
+	}
+	pattern := keywordPattern(append([]string{params.AuthType, featureName}, features[featureName].Keywords...))
+	pathKey := normalizeTerm(params.AuthType)
+
+	var results strings.Builder
+	results.WriteString(fmt.Sprintf("# %s auth examples\n\n", params.AuthType))
+
+	sides := []struct {
+		lang, label, fence, root string
+		isTest, keep             func(string) bool
+		fences, patterns         []string
+	}{
+		{"js", "JavaScript", "typescript", quickbaseJSPath, func(rel string) bool { return isTestPath(rel) && isJSTestFile(rel) }, isJSSource, []string{"ts", "typescript", "js", "javascript"}, features[featureName].JS},
+		{"go", "Go", "go", quickbaseGoPath, func(rel string) bool { return strings.HasSuffix(rel, "_test.go") }, isGoSource, []string{"go"}, features[featureName].Go},
+	}
+	for _, side := range sides {
+		if params.Language != "both" && params.Language != side.lang {
+			continue
+		}
+		examples := findAuthExamples(side.root, pattern, pathKey, side.isTest, side.fences)
+		results.WriteString(fmt.Sprintf("## %s\n\n", side.label))
+		if params.Diagram {
+			steps := authFlowSteps(auth.Header)
+			found := traceAuthFlow(side.root, resolveFeatureFiles(side.root, side.patterns), side.keep, steps)
+			results.WriteString("### Flow\n\n")
+			results.WriteString(authFlowDiagram(filepath.Base(side.root), auth.Header, steps, found))
+		}
+		if len(examples) == 0 {
+			results.WriteString("No example found in examples/, the docs or the tests\n\n")
+			continue
+		}
+		shown := examples[:1]
+		if params.All {
+			shown = examples
+		}
+		for _, ex := range shown {
+			results.WriteString(fmt.Sprintf("### %s (%s)\n\n```%s\n%s\n```\n\n", ex.source, ex.kind, side.fence, strings.TrimRight(ex.code, "\n")))
+		}
+		if !params.All && len(examples) > 1 {
+			results.WriteString("Other sources:\n")
+			for _, ex := range examples[1:] {
+				results.WriteString(fmt.Sprintf("- %s (%s)\n", ex.source, ex.kind))
+			}
+			results.WriteString("\n")
+		}
+	}
+
+	return mcp.NewToolResultText(results.String()), nil
+}
@@ -0,0 +1,164 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// flowStep is one stage of an auth flow, recognized in source by pattern.
+// Wide steps are specific enough to look for outside the feature's files
+// too, such as header injection done by the shared client.
+type flowStep struct {
+	key     string
+	label   string
+	pattern *regexp.Regexp
+	wide    bool
+}
+
+// flowMatch is where a step was found.
+type flowMatch struct {
+	file    string
+	line    int
+	outside bool // not in the feature's files
+}
+
+// authFlowSteps lists the stages to look for. header is the Authorization
+// scheme the type sends; without one any Authorization header counts.
+func authFlowSteps(header string) []flowStep {
+	headerPattern := regexp.QuoteMeta(header)
+	if header == "" {
+		headerPattern = `(?i)Authorization`
+	}
+	return []flowStep{
+		{"lookup", "cache lookup", regexp.MustCompile(`(?i)cache\s*\.\s*(?:get|has)\s*\(|cache\s*\[|\bcached?\w*\s*,\s*ok\s*:?=`), false},
+		{"expiry", "expiry check", regexp.MustCompile(`(?i)expir\w*\s*(?:>|<|\.(?:Before|After)\()|Date\.now\(\)|time\.Now\(\)\.(?:Before|After)`), false},
+		{"fetch", "token fetch", regexp.MustCompile(`(?i)fetchTempToken|getTempToken(?:DBID)?\s*\(|/auth/temporary|API_Authenticate|/auth/saml|exchangeSaml|exchangeToken`), false},
+		{"store", "cache store", regexp.MustCompile(`(?i)cache\s*\.\s*set\s*\(|cache\s*\[[^\]]+\]\s*=[^=]`), false},
+		{"header", "header injection", regexp.MustCompile(headerPattern), true},
+		{"refresh", "refresh on 401", regexp.MustCompile(`(?i)\b401\b|StatusUnauthorized|Unauthorized|refresh\w*\s*\(`), false},
+	}
+}
+
+// traceAuthFlow finds each step of the flow, in the feature's files first
+// and, for wide steps, anywhere else in the SDK source.
+func traceAuthFlow(repoPath string, featureFiles []string, keep func(string) bool, steps []flowStep) map[string]flowMatch {
+	found := map[string]flowMatch{}
+	scan := func(files []string, outside bool, wideOnly bool) {
+		for _, rel := range files {
+			data, err := os.ReadFile(filepath.Join(repoPath, rel))
+			if err != nil {
+				continue
+			}
+			lines := strings.Split(stripComments(string(data)), "\n")
+			for _, step := range steps {
+				if _, ok := found[step.key]; ok || (wideOnly && !step.wide) {
+					continue
+				}
+				for i, line := range lines {
+					if step.pattern.MatchString(line) {
+						found[step.key] = flowMatch{file: rel, line: i + 1, outside: outside}
+						break
+					}
+				}
+			}
+		}
+	}
+	scan(featureFiles, false, false)
+
+	inFeature := map[string]bool{}
+	for _, f := range featureFiles {
+		inFeature[f] = true
+	}
+	var others []string
+	for _, rel := range listSourceFiles(repoPath, keep) {
+		if !inFeature[rel] {
+			others = append(others, rel)
+		}
+	}
+	scan(others, true, true)
+	return found
+}
+
+// authFlowDiagram renders the steps found as a mermaid sequence diagram,
+// followed by where each step lives. Steps the code doesn't show are left
+// out of the diagram and listed, so it never claims more than the SDK does.
+func authFlowDiagram(sdkName, header string, steps []flowStep, found map[string]flowMatch) string {
+	has := func(key string) bool { _, ok := found[key]; return ok }
+	cached := has("lookup") || has("store")
+
+	var d strings.Builder
+	d.WriteString("```mermaid\nsequenceDiagram\n")
+	d.WriteString("    participant App\n")
+	d.WriteString(fmt.Sprintf("    participant SDK as %s\n", sdkName))
+	if cached {
+		d.WriteString("    participant Cache as Token cache\n")
+	}
+	d.WriteString("    participant QB as Quickbase API\n")
+	d.WriteString("    App->>SDK: API call\n")
+
+	fetch := func(indent string) {
+		if has("fetch") {
+			d.WriteString(indent + "SDK->>QB: fetch token\n")
+			d.WriteString(indent + "QB-->>SDK: token\n")
+		}
+		if has("store") {
+			d.WriteString(indent + "SDK->>Cache: store token with expiry\n")
+		}
+	}
+	if cached {
+		if has("lookup") {
+			d.WriteString("    SDK->>Cache: look up token\n")
+		}
+		if has("expiry") {
+			d.WriteString("    alt cached and not expired\n")
+			d.WriteString("        Cache-->>SDK: cached token\n")
+			d.WriteString("    else missing or expired\n")
+			fetch("        ")
+			d.WriteString("    end\n")
+		} else {
+			fetch("    ")
+		}
+	} else {
+		fetch("    ")
+	}
+
+	switch {
+	case has("header") && header == "":
+		d.WriteString("    SDK->>QB: request with Authorization header\n")
+	case has("header"):
+		d.WriteString(fmt.Sprintf("    SDK->>QB: request with Authorization: %s\n", header))
+	default:
+		d.WriteString("    SDK->>QB: request\n")
+	}
+	if has("refresh") {
+		d.WriteString("    opt 401 Unauthorized\n")
+		d.WriteString("        SDK->>QB: fetch a new token\n")
+		d.WriteString("        SDK->>QB: retry request\n")
+		d.WriteString("    end\n")
+	}
+	d.WriteString("    QB-->>SDK: response\n")
+	d.WriteString("    SDK-->>App: result\n")
+	d.WriteString("```\n\n")
+
+	var missing []string
+	for _, step := range steps {
+		m, ok := found[step.key]
+		if !ok {
+			missing = append(missing, step.label)
+			continue
+		}
+		where := fmt.Sprintf("%s:%d", m.file, m.line)
+		if m.outside {
+			where += " (outside the feature's files)"
+		}
+		d.WriteString(fmt.Sprintf("- %s: %s\n", step.label, where))
+	}
+	if len(missing) > 0 {
+		d.WriteString(fmt.Sprintf("- Not found in code: %s\n", strings.Join(missing, ", ")))
+	}
+	d.WriteString("\n")
+	return d.String()
+}
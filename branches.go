@@ -0,0 +1,199 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// defaultBranch guesses a repo's main branch: what origin/HEAD points at,
+// else main, else master.
+func defaultBranch(repoPath string) string {
+	if ref, err := runGit(repoPath, "symbolic-ref", "--short", "refs/remotes/origin/HEAD"); err == nil {
+		return strings.TrimPrefix(ref, "origin/")
+	}
+	for _, name := range []string{"main", "master"} {
+		if verifyRef(repoPath, name) == nil {
+			return name
+		}
+	}
+	return "HEAD"
+}
+
+// changedFile is one line of git diff --numstat joined with its
+// --name-status letter. from is the old path of a rename or copy.
+type changedFile struct {
+	status, path, from string
+	added, removed     string
+}
+
+// fileStatusNames spell out git's --name-status letters.
+var fileStatusNames = map[string]string{"A": "added", "M": "modified", "D": "deleted", "R": "renamed", "C": "copied", "T": "type changed"}
+
+// changedFiles lists the files a diff range touches with their status and
+// line counts.
+func changedFiles(repoPath string, args ...string) ([]changedFile, error) {
+	statuses, err := runGit(repoPath, append([]string{"diff", "--name-status", "-M"}, args...)...)
+	if err != nil {
+		return nil, err
+	}
+	numstat, err := runGit(repoPath, append([]string{"diff", "--numstat", "-M"}, args...)...)
+	if err != nil {
+		return nil, err
+	}
+	var files []changedFile
+	for _, line := range strings.Split(statuses, "\n") {
+		fields := strings.Split(line, "\t")
+		if len(fields) < 2 {
+			continue
+		}
+		f := changedFile{status: fields[0][:1], path: fields[len(fields)-1]}
+		if len(fields) == 3 {
+			f.from = fields[1]
+		}
+		files = append(files, f)
+	}
+	for i, line := range strings.Split(numstat, "\n") {
+		fields := strings.SplitN(line, "\t", 3)
+		if len(fields) == 3 && i < len(files) {
+			files[i].added, files[i].removed = fields[0], fields[1]
+		}
+	}
+	return files, nil
+}
+
+func (s *QuickBasePersonalMCPServer) handleCompareBranches(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	var params struct {
+		Repo     string `json:"repo"`
+		Base     string `json:"base"`
+		Head     string `json:"head"`
+		Path     string `json:"path"`
+		Diff     bool   `json:"diff"`
+		Limit    int    `json:"limit"`
+		MaxLines int    `json:"max_lines"`
+	}
+	argsData, _ := json.Marshal(request.Params.Arguments)
+	if err := json.Unmarshal(argsData, &params); err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Invalid parameters: %v", err)), nil
+	}
+	if params.Repo == "" || params.Repo == "all" || params.Head == "" {
+		return mcp.NewToolResultError("repo and head are required: branches are compared within one repo"), nil
+	}
+	if params.Limit <= 0 {
+		params.Limit = 20
+	}
+	if params.MaxLines <= 0 {
+		params.MaxLines = 400
+	}
+	repos, err := gitRepos(params.Repo)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	repo := repos[0]
+	if params.Base == "" {
+		params.Base = defaultBranch(repo.path)
+	}
+	for _, ref := range []string{params.Base, params.Head} {
+		if err := verifyRef(repo.path, ref); err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("%s: %v", repo.name, err)), nil
+		}
+	}
+	var pathArgs []string
+	if p := strings.Trim(params.Path, "/"); p != "" {
+		pathArgs = []string{"--", p}
+	}
+
+	var results strings.Builder
+	results.WriteString(fmt.Sprintf("# Compare branches: %s %s...%s\n\n", repo.name, params.Base, params.Head))
+	mergeBase, err := runGit(repo.path, "merge-base", params.Base, params.Head)
+	if err != nil {
+		results.WriteString("⚠️ The branches share no history, so files are compared tip to tip.\n\n")
+	} else {
+		results.WriteString(fmt.Sprintf("- Merge base: %s\n", commitLine(repo.path, mergeBase)))
+	}
+	counts, _ := runGit(repo.path, "rev-list", "--left-right", "--count", params.Base+"..."+params.Head)
+	behind, ahead, _ := strings.Cut(counts, "\t")
+	results.WriteString(fmt.Sprintf("- %s is %s commit(s) ahead of %s and %s behind\n", params.Head, ahead, params.Base, behind))
+	if stat, _ := runGit(repo.path, append([]string{"diff", "--shortstat", "-M", params.Base, params.Head}, pathArgs...)...); stat != "" {
+		results.WriteString(fmt.Sprintf("- Tip to tip: %s\n", strings.TrimSpace(stat)))
+	}
+	if params.Path != "" {
+		results.WriteString(fmt.Sprintf("- Path: %s\n", params.Path))
+	}
+	results.WriteString("\n")
+
+	for _, side := range []struct{ title, rangeArg string }{
+		{fmt.Sprintf("Only in %s", params.Head), params.Base + ".." + params.Head},
+		{fmt.Sprintf("Only in %s", params.Base), params.Head + ".." + params.Base},
+	} {
+		commits, err := gitLog(repo.path, false, append([]string{"-n", fmt.Sprint(params.Limit), side.rangeArg}, pathArgs...)...)
+		if err != nil || len(commits) == 0 {
+			continue
+		}
+		results.WriteString(fmt.Sprintf("## %s\n\n", side.title))
+		for _, c := range commits {
+			results.WriteString(fmt.Sprintf("- %s %s %s — %s\n", c.hash, c.date, c.author, c.subject))
+		}
+		if len(commits) == params.Limit {
+			results.WriteString(fmt.Sprintf("- … showing the latest %d\n", params.Limit))
+		}
+		results.WriteString("\n")
+	}
+
+	// What head changed since it branched (what a merge would bring in),
+	// or tip to tip when there's no common history
+	diffRange := params.Base + "..." + params.Head
+	if mergeBase == "" {
+		diffRange = params.Base + ".." + params.Head
+	}
+	files, err := changedFiles(repo.path, append([]string{diffRange}, pathArgs...)...)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Diff failed: %v", err)), nil
+	}
+	results.WriteString(fmt.Sprintf("## Files changed on %s (%d)\n\n", params.Head, len(files)))
+	if len(files) == 0 {
+		results.WriteString("None\n")
+		return mcp.NewToolResultText(results.String()), nil
+	}
+	dirs := map[string]int{}
+	for _, f := range files {
+		dirs[path.Dir(f.path)]++
+	}
+	if len(dirs) > 1 {
+		var parts []string
+		for _, d := range sortedKeys(dirs) {
+			parts = append(parts, fmt.Sprintf("%s (%d)", d, dirs[d]))
+		}
+		results.WriteString(fmt.Sprintf("By directory: %s\n\n", strings.Join(parts, ", ")))
+	}
+	results.WriteString("| File | Status | + | - |\n|---|---|---|---|\n")
+	for _, f := range files {
+		status := fileStatusNames[f.status]
+		if status == "" {
+			status = f.status
+		}
+		name := "`" + f.path + "`"
+		if f.from != "" {
+			name = fmt.Sprintf("`%s` → `%s`", f.from, f.path)
+		}
+		results.WriteString(fmt.Sprintf("| %s | %s | %s | %s |\n", name, status, f.added, f.removed))
+	}
+	results.WriteString("\n")
+
+	if params.Diff {
+		diff, err := runGit(repo.path, append([]string{"diff", "-M", diffRange}, pathArgs...)...)
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("Diff failed: %v", err)), nil
+		}
+		text, cut := truncateLines(diff, params.MaxLines)
+		results.WriteString(fmt.Sprintf("```diff\n%s\n```\n", text))
+		if cut > 0 {
+			results.WriteString(fmt.Sprintf("\n… %d more line(s); narrow it with path or raise max_lines.\n", cut))
+		}
+	}
+	return mcp.NewToolResultText(strings.TrimRight(results.String(), "\n") + "\n"), nil
+}
@@ -0,0 +1,195 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+var (
+	// jsErrorLocation is a line that only places the error before it:
+	// esbuild's "  src/client.ts:12:5:", rollup's "src/client.ts (12:5)"
+	// and vite's "file: /repo/src/client.ts:12:5"
+	jsErrorLocation = regexp.MustCompile(`^\s*(?:file: )?(\S+\.(?:[cm]?[jt]sx?|vue|svelte|json))(?::(\d+):(\d+):?| \((\d+):(\d+)\))$`)
+	// jsInlineError is esbuild's summary "src/client.ts:12:5: ERROR: message"
+	jsInlineError = regexp.MustCompile(`^\s*(\S+):(\d+):(\d+): (?:ERROR|error): (.*)$`)
+	// buildErrorLine is a message a location line can belong to
+	buildErrorLine = regexp.MustCompile(`(?i)error\b|✘`)
+	// buildNoise is what bundlers put in front of their messages
+	buildNoise = regexp.MustCompile(`^\s*(?:(?:ESM|CJS|IIFE|DTS) )?(?:✘ )?(?:\[ERROR\] |\[!\] |error during build:\s*)?(?:\(plugin [^)]*\) )?(?:\w*Error: )?`)
+)
+
+// buildCommand picks the build the build tool runs in a repo: the
+// configured one, else go build or the package's build script.
+func buildCommand(cfg serverConfig, repo gitRepo) (string, error) {
+	for _, key := range []string{repo.name, strings.TrimPrefix(repo.name, "quickbase-")} {
+		if command := cfg.BuildCommands[key]; command != "" {
+			return command, nil
+		}
+	}
+	if fileExists(filepath.Join(repo.path, "go.mod")) {
+		return "go build ./...", nil
+	}
+	if data, err := os.ReadFile(filepath.Join(repo.path, "package.json")); err == nil {
+		var pkg struct {
+			Scripts map[string]string `json:"scripts"`
+		}
+		if json.Unmarshal(data, &pkg) == nil && pkg.Scripts["build"] != "" {
+			return "npm run build", nil
+		}
+		return "", fmt.Errorf("package.json in %s has no build script; set build_commands.%s in config.yaml", repo.path, repo.name)
+	}
+	return "", fmt.Errorf("no go.mod or package.json in %s; set build_commands.%s in config.yaml", repo.path, repo.name)
+}
+
+// parseBuildErrors reads a JS build's output: tsc diagnostics (tsup's dts
+// build), esbuild's errors and summary, and rollup and vite errors, whose
+// location comes on a line after the message. Paths are made relative to
+// the repo and repeats dropped, since bundlers building several formats
+// report the same error for each.
+func parseBuildErrors(output, repoPath string) []lintIssue {
+	var issues []lintIssue
+	seen := map[string]bool{}
+	add := func(issue lintIssue) {
+		issue.file = strings.TrimPrefix(strings.TrimPrefix(issue.file, repoPath+"/"), "./")
+		key := fmt.Sprintf("%s:%d:%d:%s", issue.file, issue.line, issue.column, issue.message)
+		if !seen[key] {
+			seen[key] = true
+			issues = append(issues, issue)
+		}
+	}
+	message := ""
+	for _, line := range strings.Split(output, "\n") {
+		if m := tscError.FindStringSubmatch(strings.TrimSpace(line)); m != nil {
+			issue := lintIssue{file: m[1], severity: m[4], rule: m[5], message: m[6]}
+			issue.line, _ = strconv.Atoi(m[2])
+			issue.column, _ = strconv.Atoi(m[3])
+			if issue.severity == "error" {
+				add(issue)
+			}
+			message = ""
+			continue
+		}
+		if m := jsInlineError.FindStringSubmatch(line); m != nil {
+			issue := lintIssue{file: m[1], severity: "error", message: m[4]}
+			issue.line, _ = strconv.Atoi(m[2])
+			issue.column, _ = strconv.Atoi(m[3])
+			add(issue)
+			// vite follows it with a file: line for the same error
+			message = ""
+			continue
+		}
+		if m := jsErrorLocation.FindStringSubmatch(line); m != nil {
+			if message == "" {
+				continue
+			}
+			l, c := m[2], m[3]
+			if l == "" {
+				l, c = m[4], m[5]
+			}
+			issue := lintIssue{file: m[1], severity: "error", message: message}
+			issue.line, _ = strconv.Atoi(l)
+			issue.column, _ = strconv.Atoi(c)
+			add(issue)
+			// A stack trace's locations aren't the error's
+			message = ""
+			continue
+		}
+		if buildErrorLine.MatchString(line) {
+			if text := strings.TrimSpace(buildNoise.ReplaceAllString(line, "")); text != "" {
+				message = text
+			}
+		}
+	}
+	return issues
+}
+
+func (s *QuickBasePersonalMCPServer) handleBuild(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	var params struct {
+		Repo           string `json:"repo"`
+		TimeoutSeconds int    `json:"timeout_seconds"`
+	}
+	argsData, _ := json.Marshal(request.Params.Arguments)
+	if err := json.Unmarshal(argsData, &params); err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Invalid parameters: %v", err)), nil
+	}
+	timeout := defaultTestTimeout
+	if params.TimeoutSeconds > 0 {
+		timeout = time.Duration(params.TimeoutSeconds) * time.Second
+	}
+	if params.Repo == "" {
+		params.Repo = "all"
+	}
+	repos, err := gitRepos(params.Repo)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	if params.Repo == "all" {
+		repos = repos[:2]
+	}
+	cfg, err := loadConfig()
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to load config: %v", err)), nil
+	}
+
+	var results strings.Builder
+	results.WriteString("# Build\n\n")
+	for _, repo := range repos {
+		results.WriteString(fmt.Sprintf("## %s\n\n", repo.name))
+		command, err := buildCommand(cfg, repo)
+		if err != nil {
+			results.WriteString(fmt.Sprintf("⚠️ %v\n\n", err))
+			continue
+		}
+		result, err := runShell(ctx, repo.path, timeout, command)
+		if err != nil {
+			results.WriteString(fmt.Sprintf("⚠️ %v\n\n", err))
+			continue
+		}
+		results.WriteString(fmt.Sprintf("Ran `%s` (%s)\n\n", command, result.duration.Round(100*time.Millisecond)))
+		if result.timedOut {
+			results.WriteString(fmt.Sprintf("⏱️ Timed out after %s\n\n", timeout))
+			continue
+		}
+		output := ansiEscape.ReplaceAllString(result.output, "")
+		if result.exitCode == 0 {
+			results.WriteString("✅ Built\n\n")
+			// A build can pass without writing what the package ships
+			if entries, err := bundleEntries(repo.path); err == nil {
+				var missing []string
+				for _, b := range entries {
+					if !fileExists(filepath.Join(repo.path, b.file)) {
+						missing = append(missing, fmt.Sprintf("`%s` (%s)", b.file, b.label))
+					}
+				}
+				if len(missing) > 0 {
+					results.WriteString(fmt.Sprintf("⚠️ package.json points at files the build didn't write: %s\n\n", strings.Join(missing, ", ")))
+				}
+			}
+			continue
+		}
+
+		var issues []lintIssue
+		if fileExists(filepath.Join(repo.path, "go.mod")) {
+			issues = parseCompileErrors(output, false)
+		} else {
+			issues = parseBuildErrors(output, repo.path)
+		}
+		if len(issues) == 0 {
+			// Failed without an error we can place: missing tool, bad
+			// config, module download
+			results.WriteString(fmt.Sprintf("❌ Failed (exit %d):\n\n```\n%s\n```\n\n", result.exitCode, tailLines(output, 30)))
+			continue
+		}
+		writeIssuesByFile(&results, issues)
+	}
+	return mcp.NewToolResultText(strings.TrimRight(results.String(), "\n") + "\n"), nil
+}
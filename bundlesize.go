@@ -0,0 +1,259 @@
+package main
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// bundle is one built file quickbase-js ships, with its raw and gzipped
+// sizes.
+type bundle struct {
+	label, file  string
+	size, gzSize int
+}
+
+// bundleEntries reads which built files package.json points at, labelled
+// by format: module and exports' import are esm, main and require are cjs,
+// and browser, unpkg and jsdelivr are the browser bundle. Labels keep the
+// first file found.
+func bundleEntries(dir string) ([]bundle, error) {
+	data, err := os.ReadFile(filepath.Join(dir, "package.json"))
+	if err != nil {
+		return nil, err
+	}
+	var pkg struct {
+		Main     string          `json:"main"`
+		Module   string          `json:"module"`
+		Browser  json.RawMessage `json:"browser"`
+		Unpkg    string          `json:"unpkg"`
+		JSDelivr string          `json:"jsdelivr"`
+		Exports  json.RawMessage `json:"exports"`
+	}
+	if err := json.Unmarshal(data, &pkg); err != nil {
+		return nil, fmt.Errorf("package.json: %w", err)
+	}
+	var entries []bundle
+	seen := map[string]bool{}
+	add := func(label, file string) {
+		file = path.Clean(strings.TrimPrefix(file, "./"))
+		if file == "." || seen[label] || strings.HasSuffix(file, ".d.ts") {
+			return
+		}
+		seen[label] = true
+		entries = append(entries, bundle{label: label, file: file})
+	}
+
+	// exports is a path, or conditions nested under "." and each other
+	var walk func(raw json.RawMessage, condition string)
+	walk = func(raw json.RawMessage, condition string) {
+		var file string
+		if json.Unmarshal(raw, &file) == nil {
+			switch condition {
+			case "import", "module":
+				add("esm", file)
+			case "require":
+				add("cjs", file)
+			case "browser":
+				add("browser", file)
+			}
+			return
+		}
+		var conditions map[string]json.RawMessage
+		if json.Unmarshal(raw, &conditions) != nil {
+			return
+		}
+		for _, key := range sortedKeys(conditions) {
+			if key == "." || !strings.HasPrefix(key, ".") {
+				next := key
+				if key == "." || key == "default" || key == "node" {
+					next = condition
+				}
+				walk(conditions[key], next)
+			}
+		}
+	}
+	if len(pkg.Exports) > 0 {
+		walk(pkg.Exports, "")
+	}
+	if pkg.Module != "" {
+		add("esm", pkg.Module)
+	}
+	if pkg.Main != "" {
+		add("cjs", pkg.Main)
+	}
+	var browser string
+	if json.Unmarshal(pkg.Browser, &browser) == nil && browser != "" {
+		add("browser", browser)
+	}
+	for _, file := range []string{pkg.Unpkg, pkg.JSDelivr} {
+		if file != "" {
+			add("browser", file)
+		}
+	}
+	return entries, nil
+}
+
+// distBundles falls back to every script in dist/ when package.json
+// doesn't point at any, labelled by file name.
+func distBundles(dir string) []bundle {
+	var entries []bundle
+	walkRepo(filepath.Join(dir, "dist"), func(rel string) error {
+		for _, ext := range []string{".js", ".mjs", ".cjs"} {
+			if strings.HasSuffix(rel, ext) {
+				entries = append(entries, bundle{label: rel, file: "dist/" + rel})
+			}
+		}
+		return nil
+	})
+	return entries
+}
+
+// measureBundles fills in each bundle's size, raw and gzipped.
+func measureBundles(dir string, entries []bundle) {
+	for i, b := range entries {
+		data, err := os.ReadFile(filepath.Join(dir, b.file))
+		if err != nil {
+			entries[i].size = -1
+			continue
+		}
+		var buf bytes.Buffer
+		zw, _ := gzip.NewWriterLevel(&buf, gzip.BestCompression)
+		zw.Write(data)
+		zw.Close()
+		entries[i].size, entries[i].gzSize = len(data), buf.Len()
+	}
+}
+
+// buildBundles runs the build in dir and measures what package.json points
+// at afterwards.
+func buildBundles(ctx context.Context, dir string, timeout time.Duration) ([]bundle, commandResult, error) {
+	result, err := runShell(ctx, dir, timeout, "npm run build")
+	if err != nil {
+		return nil, result, err
+	}
+	if result.timedOut {
+		return nil, result, fmt.Errorf("npm run build timed out after %s", timeout)
+	}
+	if result.exitCode != 0 {
+		return nil, result, fmt.Errorf("npm run build failed (exit %d):\n\n```\n%s\n```", result.exitCode, tailLines(ansiEscape.ReplaceAllString(result.output, ""), 30))
+	}
+	entries, err := bundleEntries(dir)
+	if err != nil {
+		return nil, result, err
+	}
+	if len(entries) == 0 {
+		entries = distBundles(dir)
+	}
+	measureBundles(dir, entries)
+	return entries, result, nil
+}
+
+// kb formats a byte count in kB, one decimal.
+func kb(n int) string {
+	return fmt.Sprintf("%.1f kB", float64(n)/1000)
+}
+
+func (s *QuickBasePersonalMCPServer) handleBundleSize(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	var params struct {
+		Ref            string `json:"ref"`
+		TimeoutSeconds int    `json:"timeout_seconds"`
+	}
+	argsData, _ := json.Marshal(request.Params.Arguments)
+	if err := json.Unmarshal(argsData, &params); err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Invalid parameters: %v", err)), nil
+	}
+	timeout := defaultTestTimeout
+	if params.TimeoutSeconds > 0 {
+		timeout = time.Duration(params.TimeoutSeconds) * time.Second
+	}
+	repoPath := quickbaseJSPath
+	if params.Ref == "" {
+		tag, err := runGit(repoPath, "describe", "--tags", "--abbrev=0", "HEAD")
+		if err != nil {
+			return mcp.NewToolResultError("quickbase-js has no tag to compare against; pass ref"), nil
+		}
+		params.Ref = tag
+	} else if err := verifyRef(repoPath, params.Ref); err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	current, result, err := buildBundles(ctx, repoPath, timeout)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Building the working tree: %v", err)), nil
+	}
+
+	// The baseline builds in a scratch worktree, with the working tree's
+	// node_modules, so the checkout and its dist/ are left alone
+	var baseline []bundle
+	var baselineErr error
+	scratch, err := os.MkdirTemp("", "qb-bundle-")
+	if err == nil {
+		os.Remove(scratch)
+		if _, err = runGit(repoPath, "worktree", "add", "--detach", scratch, params.Ref); err == nil {
+			defer func() {
+				runGit(repoPath, "worktree", "remove", "--force", scratch)
+				os.RemoveAll(scratch)
+			}()
+			os.Symlink(filepath.Join(repoPath, "node_modules"), filepath.Join(scratch, "node_modules"))
+			baseline, _, err = buildBundles(ctx, scratch, timeout)
+		}
+	}
+	baselineErr = err
+
+	var results strings.Builder
+	results.WriteString("# quickbase-js bundle size\n\n")
+	results.WriteString(fmt.Sprintf("Built the working tree with `npm run build` (%s)", result.duration.Round(100*time.Millisecond)))
+	if baselineErr == nil {
+		results.WriteString(fmt.Sprintf(" and %s (%s) to compare against", params.Ref, commitLine(repoPath, params.Ref)))
+	}
+	results.WriteString(".\n\n")
+	if len(current) == 0 {
+		results.WriteString("⚠️ package.json points at no built files and dist/ has no scripts.\n")
+		return mcp.NewToolResultText(results.String()), nil
+	}
+
+	before := map[string]bundle{}
+	for _, b := range baseline {
+		before[b.label] = b
+	}
+	results.WriteString(fmt.Sprintf("| Bundle | File | Size | Gzipped | Δ gzipped vs %s |\n|---|---|---|---|---|\n", params.Ref))
+	for _, b := range current {
+		if b.size < 0 {
+			results.WriteString(fmt.Sprintf("| %s | `%s` | ⚠️ not built | | |\n", b.label, b.file))
+			continue
+		}
+		delta := "—"
+		if old, ok := before[b.label]; ok && old.size >= 0 {
+			change := b.gzSize - old.gzSize
+			switch {
+			case change == 0:
+				delta = "no change"
+			case old.gzSize > 0:
+				delta = fmt.Sprintf("%+.1f kB (%+.1f%%)", float64(change)/1000, 100*float64(change)/float64(old.gzSize))
+			default:
+				delta = fmt.Sprintf("%+.1f kB", float64(change)/1000)
+			}
+			if change > 0 && old.gzSize > 0 && float64(change)/float64(old.gzSize) >= 0.05 {
+				delta = "⚠️ " + delta
+			}
+		} else if baselineErr == nil {
+			delta = "new"
+		}
+		results.WriteString(fmt.Sprintf("| %s | `%s` | %s | %s | %s |\n", b.label, b.file, kb(b.size), kb(b.gzSize), delta))
+	}
+	results.WriteString("\nGzipped at the highest level, as CDNs serve them. ⚠️ marks growth of 5% or more.\n")
+	if baselineErr != nil {
+		results.WriteString(fmt.Sprintf("\n⚠️ No comparison: building %s failed: %v\n", params.Ref, baselineErr))
+	}
+	return mcp.NewToolResultText(results.String()), nil
+}
@@ -0,0 +1,201 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"gopkg.in/yaml.v3"
+)
+
+// changelogEntry is one operation's line in a tag's section.
+type changelogEntry struct {
+	kind    string // Added, Changed, Deprecated or Removed
+	subject string
+	summary string
+	details []specDetail
+}
+
+// changelogKinds are the sections of each tag, in Keep a Changelog order.
+var changelogKinds = []string{"Added", "Changed", "Deprecated", "Removed"}
+
+// changelogDetail is a detail line for release notes.
+func changelogDetail(d specDetail) string {
+	if d.Impact == impactBreaking {
+		return "**Breaking:** " + d.Text
+	}
+	return d.Text
+}
+
+func (s *QuickBasePersonalMCPServer) handleSpecChangelog(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	var params struct {
+		From           string `json:"from"`
+		To             string `json:"to"`
+		IncludeSchemas *bool  `json:"include_schemas"`
+	}
+	argsData, _ := json.Marshal(request.Params.Arguments)
+	if err := json.Unmarshal(argsData, &params); err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Invalid parameters: %v", err)), nil
+	}
+	if params.To == "" {
+		params.To = "HEAD"
+	}
+	toRef, toName, err := resolveSpecRef(params.To)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	if params.From == "" {
+		tag, err := previousTag(quickbaseSpecPath, toRef)
+		if err != nil || tag == "" {
+			return mcp.NewToolResultError(fmt.Sprintf("from is required: no tag before %s in quickbase-spec (give a tag, commit, js-pin or go-pin)", toName)), nil
+		}
+		params.From = tag
+	}
+	fromRef, fromName, err := resolveSpecRef(params.From)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	oldRoot, err := loadSpecAt(fromRef)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to load spec at %s: %v", fromName, err)), nil
+	}
+	newRoot, err := loadSpecAt(toRef)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to load spec at %s: %v", toName, err)), nil
+	}
+
+	// Changes name operations by label; map labels back to operations to
+	// group them by tag (the new spec's, or the old one's for removals)
+	byLabel := map[string]specOperation{}
+	for _, root := range []*yaml.Node{oldRoot, newRoot} {
+		for _, op := range specOperations(root) {
+			byLabel[operationLabel(op)] = op
+		}
+	}
+	var tagOrder []string
+	if tags := mappingValue(newRoot, "tags"); tags != nil {
+		for _, t := range tags.Content {
+			tagOrder = append(tagOrder, scalarAt(t, "name"))
+		}
+	}
+	grouped := map[string][]changelogEntry{}
+	addEntry := func(tag string, entry changelogEntry) {
+		if !containsString(tagOrder, tag) {
+			tagOrder = append(tagOrder, tag)
+		}
+		grouped[tag] = append(grouped[tag], entry)
+	}
+
+	changes := diffSpecs(oldRoot, newRoot)
+	var breaking []string
+	var schemaChanges []specChange
+	for _, c := range changes {
+		if c.Area == "schema" {
+			schemaChanges = append(schemaChanges, c)
+			continue
+		}
+		op := byLabel[c.Subject]
+		tag := "Other"
+		if len(op.Tags) > 0 {
+			tag = op.Tags[0]
+		}
+		subject := fmt.Sprintf("`%s %s`", op.Method, op.Path)
+		if op.OperationID != "" {
+			subject = fmt.Sprintf("**%s** (`%s %s`)", op.OperationID, op.Method, op.Path)
+		}
+		switch c.Kind {
+		case "added":
+			addEntry(tag, changelogEntry{kind: "Added", subject: subject, summary: op.Summary})
+		case "removed":
+			addEntry(tag, changelogEntry{kind: "Removed", subject: subject, summary: op.Summary})
+			breaking = append(breaking, fmt.Sprintf("- %s removed", subject))
+		default:
+			var rest []specDetail
+			for _, d := range c.Details {
+				if d.Text == "now deprecated" {
+					addEntry(tag, changelogEntry{kind: "Deprecated", subject: subject, summary: op.Summary})
+					continue
+				}
+				rest = append(rest, d)
+				if d.Impact == impactBreaking {
+					breaking = append(breaking, fmt.Sprintf("- %s: %s", subject, d.Text))
+				}
+			}
+			if len(rest) > 0 {
+				addEntry(tag, changelogEntry{kind: "Changed", subject: subject, details: rest})
+			}
+		}
+	}
+
+	var results strings.Builder
+	results.WriteString(fmt.Sprintf("# Spec changelog: %s → %s\n\n", fromName, toName))
+	if len(changes) == 0 {
+		results.WriteString("No changes to operations or schemas.\n")
+		return mcp.NewToolResultText(results.String()), nil
+	}
+	if len(breaking) > 0 {
+		results.WriteString(fmt.Sprintf("## ⚠️ Breaking changes\n\n%s\n\n", strings.Join(breaking, "\n")))
+	}
+
+	for _, tag := range tagOrder {
+		entries := grouped[tag]
+		if len(entries) == 0 {
+			continue
+		}
+		results.WriteString(fmt.Sprintf("## %s\n\n", tag))
+		for _, kind := range changelogKinds {
+			var lines []string
+			for _, e := range entries {
+				if e.kind != kind {
+					continue
+				}
+				line := "- " + e.subject
+				if e.summary != "" {
+					line += ": " + e.summary
+				}
+				for _, d := range e.details {
+					line += "\n  - " + changelogDetail(d)
+				}
+				lines = append(lines, line)
+			}
+			if len(lines) > 0 {
+				results.WriteString(fmt.Sprintf("### %s\n\n%s\n\n", kind, strings.Join(lines, "\n")))
+			}
+		}
+	}
+
+	if (params.IncludeSchemas == nil || *params.IncludeSchemas) && len(schemaChanges) > 0 {
+		results.WriteString("## Models\n\n")
+		for _, kind := range []string{"added", "changed", "removed"} {
+			var lines []string
+			for _, c := range schemaChanges {
+				if c.Kind != kind {
+					continue
+				}
+				line := fmt.Sprintf("- `%s`", c.Subject)
+				if kind == "removed" {
+					line = fmt.Sprintf("- **Breaking:** `%s`", c.Subject)
+				}
+				for _, d := range c.Details {
+					line += "\n  - " + changelogDetail(d)
+				}
+				lines = append(lines, line)
+			}
+			if len(lines) > 0 {
+				results.WriteString(fmt.Sprintf("### %s\n\n%s\n\n", strings.ToUpper(kind[:1])+kind[1:], strings.Join(lines, "\n")))
+			}
+		}
+	}
+
+	// The commits themselves, for context when writing the release notes
+	logRange := fromRef + ".." + toRef
+	if toRef == "" {
+		logRange = fromRef + "..HEAD"
+	}
+	if commits, err := runGit(quickbaseSpecPath, "log", "--format=- %s (%h)", "-n", "30", logRange); err == nil && commits != "" && fromRef != "" {
+		results.WriteString(fmt.Sprintf("## Spec commits\n\n%s\n", commits))
+	}
+	return mcp.NewToolResultText(strings.TrimRight(results.String(), "\n") + "\n"), nil
+}
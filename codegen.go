@@ -0,0 +1,397 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// oapiCodegenConfigs are where an oapi-codegen config is looked for in a
+// Go repo.
+var oapiCodegenConfigs = []string{"oapi-codegen.yaml", "oapi-codegen.yml", ".oapi-codegen.yaml", "api/oapi-codegen.yaml"}
+
+// codegenScripts are package.json scripts that regenerate a JS client, in
+// order of preference.
+var codegenScripts = []string{"generate", "codegen", "gen", "generate:api"}
+
+// codegenCommand picks the command that regenerates a repo's client: the
+// configured one, oapi-codegen with the repo's config, or the package.json
+// generate script. {spec} is replaced with the spec file.
+func codegenCommand(cfg serverConfig, repo gitRepo) (string, error) {
+	for _, key := range []string{repo.name, strings.TrimPrefix(repo.name, "quickbase-")} {
+		if command := cfg.CodegenCommands[key]; command != "" {
+			return command, nil
+		}
+	}
+	if _, err := os.Stat(filepath.Join(repo.path, "go.mod")); err == nil {
+		for _, name := range oapiCodegenConfigs {
+			if _, err := os.Stat(filepath.Join(repo.path, name)); err == nil {
+				return "oapi-codegen -config " + name + " {spec}", nil
+			}
+		}
+	}
+	var pkg struct {
+		Scripts map[string]string `json:"scripts"`
+	}
+	if data, err := os.ReadFile(filepath.Join(repo.path, "package.json")); err == nil {
+		json.Unmarshal(data, &pkg)
+		for _, name := range codegenScripts {
+			if pkg.Scripts[name] != "" {
+				return "npm run " + name, nil
+			}
+		}
+	}
+	return "", fmt.Errorf("no oapi-codegen config or package.json generate script in %s; set codegen_commands.%s in config.yaml", repo.path, repo.name)
+}
+
+// runCodegen runs a codegen command in dir against specPath, also passed
+// as QB_SPEC for scripts that read the spec from the environment.
+func runCodegen(ctx context.Context, dir, command, specPath string, timeout time.Duration) (commandResult, string, error) {
+	command = strings.ReplaceAll(command, "{spec}", shellQuote(specPath))
+	result, err := runShellEnv(ctx, dir, timeout, []string{"QB_SPEC=" + specPath}, command)
+	return result, command, err
+}
+
+// codegenSpec writes the spec an SDK's code is generated from to a temp
+// file: "pin" is the commit its spec submodule pins (quickbase-spec HEAD
+// without one), "working" the spec's working tree, anything else a
+// quickbase-spec ref. It returns the file, a description and the spec's
+// path within the spec repo.
+func codegenSpec(repoPath, which string) (string, string, string, error) {
+	working, err := findSpecFile()
+	if err != nil {
+		return "", "", "", err
+	}
+	rel, err := filepath.Rel(quickbaseSpecPath, working)
+	if err != nil {
+		return "", "", "", err
+	}
+	rel = filepath.ToSlash(rel)
+	ref, label := which, ""
+	switch which {
+	case "", "working":
+		data, err := os.ReadFile(working)
+		if err != nil {
+			return "", "", "", err
+		}
+		return writeTempSpec(data, rel, "the quickbase-spec working tree")
+	case "pin":
+		if ref = specPin(repoPath); ref != "" {
+			label = fmt.Sprintf("the pinned spec %s", shortSHA(ref))
+		} else {
+			ref, label = "HEAD", "quickbase-spec HEAD (no spec submodule)"
+		}
+	default:
+		if err := verifyRef(quickbaseSpecPath, ref); err != nil {
+			return "", "", "", err
+		}
+		label = "quickbase-spec " + ref
+	}
+	data, err := readRepoFile(quickbaseSpecPath, ref, rel)
+	if err != nil {
+		return "", "", "", fmt.Errorf("%s isn't in the local quickbase-spec: %v", label, err)
+	}
+	return writeTempSpec(data, rel, label)
+}
+
+// writeTempSpec saves spec content under its own file name, whose extension
+// generators go by.
+func writeTempSpec(data []byte, rel, label string) (string, string, string, error) {
+	dir, err := os.MkdirTemp("", "qb-codegen-spec-")
+	if err != nil {
+		return "", "", "", err
+	}
+	path := filepath.Join(dir, filepath.Base(rel))
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		os.RemoveAll(dir)
+		return "", "", "", err
+	}
+	return path, label, rel, nil
+}
+
+// dirtyFiles maps each changed or untracked file in a repo to its content
+// hash, so what a command changed can be told apart from earlier edits.
+func dirtyFiles(repoPath string) map[string]string {
+	files := map[string]string{}
+	out, err := runGit(repoPath, "status", "--porcelain", "--untracked-files=all")
+	if err != nil || out == "" {
+		return files
+	}
+	for _, line := range strings.Split(out, "\n") {
+		if len(line) < 4 {
+			continue
+		}
+		path := line[3:]
+		if _, to, ok := strings.Cut(path, " -> "); ok {
+			path = to
+		}
+		path = strings.Trim(path, `"`)
+		hash, err := runGit(repoPath, "hash-object", "--", path)
+		if err != nil {
+			hash = "deleted"
+		}
+		files[path] = hash
+	}
+	return files
+}
+
+// codegenChanges lists what a codegen run changed in dir, given the dirty
+// files before it, with +/- line counts.
+func codegenChanges(dir string, before map[string]string) []changedFile {
+	after := dirtyFiles(dir)
+	var changes []changedFile
+	for _, path := range sortedKeys(after) {
+		if before[path] == after[path] {
+			continue
+		}
+		f := changedFile{status: "M", path: path, added: "?", removed: "?"}
+		if stat, err := runGit(dir, "diff", "--numstat", "HEAD", "--", path); err == nil && stat != "" {
+			fields := strings.Fields(stat)
+			f.added, f.removed = fields[0], fields[1]
+		} else if after[path] == "deleted" {
+			f.status = "D"
+		} else {
+			_, n := untrackedDiff(dir, path)
+			f.status, f.added, f.removed = "A", fmt.Sprint(n), "0"
+		}
+		changes = append(changes, f)
+	}
+	return changes
+}
+
+func (s *QuickBasePersonalMCPServer) handleRunCodegen(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	var params struct {
+		Repo           string `json:"repo"`
+		Spec           string `json:"spec"`
+		TimeoutSeconds int    `json:"timeout_seconds"`
+	}
+	argsData, _ := json.Marshal(request.Params.Arguments)
+	if err := json.Unmarshal(argsData, &params); err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Invalid parameters: %v", err)), nil
+	}
+	timeout := defaultTestTimeout
+	if params.TimeoutSeconds > 0 {
+		timeout = time.Duration(params.TimeoutSeconds) * time.Second
+	}
+	if params.Repo == "" {
+		params.Repo = "all"
+	}
+	repos, err := gitRepos(params.Repo)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	if params.Repo == "all" {
+		repos = repos[:2]
+	}
+	cfg, err := loadConfig()
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to load config: %v", err)), nil
+	}
+
+	var results strings.Builder
+	results.WriteString("# Codegen\n\n")
+	for _, repo := range repos {
+		results.WriteString(fmt.Sprintf("## %s\n\n", repo.name))
+		command, err := codegenCommand(cfg, repo)
+		if err != nil {
+			results.WriteString(fmt.Sprintf("⚠️ %v\n\n", err))
+			continue
+		}
+		specPath, label, _, err := codegenSpec(repo.path, params.Spec)
+		if err != nil {
+			results.WriteString(fmt.Sprintf("⚠️ %v\n\n", err))
+			continue
+		}
+		before := dirtyFiles(repo.path)
+		result, _, err := runCodegen(ctx, repo.path, command, specPath, timeout)
+		os.RemoveAll(filepath.Dir(specPath))
+		if err != nil {
+			results.WriteString(fmt.Sprintf("⚠️ %v\n\n", err))
+			continue
+		}
+		results.WriteString(fmt.Sprintf("Spec: %s\n\n", label))
+		if result.timedOut || result.exitCode != 0 {
+			status := fmt.Sprintf("exit %d", result.exitCode)
+			if result.timedOut {
+				status = fmt.Sprintf("timed out after %s", timeout)
+			}
+			results.WriteString(fmt.Sprintf("`%s`: ❌ failed (%s)\n\n```\n%s\n```\n\n", command, status, tailLines(ansiEscape.ReplaceAllString(result.output, ""), 30)))
+			continue
+		}
+		results.WriteString(fmt.Sprintf("`%s`: ✅ done in %s\n\n", command, result.duration.Round(100*time.Millisecond)))
+		changes := codegenChanges(repo.path, before)
+		if len(changes) == 0 {
+			results.WriteString("No files changed: the generated code was already up to date.\n\n")
+			continue
+		}
+		results.WriteString(fmt.Sprintf("Changed %d file(s), left uncommitted:\n\n", len(changes)))
+		for _, f := range changes {
+			results.WriteString(fmt.Sprintf("- `%s` (%s, +%s/-%s)\n", f.path, fileStatusNames[f.status], f.added, f.removed))
+		}
+		results.WriteString("\n")
+	}
+	results.WriteString("Review with git_diff; check_codegen_stale compares generated code with what's committed.\n")
+	return mcp.NewToolResultText(results.String()), nil
+}
+
+// codegenCheck is the result of regenerating a repo's HEAD: the
+// generator run, and the files that came out different with their diff.
+type codegenCheck struct {
+	command, label string
+	result         commandResult
+	changes        []changedFile
+	diff           string
+}
+
+// checkCodegenAtHead regenerates repo's HEAD in a scratch worktree from
+// the given spec (see codegenSpec) and compares the result with what's
+// committed. A generator that fails is reported in result, not as err.
+func checkCodegenAtHead(ctx context.Context, cfg serverConfig, repo gitRepo, which string, timeout time.Duration) (codegenCheck, error) {
+	var check codegenCheck
+	command, err := codegenCommand(cfg, repo)
+	if err != nil {
+		return check, err
+	}
+	check.command = command
+	specPath, label, specRel, err := codegenSpec(repo.path, which)
+	if err != nil {
+		return check, err
+	}
+	defer os.RemoveAll(filepath.Dir(specPath))
+	check.label = label
+
+	// A scratch worktree of HEAD leaves the working tree alone and
+	// compares against exactly what's committed
+	scratch, err := os.MkdirTemp("", "qb-codegen-")
+	if err != nil {
+		return check, err
+	}
+	os.Remove(scratch)
+	if _, err := runGit(repo.path, "worktree", "add", "--detach", scratch, "HEAD"); err != nil {
+		return check, err
+	}
+	defer func() {
+		runGit(repo.path, "worktree", "remove", "--force", scratch)
+		os.RemoveAll(scratch)
+	}()
+
+	// Scripts that read the spec from the submodule find it there,
+	// and npm generators find their node_modules
+	skip := []string{"node_modules"}
+	if sub, ok := specSubmodule(repo.path); ok {
+		dst := filepath.Join(scratch, sub.path, specRel)
+		if data, err := os.ReadFile(specPath); err == nil && os.MkdirAll(filepath.Dir(dst), 0o755) == nil {
+			os.WriteFile(dst, data, 0o644)
+		}
+		skip = append(skip, sub.path)
+	}
+	if _, err := os.Stat(filepath.Join(repo.path, "node_modules")); err == nil {
+		os.Symlink(filepath.Join(repo.path, "node_modules"), filepath.Join(scratch, "node_modules"))
+	}
+
+	check.result, _, err = runCodegen(ctx, scratch, command, specPath, timeout)
+	if err != nil || check.result.timedOut || check.result.exitCode != 0 {
+		return check, err
+	}
+	for _, f := range codegenChanges(scratch, map[string]string{}) {
+		ignored := false
+		for _, prefix := range skip {
+			if f.path == prefix || strings.HasPrefix(f.path, prefix+"/") {
+				ignored = true
+			}
+		}
+		if !ignored {
+			check.changes = append(check.changes, f)
+		}
+	}
+	if len(check.changes) > 0 {
+		check.diff, _ = runGit(scratch, "diff", "HEAD")
+		for _, f := range check.changes {
+			if f.status == "A" {
+				text, _ := untrackedDiff(scratch, f.path)
+				check.diff += "\n" + text
+			}
+		}
+	}
+	return check, nil
+}
+
+func (s *QuickBasePersonalMCPServer) handleCheckCodegenStale(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	var params struct {
+		Repo           string `json:"repo"`
+		Spec           string `json:"spec"`
+		TimeoutSeconds int    `json:"timeout_seconds"`
+	}
+	argsData, _ := json.Marshal(request.Params.Arguments)
+	if err := json.Unmarshal(argsData, &params); err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Invalid parameters: %v", err)), nil
+	}
+	if params.Spec == "" {
+		// What's committed should match the spec the commit pins
+		params.Spec = "pin"
+	}
+	timeout := defaultTestTimeout
+	if params.TimeoutSeconds > 0 {
+		timeout = time.Duration(params.TimeoutSeconds) * time.Second
+	}
+	if params.Repo == "" {
+		params.Repo = "all"
+	}
+	repos, err := gitRepos(params.Repo)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	if params.Repo == "all" {
+		repos = repos[:2]
+	}
+	cfg, err := loadConfig()
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to load config: %v", err)), nil
+	}
+
+	var results strings.Builder
+	results.WriteString("# Generated code vs HEAD\n\n")
+	stale := 0
+	for _, repo := range repos {
+		results.WriteString(fmt.Sprintf("## %s\n\n", repo.name))
+		check, err := checkCodegenAtHead(ctx, cfg, repo, params.Spec, timeout)
+		if err != nil {
+			results.WriteString(fmt.Sprintf("⚠️ %v\n\n", err))
+			continue
+		}
+		results.WriteString(fmt.Sprintf("Regenerated HEAD (%s) from %s with `%s`\n\n", commitLine(repo.path, "HEAD"), check.label, check.command))
+		if check.result.timedOut || check.result.exitCode != 0 {
+			status := fmt.Sprintf("exit %d", check.result.exitCode)
+			if check.result.timedOut {
+				status = fmt.Sprintf("timed out after %s", timeout)
+			}
+			results.WriteString(fmt.Sprintf("❌ Generator failed (%s)\n\n```\n%s\n```\n\n", status, tailLines(ansiEscape.ReplaceAllString(check.result.output, ""), 30)))
+			continue
+		}
+		if len(check.changes) == 0 {
+			results.WriteString("✅ Up to date: regenerating changes nothing.\n\n")
+			continue
+		}
+		stale++
+		results.WriteString(fmt.Sprintf("⚠️ Stale: %d file(s) differ from HEAD once regenerated\n\n", len(check.changes)))
+		for _, f := range check.changes {
+			results.WriteString(fmt.Sprintf("- `%s` (%s, +%s/-%s)\n", f.path, fileStatusNames[f.status], f.added, f.removed))
+		}
+		text, more := truncateLines(strings.TrimSpace(check.diff), 80)
+		results.WriteString(fmt.Sprintf("\n```diff\n%s\n```\n", text))
+		if more > 0 {
+			results.WriteString(fmt.Sprintf("… %d more diff line(s)\n", more))
+		}
+		results.WriteString("\n")
+	}
+	if stale > 0 {
+		results.WriteString("Regenerate with run_codegen (spec: pin) and commit the result. A generator version different from the one last used can also cause differences.\n")
+	}
+	return mcp.NewToolResultText(strings.TrimRight(results.String(), "\n") + "\n"), nil
+}
@@ -0,0 +1,65 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+)
+
+// commandResult is the outcome of an external command.
+type commandResult struct {
+	output   string
+	exitCode int
+	duration time.Duration
+	timedOut bool
+}
+
+// runShell runs a shell command in dir with a timeout, capturing combined
+// stdout and stderr. A non-zero exit is reported in the result, not as an
+// error; err is only set when the command could not be started.
+func runShell(ctx context.Context, dir string, timeout time.Duration, command string) (commandResult, error) {
+	return runShellEnv(ctx, dir, timeout, nil, command)
+}
+
+// runShellEnv is runShell with extra KEY=VALUE environment variables on top
+// of the server's own environment.
+func runShellEnv(ctx context.Context, dir string, timeout time.Duration, env []string, command string) (commandResult, error) {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	cmd := exec.CommandContext(ctx, "sh", "-c", command)
+	cmd.Dir = dir
+	if len(env) > 0 {
+		cmd.Env = append(os.Environ(), env...)
+	}
+	var out bytes.Buffer
+	cmd.Stdout = &out
+	cmd.Stderr = &out
+
+	start := time.Now()
+	err := cmd.Run()
+	result := commandResult{output: out.String(), duration: time.Since(start)}
+	if ctx.Err() == context.DeadlineExceeded {
+		result.timedOut = true
+		result.exitCode = -1
+		return result, nil
+	}
+	if err != nil {
+		if exitErr, ok := err.(*exec.ExitError); ok {
+			result.exitCode = exitErr.ExitCode()
+			return result, nil
+		}
+		return result, fmt.Errorf("failed to run %q: %w", command, err)
+	}
+	return result, nil
+}
+
+// shellQuote quotes s as one sh word, for user input appended to a
+// command line.
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
@@ -0,0 +1,304 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// dirFile is one source file in a directory comparison.
+type dirFile struct {
+	rel     string
+	lines   int
+	exports []string
+	content string
+}
+
+// readDirFiles loads the source files under dir (relative to root).
+func readDirFiles(root, dir string, keep func(string) bool, exports func(string) []string) ([]dirFile, error) {
+	full, err := resolveRepoPath(root, dir)
+	if err != nil {
+		return nil, err
+	}
+	info, err := os.Stat(full)
+	if err != nil {
+		return nil, fmt.Errorf("directory not found: %s", dir)
+	}
+	if !info.IsDir() {
+		return nil, fmt.Errorf("not a directory: %s", dir)
+	}
+
+	var files []dirFile
+	walkRepo(full, func(rel string) error {
+		if !keep(rel) {
+			return nil
+		}
+		data, err := os.ReadFile(filepath.Join(full, rel))
+		if err != nil {
+			return nil
+		}
+		content := string(data)
+		files = append(files, dirFile{
+			rel:     path.Join(filepath.ToSlash(dir), rel),
+			lines:   strings.Count(content, "\n"),
+			exports: exports(content),
+			content: content,
+		})
+		return nil
+	})
+	return files, nil
+}
+
+// fileStem normalizes a file name for pairing across languages, so
+// temp-token.ts and temp_token.go share a stem.
+func fileStem(rel string) string {
+	base := path.Base(rel)
+	return normalizeTerm(strings.TrimSuffix(base, path.Ext(base)))
+}
+
+// compareDirectories summarizes a JS directory against a Go directory,
+// pairing files by normalized name.
+func compareDirectories(jsDir, goDir string, includeContents bool) (string, error) {
+	jsFiles, err := readDirFiles(quickbaseJSPath, jsDir, isJSSource, jsExports)
+	if err != nil {
+		return "", fmt.Errorf("quickbase-js: %w", err)
+	}
+	goFiles, err := readDirFiles(quickbaseGoPath, goDir, isGoSource, func(src string) []string { return goExports([]byte(src)) })
+	if err != nil {
+		return "", fmt.Errorf("quickbase-go: %w", err)
+	}
+
+	type pair struct {
+		js *dirFile
+		g  *dirFile
+	}
+	pairs := map[string]*pair{}
+	for i := range jsFiles {
+		stem := fileStem(jsFiles[i].rel)
+		if pairs[stem] == nil {
+			pairs[stem] = &pair{}
+		}
+		pairs[stem].js = &jsFiles[i]
+	}
+	for i := range goFiles {
+		stem := fileStem(goFiles[i].rel)
+		if pairs[stem] == nil {
+			pairs[stem] = &pair{}
+		}
+		pairs[stem].g = &goFiles[i]
+	}
+	stems := make([]string, 0, len(pairs))
+	for stem := range pairs {
+		stems = append(stems, stem)
+	}
+	sort.Strings(stems)
+
+	cell := func(f *dirFile) (string, string, string) {
+		if f == nil {
+			return "—", "", ""
+		}
+		return f.rel, fmt.Sprintf("%d", f.lines), fmt.Sprintf("%d", len(f.exports))
+	}
+
+	var results strings.Builder
+	results.WriteString(fmt.Sprintf("# Comparing directories: %s ↔ %s\n\n", jsDir, goDir))
+	results.WriteString("| JS file | Lines | Exports | Go file | Lines | Exports |\n|---|---|---|---|---|---|\n")
+	jsOnly, goOnly := 0, 0
+	for _, stem := range stems {
+		p := pairs[stem]
+		jf, jl, je := cell(p.js)
+		gf, gl, ge := cell(p.g)
+		results.WriteString(fmt.Sprintf("| %s | %s | %s | %s | %s | %s |\n", jf, jl, je, gf, gl, ge))
+		if p.g == nil {
+			jsOnly++
+		}
+		if p.js == nil {
+			goOnly++
+		}
+	}
+	results.WriteString(fmt.Sprintf("\n%d JS files, %d Go files; %d only in JS, %d only in Go\n\n", len(jsFiles), len(goFiles), jsOnly, goOnly))
+
+	results.WriteString("## Exported symbols\n\n")
+	for _, stem := range stems {
+		p := pairs[stem]
+		for _, side := range []struct {
+			label string
+			f     *dirFile
+		}{{"JS", p.js}, {"Go", p.g}} {
+			if side.f == nil || len(side.f.exports) == 0 {
+				continue
+			}
+			results.WriteString(fmt.Sprintf("- %s %s: %s\n", side.label, side.f.rel, strings.Join(side.f.exports, ", ")))
+		}
+	}
+
+	if includeContents {
+		results.WriteString("\n## Contents\n\n")
+		for _, f := range jsFiles {
+			results.WriteString(fmt.Sprintf("### JavaScript (%s)\n\n```typescript\n%s\n```\n\n", f.rel, f.content))
+		}
+		for _, f := range goFiles {
+			results.WriteString(fmt.Sprintf("### Go (%s)\n\n```go\n%s\n```\n\n", f.rel, f.content))
+		}
+	}
+
+	return results.String(), nil
+}
+
+var identPattern = regexp.MustCompile(`[A-Za-z_][A-Za-z0-9_]*`)
+
+// stripComments removes // and /* */ comments from TS or Go source while
+// leaving string literals (including URLs containing "//") and line
+// numbers intact.
+func stripComments(src string) string {
+	var out strings.Builder
+	var quote byte
+	for i := 0; i < len(src); i++ {
+		c := src[i]
+		if quote != 0 {
+			out.WriteByte(c)
+			if c == '\\' && quote != '`' && i+1 < len(src) {
+				i++
+				out.WriteByte(src[i])
+			} else if c == quote {
+				quote = 0
+			}
+			continue
+		}
+		switch {
+		case c == '"' || c == '\'' || c == '`':
+			quote = c
+			out.WriteByte(c)
+		case c == '/' && i+1 < len(src) && src[i+1] == '/':
+			for i < len(src) && src[i] != '\n' {
+				i++
+			}
+			if i < len(src) {
+				out.WriteByte('\n')
+			}
+		case c == '/' && i+1 < len(src) && src[i+1] == '*':
+			end := strings.Index(src[i+2:], "*/")
+			if end < 0 {
+				return out.String()
+			}
+			// Keep the line count so positions still line up with the source
+			out.WriteString(strings.Repeat("\n", strings.Count(src[i:i+2+end], "\n")))
+			i += end + 3
+		default:
+			out.WriteByte(c)
+		}
+	}
+	return out.String()
+}
+
+// normalizeForDiff reduces source to a language-neutral form: comments and
+// blank lines removed, whitespace collapsed, trailing semicolons dropped and
+// identifiers folded so userToken, UserToken and user_token compare equal.
+func normalizeForDiff(src string) string {
+	var lines []string
+	for _, line := range strings.Split(stripComments(src), "\n") {
+		line = strings.Join(strings.Fields(line), " ")
+		line = strings.TrimSuffix(line, ";")
+		if line == "" || line == "}" || line == ")" || line == "})" || line == "};" {
+			continue
+		}
+		lines = append(lines, identPattern.ReplaceAllStringFunc(line, normalizeTerm))
+	}
+	return strings.Join(lines, "\n") + "\n"
+}
+
+// unifiedDiff diffs two texts with `git diff --no-index`, labelling the
+// sides with the given names.
+func unifiedDiff(a, b, labelA, labelB string) (string, error) {
+	dir, err := os.MkdirTemp("", "qb-diff-")
+	if err != nil {
+		return "", err
+	}
+	defer os.RemoveAll(dir)
+	pathA, pathB := filepath.Join(dir, "a"), filepath.Join(dir, "b")
+	if err := os.WriteFile(pathA, []byte(a), 0o600); err != nil {
+		return "", err
+	}
+	if err := os.WriteFile(pathB, []byte(b), 0o600); err != nil {
+		return "", err
+	}
+
+	// git diff exits 1 when the inputs differ, which is the expected case
+	cmd := exec.Command("git", "diff", "--no-index", "--no-color", "-U3", pathA, pathB)
+	out, err := cmd.Output()
+	if err != nil {
+		if exitErr, ok := err.(*exec.ExitError); !ok || exitErr.ExitCode() != 1 {
+			return "", fmt.Errorf("git diff failed: %w", err)
+		}
+	}
+
+	// Replace the temp-file header with the real labels
+	lines := strings.Split(string(out), "\n")
+	var diff strings.Builder
+	diff.WriteString(fmt.Sprintf("--- %s\n+++ %s\n", labelA, labelB))
+	inHunks := false
+	for _, line := range lines {
+		if strings.HasPrefix(line, "@@") {
+			inHunks = true
+		}
+		if inHunks {
+			diff.WriteString(line)
+			diff.WriteString("\n")
+		}
+	}
+	return strings.TrimRight(diff.String(), "\n") + "\n", nil
+}
+
+// diffImplementations normalizes each side's files and returns a unified
+// diff of JS against Go. Empty refs read the working trees.
+func diffImplementations(feature string, jsFiles, goFiles []string, jsRef, goRef string) (string, error) {
+	readSide := func(root, ref string, files []string) (string, []string) {
+		var text strings.Builder
+		var missing []string
+		for _, rel := range files {
+			data, err := readRepoFile(root, ref, rel)
+			if err != nil {
+				missing = append(missing, rel)
+				continue
+			}
+			text.WriteString(normalizeForDiff(string(data)))
+		}
+		return text.String(), missing
+	}
+	jsText, jsMissing := readSide(quickbaseJSPath, jsRef, jsFiles)
+	goText, goMissing := readSide(quickbaseGoPath, goRef, goFiles)
+
+	var results strings.Builder
+	results.WriteString(fmt.Sprintf("# Normalized diff: %s\n\n", feature))
+	results.WriteString("Comments, blank lines and semicolons are stripped and identifiers are folded (userToken = UserToken = user_token), so only structural differences remain.\n\n")
+	for _, rel := range jsMissing {
+		results.WriteString(fmt.Sprintf("⚠️ JS file not found: %s\n", rel))
+	}
+	for _, rel := range goMissing {
+		results.WriteString(fmt.Sprintf("⚠️ Go file not found: %s\n", rel))
+	}
+
+	if jsText == goText {
+		results.WriteString("No differences after normalization.\n")
+		return results.String(), nil
+	}
+	diff, err := unifiedDiff(jsText, goText, refLabel("js/"+strings.Join(jsFiles, ",js/"), jsRef), refLabel("go/"+strings.Join(goFiles, ",go/"), goRef))
+	if err != nil {
+		return "", err
+	}
+	results.WriteString(fmt.Sprintf("\n```diff\n%s```\n", diff))
+	return results.String(), nil
+}
+
+// refLabel appends "@ref" to a label when comparing a git ref.
+func refLabel(label, ref string) string {
+	if ref == "" {
+		return label
+	}
+	return label + "@" + ref
+}
@@ -0,0 +1,187 @@
+package main
+
+import "strings"
+
+// configFileNames hold personal settings (realm, sample IDs, token variable
+// names), looked up in configDir, first match wins.
+var configFileNames = []string{"config.yaml", "config.yml", "config.json"}
+
+// serverConfig is the user's personal settings, used to fill in examples.
+type serverConfig struct {
+	Realm        string `json:"realm" yaml:"realm"`
+	AppID        string `json:"app_id" yaml:"app_id"`
+	TableID      string `json:"table_id" yaml:"table_id"`
+	UserTokenEnv string `json:"user_token_env" yaml:"user_token_env"`
+	AppTokenEnv  string `json:"app_token_env" yaml:"app_token_env"`
+
+	// UserTokenKeychain names the keychain item (service) holding the user
+	// token, for the live tools when it isn't in the environment or .env.
+	UserTokenKeychain string `json:"user_token_keychain" yaml:"user_token_keychain"`
+
+	// Sandbox settings are used by run_example, which refuses to run
+	// without a sandbox realm so examples never touch production.
+	SandboxRealm   string `json:"sandbox_realm" yaml:"sandbox_realm"`
+	SandboxAppID   string `json:"sandbox_app_id" yaml:"sandbox_app_id"`
+	SandboxTableID string `json:"sandbox_table_id" yaml:"sandbox_table_id"`
+
+	// Sandbox confines the live tools to the sandbox realm, the sandbox
+	// app and that app's tables; calls naming any other dbid are refused.
+	Sandbox bool `json:"sandbox" yaml:"sandbox"`
+
+	// SchemaDir is where qb_export_schema writes app schemas, ideally a
+	// git repo. Empty means the schemas directory in the config directory.
+	SchemaDir string `json:"schema_dir" yaml:"schema_dir"`
+
+	// Fixture directories shared by the SDKs' tests, relative to each
+	// repo, for check_fixture_parity. Empty means look in the usual places.
+	JSFixturesDir string `json:"js_fixtures_dir" yaml:"js_fixtures_dir"`
+	GoFixturesDir string `json:"go_fixtures_dir" yaml:"go_fixtures_dir"`
+
+	// AuthTypes adds to (or overrides) the built-in auth types offered by
+	// get_auth_example, so a new SDK auth method needs no server change.
+	AuthTypes map[string]authType `json:"auth_types" yaml:"auth_types"`
+
+	// Repos adds repositories (name to path) for the git tools to work
+	// across alongside the three Quickbase repos.
+	Repos map[string]string `json:"repos" yaml:"repos"`
+
+	// TestCommands overrides the command run_tests runs in a repo, keyed
+	// by repo name ("quickbase-go" or just "go").
+	TestCommands map[string]string `json:"test_commands" yaml:"test_commands"`
+
+	// LintCommands overrides the linter run_lint runs in a repo, keyed the
+	// same way. JSON output from golangci-lint or eslint is read best.
+	LintCommands map[string]string `json:"lint_commands" yaml:"lint_commands"`
+
+	// CodegenCommands overrides how run_codegen and check_codegen_stale
+	// regenerate a repo's client, keyed the same way. {spec} is replaced
+	// with the spec file.
+	CodegenCommands map[string]string `json:"codegen_commands" yaml:"codegen_commands"`
+
+	// BuildCommands overrides the build the build tool runs in a repo,
+	// keyed the same way.
+	BuildCommands map[string]string `json:"build_commands" yaml:"build_commands"`
+
+	// LicenseAllowlist replaces the SPDX licenses audit_licenses accepts
+	// in the SDKs' dependencies.
+	LicenseAllowlist []string `json:"license_allowlist" yaml:"license_allowlist"`
+
+	source string
+}
+
+// defaultConfig fills in placeholders for anything not configured.
+var defaultConfig = serverConfig{
+	Realm:        "myrealm",
+	AppID:        "bqxxxxxxx",
+	TableID:      "bqyyyyyyy",
+	UserTokenEnv: "QB_USER_TOKEN",
+	AppTokenEnv:  "QB_APP_TOKEN",
+	AuthTypes:    defaultAuthTypes,
+}
+
+// authType is how an auth type is found in the SDKs: the feature map entry
+// implementing it and the Authorization scheme it ends up sending, if it
+// has a fixed one.
+type authType struct {
+	Feature string `json:"feature" yaml:"feature"`
+	Header  string `json:"header" yaml:"header"`
+}
+
+// defaultAuthTypes are the auth types both SDKs ship with. SSO exchanges
+// the SAML assertion for a temp token.
+var defaultAuthTypes = map[string]authType{
+	"user-token": {Feature: "user-token", Header: "QB-USER-TOKEN"},
+	"temp-token": {Feature: "temp-token", Header: "QB-TEMP-TOKEN"},
+	"sso":        {Feature: "sso", Header: "QB-TEMP-TOKEN"},
+	"ticket":     {Feature: "ticket-auth", Header: "QB-TICKET"},
+}
+
+// loadConfig reads the config file over the defaults. Empty values keep
+// their defaults.
+func loadConfig() (serverConfig, error) {
+	var custom serverConfig
+	path, err := readConfigFile(configFileNames, &custom)
+	if err != nil {
+		return defaultConfig, err
+	}
+	cfg := defaultConfig
+	cfg.source = "built-in"
+	if path != "" {
+		cfg.source = path
+	}
+	for _, f := range []struct {
+		dst *string
+		src string
+	}{
+		{&cfg.Realm, custom.Realm},
+		{&cfg.AppID, custom.AppID},
+		{&cfg.TableID, custom.TableID},
+		{&cfg.UserTokenEnv, custom.UserTokenEnv},
+		{&cfg.AppTokenEnv, custom.AppTokenEnv},
+		{&cfg.UserTokenKeychain, custom.UserTokenKeychain},
+		{&cfg.SandboxRealm, custom.SandboxRealm},
+		{&cfg.SandboxAppID, custom.SandboxAppID},
+		{&cfg.SandboxTableID, custom.SandboxTableID},
+		{&cfg.SchemaDir, custom.SchemaDir},
+		{&cfg.JSFixturesDir, custom.JSFixturesDir},
+		{&cfg.GoFixturesDir, custom.GoFixturesDir},
+	} {
+		if f.src != "" {
+			*f.dst = f.src
+		}
+	}
+	// Accept either the realm name or its hostname
+	cfg.Realm = strings.TrimSuffix(cfg.Realm, ".quickbase.com")
+	cfg.SandboxRealm = strings.TrimSuffix(cfg.SandboxRealm, ".quickbase.com")
+
+	cfg.AuthTypes = make(map[string]authType, len(defaultAuthTypes)+len(custom.AuthTypes))
+	for name, t := range defaultAuthTypes {
+		cfg.AuthTypes[name] = t
+	}
+	for name, t := range custom.AuthTypes {
+		// The feature usually shares the auth type's name
+		if t.Feature == "" {
+			t.Feature = name
+		}
+		cfg.AuthTypes[name] = t
+	}
+	cfg.Repos = custom.Repos
+	cfg.TestCommands = custom.TestCommands
+	cfg.LintCommands = custom.LintCommands
+	cfg.CodegenCommands = custom.CodegenCommands
+	cfg.BuildCommands = custom.BuildCommands
+	cfg.LicenseAllowlist = custom.LicenseAllowlist
+	cfg.Sandbox = custom.Sandbox
+	return cfg, nil
+}
+
+// authTypeNames lists the configured auth types for the tool schema. The
+// schema is built at startup, so new types appear after a restart; the
+// handler itself reads the config on every call.
+func authTypeNames() []string {
+	cfg, _ := loadConfig()
+	return sortedKeys(cfg.AuthTypes)
+}
+
+// sandbox returns the config with the sandbox realm and IDs in place of
+// the regular ones, or false when no sandbox realm is configured. Missing
+// sandbox IDs fall back to the placeholders, never the production IDs.
+func (c serverConfig) sandbox() (serverConfig, bool) {
+	if c.SandboxRealm == "" {
+		return c, false
+	}
+	c.Realm = c.SandboxRealm
+	c.AppID, c.TableID = defaultConfig.AppID, defaultConfig.TableID
+	if c.SandboxAppID != "" {
+		c.AppID = c.SandboxAppID
+	}
+	if c.SandboxTableID != "" {
+		c.TableID = c.SandboxTableID
+	}
+	return c, true
+}
+
+// Hostname is the realm's full hostname.
+func (c serverConfig) Hostname() string {
+	return c.Realm + ".quickbase.com"
+}
@@ -0,0 +1,275 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"path"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"gopkg.in/yaml.v3"
+)
+
+// correspondence is a proposed JS ↔ Go file pairing.
+type correspondence struct {
+	jsFile   string
+	goFile   string
+	nameSim  float64
+	coChange float64
+	score    float64
+}
+
+var camelBoundary = regexp.MustCompile(`([a-z0-9])([A-Z])`)
+
+// noiseTokens carry no information about what a file implements.
+var noiseTokens = map[string]bool{
+	"src": true, "lib": true, "pkg": true, "internal": true, "index": true,
+	"main": true, "ts": true, "js": true, "go": true, "mod": true,
+}
+
+// pathTokens splits a path into lowercase word tokens, treating camelCase,
+// '-', '_', '.' and '/' as boundaries.
+func pathTokens(p string) []string {
+	p = camelBoundary.ReplaceAllString(p, "$1 $2")
+	fields := strings.FieldsFunc(strings.ToLower(p), func(r rune) bool {
+		return r == '/' || r == '-' || r == '_' || r == '.' || r == ' '
+	})
+	var tokens []string
+	for _, f := range fields {
+		if !noiseTokens[f] {
+			tokens = append(tokens, f)
+		}
+	}
+	return tokens
+}
+
+// jaccard returns |a ∩ b| / |a ∪ b| over token sets.
+func jaccard(a, b []string) float64 {
+	if len(a) == 0 || len(b) == 0 {
+		return 0
+	}
+	set := map[string]int{}
+	for _, t := range a {
+		set[t] |= 1
+	}
+	for _, t := range b {
+		set[t] |= 2
+	}
+	both := 0
+	for _, v := range set {
+		if v == 3 {
+			both++
+		}
+	}
+	return float64(both) / float64(len(set))
+}
+
+// nameSimilarity compares file base names (weighted 0.8) and their
+// directories (0.2). A base-name match after normalization scores 1.
+func nameSimilarity(jsFile, goFile string) float64 {
+	jsBase := strings.TrimSuffix(path.Base(jsFile), path.Ext(jsFile))
+	goBase := strings.TrimSuffix(path.Base(goFile), path.Ext(goFile))
+	base := jaccard(pathTokens(jsBase), pathTokens(goBase))
+	if normalizeTerm(jsBase) == normalizeTerm(goBase) {
+		base = 1
+	}
+	dir := jaccard(pathTokens(path.Dir(jsFile)), pathTokens(path.Dir(goFile)))
+	return 0.8*base + 0.2*dir
+}
+
+// fileCommitTimes maps each file to the unix times of commits touching it,
+// from the most recent maxCommits commits.
+func fileCommitTimes(repoPath string, maxCommits int) (map[string][]int64, error) {
+	out, err := runGit(repoPath, "log", "-n", strconv.Itoa(maxCommits), "--name-only", "--format=@%ct")
+	if err != nil {
+		return nil, err
+	}
+	times := map[string][]int64{}
+	var current int64
+	for _, line := range strings.Split(out, "\n") {
+		line = strings.TrimSpace(line)
+		switch {
+		case line == "":
+		case strings.HasPrefix(line, "@"):
+			current, _ = strconv.ParseInt(line[1:], 10, 64)
+		default:
+			times[line] = append(times[line], current)
+		}
+	}
+	return times, nil
+}
+
+// coChangeScore is the Jaccard overlap of two files' commit histories,
+// where commits within window seconds of each other count as the same change.
+func coChangeScore(a, b []int64, window int64) float64 {
+	if len(a) == 0 || len(b) == 0 {
+		return 0
+	}
+	matched := 0
+	for _, ta := range a {
+		for _, tb := range b {
+			if d := ta - tb; d <= window && d >= -window {
+				matched++
+				break
+			}
+		}
+	}
+	return float64(matched) / float64(len(a)+len(b)-matched)
+}
+
+// featureNameFor derives a feature-map key from a file name.
+func featureNameFor(file string) string {
+	base := strings.TrimSuffix(path.Base(file), path.Ext(file))
+	return strings.Join(pathTokens(base), "-")
+}
+
+// learnCorrespondences scores every unmapped JS/Go source file pair.
+func learnCorrespondences(features map[string]featureEntry, windowHours, maxCommits int) ([]correspondence, error) {
+	mapped := map[string]bool{}
+	for _, entry := range features {
+		for _, rel := range resolveFeatureFiles(quickbaseJSPath, entry.JS) {
+			mapped["js:"+rel] = true
+		}
+		for _, rel := range resolveFeatureFiles(quickbaseGoPath, entry.Go) {
+			mapped["go:"+rel] = true
+		}
+	}
+
+	jsFiles := listSourceFiles(quickbaseJSPath, isJSSource)
+	goFiles := listSourceFiles(quickbaseGoPath, isGoSource)
+
+	// History is best-effort: without it, pairs are ranked on names alone
+	jsTimes, err := fileCommitTimes(quickbaseJSPath, maxCommits)
+	if err != nil {
+		jsTimes = map[string][]int64{}
+	}
+	goTimes, err := fileCommitTimes(quickbaseGoPath, maxCommits)
+	if err != nil {
+		goTimes = map[string][]int64{}
+	}
+	window := int64(windowHours) * 3600
+
+	var pairs []correspondence
+	for _, js := range jsFiles {
+		if mapped["js:"+js] {
+			continue
+		}
+		for _, goFile := range goFiles {
+			if mapped["go:"+goFile] {
+				continue
+			}
+			c := correspondence{
+				jsFile:   js,
+				goFile:   goFile,
+				nameSim:  nameSimilarity(js, goFile),
+				coChange: coChangeScore(jsTimes[js], goTimes[goFile], window),
+			}
+			c.score = 0.7*c.nameSim + 0.3*c.coChange
+			if c.nameSim > 0 {
+				pairs = append(pairs, c)
+			}
+		}
+	}
+
+	sort.Slice(pairs, func(i, j int) bool {
+		if pairs[i].score != pairs[j].score {
+			return pairs[i].score > pairs[j].score
+		}
+		return pairs[i].jsFile+pairs[i].goFile < pairs[j].jsFile+pairs[j].goFile
+	})
+
+	// Keep only the best partner for each file
+	used := map[string]bool{}
+	var best []correspondence
+	for _, c := range pairs {
+		if used["js:"+c.jsFile] || used["go:"+c.goFile] {
+			continue
+		}
+		used["js:"+c.jsFile] = true
+		used["go:"+c.goFile] = true
+		best = append(best, c)
+	}
+	return best, nil
+}
+
+func (s *QuickBasePersonalMCPServer) handleLearnCorrespondences(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	var params struct {
+		MinScore    float64 `json:"min_score"`
+		WindowHours int     `json:"window_hours"`
+		MaxCommits  int     `json:"max_commits"`
+		Limit       int     `json:"limit"`
+	}
+	argsData, _ := json.Marshal(request.Params.Arguments)
+	if err := json.Unmarshal(argsData, &params); err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Invalid parameters: %v", err)), nil
+	}
+	if params.MinScore <= 0 {
+		params.MinScore = 0.5
+	}
+	if params.WindowHours <= 0 {
+		params.WindowHours = 72
+	}
+	if params.MaxCommits <= 0 {
+		params.MaxCommits = 500
+	}
+	if params.Limit <= 0 {
+		params.Limit = 25
+	}
+
+	features, err := loadFeatureMap()
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to load feature map: %v", err)), nil
+	}
+	pairs, err := learnCorrespondences(features, params.WindowHours, params.MaxCommits)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to learn correspondences: %v", err)), nil
+	}
+
+	var proposals []correspondence
+	for _, c := range pairs {
+		if c.score >= params.MinScore {
+			proposals = append(proposals, c)
+		}
+	}
+	if len(proposals) > params.Limit {
+		proposals = proposals[:params.Limit]
+	}
+
+	var results strings.Builder
+	results.WriteString("# Proposed File Correspondences\n\n")
+	if len(proposals) == 0 {
+		results.WriteString(fmt.Sprintf("No unmapped pairs scored at least %.2f\n", params.MinScore))
+		return mcp.NewToolResultText(results.String()), nil
+	}
+
+	results.WriteString(fmt.Sprintf("Scored on file naming (70%%) and commits within %dh of each other (30%%). Files already in the feature map are skipped.\n\n", params.WindowHours))
+	results.WriteString("| Score | JS | Go | Name | Co-change |\n|---|---|---|---|---|\n")
+	additions := map[string]featureEntry{}
+	for _, c := range proposals {
+		results.WriteString(fmt.Sprintf("| %.2f | %s | %s | %.2f | %.2f |\n", c.score, c.jsFile, c.goFile, c.nameSim, c.coChange))
+		name := featureNameFor(c.jsFile)
+		if _, taken := features[name]; taken || name == "" {
+			name = featureNameFor(c.goFile)
+		}
+		if _, taken := additions[name]; taken {
+			continue
+		}
+		additions[name] = featureEntry{JS: []string{c.jsFile}, Go: []string{c.goFile}}
+	}
+
+	var snippet strings.Builder
+	enc := yaml.NewEncoder(&snippet)
+	enc.SetIndent(2)
+	if err := enc.Encode(additions); err == nil {
+		enc.Close()
+		results.WriteString("\n## Suggested features.yaml additions\n\n```yaml\n")
+		results.WriteString(snippet.String())
+		results.WriteString("```\n")
+	}
+
+	return mcp.NewToolResultText(results.String()), nil
+}
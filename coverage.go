@@ -0,0 +1,300 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// fileCoverage is statement coverage for one source file.
+type fileCoverage struct {
+	covered, total int
+}
+
+// coveragePct formats covered/total as a percentage, or "—" with nothing
+// to cover.
+func coveragePct(covered, total int) string {
+	if total == 0 {
+		return "—"
+	}
+	return fmt.Sprintf("%.1f%%", 100*float64(covered)/float64(total))
+}
+
+// withCoverage adds the runner's coverage flags to a test command, writing
+// the report into dir: a cover profile for Go (every package counted, so
+// code only other packages' tests reach is covered too) and an istanbul
+// json-summary for vitest and jest.
+func withCoverage(command, runner, dir string) (string, error) {
+	switch runner {
+	case "go":
+		rest, _ := strings.CutPrefix(command, "go test")
+		return "go test -coverprofile=" + shellQuote(filepath.Join(dir, "cover.out")) + " -coverpkg=./..." + rest, nil
+	case "vitest":
+		return withArgs(command, "--coverage.enabled --coverage.reporter=json-summary --coverage.reportsDirectory="+shellQuote(dir)), nil
+	case "jest":
+		return withArgs(command, "--coverage --coverageReporters=json-summary --coverageDirectory="+shellQuote(dir)), nil
+	}
+	return "", fmt.Errorf("can't tell how to collect coverage from %q; use go test, vitest or jest", command)
+}
+
+// readGoCoverProfile totals a cover profile per file, relative to the
+// repo. With -coverpkg each test binary repeats every block, so a block
+// counts as covered if any run reached it.
+func readGoCoverProfile(path, repoPath string) (map[string]fileCoverage, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	type block struct {
+		statements int
+		hit        bool
+	}
+	blocks := map[string]*block{}
+	for _, line := range strings.Split(string(data), "\n") {
+		fields := strings.Fields(line)
+		if len(fields) != 3 || strings.HasPrefix(line, "mode:") {
+			continue
+		}
+		statements, _ := strconv.Atoi(fields[1])
+		count, _ := strconv.Atoi(fields[2])
+		b := blocks[fields[0]]
+		if b == nil {
+			b = &block{statements: statements}
+			blocks[fields[0]] = b
+		}
+		b.hit = b.hit || count > 0
+	}
+	files := map[string]fileCoverage{}
+	for key, b := range blocks {
+		file, _, _ := strings.Cut(key, ":")
+		rel := goPackageDir(repoPath, file)
+		if rel == "" {
+			rel = file
+		}
+		fc := files[rel]
+		fc.total += b.statements
+		if b.hit {
+			fc.covered += b.statements
+		}
+		files[rel] = fc
+	}
+	return files, nil
+}
+
+// readCoverageSummary reads istanbul's coverage-summary.json, keyed by
+// absolute path, into statement coverage relative to the repo.
+func readCoverageSummary(path, repoPath string) (map[string]fileCoverage, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var summary map[string]struct {
+		Statements struct {
+			Total   int `json:"total"`
+			Covered int `json:"covered"`
+		} `json:"statements"`
+	}
+	if err := json.Unmarshal(data, &summary); err != nil {
+		return nil, err
+	}
+	files := map[string]fileCoverage{}
+	for file, s := range summary {
+		if file == "total" {
+			continue
+		}
+		rel := file
+		if r, err := filepath.Rel(repoPath, file); err == nil && !strings.HasPrefix(r, "..") {
+			rel = filepath.ToSlash(r)
+		}
+		files[rel] = fileCoverage{covered: s.Statements.Covered, total: s.Statements.Total}
+	}
+	return files, nil
+}
+
+// runCoverage runs a repo's tests with coverage on and reads the result,
+// along with the test command used and its exit code.
+func runCoverage(ctx context.Context, cfg serverConfig, repo gitRepo, timeout time.Duration) (map[string]fileCoverage, string, int, error) {
+	command, err := testCommand(cfg, repo)
+	if err != nil {
+		return nil, "", 0, err
+	}
+	runner := testRunner(repo.path, command)
+	dir, err := os.MkdirTemp("", "qb-coverage-")
+	if err != nil {
+		return nil, "", 0, err
+	}
+	defer os.RemoveAll(dir)
+	coverCommand, err := withCoverage(command, runner, dir)
+	if err != nil {
+		return nil, "", 0, err
+	}
+	result, err := runShell(ctx, repo.path, timeout, coverCommand)
+	if err != nil {
+		return nil, command, 0, err
+	}
+	if result.timedOut {
+		return nil, command, 0, fmt.Errorf("timed out after %s", timeout)
+	}
+	var files map[string]fileCoverage
+	if runner == "go" {
+		files, err = readGoCoverProfile(filepath.Join(dir, "cover.out"), repo.path)
+	} else {
+		files, err = readCoverageSummary(filepath.Join(dir, "coverage-summary.json"), repo.path)
+	}
+	if err != nil {
+		// Failing tests still leave a report; no report means the run broke
+		return nil, command, result.exitCode, fmt.Errorf("no coverage report (exit %d):\n\n```\n%s\n```", result.exitCode, tailLines(ansiEscape.ReplaceAllString(result.output, ""), 20))
+	}
+	return files, command, result.exitCode, nil
+}
+
+func (s *QuickBasePersonalMCPServer) handleCoverageReport(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	var params struct {
+		Feature        string  `json:"feature"`
+		Threshold      float64 `json:"threshold"`
+		TimeoutSeconds int     `json:"timeout_seconds"`
+	}
+	argsData, _ := json.Marshal(request.Params.Arguments)
+	if err := json.Unmarshal(argsData, &params); err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Invalid parameters: %v", err)), nil
+	}
+	if params.Threshold <= 0 {
+		params.Threshold = 20
+	}
+	timeout := defaultTestTimeout
+	if params.TimeoutSeconds > 0 {
+		timeout = time.Duration(params.TimeoutSeconds) * time.Second
+	}
+	features, err := loadFeatureMap()
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to load feature map: %v", err)), nil
+	}
+	names := featureNames(features)
+	if params.Feature != "" {
+		if _, ok := features[params.Feature]; !ok {
+			return mcp.NewToolResultError(fmt.Sprintf("Unknown feature: %s (known: %s)", params.Feature, strings.Join(names, ", "))), nil
+		}
+		names = []string{params.Feature}
+	}
+	cfg, err := loadConfig()
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to load config: %v", err)), nil
+	}
+
+	var results strings.Builder
+	results.WriteString("# Coverage report\n\n")
+	var coverage [2]map[string]fileCoverage
+	for i, repo := range []gitRepo{{"quickbase-js", quickbaseJSPath}, {"quickbase-go", quickbaseGoPath}} {
+		files, command, exitCode, err := runCoverage(ctx, cfg, repo, timeout)
+		if err != nil {
+			results.WriteString(fmt.Sprintf("- %s: ⚠️ %v\n", repo.name, err))
+			continue
+		}
+		coverage[i] = files
+		var covered, total int
+		for _, fc := range files {
+			covered += fc.covered
+			total += fc.total
+		}
+		results.WriteString(fmt.Sprintf("- %s: %s of statements in %d file(s), from `%s` with coverage", repo.name, coveragePct(covered, total), len(files), command))
+		if exitCode != 0 {
+			results.WriteString(fmt.Sprintf(" (exit %d: some tests failed, so this is the coverage of the rest)", exitCode))
+		}
+		results.WriteString("\n")
+	}
+	results.WriteString("\n")
+	if coverage[0] == nil && coverage[1] == nil {
+		return mcp.NewToolResultText(results.String()), nil
+	}
+
+	// rollup sums a feature's files in one SDK's coverage
+	type rollup struct {
+		covered, total int
+		files          []string
+	}
+	sum := func(files map[string]fileCoverage, patterns []string) rollup {
+		var r rollup
+		match := featureMatcher(patterns)
+		for _, rel := range sortedKeys(files) {
+			if match(rel) {
+				r.covered += files[rel].covered
+				r.total += files[rel].total
+				r.files = append(r.files, rel)
+			}
+		}
+		return r
+	}
+	cell := func(r rollup, ran bool) string {
+		switch {
+		case !ran:
+			return "not run"
+		case r.total == 0:
+			return "no files"
+		}
+		return fmt.Sprintf("%s (%d/%d)", coveragePct(r.covered, r.total), r.covered, r.total)
+	}
+
+	type gap struct {
+		feature, lower string
+		points         float64
+		files          []string
+		coverage       map[string]fileCoverage
+	}
+	var gaps []gap
+	results.WriteString("| Feature | JS | Go | Gap |\n|---|---|---|---|\n")
+	for _, name := range names {
+		js, goSide := sum(coverage[0], features[name].JS), sum(coverage[1], features[name].Go)
+		gapCell := ""
+		if js.total > 0 && goSide.total > 0 {
+			jsPct := 100 * float64(js.covered) / float64(js.total)
+			goPct := 100 * float64(goSide.covered) / float64(goSide.total)
+			points := jsPct - goPct
+			switch {
+			case points >= params.Threshold:
+				gaps = append(gaps, gap{name, "Go", points, goSide.files, coverage[1]})
+				gapCell = fmt.Sprintf("⚠️ Go %.0f points lower", points)
+			case -points >= params.Threshold:
+				gaps = append(gaps, gap{name, "JS", -points, js.files, coverage[0]})
+				gapCell = fmt.Sprintf("⚠️ JS %.0f points lower", -points)
+			default:
+				gapCell = fmt.Sprintf("%+.0f", points)
+			}
+		}
+		results.WriteString(fmt.Sprintf("| %s | %s | %s | %s |\n", name, cell(js, coverage[0] != nil), cell(goSide, coverage[1] != nil), gapCell))
+	}
+	results.WriteString("\nCoverage is statements covered/total over each feature's mapped files; Gap is JS minus Go in percentage points.\n\n")
+
+	switch {
+	case coverage[0] == nil || coverage[1] == nil:
+		results.WriteString("Only one SDK has coverage, so there's nothing to compare.\n")
+		return mcp.NewToolResultText(results.String()), nil
+	case len(gaps) == 0:
+		results.WriteString(fmt.Sprintf("No feature is %.0f or more points lower in one SDK.\n", params.Threshold))
+		return mcp.NewToolResultText(results.String()), nil
+	}
+	sort.SliceStable(gaps, func(i, j int) bool { return gaps[i].points > gaps[j].points })
+	results.WriteString(fmt.Sprintf("## Lagging features (%d)\n\n", len(gaps)))
+	for _, g := range gaps {
+		results.WriteString(fmt.Sprintf("### %s: %s %.0f points lower\n\n", g.feature, g.lower, g.points))
+		// Least covered first, where tests would help most
+		files := append([]string(nil), g.files...)
+		sort.SliceStable(files, func(i, j int) bool {
+			a, b := g.coverage[files[i]], g.coverage[files[j]]
+			return a.total-a.covered > b.total-b.covered
+		})
+		for _, rel := range files {
+			fc := g.coverage[rel]
+			results.WriteString(fmt.Sprintf("- `%s`: %s (%d of %d statements uncovered)\n", rel, coveragePct(fc.covered, fc.total), fc.total-fc.covered, fc.total))
+		}
+		results.WriteString("\n")
+	}
+	return mcp.NewToolResultText(strings.TrimRight(results.String(), "\n") + "\n"), nil
+}
@@ -0,0 +1,189 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+const decisionBucket = "decisions"
+
+// decision is a settled architecture choice, e.g. "we retry only
+// idempotent methods". Superseded decisions are kept for history.
+type decision struct {
+	ID           uint64    `json:"id"`
+	Title        string    `json:"title"`
+	Decision     string    `json:"decision"`
+	Rationale    string    `json:"rationale,omitempty"`
+	Features     []string  `json:"features,omitempty"`
+	Tags         []string  `json:"tags,omitempty"`
+	SupersededBy uint64    `json:"superseded_by,omitempty"`
+	Supersedes   uint64    `json:"supersedes,omitempty"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+// matches reports whether every word of query appears in the decision.
+func (d decision) matches(query string) bool {
+	haystack := strings.ToLower(strings.Join([]string{
+		d.Title, d.Decision, d.Rationale,
+		strings.Join(d.Features, " "), strings.Join(d.Tags, " "),
+	}, " "))
+	for _, word := range strings.Fields(strings.ToLower(query)) {
+		if !strings.Contains(haystack, word) {
+			return false
+		}
+	}
+	return true
+}
+
+// hasFeature reports whether the decision is linked to feature.
+func (d decision) hasFeature(feature string) bool {
+	for _, f := range d.Features {
+		if f == feature {
+			return true
+		}
+	}
+	return false
+}
+
+// decisions returns all recorded decisions in the order they were made.
+func (s *QuickBasePersonalMCPServer) decisions() ([]decision, error) {
+	var all []decision
+	err := s.store.each(decisionBucket, func(key string, data []byte) error {
+		var d decision
+		if err := json.Unmarshal(data, &d); err != nil {
+			return nil
+		}
+		all = append(all, d)
+		return nil
+	})
+	return all, err
+}
+
+// writeDecision formats a decision as a markdown block.
+func writeDecision(results *strings.Builder, d decision) {
+	results.WriteString(fmt.Sprintf("### #%d %s", d.ID, d.Title))
+	if d.SupersededBy != 0 {
+		results.WriteString(fmt.Sprintf(" (superseded by #%d)", d.SupersededBy))
+	}
+	results.WriteString("\n\n")
+	results.WriteString(d.Decision + "\n\n")
+	if d.Rationale != "" {
+		results.WriteString(fmt.Sprintf("**Why:** %s\n\n", d.Rationale))
+	}
+	var meta []string
+	if len(d.Features) > 0 {
+		meta = append(meta, "features: "+strings.Join(d.Features, ", "))
+	}
+	if len(d.Tags) > 0 {
+		meta = append(meta, "tags: "+strings.Join(d.Tags, ", "))
+	}
+	if d.Supersedes != 0 {
+		meta = append(meta, fmt.Sprintf("supersedes #%d", d.Supersedes))
+	}
+	meta = append(meta, "recorded "+d.CreatedAt.Local().Format("2006-01-02"))
+	results.WriteString("_" + strings.Join(meta, " · ") + "_\n\n")
+}
+
+func (s *QuickBasePersonalMCPServer) handleRecordDecision(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	var params struct {
+		Title      string   `json:"title"`
+		Decision   string   `json:"decision"`
+		Rationale  string   `json:"rationale"`
+		Features   []string `json:"features"`
+		Tags       []string `json:"tags"`
+		Supersedes uint64   `json:"supersedes"`
+	}
+	argsData, _ := json.Marshal(request.Params.Arguments)
+	if err := json.Unmarshal(argsData, &params); err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Invalid parameters: %v", err)), nil
+	}
+	if params.Title == "" || params.Decision == "" {
+		return mcp.NewToolResultError("title and decision are required"), nil
+	}
+
+	var old decision
+	if params.Supersedes != 0 {
+		found, err := s.store.get(decisionBucket, sequenceKey(params.Supersedes), &old)
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("Failed to read decision #%d: %v", params.Supersedes, err)), nil
+		}
+		if !found {
+			return mcp.NewToolResultError(fmt.Sprintf("Unknown decision: #%d", params.Supersedes)), nil
+		}
+	}
+
+	var recorded decision
+	id, err := s.store.insert(decisionBucket, func(id uint64) interface{} {
+		recorded = decision{
+			ID:         id,
+			Title:      params.Title,
+			Decision:   params.Decision,
+			Rationale:  params.Rationale,
+			Features:   params.Features,
+			Tags:       params.Tags,
+			Supersedes: params.Supersedes,
+			CreatedAt:  time.Now(),
+		}
+		return recorded
+	})
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to record decision: %v", err)), nil
+	}
+
+	if params.Supersedes != 0 {
+		old.SupersededBy = id
+		if err := s.store.put(decisionBucket, sequenceKey(old.ID), old); err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("Recorded #%d but failed to mark #%d superseded: %v", id, old.ID, err)), nil
+		}
+	}
+
+	var results strings.Builder
+	results.WriteString("Recorded decision:\n\n")
+	writeDecision(&results, recorded)
+	return mcp.NewToolResultText(results.String()), nil
+}
+
+func (s *QuickBasePersonalMCPServer) handleSearchDecisions(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	var params struct {
+		Query             string `json:"query"`
+		Feature           string `json:"feature"`
+		IncludeSuperseded bool   `json:"include_superseded"`
+	}
+	argsData, _ := json.Marshal(request.Params.Arguments)
+	if err := json.Unmarshal(argsData, &params); err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Invalid parameters: %v", err)), nil
+	}
+
+	all, err := s.decisions()
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to read decisions: %v", err)), nil
+	}
+
+	var results strings.Builder
+	results.WriteString("# Decisions\n\n")
+	count := 0
+	for i := len(all) - 1; i >= 0; i-- {
+		d := all[i]
+		if d.SupersededBy != 0 && !params.IncludeSuperseded {
+			continue
+		}
+		if params.Feature != "" && !d.hasFeature(params.Feature) {
+			continue
+		}
+		if !d.matches(params.Query) {
+			continue
+		}
+		writeDecision(&results, d)
+		count++
+	}
+	if count == 0 {
+		results.WriteString("No matching decisions. Use record_decision to add one.\n")
+	}
+
+	return mcp.NewToolResultText(results.String()), nil
+}
@@ -0,0 +1,353 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// outdatedDep is a dependency with a newer version available.
+type outdatedDep struct {
+	name, current, wanted, latest string
+	indirect                      bool
+	note                          string
+}
+
+// vulnerability is a known vulnerability reaching a repo through a
+// dependency.
+type vulnerability struct {
+	id, pkg, version, fixed, severity, summary string
+	// reached is true when the vulnerable code is called (govulncheck) or
+	// the package is a direct dependency (npm audit)
+	reached bool
+}
+
+// goOutdated reads go list -m -u -json all: modules with an Update.
+func goOutdated(ctx context.Context, repoPath string, timeout time.Duration) ([]outdatedDep, error) {
+	result, err := runShell(ctx, repoPath, timeout, "go list -m -u -json all")
+	if err != nil {
+		return nil, err
+	}
+	if result.timedOut || result.exitCode != 0 {
+		return nil, fmt.Errorf("go list -m -u failed: %s", tailLines(result.output, 5))
+	}
+	var deps []outdatedDep
+	dec := json.NewDecoder(strings.NewReader(result.output))
+	for {
+		var m struct {
+			Path, Version, Deprecated string
+			Main, Indirect            bool
+			Update                    *struct{ Version string }
+		}
+		if err := dec.Decode(&m); err == io.EOF {
+			break
+		} else if err != nil {
+			return deps, err
+		}
+		if m.Main || (m.Update == nil && m.Deprecated == "") {
+			continue
+		}
+		d := outdatedDep{name: m.Path, current: m.Version, indirect: m.Indirect}
+		if m.Update != nil {
+			d.latest = m.Update.Version
+		}
+		if m.Deprecated != "" {
+			d.note = "deprecated: " + m.Deprecated
+		}
+		deps = append(deps, d)
+	}
+	return deps, nil
+}
+
+// govulncheckFindings reads govulncheck -format json. A finding whose trace
+// reaches a function is called; others are only imported or required.
+func govulncheckFindings(ctx context.Context, repoPath string, timeout time.Duration) ([]vulnerability, error) {
+	result, err := runShell(ctx, repoPath, timeout, "govulncheck -format json ./...")
+	if err != nil {
+		return nil, err
+	}
+	if result.exitCode == 127 {
+		return nil, fmt.Errorf("govulncheck not installed (go install golang.org/x/vuln/cmd/govulncheck@latest)")
+	}
+	if result.timedOut {
+		return nil, fmt.Errorf("govulncheck timed out after %s", timeout)
+	}
+	summaries := map[string]string{}
+	found := map[string]*vulnerability{}
+	var order []string
+	dec := json.NewDecoder(strings.NewReader(result.output[max(strings.Index(result.output, "{"), 0):]))
+	for {
+		var msg struct {
+			OSV *struct {
+				ID      string `json:"id"`
+				Summary string `json:"summary"`
+			} `json:"osv"`
+			Finding *struct {
+				OSV          string `json:"osv"`
+				FixedVersion string `json:"fixed_version"`
+				Trace        []struct {
+					Module   string `json:"module"`
+					Version  string `json:"version"`
+					Function string `json:"function"`
+				} `json:"trace"`
+			} `json:"finding"`
+		}
+		if err := dec.Decode(&msg); err == io.EOF {
+			break
+		} else if err != nil {
+			if len(order) == 0 && len(summaries) == 0 {
+				return nil, fmt.Errorf("govulncheck failed: %s", tailLines(result.output, 5))
+			}
+			break
+		}
+		if msg.OSV != nil {
+			summaries[msg.OSV.ID] = msg.OSV.Summary
+		}
+		if f := msg.Finding; f != nil && len(f.Trace) > 0 {
+			v := found[f.OSV]
+			if v == nil {
+				v = &vulnerability{id: f.OSV, pkg: f.Trace[0].Module, version: f.Trace[0].Version, fixed: f.FixedVersion}
+				found[f.OSV] = v
+				order = append(order, f.OSV)
+			}
+			v.reached = v.reached || f.Trace[0].Function != ""
+		}
+	}
+	var vulns []vulnerability
+	for _, id := range order {
+		v := *found[id]
+		v.summary = summaries[id]
+		vulns = append(vulns, v)
+	}
+	return vulns, nil
+}
+
+// npmOutdated reads npm outdated --json, which exits 1 when anything is.
+func npmOutdated(ctx context.Context, repoPath string, timeout time.Duration) ([]outdatedDep, error) {
+	result, err := runShell(ctx, repoPath, timeout, "npm outdated --json")
+	if err != nil {
+		return nil, err
+	}
+	var out map[string]struct {
+		Current, Wanted, Latest string
+		Type                    string `json:"type"`
+	}
+	start := strings.Index(result.output, "{")
+	if result.timedOut || start < 0 || json.Unmarshal([]byte(result.output[start:]), &out) != nil {
+		return nil, fmt.Errorf("npm outdated failed: %s", tailLines(result.output, 5))
+	}
+	if _, failed := out["error"]; failed {
+		return nil, fmt.Errorf("npm outdated failed: %s", tailLines(result.output, 5))
+	}
+	var deps []outdatedDep
+	for _, name := range sortedKeys(out) {
+		o := out[name]
+		d := outdatedDep{name: name, current: o.Current, wanted: o.Wanted, latest: o.Latest}
+		if o.Current == "" {
+			d.current, d.note = "missing", "not installed"
+		}
+		if o.Type == "devDependencies" {
+			d.note = strings.TrimPrefix(d.note+", dev", ", ")
+		}
+		deps = append(deps, d)
+	}
+	return deps, nil
+}
+
+// npmAudit reads npm audit --json. Advisories in via name the vulnerable
+// package; plain strings in via are dependencies it comes through.
+func npmAudit(ctx context.Context, repoPath string, timeout time.Duration) ([]vulnerability, error) {
+	result, err := runShell(ctx, repoPath, timeout, "npm audit --json")
+	if err != nil {
+		return nil, err
+	}
+	var out struct {
+		Vulnerabilities map[string]struct {
+			Severity     string            `json:"severity"`
+			IsDirect     bool              `json:"isDirect"`
+			Range        string            `json:"range"`
+			Via          []json.RawMessage `json:"via"`
+			FixAvailable json.RawMessage   `json:"fixAvailable"`
+		} `json:"vulnerabilities"`
+		Error *struct {
+			Summary string `json:"summary"`
+		} `json:"error"`
+	}
+	start := strings.Index(result.output, "{")
+	if result.timedOut || start < 0 || json.Unmarshal([]byte(result.output[start:]), &out) != nil {
+		return nil, fmt.Errorf("npm audit failed: %s", tailLines(result.output, 5))
+	}
+	if out.Error != nil {
+		return nil, fmt.Errorf("npm audit failed: %s", out.Error.Summary)
+	}
+	var vulns []vulnerability
+	for _, name := range sortedKeys(out.Vulnerabilities) {
+		v := out.Vulnerabilities[name]
+		vuln := vulnerability{pkg: name, version: v.Range, severity: v.Severity, reached: v.IsDirect}
+		var through []string
+		for _, raw := range v.Via {
+			var advisory struct {
+				Title string `json:"title"`
+				URL   string `json:"url"`
+			}
+			var dep string
+			if json.Unmarshal(raw, &dep) == nil {
+				through = append(through, dep)
+			} else if json.Unmarshal(raw, &advisory) == nil && vuln.id == "" {
+				vuln.id = advisory.URL[strings.LastIndex(advisory.URL, "/")+1:]
+				vuln.summary = advisory.Title
+			}
+		}
+		if vuln.summary == "" {
+			vuln.summary = "via " + strings.Join(through, ", ")
+		}
+		var fix struct {
+			Name, Version string
+		}
+		switch {
+		case string(v.FixAvailable) == "true":
+			vuln.fixed = "npm audit fix"
+		case json.Unmarshal(v.FixAvailable, &fix) == nil && fix.Version != "":
+			vuln.fixed = fmt.Sprintf("%s@%s (breaking)", fix.Name, fix.Version)
+		default:
+			vuln.fixed = "none"
+		}
+		vulns = append(vulns, vuln)
+	}
+	return vulns, nil
+}
+
+func (s *QuickBasePersonalMCPServer) handleCheckDependencies(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	var params struct {
+		Repo            string `json:"repo"`
+		IncludeIndirect bool   `json:"include_indirect"`
+		Vulnerabilities *bool  `json:"vulnerabilities"`
+		TimeoutSeconds  int    `json:"timeout_seconds"`
+	}
+	argsData, _ := json.Marshal(request.Params.Arguments)
+	if err := json.Unmarshal(argsData, &params); err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Invalid parameters: %v", err)), nil
+	}
+	checkVulns := params.Vulnerabilities == nil || *params.Vulnerabilities
+	timeout := defaultTestTimeout
+	if params.TimeoutSeconds > 0 {
+		timeout = time.Duration(params.TimeoutSeconds) * time.Second
+	}
+	if params.Repo == "" {
+		params.Repo = "all"
+	}
+	repos, err := gitRepos(params.Repo)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	if params.Repo == "all" {
+		repos = repos[:2]
+	}
+
+	var results strings.Builder
+	results.WriteString("# Dependencies\n\n")
+	for _, repo := range repos {
+		results.WriteString(fmt.Sprintf("## %s\n\n", repo.name))
+		var outdated []outdatedDep
+		var vulns []vulnerability
+		var outdatedErr, vulnErr error
+		switch {
+		case fileExists(filepath.Join(repo.path, "go.mod")):
+			outdated, outdatedErr = goOutdated(ctx, repo.path, timeout)
+			if checkVulns {
+				vulns, vulnErr = govulncheckFindings(ctx, repo.path, timeout)
+			}
+		case fileExists(filepath.Join(repo.path, "package.json")):
+			outdated, outdatedErr = npmOutdated(ctx, repo.path, timeout)
+			if checkVulns {
+				vulns, vulnErr = npmAudit(ctx, repo.path, timeout)
+			}
+		default:
+			results.WriteString("No go.mod or package.json\n\n")
+			continue
+		}
+
+		results.WriteString("### Outdated\n\n")
+		indirect := 0
+		var shown []outdatedDep
+		for _, d := range outdated {
+			if d.indirect && !params.IncludeIndirect {
+				indirect++
+				continue
+			}
+			shown = append(shown, d)
+		}
+		switch {
+		case outdatedErr != nil:
+			results.WriteString(fmt.Sprintf("⚠️ %v\n\n", outdatedErr))
+		case len(shown) == 0:
+			results.WriteString("Everything is up to date.\n\n")
+		default:
+			results.WriteString("| Dependency | Current | Wanted | Latest | Note |\n|---|---|---|---|---|\n")
+			for _, d := range shown {
+				note := d.note
+				if d.indirect {
+					note = strings.TrimPrefix(note+", indirect", ", ")
+				}
+				if current := majorVersion(d.current); current >= 0 && majorVersion(d.latest) > current {
+					note = strings.TrimPrefix(note+", major", ", ")
+				}
+				results.WriteString(fmt.Sprintf("| %s | %s | %s | %s | %s |\n", d.name, d.current, dash(d.wanted), dash(d.latest), docCell(note)))
+			}
+			results.WriteString("\n")
+		}
+		if indirect > 0 {
+			results.WriteString(fmt.Sprintf("%d indirect module(s) also have updates; set include_indirect to list them.\n\n", indirect))
+		}
+
+		if !checkVulns {
+			continue
+		}
+		results.WriteString("### Vulnerabilities\n\n")
+		switch {
+		case vulnErr != nil:
+			results.WriteString(fmt.Sprintf("⚠️ %v\n\n", vulnErr))
+		case len(vulns) == 0:
+			results.WriteString("None known.\n\n")
+		default:
+			// Reached ones first: called code, or direct dependencies
+			sort.SliceStable(vulns, func(i, j int) bool { return vulns[i].reached && !vulns[j].reached })
+			reachedLabel := "Called"
+			if !fileExists(filepath.Join(repo.path, "go.mod")) {
+				reachedLabel = "Direct"
+			}
+			results.WriteString(fmt.Sprintf("| ID | Package | Version | Severity | Fixed in | %s | Summary |\n|---|---|---|---|---|---|---|\n", reachedLabel))
+			for _, v := range vulns {
+				reached := "no"
+				if v.reached {
+					reached = "⚠️ yes"
+				}
+				results.WriteString(fmt.Sprintf("| %s | %s | %s | %s | %s | %s | %s |\n", dash(v.id), v.pkg, dash(v.version), dash(v.severity), dash(v.fixed), reached, docCell(v.summary)))
+			}
+			results.WriteString("\n")
+		}
+	}
+	return mcp.NewToolResultText(strings.TrimRight(results.String(), "\n") + "\n"), nil
+}
+
+// fileExists reports whether path exists.
+func fileExists(path string) bool {
+	_, err := os.Stat(path)
+	return err == nil
+}
+
+// dash is s, or "—" when empty.
+func dash(s string) string {
+	if s == "" {
+		return "—"
+	}
+	return s
+}
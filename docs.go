@@ -0,0 +1,227 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// docSection is a markdown section: the text under a heading up to the
+// next heading of any level.
+type docSection struct {
+	file    string
+	heading string
+	body    string
+}
+
+// docCoverage records where a feature shows up in one SDK's documentation.
+type docCoverage struct {
+	implemented bool
+	sections    []string
+	examples    []string
+}
+
+// keywordPattern builds a case-insensitive matcher for a feature's keywords,
+// anchoring on word boundaries so short terms like "sso" don't match inside
+// other words.
+func keywordPattern(keywords []string) *regexp.Regexp {
+	alts := make([]string, 0, len(keywords))
+	for _, kw := range keywords {
+		alts = append(alts, regexp.QuoteMeta(kw))
+	}
+	return regexp.MustCompile(`(?i)\b(?:` + strings.Join(alts, "|") + `)\b`)
+}
+
+// docFiles lists a repo's README and the markdown under docs/.
+func docFiles(repoPath string) []string {
+	var files []string
+	for _, name := range []string{"README.md", "readme.md", "README.mdx"} {
+		if _, err := os.Stat(filepath.Join(repoPath, name)); err == nil {
+			files = append(files, name)
+			break
+		}
+	}
+	walkRepo(filepath.Join(repoPath, "docs"), func(rel string) error {
+		if ext := filepath.Ext(rel); ext == ".md" || ext == ".mdx" {
+			files = append(files, "docs/"+rel)
+		}
+		return nil
+	})
+	return files
+}
+
+// readDocSections splits the README and docs/ markdown of a repo into
+// sections. Headings inside fenced code blocks are ignored.
+func readDocSections(repoPath string) []docSection {
+	var sections []docSection
+	for _, file := range docFiles(repoPath) {
+		f, err := os.Open(filepath.Join(repoPath, file))
+		if err != nil {
+			continue
+		}
+		current := docSection{file: file, heading: "(top)"}
+		var body strings.Builder
+		inFence := false
+		scanner := bufio.NewScanner(f)
+		scanner.Buffer(make([]byte, 1024*1024), 1024*1024)
+		for scanner.Scan() {
+			line := scanner.Text()
+			if strings.HasPrefix(strings.TrimSpace(line), "```") {
+				inFence = !inFence
+			}
+			if !inFence && strings.HasPrefix(line, "#") {
+				current.body = body.String()
+				sections = append(sections, current)
+				current = docSection{file: file, heading: strings.TrimSpace(strings.TrimLeft(line, "#"))}
+				body.Reset()
+				continue
+			}
+			body.WriteString(line)
+			body.WriteString("\n")
+		}
+		f.Close()
+		current.body = body.String()
+		sections = append(sections, current)
+	}
+	return sections
+}
+
+// exampleFiles lists files under the examples/ (or example/) directory.
+func exampleFiles(repoPath string) []string {
+	var files []string
+	for _, dir := range []string{"examples", "example"} {
+		walkRepo(filepath.Join(repoPath, dir), func(rel string) error {
+			files = append(files, dir+"/"+rel)
+			return nil
+		})
+	}
+	return files
+}
+
+// docsCoverageFor reports where each feature is documented in one SDK.
+func docsCoverageFor(repoPath string, features map[string]featureEntry, implPaths func(featureEntry) []string) map[string]*docCoverage {
+	sections := readDocSections(repoPath)
+	examples := exampleFiles(repoPath)
+	exampleContent := make(map[string]string, len(examples))
+	for _, ex := range examples {
+		if data, err := os.ReadFile(filepath.Join(repoPath, ex)); err == nil {
+			exampleContent[ex] = string(data)
+		}
+	}
+
+	coverage := map[string]*docCoverage{}
+	for _, name := range featureNames(features) {
+		feature := features[name]
+		cov := &docCoverage{implemented: featureImplemented(repoPath, implPaths(feature))}
+		pattern := keywordPattern(append([]string{name}, feature.Keywords...))
+		for _, sec := range sections {
+			if pattern.MatchString(sec.heading) || pattern.MatchString(sec.body) {
+				cov.sections = append(cov.sections, fmt.Sprintf("%s › %s", sec.file, sec.heading))
+			}
+		}
+		for _, ex := range examples {
+			if pattern.MatchString(ex) || pattern.MatchString(exampleContent[ex]) {
+				cov.examples = append(cov.examples, ex)
+			}
+		}
+		coverage[name] = cov
+	}
+	return coverage
+}
+
+func (s *QuickBasePersonalMCPServer) handleDocsCoverage(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	var params struct {
+		Feature string `json:"feature"`
+	}
+	argsData, _ := json.Marshal(request.Params.Arguments)
+	if err := json.Unmarshal(argsData, &params); err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Invalid parameters: %v", err)), nil
+	}
+	features, err := loadFeatureMap()
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to load feature map: %v", err)), nil
+	}
+	if params.Feature != "" {
+		if _, ok := features[params.Feature]; !ok {
+			return mcp.NewToolResultError(fmt.Sprintf("Unknown feature: %s", params.Feature)), nil
+		}
+	}
+
+	jsCov := docsCoverageFor(quickbaseJSPath, features, func(f featureEntry) []string { return f.JS })
+	goCov := docsCoverageFor(quickbaseGoPath, features, func(f featureEntry) []string { return f.Go })
+
+	names := featureNames(features)
+	if params.Feature != "" {
+		names = []string{params.Feature}
+	}
+
+	mark := func(ok bool) string {
+		if ok {
+			return "✅"
+		}
+		return "❌"
+	}
+
+	var results strings.Builder
+	results.WriteString("# Documentation Coverage\n\n")
+	results.WriteString("| Feature | JS impl | JS docs | JS examples | Go impl | Go docs | Go examples |\n")
+	results.WriteString("|---|---|---|---|---|---|---|\n")
+	var gaps []string
+	for _, name := range names {
+		js, g := jsCov[name], goCov[name]
+		results.WriteString(fmt.Sprintf("| %s | %s | %d | %d | %s | %d | %d |\n",
+			name, mark(js.implemented), len(js.sections), len(js.examples),
+			mark(g.implemented), len(g.sections), len(g.examples)))
+
+		for _, side := range []struct {
+			label string
+			cov   *docCoverage
+			other *docCoverage
+		}{{"JS", js, g}, {"Go", g, js}} {
+			if side.cov.implemented && len(side.cov.sections) == 0 {
+				gaps = append(gaps, fmt.Sprintf("- **%s**: implemented in %s but not mentioned in its README/docs", name, side.label))
+			}
+			if side.cov.implemented && len(side.cov.examples) == 0 && len(side.other.examples) > 0 {
+				gaps = append(gaps, fmt.Sprintf("- **%s**: has examples in the other SDK but none in %s", name, side.label))
+			}
+		}
+	}
+
+	results.WriteString("\n## Gaps\n\n")
+	if len(gaps) == 0 {
+		results.WriteString("None - every implemented feature is documented in both SDKs.\n")
+	} else {
+		results.WriteString(strings.Join(gaps, "\n"))
+		results.WriteString("\n")
+	}
+
+	results.WriteString("\n## Details\n\n")
+	for _, name := range names {
+		results.WriteString(fmt.Sprintf("### %s\n\n", name))
+		for _, side := range []struct {
+			label string
+			cov   *docCoverage
+		}{{"JavaScript", jsCov[name]}, {"Go", goCov[name]}} {
+			results.WriteString(fmt.Sprintf("**%s**\n", side.label))
+			if len(side.cov.sections) == 0 && len(side.cov.examples) == 0 {
+				results.WriteString("- (no docs or examples)\n")
+			}
+			for _, sec := range side.cov.sections {
+				results.WriteString(fmt.Sprintf("- docs: %s\n", sec))
+			}
+			for _, ex := range side.cov.examples {
+				results.WriteString(fmt.Sprintf("- example: %s\n", ex))
+			}
+			results.WriteString("\n")
+		}
+	}
+
+	return mcp.NewToolResultText(results.String()), nil
+}
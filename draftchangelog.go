@@ -0,0 +1,191 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// commitGroups are the changelog sections for conventional commit types,
+// in the order they're written. Types not listed go under Other.
+var commitGroups = []struct {
+	title string
+	types []string
+}{
+	{"Features", []string{"feat", "feature"}},
+	{"Bug Fixes", []string{"fix", "bugfix"}},
+	{"Performance", []string{"perf"}},
+	{"Refactoring", []string{"refactor"}},
+	{"Documentation", []string{"docs", "doc"}},
+	{"Tests", []string{"test", "tests"}},
+	{"Build and CI", []string{"build", "ci"}},
+	{"Chores", []string{"chore", "style", "revert"}},
+}
+
+var semverTag = regexp.MustCompile(`^(v?)(\d+)\.(\d+)\.(\d+)`)
+
+// nextVersion suggests the version after tag for a release with breaking
+// changes or features. Before 1.0 a breaking change only bumps the minor.
+func nextVersion(tag string, breaking, features bool) (string, string) {
+	m := semverTag.FindStringSubmatch(tag)
+	if m == nil {
+		return "", ""
+	}
+	major, _ := strconv.Atoi(m[2])
+	minor, _ := strconv.Atoi(m[3])
+	patch, _ := strconv.Atoi(m[4])
+	switch {
+	case breaking && major > 0:
+		return fmt.Sprintf("%s%d.0.0", m[1], major+1), "breaking changes"
+	case breaking || features:
+		reason := "new features"
+		if breaking {
+			reason = "breaking changes before 1.0"
+		}
+		return fmt.Sprintf("%s%d.%d.0", m[1], major, minor+1), reason
+	}
+	return fmt.Sprintf("%s%d.%d.%d", m[1], major, minor, patch+1), "fixes only"
+}
+
+func (s *QuickBasePersonalMCPServer) handleDraftChangelog(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	var params struct {
+		Repo    string `json:"repo"`
+		From    string `json:"from"`
+		To      string `json:"to"`
+		Version string `json:"version"`
+	}
+	argsData, _ := json.Marshal(request.Params.Arguments)
+	if err := json.Unmarshal(argsData, &params); err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Invalid parameters: %v", err)), nil
+	}
+	if params.Repo == "" {
+		// Release prep is usually both SDKs
+		params.Repo = "all"
+	}
+	repos, err := gitRepos(params.Repo)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	if params.Repo == "all" {
+		repos = repos[:2]
+	}
+
+	var results strings.Builder
+	results.WriteString("# Changelog draft\n\n")
+	for _, repo := range repos {
+		results.WriteString(fmt.Sprintf("## %s\n\n", repo.name))
+		to := params.To
+		if to == "" {
+			to = "HEAD"
+		}
+		if err := verifyRef(repo.path, to); err != nil {
+			results.WriteString(fmt.Sprintf("%v\n\n", err))
+			continue
+		}
+		from := params.From
+		if from == "" {
+			if params.To == "" {
+				from, _ = previousTag(repo.path, "")
+			} else {
+				from, _ = previousTag(repo.path, to)
+			}
+		} else if err := verifyRef(repo.path, from); err != nil {
+			results.WriteString(fmt.Sprintf("%v\n\n", err))
+			continue
+		}
+		rangeArg := to
+		if from != "" {
+			rangeArg = from + ".." + to
+		}
+		commits, err := gitLog(repo.path, false, "--no-merges", rangeArg)
+		if err != nil {
+			results.WriteString(fmt.Sprintf("⚠️ %v\n\n", err))
+			continue
+		}
+		switch {
+		case len(commits) == 0:
+			results.WriteString(fmt.Sprintf("Nothing to release: no commits from %s to %s.\n\n", orNone(from), to))
+			continue
+		case from == "":
+			results.WriteString(fmt.Sprintf("No tags yet, so all %d commit(s) up to %s are included.\n\n", len(commits), to))
+		default:
+			results.WriteString(fmt.Sprintf("%d commit(s) from %s to %s.\n\n", len(commits), from, to))
+		}
+
+		// Breaking changes are marked with ! or a BREAKING CHANGE footer
+		breakingFooter := map[string]bool{}
+		if out, err := runGit(repo.path, "log", "--no-merges", "--format=%h", "--grep=^BREAKING[ -]CHANGE", rangeArg); err == nil && out != "" {
+			for _, hash := range strings.Split(out, "\n") {
+				breakingFooter[hash] = true
+			}
+		}
+		sections := map[string][]string{}
+		var breaking, other []string
+		for _, c := range commits {
+			m := conventionalPrefix.FindStringSubmatch(c.subject)
+			if m == nil {
+				other = append(other, fmt.Sprintf("- %s (%s)", c.subject, c.hash))
+				continue
+			}
+			description := strings.TrimSpace(c.subject[len(m[0]):])
+			line := fmt.Sprintf("- %s (%s)", description, c.hash)
+			if m[3] != "" {
+				line = fmt.Sprintf("- **%s:** %s (%s)", m[3], description, c.hash)
+			}
+			if m[4] == "!" || breakingFooter[c.hash] {
+				breaking = append(breaking, line)
+			}
+			title := "Other"
+			for _, g := range commitGroups {
+				if containsString(g.types, strings.ToLower(m[1])) {
+					title = g.title
+					break
+				}
+			}
+			if title == "Other" {
+				other = append(other, line)
+			} else {
+				sections[title] = append(sections[title], line)
+			}
+		}
+
+		version := params.Version
+		if version == "" {
+			if next, reason := nextVersion(from, len(breaking) > 0, len(sections["Features"]) > 0); next != "" {
+				version = next
+				results.WriteString(fmt.Sprintf("Suggested version: %s (%s since %s)\n\n", next, reason, from))
+			} else {
+				version = "Unreleased"
+			}
+		}
+		var draft strings.Builder
+		draft.WriteString(fmt.Sprintf("## [%s] - %s\n", version, time.Now().Format("2006-01-02")))
+		if len(breaking) > 0 {
+			draft.WriteString("\n### ⚠ BREAKING CHANGES\n\n" + strings.Join(breaking, "\n") + "\n")
+		}
+		for _, g := range commitGroups {
+			if lines := sections[g.title]; len(lines) > 0 {
+				draft.WriteString(fmt.Sprintf("\n### %s\n\n%s\n", g.title, strings.Join(lines, "\n")))
+			}
+		}
+		if len(other) > 0 {
+			draft.WriteString("\n### Other\n\n" + strings.Join(other, "\n") + "\n")
+		}
+		results.WriteString(fmt.Sprintf("```markdown\n%s```\n\n", draft.String()))
+		if len(other) > 0 {
+			results.WriteString(fmt.Sprintf("%d commit(s) under Other have no conventional commit type; move them to the right section.\n\n", len(other)))
+		}
+		if _, err := os.Stat(filepath.Join(repo.path, "CHANGELOG.md")); err == nil {
+			results.WriteString("Add it above the latest entry in CHANGELOG.md.\n\n")
+		}
+	}
+	return mcp.NewToolResultText(strings.TrimRight(results.String(), "\n") + "\n"), nil
+}
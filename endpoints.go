@@ -0,0 +1,684 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"go/format"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"gopkg.in/yaml.v3"
+)
+
+// findOperation looks an operation up by operationId (any casing) or by
+// "METHOD /path".
+func findOperation(ops []specOperation, id string) (specOperation, bool) {
+	key := normalizeTerm(id)
+	for _, op := range ops {
+		if normalizeTerm(op.OperationID) == key || strings.EqualFold(op.Method+" "+op.Path, id) {
+			return op, true
+		}
+	}
+	return specOperation{}, false
+}
+
+// sdkMethod is where an SDK declares the client method for an operation.
+type sdkMethod struct {
+	name, file, signature string
+	found                 bool
+}
+
+// sdkMethodIndex maps normalized names of an SDK's functions, methods and
+// interface members to where they are declared. Generated clients name
+// methods by operationId, so looking an operation up is a name match
+// rather than a call-site search. Declared methods win over interface
+// members that only describe them.
+func sdkMethodIndex(repoPath string, keep func(string) bool, isGo bool) map[string]sdkMethod {
+	index := map[string]sdkMethod{}
+	members := map[string]sdkMethod{}
+	for _, rel := range listSourceFiles(repoPath, keep) {
+		data, err := os.ReadFile(filepath.Join(repoPath, rel))
+		if err != nil {
+			continue
+		}
+		var symbols []apiSymbol
+		if isGo {
+			symbols = goSymbols(rel, data)
+		} else {
+			symbols = jsSymbols(rel, string(data))
+			// Methods of non-exported classes and object literals
+			for _, m := range jsMethodDecl.FindAllStringSubmatch(string(data), -1) {
+				if key := normalizeTerm(m[1]); !jsMethodKeywords[m[1]] && !members[key].found {
+					members[key] = sdkMethod{name: m[1], file: rel, signature: m[1] + "(…)", found: true}
+				}
+			}
+		}
+		for _, sym := range symbols {
+			short := sym.name[strings.LastIndex(sym.name, ".")+1:]
+			if key := normalizeTerm(short); sym.kind != "type" && !index[key].found {
+				index[key] = sdkMethod{name: short, file: rel, signature: sym.signature, found: true}
+			}
+			for _, f := range sym.fields {
+				if key := normalizeTerm(f); !members[key].found {
+					members[key] = sdkMethod{name: f, file: rel, signature: sym.name + "." + f, found: true}
+				}
+			}
+		}
+	}
+	for key, m := range members {
+		if !index[key].found {
+			index[key] = m
+		}
+	}
+	return index
+}
+
+// generatedMethodAffixes are the variants generators emit alongside the
+// plain method: typescript-fetch's runQueryRaw, oapi-codegen's
+// RunQueryWithResponse and NewRunQueryRequest.
+var generatedMethodAffixes = []struct{ prefix, suffix string }{
+	{"", ""},
+	{"", "withresponse"},
+	{"", "withbodywithresponse"},
+	{"", "withbody"},
+	{"", "raw"},
+	{"new", "request"},
+}
+
+// lookupOperation finds the client method for an operationId, preferring
+// the plain name over generated variants.
+func lookupOperation(index map[string]sdkMethod, opID string) sdkMethod {
+	key := normalizeTerm(opID)
+	for _, a := range generatedMethodAffixes {
+		if m, ok := index[a.prefix+key+a.suffix]; ok {
+			return m
+		}
+	}
+	return sdkMethod{}
+}
+
+// goFieldName turns a JSON property into the Go field name a generator
+// would emit: upper camel case with ID/URL initialisms.
+func goFieldName(name string) string {
+	var b strings.Builder
+	upper := true
+	for _, r := range name {
+		if r == '_' || r == '-' || r == ' ' {
+			upper = true
+			continue
+		}
+		if upper {
+			r = unicode.ToUpper(r)
+			upper = false
+		}
+		b.WriteRune(r)
+	}
+	out := b.String()
+	for _, initialism := range []string{"Id", "Url"} {
+		if strings.HasSuffix(out, initialism) {
+			out = strings.TrimSuffix(out, initialism) + strings.ToUpper(initialism)
+		}
+	}
+	return out
+}
+
+// lowerFirst is the JS spelling of an operationId (runQuery).
+func lowerFirst(s string) string {
+	if s == "" {
+		return s
+	}
+	return strings.ToLower(s[:1]) + s[1:]
+}
+
+// exampleField is one property of a generated request value.
+type exampleField struct {
+	name     string
+	required bool
+	schema   *yaml.Node
+}
+
+// schemaFields lists an object schema's properties, required first, in
+// document order otherwise.
+func schemaFields(schema *yaml.Node) []exampleField {
+	required := map[string]bool{}
+	if req := mappingValue(schema, "required"); req != nil {
+		for _, r := range req.Content {
+			required[r.Value] = true
+		}
+	}
+	props := mappingValue(schema, "properties")
+	var fields []exampleField
+	for j := 0; props != nil && j+1 < len(props.Content); j += 2 {
+		name := props.Content[j].Value
+		fields = append(fields, exampleField{name: name, required: required[name], schema: props.Content[j+1]})
+	}
+	sort.SliceStable(fields, func(i, j int) bool { return fields[i].required && !fields[j].required })
+	return fields
+}
+
+// exampleValue builds a plausible value for a schema: its example, enum or
+// default when the spec has one, then the configured IDs for properties
+// that name a table or app, then a placeholder for the type.
+type exampleValue struct {
+	root *yaml.Node
+	cfg  serverConfig
+}
+
+// literal is a scalar sample rendered for both languages.
+type literal struct {
+	kind  string // "string", "integer", "number", "boolean"
+	value string
+}
+
+func (e exampleValue) scalar(name string, schema *yaml.Node) literal {
+	typ := ""
+	if t := mappingValue(schema, "type"); t != nil {
+		typ = t.Value
+	}
+	for _, key := range []string{"example", "default"} {
+		if v := mappingValue(schema, key); v != nil && v.Kind == yaml.ScalarNode {
+			return literal{kind: typ, value: v.Value}
+		}
+	}
+	if v := mappingValue(schema, "enum"); v != nil && len(v.Content) > 0 {
+		return literal{kind: typ, value: v.Content[0].Value}
+	}
+	n := normalizeTerm(name)
+	switch typ {
+	case "integer", "number":
+		if min := scalarAt(schema, "minimum"); min != "" && !strings.HasPrefix(min, "-") && min != "0" {
+			return literal{kind: typ, value: min}
+		}
+		if typ == "integer" && (strings.HasSuffix(n, "fieldid") || n == "fid" || n == "select") {
+			return literal{kind: typ, value: "3"}
+		}
+		return literal{kind: typ, value: "0"}
+	case "boolean":
+		return literal{kind: typ, value: "false"}
+	}
+	switch scalarAt(schema, "format") {
+	case "date-time":
+		return literal{kind: "string", value: "2024-01-15T09:30:00Z"}
+	case "date":
+		return literal{kind: "string", value: "2024-01-15"}
+	case "time":
+		return literal{kind: "string", value: "09:30:00"}
+	case "email":
+		return literal{kind: "string", value: "user@example.com"}
+	case "uri", "url":
+		return literal{kind: "string", value: "https://example.com"}
+	case "uuid":
+		return literal{kind: "string", value: "3fa85f64-5717-4562-b3fc-2c963f66afa6"}
+	case "hostname":
+		return literal{kind: "string", value: e.cfg.Hostname()}
+	case "byte":
+		return literal{kind: "string", value: "aGVsbG8="}
+	}
+	switch {
+	case n == "from" || n == "to" || n == "tableid" || n == "dbid":
+		return literal{kind: "string", value: e.cfg.TableID}
+	case n == "appid":
+		return literal{kind: "string", value: e.cfg.AppID}
+	case n == "where":
+		return literal{kind: "string", value: "{3.GT.'0'}"}
+	case n == "qbrealmhostname" || n == "realm" || n == "hostname":
+		return literal{kind: "string", value: e.cfg.Hostname()}
+	}
+	return literal{kind: "string", value: "<" + name + ">"}
+}
+
+func (l literal) js() string {
+	if l.kind == "string" || l.kind == "" {
+		if strings.Contains(l.value, "'") && !strings.Contains(l.value, `"`) {
+			return `"` + l.value + `"`
+		}
+		return "'" + strings.ReplaceAll(l.value, "'", `\'`) + "'"
+	}
+	return l.value
+}
+
+func (l literal) goExpr() string {
+	if l.kind == "string" || l.kind == "" {
+		return strconv.Quote(l.value)
+	}
+	return l.value
+}
+
+func (l literal) json() string {
+	if l.kind == "string" || l.kind == "" {
+		// Not json.Marshal, which escapes the <placeholder> brackets
+		var b strings.Builder
+		enc := json.NewEncoder(&b)
+		enc.SetEscapeHTML(false)
+		enc.Encode(l.value)
+		return strings.TrimSuffix(b.String(), "\n")
+	}
+	return l.value
+}
+
+// flatten resolves a schema's $ref and folds composition into a single
+// schema: allOf members are merged and the first oneOf/anyOf alternative
+// stands in for the rest. The $ref name is returned for type naming.
+func (e exampleValue) flatten(schema *yaml.Node) (*yaml.Node, string) {
+	schema, ref := resolveRef(e.root, schema)
+	if mappingValue(schema, "properties") == nil {
+		for _, combo := range []string{"oneOf", "anyOf"} {
+			if alts := mappingValue(schema, combo); alts != nil && len(alts.Content) > 0 {
+				alt, altRef := e.flatten(alts.Content[0])
+				if ref == "" {
+					ref = altRef
+				}
+				return alt, ref
+			}
+		}
+	}
+	members := mappingValue(schema, "allOf")
+	if members == nil {
+		return schema, ref
+	}
+	merged := &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+	props := &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+	required := &yaml.Node{Kind: yaml.SequenceNode, Tag: "!!seq"}
+	parts := append(append([]*yaml.Node{}, members.Content...), schema)
+	for i, part := range parts {
+		if i < len(parts)-1 {
+			var partRef string
+			part, partRef = e.flatten(part)
+			// Extending a named schema keeps its type name
+			if ref == "" {
+				ref = partRef
+			}
+		}
+		for j := 0; part != nil && j+1 < len(part.Content); j += 2 {
+			switch key := part.Content[j].Value; key {
+			case "allOf":
+			case "properties":
+				props.Content = append(props.Content, part.Content[j+1].Content...)
+			case "required":
+				required.Content = append(required.Content, part.Content[j+1].Content...)
+			default:
+				if mappingValue(merged, key) == nil {
+					merged.Content = append(merged.Content, part.Content[j], part.Content[j+1])
+				}
+			}
+		}
+	}
+	str := func(v string) *yaml.Node { return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: v} }
+	if mappingValue(merged, "type") == nil {
+		merged.Content = append(merged.Content, str("type"), str("object"))
+	}
+	merged.Content = append(merged.Content, str("properties"), props, str("required"), required)
+	return merged, ref
+}
+
+// mapEntry is the sample key and value schema of a map-like object (one
+// with additionalProperties but no properties), or nil. Quickbase keys
+// record values by field ID, so a {value: …} entry gets one.
+func (e exampleValue) mapEntry(schema *yaml.Node) (string, *yaml.Node) {
+	if mappingValue(schema, "properties") != nil {
+		return "", nil
+	}
+	value := mappingValue(schema, "additionalProperties")
+	if value == nil || value.Kind != yaml.MappingNode {
+		return "", nil
+	}
+	if flat, _ := e.flatten(value); mappingValue(mappingValue(flat, "properties"), "value") != nil {
+		return "6", value
+	}
+	return "key", value
+}
+
+// jsValue renders a schema as a TS literal at the given indent.
+func (e exampleValue) jsValue(name string, schema *yaml.Node, indent string, all bool, depth int) string {
+	schema, _ = e.flatten(schema)
+	switch schemaType(schema) {
+	case "array":
+		item, _ := e.flatten(mappingValue(schema, "items"))
+		if schemaType(item) == "object" {
+			if depth > 3 {
+				return "[]"
+			}
+			return "[" + e.jsValue(name, item, indent, all, depth+1) + "]"
+		}
+		return "[" + e.scalar(name, item).js() + "]"
+	case "object":
+		if key, value := e.mapEntry(schema); value != nil && depth <= 3 {
+			return fmt.Sprintf("{\n%s  '%s': %s,\n%s}", indent, key, e.jsValue(key, value, indent+"  ", all, depth+1), indent)
+		}
+		fields := schemaFields(schema)
+		if len(fields) == 0 || depth > 3 {
+			return "{}"
+		}
+		var b strings.Builder
+		b.WriteString("{\n")
+		for _, f := range fields {
+			if !f.required && !all {
+				continue
+			}
+			b.WriteString(fmt.Sprintf("%s  %s: %s,\n", indent, f.name, e.jsValue(f.name, f.schema, indent+"  ", all, depth+1)))
+		}
+		b.WriteString(indent + "}")
+		return b.String()
+	}
+	return e.scalar(name, schema).js()
+}
+
+// jsonValue renders a schema as indented JSON, properties in spec order
+// with required ones first.
+func (e exampleValue) jsonValue(name string, schema *yaml.Node, indent string, all bool, depth int) string {
+	schema, _ = e.flatten(schema)
+	switch schemaType(schema) {
+	case "array":
+		item, _ := e.flatten(mappingValue(schema, "items"))
+		if schemaType(item) == "object" {
+			if depth > 3 {
+				return "[]"
+			}
+			elem := e.jsonValue(name, item, indent+"  ", all, depth+1)
+			if elem == "{}" {
+				return "[{}]"
+			}
+			return fmt.Sprintf("[\n%s  %s\n%s]", indent, elem, indent)
+		}
+		return "[" + e.scalar(name, item).json() + "]"
+	case "object":
+		if key, value := e.mapEntry(schema); value != nil && depth <= 3 {
+			return fmt.Sprintf("{\n%s  %q: %s\n%s}", indent, key, e.jsonValue(key, value, indent+"  ", all, depth+1), indent)
+		}
+		var entries []string
+		for _, f := range schemaFields(schema) {
+			if f.required || all {
+				entries = append(entries, fmt.Sprintf("%s  %q: %s", indent, f.name, e.jsonValue(f.name, f.schema, indent+"  ", all, depth+1)))
+			}
+		}
+		if len(entries) == 0 || depth > 3 {
+			return "{}"
+		}
+		return "{\n" + strings.Join(entries, ",\n") + "\n" + indent + "}"
+	}
+	return e.scalar(name, schema).json()
+}
+
+// goValue renders a schema as a Go expression. Object types are named
+// after their $ref, as generated SDK types are.
+func (e exampleValue) goValue(name string, schema *yaml.Node, typeName, indent string, all bool, depth int) string {
+	schema, ref := e.flatten(schema)
+	if ref != "" {
+		typeName = ref
+	}
+	switch schemaType(schema) {
+	case "array":
+		item, itemRef := e.flatten(mappingValue(schema, "items"))
+		switch schemaType(item) {
+		case "object":
+			if itemRef == "" {
+				// A slice of maps, such as Quickbase record data
+				if elem := e.goValue(name, item, "", indent, all, depth+1); strings.HasPrefix(elem, "map[") && depth <= 3 {
+					typ := elem[:strings.Index(elem, "{")]
+					return "[]" + typ + "{" + strings.TrimPrefix(elem, typ) + "}"
+				}
+				return "[]map[string]any{}"
+			}
+			if depth > 3 {
+				return "[]quickbase." + itemRef + "{}"
+			}
+			// Elements of a typed slice drop their type name
+			elem := strings.TrimPrefix(e.goValue(name, item, itemRef, indent, all, depth+1), "&quickbase."+itemRef)
+			return "[]quickbase." + itemRef + "{" + elem + "}"
+		case "integer":
+			return "[]int{" + e.scalar(name, item).goExpr() + "}"
+		case "number":
+			return "[]float64{" + e.scalar(name, item).goExpr() + "}"
+		case "boolean":
+			return "[]bool{" + e.scalar(name, item).goExpr() + "}"
+		}
+		return "[]string{" + e.scalar(name, item).goExpr() + "}"
+	case "object":
+		if key, value := e.mapEntry(schema); value != nil && depth <= 3 {
+			flat, valueRef := e.flatten(value)
+			switch {
+			case valueRef != "":
+				elem := strings.TrimPrefix(e.goValue(key, value, valueRef, indent+"\t", all, depth+1), "&quickbase."+valueRef)
+				return fmt.Sprintf("map[string]quickbase.%s{\n%s\t%q: %s,\n%s}", valueRef, indent, key, elem, indent)
+			case schemaType(flat) != "object" && schemaType(flat) != "array":
+				return fmt.Sprintf("map[string]any{%q: %s}", key, e.scalar(key, flat).goExpr())
+			}
+		}
+		if typeName == "" {
+			return "map[string]any{}"
+		}
+		prefix := "quickbase."
+		if depth > 0 {
+			prefix = "&quickbase."
+		}
+		fields := schemaFields(schema)
+		if len(fields) == 0 || depth > 3 {
+			return prefix + typeName + "{}"
+		}
+		var b strings.Builder
+		b.WriteString(prefix + typeName + "{\n")
+		for _, f := range fields {
+			if !f.required && !all {
+				continue
+			}
+			b.WriteString(fmt.Sprintf("%s\t%s: %s,\n", indent, goFieldName(f.name), e.goValue(f.name, f.schema, "", indent+"\t", all, depth+1)))
+		}
+		b.WriteString(indent + "}")
+		return b.String()
+	}
+	return e.scalar(name, schema).goExpr()
+}
+
+// schemaType is a schema's type, inferring object from properties.
+func schemaType(schema *yaml.Node) string {
+	if t := mappingValue(schema, "type"); t != nil {
+		return t.Value
+	}
+	if mappingValue(schema, "properties") != nil {
+		return "object"
+	}
+	return ""
+}
+
+// operationInputs splits an operation's inputs: path and query parameters
+// (header parameters are the client's job) and the JSON request body.
+func operationInputs(root *yaml.Node, op specOperation) (params []exampleField, body *yaml.Node) {
+	if list := mappingValue(op.Node, "parameters"); list != nil {
+		for _, p := range list.Content {
+			p, _ = resolveRef(root, p)
+			in := mappingValue(p, "in")
+			name := mappingValue(p, "name")
+			if in == nil || name == nil || (in.Value != "path" && in.Value != "query") {
+				continue
+			}
+			required := mappingValue(p, "required")
+			params = append(params, exampleField{name: name.Value, required: in.Value == "path" || (required != nil && required.Value == "true"), schema: mappingValue(p, "schema")})
+		}
+	}
+	rb, _ := resolveRef(root, mappingValue(op.Node, "requestBody"))
+	if content := mappingValue(rb, "content"); content != nil {
+		if media := mappingValue(content, "application/json"); media != nil {
+			body = mappingValue(media, "schema")
+		}
+	}
+	return params, body
+}
+
+// optionalNames lists the properties left out of a required-only example.
+func optionalNames(root *yaml.Node, params []exampleField, body *yaml.Node) []string {
+	var names []string
+	for _, p := range params {
+		if !p.required {
+			names = append(names, p.name)
+		}
+	}
+	if body != nil {
+		schema, _ := resolveRef(root, body)
+		for _, f := range schemaFields(schema) {
+			if !f.required {
+				names = append(names, f.name)
+			}
+		}
+	}
+	return names
+}
+
+// jsEndpointExample calls the method with one options object holding the
+// path, query and body properties, as the JS client takes them.
+func jsEndpointExample(e exampleValue, method string, params []exampleField, body *yaml.Node, all bool) string {
+	var props []string
+	for _, p := range params {
+		if p.required || all {
+			props = append(props, fmt.Sprintf("  %s: %s,", p.name, e.jsValue(p.name, p.schema, "  ", all, 1)))
+		}
+	}
+	if body != nil {
+		obj := e.jsValue("", body, "", all, 0)
+		if inner := strings.TrimSuffix(strings.TrimPrefix(obj, "{\n"), "}"); inner != obj {
+			props = append(props, strings.TrimRight(inner, "\n"))
+		}
+	}
+	var b strings.Builder
+	b.WriteString("import { createClient } from 'quickbase-js';\n\n")
+	b.WriteString(fmt.Sprintf("const qb = createClient({\n  realm: '%s',\n  auth: { type: 'user-token', userToken: process.env.%s! },\n});\n\n", e.cfg.Realm, e.cfg.UserTokenEnv))
+	if len(props) == 0 {
+		b.WriteString(fmt.Sprintf("const result = await qb.%s();\n", method))
+	} else {
+		b.WriteString(fmt.Sprintf("const result = await qb.%s({\n%s\n});\n", method, strings.Join(props, "\n")))
+	}
+	b.WriteString("console.log(result);\n")
+	return b.String()
+}
+
+// goEndpointExample passes path parameters positionally, then the request
+// body (or a params struct for query parameters) as a typed value.
+func goEndpointExample(e exampleValue, op specOperation, method string, params []exampleField, body *yaml.Node, all bool) string {
+	args := []string{"ctx"}
+	var query []exampleField
+	for _, p := range params {
+		if strings.Contains(op.Path, "{"+p.name+"}") {
+			args = append(args, e.scalar(p.name, p.schema).goExpr())
+		} else if p.required || all {
+			query = append(query, p)
+		}
+	}
+	switch {
+	case body != nil:
+		args = append(args, e.goValue("", body, goFieldName(op.OperationID)+"Request", "\t", all, 0))
+	case len(query) > 0:
+		var b strings.Builder
+		b.WriteString("quickbase." + goFieldName(op.OperationID) + "Params{\n")
+		for _, q := range query {
+			b.WriteString(fmt.Sprintf("\t\t%s: %s,\n", goFieldName(q.name), e.goValue(q.name, q.schema, "", "\t\t", all, 1)))
+		}
+		b.WriteString("\t}")
+		args = append(args, b.String())
+	}
+
+	var b strings.Builder
+	b.WriteString("package main\n\nimport (\n\t\"context\"\n\t\"fmt\"\n\t\"log\"\n\t\"os\"\n\n\t\"github.com/DrewBradfordXYZ/quickbase-go\"\n)\n\n")
+	b.WriteString("func main() {\n")
+	b.WriteString(fmt.Sprintf("\tclient, err := quickbase.New(%q, quickbase.WithUserToken(os.Getenv(%q)))\n\tif err != nil {\n\t\tlog.Fatal(err)\n\t}\n\tctx := context.Background()\n\n", e.cfg.Realm, e.cfg.UserTokenEnv))
+	b.WriteString(fmt.Sprintf("\tresult, err := client.%s(%s)\n", method, strings.Join(args, ", ")))
+	b.WriteString("\tif err != nil {\n\t\tlog.Fatal(err)\n\t}\n\tfmt.Printf(\"%+v\\n\", result)\n}\n")
+	// Align struct fields the way gofmt would
+	if formatted, err := format.Source([]byte(b.String())); err == nil {
+		return string(formatted)
+	}
+	return b.String()
+}
+
+func (s *QuickBasePersonalMCPServer) handleGetEndpointExample(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	var params struct {
+		OperationID     string `json:"operation_id"`
+		Language        string `json:"language"`
+		IncludeOptional bool   `json:"include_optional"`
+	}
+	argsData, _ := json.Marshal(request.Params.Arguments)
+	if err := json.Unmarshal(argsData, &params); err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Invalid parameters: %v", err)), nil
+	}
+	if params.Language == "" {
+		params.Language = "both"
+	}
+
+	root, err := loadSpec()
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to load spec: %v", err)), nil
+	}
+	ops := specOperations(root)
+	op, ok := findOperation(ops, params.OperationID)
+	if !ok {
+		var ids []string
+		for _, o := range ops {
+			if o.OperationID != "" {
+				ids = append(ids, o.OperationID)
+			}
+		}
+		return mcp.NewToolResultError(fmt.Sprintf("Unknown operation: %q (available: %s)", params.OperationID, strings.Join(ids, ", "))), nil
+	}
+	if op.OperationID == "" {
+		return mcp.NewToolResultError(fmt.Sprintf("%s %s has no operationId, so no SDK method name can be derived", op.Method, op.Path)), nil
+	}
+	cfg, err := loadConfig()
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to load config: %v", err)), nil
+	}
+
+	e := exampleValue{root: root, cfg: cfg}
+	inputs, body := operationInputs(root, op)
+
+	var results strings.Builder
+	results.WriteString(fmt.Sprintf("# %s\n\n`%s %s`", op.OperationID, op.Method, op.Path))
+	if op.Summary != "" {
+		results.WriteString(" — " + op.Summary)
+	}
+	results.WriteString("\n\n")
+	if op.Deprecated {
+		results.WriteString("**Deprecated** in the spec.\n\n")
+	}
+
+	sides := []struct {
+		lang, label, fence, root string
+		keep                     func(string) bool
+		isGo                     bool
+		method                   string
+	}{
+		{"js", "JavaScript", "typescript", quickbaseJSPath, isJSSource, false, lowerFirst(op.OperationID)},
+		{"go", "Go", "go", quickbaseGoPath, isGoSource, true, goFieldName(op.OperationID)},
+	}
+	for _, side := range sides {
+		if params.Language != "both" && params.Language != side.lang {
+			continue
+		}
+		results.WriteString(fmt.Sprintf("## %s\n\n", side.label))
+		method := side.method
+		if m := lookupOperation(sdkMethodIndex(side.root, side.keep, side.isGo), op.OperationID); m.found {
+			method = m.name
+			results.WriteString(fmt.Sprintf("`%s` in %s\n\n", m.signature, m.file))
+		} else {
+			results.WriteString(fmt.Sprintf("No `%s` found in %s; the name is derived from the operationId.\n\n", method, filepath.Base(side.root)))
+		}
+		var code string
+		if side.isGo {
+			code = goEndpointExample(e, op, method, inputs, body, params.IncludeOptional)
+		} else {
+			code = jsEndpointExample(e, method, inputs, body, params.IncludeOptional)
+		}
+		results.WriteString(fmt.Sprintf("```%s\n%s```\n\n", side.fence, code))
+	}
+
+	if !params.IncludeOptional {
+		if optional := optionalNames(root, inputs, body); len(optional) > 0 {
+			results.WriteString(fmt.Sprintf("Optional inputs left out (pass include_optional to add them): %s\n", strings.Join(optional, ", ")))
+		}
+	}
+
+	return mcp.NewToolResultText(results.String()), nil
+}
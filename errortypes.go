@@ -0,0 +1,373 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// errorType is a custom error: a Go type with an Error() string method or
+// sentinel error variable, or a JS class extending Error.
+type errorType struct {
+	name     string
+	file     string
+	kind     string // "type", "sentinel" or "class"
+	parent   string // JS base class
+	status   int
+	byName   bool // status inferred from the name rather than the code
+	fields   []string
+	matchKey string
+}
+
+// statusByName infers an HTTP status from common error names.
+var statusByName = []struct {
+	words  []string
+	status int
+}{
+	{[]string{"badrequest", "validation", "invalid"}, 400},
+	{[]string{"unauthorized", "unauthenticated", "auth"}, 401},
+	{[]string{"forbidden", "permission"}, 403},
+	{[]string{"notfound"}, 404},
+	{[]string{"conflict"}, 409},
+	{[]string{"ratelimit", "toomanyrequests", "throttl"}, 429},
+	{[]string{"internal", "server"}, 500},
+	{[]string{"unavailable"}, 503},
+	{[]string{"timeout"}, 504},
+}
+
+var (
+	jsErrorClass  = regexp.MustCompile(`(?m)^[ \t]*(?:export\s+)?(?:default\s+)?(?:abstract\s+)?class\s+([A-Za-z_$][\w$]*)\s+extends\s+([A-Za-z_$][\w$.]*)`)
+	jsSuperStatus = regexp.MustCompile(`super\([^)]*?,\s*([1-5]\d\d)\b`)
+	statusLiteral = regexp.MustCompile(`\b([1-5]\d\d)\b|http\.Status(\w+)`)
+)
+
+// errorKey normalizes an error name for matching: case and separators are
+// ignored, as are Err prefixes and Error/Exception suffixes.
+func errorKey(name string) string {
+	key := normalizeTerm(name)
+	for _, suffix := range []string{"exception", "error", "err"} {
+		key = strings.TrimSuffix(key, suffix)
+	}
+	if strings.HasPrefix(name, "Err") && len(name) > 3 && name[3] >= 'A' && name[3] <= 'Z' {
+		key = strings.TrimPrefix(key, "err")
+	}
+	return key
+}
+
+// inferStatus fills in the status from the name when the code gave none.
+func (e *errorType) inferStatus() {
+	e.matchKey = errorKey(e.name)
+	if e.status != 0 {
+		return
+	}
+	for _, s := range statusByName {
+		for _, w := range s.words {
+			if strings.Contains(e.matchKey, w) {
+				e.status, e.byName = s.status, true
+				return
+			}
+		}
+	}
+}
+
+// statusNear looks for an HTTP status within a few lines of each mention
+// of name, as in `case 404: return &NotFoundError{...}`.
+func statusNear(lines []string, name string) int {
+	mention := regexp.MustCompile(`\b` + regexp.QuoteMeta(name) + `\b`)
+	for i, line := range lines {
+		if !mention.MatchString(line) || strings.Contains(line, "type "+name) || strings.Contains(line, "class "+name) {
+			continue
+		}
+		for j := i; j >= 0 && j >= i-2; j-- {
+			m := statusLiteral.FindStringSubmatch(lines[j])
+			if m == nil || !strings.Contains(lines[j], "case") && !strings.Contains(lines[j], "==") {
+				continue
+			}
+			if m[1] != "" {
+				status, _ := strconv.Atoi(m[1])
+				return status
+			}
+			if status := httpStatusCodes[m[2]]; status != 0 {
+				return status
+			}
+		}
+	}
+	return 0
+}
+
+// httpStatusCodes maps net/http constant names to codes for the statuses
+// error types usually correspond to.
+var httpStatusCodes = map[string]int{
+	"BadRequest": 400, "Unauthorized": 401, "Forbidden": 403, "NotFound": 404,
+	"Conflict": 409, "TooManyRequests": 429, "InternalServerError": 500,
+	"BadGateway": 502, "ServiceUnavailable": 503, "GatewayTimeout": 504,
+}
+
+// goErrorTypes finds types with an Error() string method and exported
+// sentinel errors across the Go SDK.
+func goErrorTypes(repoPath string) []errorType {
+	var found []errorType
+	for _, rel := range listSourceFiles(repoPath, isGoSource) {
+		data, err := os.ReadFile(filepath.Join(repoPath, rel))
+		if err != nil {
+			continue
+		}
+		fset := token.NewFileSet()
+		file, err := parser.ParseFile(fset, rel, data, parser.SkipObjectResolution)
+		if err != nil {
+			continue
+		}
+		lines := strings.Split(string(data), "\n")
+
+		structs := map[string]*ast.StructType{}
+		ast.Inspect(file, func(n ast.Node) bool {
+			if ts, ok := n.(*ast.TypeSpec); ok {
+				if st, ok := ts.Type.(*ast.StructType); ok {
+					structs[ts.Name.Name] = st
+				}
+			}
+			return true
+		})
+
+		for _, decl := range file.Decls {
+			switch d := decl.(type) {
+			case *ast.FuncDecl:
+				if d.Name.Name != "Error" || d.Recv == nil || len(d.Recv.List) == 0 || d.Type.Params.NumFields() != 0 {
+					continue
+				}
+				if res := d.Type.Results; res == nil || len(res.List) != 1 || types.ExprString(res.List[0].Type) != "string" {
+					continue
+				}
+				name := receiverName(d.Recv.List[0].Type)
+				if !ast.IsExported(name) {
+					continue
+				}
+				e := errorType{name: name, file: rel, kind: "type", status: statusNear(lines, name)}
+				if st := structs[name]; st != nil {
+					for _, field := range st.Fields.List {
+						for _, n := range field.Names {
+							if n.IsExported() {
+								e.fields = append(e.fields, n.Name)
+							}
+						}
+					}
+				}
+				found = append(found, e)
+			case *ast.GenDecl:
+				if d.Tok != token.VAR {
+					continue
+				}
+				for _, spec := range d.Specs {
+					vs := spec.(*ast.ValueSpec)
+					for i, n := range vs.Names {
+						if !n.IsExported() || i >= len(vs.Values) {
+							continue
+						}
+						if call, ok := vs.Values[i].(*ast.CallExpr); ok {
+							if fn := types.ExprString(call.Fun); fn == "errors.New" || fn == "fmt.Errorf" {
+								found = append(found, errorType{name: n.Name, file: rel, kind: "sentinel", status: statusNear(lines, n.Name)})
+							}
+						}
+					}
+				}
+			}
+		}
+	}
+	return found
+}
+
+// jsErrorTypes finds classes that extend Error, directly or through
+// another error class, across the JS SDK.
+func jsErrorTypes(repoPath string) []errorType {
+	classes := map[string]*errorType{}
+	for _, rel := range listSourceFiles(repoPath, isJSSource) {
+		data, err := os.ReadFile(filepath.Join(repoPath, rel))
+		if err != nil {
+			continue
+		}
+		src := stripComments(string(data))
+		lines := strings.Split(src, "\n")
+		for _, m := range jsErrorClass.FindAllStringSubmatchIndex(src, -1) {
+			name, parent := src[m[2]:m[3]], src[m[4]:m[5]]
+			e := &errorType{name: name, parent: parent, file: rel, kind: "class"}
+			if open := strings.Index(src[m[1]:], "{"); open >= 0 {
+				if end := matchClose(src, m[1]+open); end >= 0 {
+					body := src[m[1]+open : end+1]
+					if sm := jsSuperStatus.FindStringSubmatch(body); sm != nil {
+						e.status, _ = strconv.Atoi(sm[1])
+					}
+					for _, sym := range jsSymbols(rel, "export class "+name+" "+body) {
+						if sym.name == name+".constructor" {
+							for _, p := range sym.params {
+								if p != "message" {
+									e.fields = append(e.fields, p)
+								}
+							}
+						}
+					}
+				}
+			}
+			if e.status == 0 {
+				e.status = statusNear(lines, name)
+			}
+			classes[name] = e
+		}
+	}
+
+	// Keep classes whose ancestry reaches Error
+	isError := func(name string) bool {
+		for seen := 0; seen < 20; seen++ {
+			c, ok := classes[name]
+			if !ok {
+				return name == "Error"
+			}
+			name = c.parent
+		}
+		return false
+	}
+	var found []errorType
+	for _, name := range sortedKeys(classes) {
+		if isError(classes[name].parent) {
+			found = append(found, *classes[name])
+		}
+	}
+	return found
+}
+
+// errorPair is a JS error class and its Go counterpart.
+type errorPair struct {
+	js, g *errorType
+	by    string
+}
+
+// pairErrorTypes collects both SDKs' error types and pairs them by name
+// first, then by HTTP status among the leftovers.
+func pairErrorTypes() (pairs []errorPair, onlyJS, onlyGo []*errorType, jsCount, goCount int) {
+	jsErrs := jsErrorTypes(quickbaseJSPath)
+	goErrs := goErrorTypes(quickbaseGoPath)
+	for i := range jsErrs {
+		jsErrs[i].inferStatus()
+	}
+	for i := range goErrs {
+		goErrs[i].inferStatus()
+	}
+	// Types pair before sentinels, so NotFoundError beats ErrNotFound
+	sort.SliceStable(goErrs, func(a, b int) bool { return goErrs[a].kind == "type" && goErrs[b].kind != "type" })
+
+	usedGo := map[int]bool{}
+	var unmatchedJS []*errorType
+	for i := range jsErrs {
+		j := &jsErrs[i]
+		matched := false
+		for k := range goErrs {
+			if !usedGo[k] && goErrs[k].matchKey == j.matchKey {
+				usedGo[k] = true
+				pairs = append(pairs, errorPair{j, &goErrs[k], "name"})
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			unmatchedJS = append(unmatchedJS, j)
+		}
+	}
+	for _, j := range unmatchedJS {
+		candidate := -1
+		for k := range goErrs {
+			if !usedGo[k] && j.status != 0 && goErrs[k].status == j.status {
+				if candidate >= 0 {
+					candidate = -2 // ambiguous
+					break
+				}
+				candidate = k
+			}
+		}
+		if candidate >= 0 {
+			usedGo[candidate] = true
+			pairs = append(pairs, errorPair{j, &goErrs[candidate], fmt.Sprintf("status %d", j.status)})
+		} else {
+			onlyJS = append(onlyJS, j)
+		}
+	}
+	for k := range goErrs {
+		if !usedGo[k] {
+			onlyGo = append(onlyGo, &goErrs[k])
+		}
+	}
+	sort.Slice(pairs, func(a, b int) bool { return pairs[a].js.name < pairs[b].js.name })
+	return pairs, onlyJS, onlyGo, len(jsErrs), len(goErrs)
+}
+
+func (s *QuickBasePersonalMCPServer) handleCompareErrors(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	pairs, onlyJS, onlyGo, jsCount, goCount := pairErrorTypes()
+
+	status := func(e *errorType) string {
+		switch {
+		case e.status == 0:
+			return "—"
+		case e.byName:
+			return fmt.Sprintf("%d (by name)", e.status)
+		}
+		return strconv.Itoa(e.status)
+	}
+
+	var results strings.Builder
+	results.WriteString("# Error Type Comparison\n\n")
+	results.WriteString(fmt.Sprintf("%d JS error classes, %d Go error types; %d matched, %d only in JS, %d only in Go\n\n", jsCount, goCount, len(pairs), len(onlyJS), len(onlyGo)))
+
+	results.WriteString("## Matched\n\n")
+	if len(pairs) == 0 {
+		results.WriteString("None\n\n")
+	} else {
+		results.WriteString("| JS | Go | Status (JS / Go) | Matched by | Notes |\n|---|---|---|---|---|\n")
+		for _, p := range pairs {
+			var notes []string
+			if p.js.status != 0 && p.g.status != 0 && p.js.status != p.g.status {
+				notes = append(notes, "❌ status differs")
+			}
+			if only := diffNames(p.js.fields, p.g.fields); len(only) > 0 {
+				notes = append(notes, "fields only in JS: "+strings.Join(only, ", "))
+			}
+			if only := diffNames(p.g.fields, p.js.fields); len(only) > 0 {
+				notes = append(notes, "fields only in Go: "+strings.Join(only, ", "))
+			}
+			results.WriteString(fmt.Sprintf("| %s (%s) | %s (%s) | %s / %s | %s | %s |\n", p.js.name, p.js.file, p.g.name, p.g.file, status(p.js), status(p.g), p.by, strings.Join(notes, "; ")))
+		}
+		results.WriteString("\n")
+	}
+
+	writeGap := func(title string, errs []*errorType) {
+		results.WriteString(fmt.Sprintf("## %s (%d)\n\n", title, len(errs)))
+		if len(errs) == 0 {
+			results.WriteString("None\n\n")
+			return
+		}
+		for _, e := range errs {
+			line := fmt.Sprintf("- %s (%s, %s", e.name, e.file, e.kind)
+			if e.parent != "" {
+				line += " extends " + e.parent
+			}
+			if e.status != 0 {
+				line += ", status " + status(e)
+			}
+			results.WriteString(line + ")\n")
+		}
+		results.WriteString("\n")
+	}
+	writeGap("Only in JS", onlyJS)
+	writeGap("Only in Go", onlyGo)
+
+	results.WriteString("Statuses come from `super(message, 429)` calls, `case 404:` branches next to the type, or failing that the error's name.\n")
+	return mcp.NewToolResultText(results.String()), nil
+}
@@ -0,0 +1,242 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"gopkg.in/yaml.v3"
+)
+
+// extractedTopLevel are the document keys copied into a mini-spec as-is;
+// paths, components and tags are rebuilt from what the operations use.
+var extractedTopLevel = []string{"openapi", "info", "servers", "security", "externalDocs"}
+
+// componentRefs collects the "#/components/<kind>/<name>" refs under n,
+// following each one into its target so nested schemas come along.
+func componentRefs(root, n *yaml.Node, used map[string]map[string]bool) {
+	if n == nil {
+		return
+	}
+	if ref := mappingValue(n, "$ref"); ref != nil && strings.HasPrefix(ref.Value, "#/components/") {
+		parts := strings.SplitN(strings.TrimPrefix(ref.Value, "#/components/"), "/", 2)
+		if len(parts) == 2 {
+			kind := parts[0]
+			name := strings.NewReplacer("~1", "/", "~0", "~").Replace(parts[1])
+			if used[kind] == nil {
+				used[kind] = map[string]bool{}
+			}
+			if !used[kind][name] {
+				used[kind][name] = true
+				componentRefs(root, mappingValue(mappingValue(mappingValue(root, "components"), kind), name), used)
+			}
+		}
+	}
+	for _, c := range n.Content {
+		componentRefs(root, c, used)
+	}
+}
+
+// securityNames adds the scheme names a security requirement list uses.
+func securityNames(security *yaml.Node, used map[string]map[string]bool) {
+	if security == nil {
+		return
+	}
+	for _, req := range security.Content {
+		for _, name := range mappingKeys(req) {
+			if used["securitySchemes"] == nil {
+				used["securitySchemes"] = map[string]bool{}
+			}
+			used["securitySchemes"][name] = true
+		}
+	}
+}
+
+// extractSpec builds a self-contained document holding only ops and the
+// components, security schemes and tags they need, in the spec's order.
+func extractSpec(root *yaml.Node, ops []specOperation) *yaml.Node {
+	str := func(v string) *yaml.Node { return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: v} }
+	mapping := func() *yaml.Node { return &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"} }
+
+	out := mapping()
+	for _, key := range extractedTopLevel {
+		if v := mappingValue(root, key); v != nil {
+			out.Content = append(out.Content, str(key), v)
+		}
+	}
+
+	selected := map[string]bool{}
+	tagsUsed := map[string]bool{}
+	for _, op := range ops {
+		selected[strings.ToLower(op.Method)+" "+op.Path] = true
+		for _, t := range op.Tags {
+			tagsUsed[t] = true
+		}
+	}
+
+	if specTags := mappingValue(root, "tags"); specTags != nil {
+		tags := &yaml.Node{Kind: yaml.SequenceNode, Tag: "!!seq"}
+		for _, t := range specTags.Content {
+			if tagsUsed[scalarAt(t, "name")] {
+				tags.Content = append(tags.Content, t)
+			}
+		}
+		if len(tags.Content) > 0 {
+			out.Content = append(out.Content, str("tags"), tags)
+		}
+	}
+
+	// Keep path items in spec order, with their shared keys (parameters,
+	// servers, summary) and only the selected methods
+	used := map[string]map[string]bool{}
+	securityNames(mappingValue(root, "security"), used)
+	paths := mapping()
+	specPaths := mappingValue(root, "paths")
+	for _, path := range mappingKeys(specPaths) {
+		item := mappingValue(specPaths, path)
+		var kept []*yaml.Node
+		var shared []*yaml.Node
+		for i := 0; i+1 < len(item.Content); i += 2 {
+			key, value := item.Content[i], item.Content[i+1]
+			switch {
+			case !containsString(httpMethods, key.Value):
+				shared = append(shared, key, value)
+			case selected[key.Value+" "+path]:
+				kept = append(kept, key, value)
+				securityNames(mappingValue(value, "security"), used)
+			}
+		}
+		if len(kept) == 0 {
+			continue
+		}
+		pathItem := mapping()
+		pathItem.Content = append(shared, kept...)
+		componentRefs(root, pathItem, used)
+		paths.Content = append(paths.Content, str(path), pathItem)
+	}
+	out.Content = append(out.Content, str("paths"), paths)
+
+	components := mapping()
+	specComponents := mappingValue(root, "components")
+	for _, kind := range mappingKeys(specComponents) {
+		if len(used[kind]) == 0 {
+			continue
+		}
+		section := mapping()
+		all := mappingValue(specComponents, kind)
+		for _, name := range mappingKeys(all) {
+			if used[kind][name] {
+				section.Content = append(section.Content, str(name), mappingValue(all, name))
+			}
+		}
+		components.Content = append(components.Content, str(kind), section)
+	}
+	if len(components.Content) > 0 {
+		out.Content = append(out.Content, str("components"), components)
+	}
+
+	return out
+}
+
+func (s *QuickBasePersonalMCPServer) handleExtractSpec(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	var params struct {
+		Tag        string   `json:"tag"`
+		Operations []string `json:"operations"`
+		Format     string   `json:"format"`
+		Save       bool     `json:"save"`
+	}
+	argsData, _ := json.Marshal(request.Params.Arguments)
+	if err := json.Unmarshal(argsData, &params); err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Invalid parameters: %v", err)), nil
+	}
+	if (params.Tag == "") == (len(params.Operations) == 0) {
+		return mcp.NewToolResultError("Give either tag (e.g. 'Records') or operations (operationIds or 'METHOD /path')"), nil
+	}
+	if params.Format == "" {
+		params.Format = "yaml"
+	}
+	if params.Format != "yaml" && params.Format != "json" {
+		return mcp.NewToolResultError(fmt.Sprintf("Unknown format: %s (use yaml or json)", params.Format)), nil
+	}
+
+	root, err := loadSpec()
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to load spec: %v", err)), nil
+	}
+	all := specOperations(root)
+	var ops []specOperation
+	name := params.Tag
+	if params.Tag != "" {
+		var tags []string
+		for _, op := range all {
+			for _, t := range op.Tags {
+				if !containsString(tags, t) {
+					tags = append(tags, t)
+				}
+				if strings.EqualFold(t, params.Tag) {
+					ops = append(ops, op)
+					name = t
+				}
+			}
+		}
+		if len(ops) == 0 {
+			return mcp.NewToolResultError(fmt.Sprintf("No operations are tagged %q (tags: %s)", params.Tag, strings.Join(tags, ", "))), nil
+		}
+	} else {
+		var unknown []string
+		for _, id := range params.Operations {
+			op, ok := findOperation(all, strings.TrimSpace(id))
+			if !ok {
+				unknown = append(unknown, id)
+				continue
+			}
+			ops = append(ops, op)
+		}
+		if len(unknown) > 0 {
+			return mcp.NewToolResultError(fmt.Sprintf("Unknown operation(s): %s; give operationIds or 'METHOD /path', or use spec_search", strings.Join(unknown, ", "))), nil
+		}
+		name = fmt.Sprintf("%d operations", len(ops))
+		if len(ops) == 1 {
+			name = ops[0].OperationID
+		}
+	}
+
+	mini := extractSpec(root, ops)
+	rendered, err := renderNode(mini, params.Format)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to render spec: %v", err)), nil
+	}
+	full, _ := renderNode(root, params.Format)
+
+	var results strings.Builder
+	results.WriteString(fmt.Sprintf("# Extracted Spec: %s\n\n", name))
+	var ids []string
+	for _, op := range ops {
+		id := op.OperationID
+		if id == "" {
+			id = op.Method + " " + op.Path
+		}
+		ids = append(ids, id)
+	}
+	results.WriteString(fmt.Sprintf("- Operations (%d): %s\n", len(ops), strings.Join(ids, ", ")))
+	components := mappingValue(mini, "components")
+	for _, kind := range mappingKeys(components) {
+		results.WriteString(fmt.Sprintf("- %s (%d): %s\n", kind, len(mappingKeys(mappingValue(components, kind))), strings.Join(mappingKeys(mappingValue(components, kind)), ", ")))
+	}
+	results.WriteString(fmt.Sprintf("- Size: %d bytes, %.1f%% of the full spec (%d bytes)\n", len(rendered), 100*float64(len(rendered))/float64(max(len(full), 1)), len(full)))
+
+	if params.Save {
+		file := filepath.Join(os.TempDir(), fmt.Sprintf("quickbase-spec-%s.%s", fileSlug(name), params.Format))
+		if err := os.WriteFile(file, []byte(rendered+"\n"), 0o644); err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("Failed to write %s: %v", file, err)), nil
+		}
+		results.WriteString(fmt.Sprintf("- Saved to `%s`\n", file))
+		return mcp.NewToolResultText(results.String()), nil
+	}
+	results.WriteString(fmt.Sprintf("\n```%s\n%s\n```\n", params.Format, rendered))
+	return mcp.NewToolResultText(results.String()), nil
+}
@@ -0,0 +1,290 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"gopkg.in/yaml.v3"
+)
+
+// featureEntry describes where a feature lives in each SDK. JS and Go hold
+// paths or globs (`**` matches across directories) relative to the repo
+// root. Keywords are the terms used to find the feature in docs and examples.
+// Operations lists the spec operations (operationId or "METHOD /path") the
+// feature implements.
+type featureEntry struct {
+	JS         []string `json:"js" yaml:"js"`
+	Go         []string `json:"go" yaml:"go"`
+	Keywords   []string `json:"keywords,omitempty" yaml:"keywords,omitempty"`
+	Operations []string `json:"operations,omitempty" yaml:"operations,omitempty"`
+
+	// source records where the entry came from: "built-in", the features
+	// file path or "annotations"
+	source string
+	// annotated marks the files added from qb:feature annotations
+	annotated map[string]bool
+}
+
+// defaultFeatureMap is used when no features file exists, and as the base
+// that entries in the features file override.
+var defaultFeatureMap = map[string]featureEntry{
+	"ticket-auth": {JS: []string{"src/auth/ticket.ts"}, Go: []string{"auth/ticket.go"}, Keywords: []string{"ticket", "API_Authenticate"}},
+	"temp-token":  {JS: []string{"src/auth/temp-token.ts"}, Go: []string{"auth/temp_token.go"}, Keywords: []string{"temp token", "temporary token", "tempToken", "temp_token"}, Operations: []string{"getTempTokenDBID"}},
+	"user-token":  {JS: []string{"src/auth/user-token.ts"}, Go: []string{"auth/user_token.go"}, Keywords: []string{"user token", "userToken", "user_token"}},
+	"sso":         {JS: []string{"src/auth/sso.ts"}, Go: []string{"auth/sso_token.go"}, Keywords: []string{"sso", "SAML"}},
+	"pagination":  {JS: []string{"src/client/pagination.ts"}, Go: []string{"client/pagination.go"}, Keywords: []string{"pagination", "paginate", "next page"}, Operations: []string{"runQuery", "getRecordsModifiedSince"}},
+	"retry":       {JS: []string{"src/client/retry.ts"}, Go: []string{"client/client.go"}, Keywords: []string{"retry", "retries", "backoff"}},
+	"throttle":    {JS: []string{"src/client/throttle.ts"}, Go: []string{"client/throttle.go"}, Keywords: []string{"throttle", "rate limit", "rateLimit"}},
+}
+
+// featureFileNames are looked up in configDir, first match wins.
+var featureFileNames = []string{"features.yaml", "features.yml", "features.json"}
+
+// loadFeatureMap returns the default feature map merged with the user's
+// features file, if any, plus the files the SDKs annotate with qb:feature.
+// It is read on every call so edits apply without restarting the server.
+func loadFeatureMap() (map[string]featureEntry, error) {
+	features := make(map[string]featureEntry, len(defaultFeatureMap))
+	for name, entry := range defaultFeatureMap {
+		entry.source = "built-in"
+		features[name] = entry
+	}
+
+	var custom map[string]featureEntry
+	path, err := readConfigFile(featureFileNames, &custom)
+	if err != nil {
+		return nil, err
+	}
+	for feature, entry := range custom {
+		entry.source = path
+		features[feature] = entry
+	}
+	annotateFeatureMap(features)
+
+	return features, nil
+}
+
+// readConfigFile decodes the first of names that exists in configDir into v,
+// as JSON or YAML by extension, and returns its path. It returns "" when none
+// of the files exist.
+func readConfigFile(names []string, v interface{}) (string, error) {
+	for _, name := range names {
+		path := filepath.Join(configDir, name)
+		data, err := os.ReadFile(path)
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			return "", err
+		}
+		if filepath.Ext(name) == ".json" {
+			err = json.Unmarshal(data, v)
+		} else {
+			err = yaml.Unmarshal(data, v)
+		}
+		if err != nil {
+			return "", fmt.Errorf("failed to parse %s: %w", path, err)
+		}
+		return path, nil
+	}
+	return "", nil
+}
+
+// featureNames returns the feature map keys in sorted order.
+func featureNames(features map[string]featureEntry) []string {
+	names := make([]string, 0, len(features))
+	for name := range features {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// globRegexp converts a slash-separated glob into an anchored regexp.
+// `**` matches any number of directories, `*` and `?` stay within one.
+func globRegexp(pattern string) (*regexp.Regexp, error) {
+	var re strings.Builder
+	re.WriteString("^")
+	for i := 0; i < len(pattern); i++ {
+		switch c := pattern[i]; c {
+		case '*':
+			if i+1 < len(pattern) && pattern[i+1] == '*' {
+				i++
+				if i+1 < len(pattern) && pattern[i+1] == '/' {
+					i++
+					re.WriteString("(?:.*/)?")
+				} else {
+					re.WriteString(".*")
+				}
+			} else {
+				re.WriteString("[^/]*")
+			}
+		case '?':
+			re.WriteString("[^/]")
+		default:
+			re.WriteString(regexp.QuoteMeta(string(c)))
+		}
+	}
+	re.WriteString("$")
+	return regexp.Compile(re.String())
+}
+
+// resolveFeatureFiles expands a feature's paths/globs against repoPath.
+// Plain paths are returned as-is even if missing so callers can report
+// them; globs contribute only the files they match. Patterns starting with
+// "!" exclude matching files (e.g. "!**/*_test.go").
+func resolveFeatureFiles(repoPath string, patterns []string) []string {
+	return resolveFeatureFilesAt(repoPath, "", patterns)
+}
+
+// resolveFeatureFilesAt is resolveFeatureFiles against the tree of a git
+// ref instead of the working tree. An empty ref means the working tree.
+func resolveFeatureFilesAt(repoPath, ref string, patterns []string) []string {
+	var files []string
+	var excludes []*regexp.Regexp
+	seen := map[string]bool{}
+	add := func(rel string) {
+		if !seen[rel] {
+			seen[rel] = true
+			files = append(files, rel)
+		}
+	}
+
+	for _, pattern := range patterns {
+		pattern = filepath.ToSlash(pattern)
+		if strings.HasPrefix(pattern, "!") {
+			if re, err := globRegexp(pattern[1:]); err == nil {
+				excludes = append(excludes, re)
+			}
+			continue
+		}
+		if !strings.ContainsAny(pattern, "*?") {
+			add(pattern)
+			continue
+		}
+		re, err := globRegexp(pattern)
+		if err != nil {
+			continue
+		}
+		// Only walk the directory before the first wildcard
+		base := pattern[:strings.IndexAny(pattern, "*?")]
+		base = base[:strings.LastIndex(base, "/")+1]
+		var matched []string
+		if ref != "" {
+			out, _ := runGit(repoPath, "ls-tree", "-r", "--name-only", ref, "--", base)
+			for _, rel := range strings.Split(out, "\n") {
+				if rel != "" && re.MatchString(rel) {
+					matched = append(matched, rel)
+				}
+			}
+		} else {
+			walkRepo(filepath.Join(repoPath, base), func(rel string) error {
+				if re.MatchString(base + rel) {
+					matched = append(matched, base+rel)
+				}
+				return nil
+			})
+		}
+		sort.Strings(matched)
+		for _, rel := range matched {
+			add(rel)
+		}
+	}
+
+	if len(excludes) == 0 {
+		return files
+	}
+	kept := files[:0]
+	for _, rel := range files {
+		excluded := false
+		for _, re := range excludes {
+			if re.MatchString(rel) {
+				excluded = true
+				break
+			}
+		}
+		if !excluded {
+			kept = append(kept, rel)
+		}
+	}
+	return kept
+}
+
+// featureImplemented reports whether any of the feature's files exist.
+func featureImplemented(repoPath string, patterns []string) bool {
+	for _, rel := range resolveFeatureFiles(repoPath, patterns) {
+		if _, err := os.Stat(filepath.Join(repoPath, rel)); err == nil {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *QuickBasePersonalMCPServer) handleListComparableFeatures(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	var params struct {
+		IncludeDiscovered bool `json:"include_discovered"`
+	}
+	argsData, _ := json.Marshal(request.Params.Arguments)
+	if err := json.Unmarshal(argsData, &params); err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Invalid parameters: %v", err)), nil
+	}
+
+	features, err := loadFeatureMap()
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to load feature map: %v", err)), nil
+	}
+
+	var results strings.Builder
+	results.WriteString(fmt.Sprintf("# Comparable Features (%d)\n\n", len(features)))
+	for _, name := range featureNames(features) {
+		entry := features[name]
+		results.WriteString(fmt.Sprintf("## %s\n\nSource: %s\n\n", name, entry.source))
+		for _, side := range []struct {
+			label    string
+			root     string
+			patterns []string
+		}{{"JS", quickbaseJSPath, entry.JS}, {"Go", quickbaseGoPath, entry.Go}} {
+			files := resolveFeatureFiles(side.root, side.patterns)
+			if len(files) == 0 {
+				results.WriteString(fmt.Sprintf("- %s: (no files match %s)\n", side.label, strings.Join(side.patterns, ", ")))
+				continue
+			}
+			for _, rel := range files {
+				mark := "✅"
+				if _, err := os.Stat(filepath.Join(side.root, rel)); err != nil {
+					mark = "❌ missing"
+				}
+				results.WriteString(fmt.Sprintf("- %s: %s %s\n", side.label, rel, mark))
+			}
+		}
+		results.WriteString("\n")
+	}
+
+	if params.IncludeDiscovered {
+		results.WriteString("## Discovered (not yet in the feature map)\n\n")
+		pairs, err := learnCorrespondences(features, 72, 500)
+		if err != nil {
+			results.WriteString(fmt.Sprintf("Discovery failed: %v\n", err))
+		}
+		count := 0
+		for _, c := range pairs {
+			if c.score < 0.5 {
+				continue
+			}
+			count++
+			results.WriteString(fmt.Sprintf("- %s: js %s ↔ go %s (score %.2f)\n", featureNameFor(c.jsFile), c.jsFile, c.goFile, c.score))
+		}
+		if count == 0 {
+			results.WriteString("None\n")
+		}
+	}
+
+	return mcp.NewToolResultText(results.String()), nil
+}
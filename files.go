@@ -0,0 +1,111 @@
+package main
+
+import (
+	"fmt"
+	"io/fs"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// skipDirs are never descended into when scanning a repo.
+var skipDirs = map[string]bool{
+	".git":         true,
+	"node_modules": true,
+	"vendor":       true,
+	"coverage":     true,
+}
+
+// walkRepo calls fn for every regular file under root, passing the path
+// relative to root in slash form.
+func walkRepo(root string, fn func(rel string) error) error {
+	return filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return nil
+		}
+		if d.IsDir() {
+			if skipDirs[d.Name()] && path != root {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !d.Type().IsRegular() {
+			return nil
+		}
+		rel, err := filepath.Rel(root, path)
+		if err != nil {
+			return nil
+		}
+		return fn(filepath.ToSlash(rel))
+	})
+}
+
+// sortedKeys returns the keys of m in sorted order.
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// isJSSource reports whether rel is hand-written JS/TS source (not a test,
+// declaration file or build output).
+func isJSSource(rel string) bool {
+	switch filepath.Ext(rel) {
+	case ".ts", ".tsx", ".js", ".mjs", ".cjs":
+	default:
+		return false
+	}
+	if strings.HasSuffix(rel, ".d.ts") || isTestPath(rel) {
+		return false
+	}
+	p := "/" + rel
+	return !strings.Contains(p, "/dist/") && !strings.Contains(p, "/build/") && !strings.Contains(p, "/examples/")
+}
+
+// isGoSource reports whether rel is a non-test Go file outside examples.
+func isGoSource(rel string) bool {
+	return strings.HasSuffix(rel, ".go") && !isTestPath(rel) && !strings.Contains("/"+rel, "/examples/")
+}
+
+// listSourceFiles returns the SDK source files in a repo, filtered by keep.
+func listSourceFiles(repoPath string, keep func(rel string) bool) []string {
+	var files []string
+	walkRepo(repoPath, func(rel string) error {
+		if keep(rel) {
+			files = append(files, rel)
+		}
+		return nil
+	})
+	return files
+}
+
+// resolveRepoPath joins rel onto root and rejects results that escape root
+// (via "..", absolute paths or symlinks).
+func resolveRepoPath(root, rel string) (string, error) {
+	if filepath.IsAbs(rel) {
+		return "", fmt.Errorf("path must be relative to the repo: %s", rel)
+	}
+	full := filepath.Join(root, filepath.FromSlash(rel))
+	if !withinRoot(root, full) {
+		return "", fmt.Errorf("path escapes the repo: %s", rel)
+	}
+	// Follow symlinks for paths that exist so a link can't point outside the repo
+	if resolved, err := filepath.EvalSymlinks(full); err == nil {
+		realRoot, err := filepath.EvalSymlinks(root)
+		if err != nil {
+			realRoot = root
+		}
+		if !withinRoot(realRoot, resolved) {
+			return "", fmt.Errorf("path escapes the repo via a symlink: %s", rel)
+		}
+	}
+	return full, nil
+}
+
+func withinRoot(root, path string) bool {
+	r, err := filepath.Rel(root, path)
+	return err == nil && r != ".." && !strings.HasPrefix(r, ".."+string(filepath.Separator))
+}
@@ -0,0 +1,231 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// Fixture directories tried in each SDK when none is configured, first
+// existing one wins.
+var (
+	jsFixtureDirs = []string{"tests/fixtures", "test/fixtures", "__fixtures__", "src/__fixtures__", "fixtures"}
+	goFixtureDirs = []string{"testdata/fixtures", "testdata", "fixtures"}
+)
+
+// fixtureDir picks the fixtures directory of an SDK: the explicit one if
+// given (it must stay inside the repo), else the first default that exists.
+func fixtureDir(repoPath, explicit string, defaults []string) (string, error) {
+	if explicit != "" {
+		full, err := resolveRepoPath(repoPath, explicit)
+		if err != nil {
+			return "", err
+		}
+		if info, err := os.Stat(full); err != nil || !info.IsDir() {
+			return "", fmt.Errorf("fixtures directory not found: %s", explicit)
+		}
+		return full, nil
+	}
+	for _, rel := range defaults {
+		full := filepath.Join(repoPath, rel)
+		if info, err := os.Stat(full); err == nil && info.IsDir() {
+			return full, nil
+		}
+	}
+	return "", fmt.Errorf("no fixtures directory in %s (looked for %s)", repoPath, strings.Join(defaults, ", "))
+}
+
+// fixtureFile is one fixture and its content hash.
+type fixtureFile struct {
+	data []byte
+	hash string
+}
+
+// readFixtures hashes every file under dir, keyed by path relative to it.
+// A symlinked dir (fixtures shared from elsewhere) is followed.
+func readFixtures(dir string) map[string]fixtureFile {
+	if resolved, err := filepath.EvalSymlinks(dir); err == nil {
+		dir = resolved
+	}
+	files := map[string]fixtureFile{}
+	walkRepo(dir, func(rel string) error {
+		data, err := os.ReadFile(filepath.Join(dir, filepath.FromSlash(rel)))
+		if err != nil {
+			return nil
+		}
+		sum := sha256.Sum256(data)
+		files[rel] = fixtureFile{data: data, hash: hex.EncodeToString(sum[:])}
+		return nil
+	})
+	return files
+}
+
+// jsonDiffPaths lists where two JSON documents differ, as jq-style paths,
+// stopping after max entries.
+func jsonDiffPaths(a, b interface{}, at string, max int, out *[]string) {
+	if len(*out) >= max {
+		return
+	}
+	if at == "" {
+		at = "."
+	}
+	switch av := a.(type) {
+	case map[string]interface{}:
+		bv, ok := b.(map[string]interface{})
+		if !ok {
+			break
+		}
+		keys := map[string]bool{}
+		for k := range av {
+			keys[k] = true
+		}
+		for k := range bv {
+			keys[k] = true
+		}
+		for _, k := range sortedKeys(keys) {
+			child := strings.TrimSuffix(at, ".") + "." + k
+			x, inA := av[k]
+			y, inB := bv[k]
+			switch {
+			case !inA:
+				*out = append(*out, child+" (only in Go)")
+			case !inB:
+				*out = append(*out, child+" (only in JS)")
+			default:
+				jsonDiffPaths(x, y, child, max, out)
+			}
+			if len(*out) >= max {
+				return
+			}
+		}
+		return
+	case []interface{}:
+		bv, ok := b.([]interface{})
+		if !ok {
+			break
+		}
+		if len(av) != len(bv) {
+			*out = append(*out, fmt.Sprintf("%s (length %d vs %d)", at, len(av), len(bv)))
+			return
+		}
+		for i := range av {
+			jsonDiffPaths(av[i], bv[i], fmt.Sprintf("%s[%d]", at, i), max, out)
+		}
+		return
+	}
+	if !reflect.DeepEqual(a, b) {
+		*out = append(*out, at)
+	}
+}
+
+func (s *QuickBasePersonalMCPServer) handleCheckFixtureParity(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	var params struct {
+		JSDir string `json:"js_dir"`
+		GoDir string `json:"go_dir"`
+	}
+	argsData, _ := json.Marshal(request.Params.Arguments)
+	if err := json.Unmarshal(argsData, &params); err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Invalid parameters: %v", err)), nil
+	}
+	cfg, _ := loadConfig()
+	if params.JSDir == "" {
+		params.JSDir = cfg.JSFixturesDir
+	}
+	if params.GoDir == "" {
+		params.GoDir = cfg.GoFixturesDir
+	}
+
+	jsDir, err := fixtureDir(quickbaseJSPath, params.JSDir, jsFixtureDirs)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	goDir, err := fixtureDir(quickbaseGoPath, params.GoDir, goFixtureDirs)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	jsRel, _ := filepath.Rel(quickbaseJSPath, jsDir)
+	goRel, _ := filepath.Rel(quickbaseGoPath, goDir)
+
+	var results strings.Builder
+	results.WriteString("# Fixture Parity\n\n")
+	results.WriteString(fmt.Sprintf("- JS: quickbase-js/%s\n- Go: quickbase-go/%s\n\n", filepath.ToSlash(jsRel), filepath.ToSlash(goRel)))
+	jsReal, _ := filepath.EvalSymlinks(jsDir)
+	if goReal, _ := filepath.EvalSymlinks(goDir); jsReal != "" && jsReal == goReal {
+		results.WriteString(fmt.Sprintf("Both point at the same directory (%s), so the fixtures are identical.\n", jsReal))
+		return mcp.NewToolResultText(results.String()), nil
+	}
+
+	jsFiles, goFiles := readFixtures(jsDir), readFixtures(goDir)
+	var identical, onlyJS, onlyGo, formatting, diverged []string
+	for _, rel := range sortedKeys(jsFiles) {
+		g, ok := goFiles[rel]
+		if !ok {
+			onlyJS = append(onlyJS, rel)
+			continue
+		}
+		j := jsFiles[rel]
+		if j.hash == g.hash {
+			identical = append(identical, rel)
+			continue
+		}
+		// JSON that parses the same differs only in formatting (or a BOM)
+		var jv, gv interface{}
+		if json.Unmarshal(trimBOM(j.data), &jv) == nil && json.Unmarshal(trimBOM(g.data), &gv) == nil {
+			if reflect.DeepEqual(jv, gv) {
+				formatting = append(formatting, rel)
+				continue
+			}
+			var paths []string
+			jsonDiffPaths(jv, gv, "", 10, &paths)
+			diverged = append(diverged, fmt.Sprintf("- %s (JS %s, Go %s): differs at %s", rel, j.hash[:8], g.hash[:8], strings.Join(paths, ", ")))
+			continue
+		}
+		diverged = append(diverged, fmt.Sprintf("- %s (JS %s, %d bytes; Go %s, %d bytes)", rel, j.hash[:8], len(j.data), g.hash[:8], len(g.data)))
+	}
+	for _, rel := range sortedKeys(goFiles) {
+		if _, ok := jsFiles[rel]; !ok {
+			onlyGo = append(onlyGo, rel)
+		}
+	}
+
+	results.WriteString("| | Fixtures |\n|---|---|\n")
+	results.WriteString(fmt.Sprintf("| Identical | %d |\n| Formatting differs only | %d |\n| Diverged | %d |\n| Only in JS | %d |\n| Only in Go | %d |\n\n",
+		len(identical), len(formatting), len(diverged), len(onlyJS), len(onlyGo)))
+
+	if len(diverged) > 0 {
+		results.WriteString(fmt.Sprintf("## ❌ Diverged (%d)\n\n%s\n\n", len(diverged), strings.Join(diverged, "\n")))
+	}
+	for _, section := range []struct {
+		title string
+		files []string
+	}{
+		{"⚠️ Only in quickbase-js", onlyJS},
+		{"⚠️ Only in quickbase-go", onlyGo},
+		{"Formatting differs only", formatting},
+	} {
+		if len(section.files) == 0 {
+			continue
+		}
+		results.WriteString(fmt.Sprintf("## %s (%d)\n\n- %s\n\n", section.title, len(section.files), strings.Join(section.files, "\n- ")))
+	}
+	if len(diverged)+len(onlyJS)+len(onlyGo) == 0 {
+		results.WriteString("✅ The fixtures match.\n")
+	}
+
+	return mcp.NewToolResultText(results.String()), nil
+}
+
+// trimBOM drops a UTF-8 byte order mark, which editors add to JSON files
+// on one platform but not the other.
+func trimBOM(data []byte) []byte {
+	return bytes.TrimPrefix(data, []byte("\xef\xbb\xbf"))
+}
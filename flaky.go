@@ -0,0 +1,270 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"path"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// flakyNoise is what differs between runs of the same test without
+// meaning anything: durations, pointers and timestamps.
+var flakyNoise = regexp.MustCompile(`\b\d+(\.\d+)?\s?(ns|µs|us|ms|s)\b|0x[0-9a-fA-F]+|\d{4}-\d\d-\d\dT[\d:.]+(Z|[+-]\d\d:\d\d)?|\b\d\d:\d\d:\d\d(\.\d+)?\b`)
+
+// flakyRun is one run of the test under detect_flaky.
+type flakyRun struct {
+	n        int
+	status   string
+	duration time.Duration
+	output   string
+	failures []testFailure
+	matched  bool
+}
+
+// runTestOnce runs a test command narrowed to one test and reads the
+// result the way run_tests does.
+func runTestOnce(ctx context.Context, repoPath, command, runner, name string, timeout time.Duration) flakyRun {
+	var run flakyRun
+	result, err := runShell(ctx, repoPath, timeout, command)
+	if err != nil {
+		run.status, run.output = "error", err.Error()
+		return run
+	}
+	run.duration = result.duration
+	output := ansiEscape.ReplaceAllString(result.output, "")
+	var report testReport
+	switch {
+	case runner == "go":
+		report, output = parseGoTestJSON(output)
+		if text, _ := goTestOutput(output, name); text != "" {
+			output = text
+		}
+	case runner != "":
+		text, ok := "", false
+		if report, text, ok = parseJSTestJSON(output, repoPath); ok {
+			output = text
+		} else {
+			report = parseJSTestOutput(output)
+		}
+	default:
+		report = parseJSTestOutput(output)
+	}
+	for i, f := range report.failures {
+		if f.pkg != "" && f.file != "" && !strings.Contains(f.file, "/") {
+			report.failures[i].file = path.Join(goPackageDir(repoPath, f.pkg), f.file)
+		}
+	}
+	run.output, run.failures = output, report.failures
+	// go test passes when -run matches nothing; text reporters may not
+	// give counts at all
+	run.matched = report.passed+report.failed > 0 || (!report.counted && runner != "go")
+	switch {
+	case result.timedOut:
+		run.status = "timed out"
+	case result.exitCode != 0:
+		run.status = "failed"
+	default:
+		run.status = "passed"
+	}
+	return run
+}
+
+// failureKey is a failing run's messages with the noise taken out, so
+// runs that failed the same way group together.
+func failureKey(run flakyRun) string {
+	if len(run.failures) == 0 {
+		return run.status + "\n" + flakyNoise.ReplaceAllString(tailLines(run.output, 10), "…")
+	}
+	var parts []string
+	for _, f := range run.failures {
+		parts = append(parts, f.name+"\n"+flakyNoise.ReplaceAllString(f.message, "…"))
+	}
+	return run.status + "\n" + strings.Join(parts, "\n")
+}
+
+func (s *QuickBasePersonalMCPServer) handleDetectFlaky(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	var params struct {
+		TestName       string `json:"test_name"`
+		GoTestName     string `json:"go_test_name"`
+		JSTestName     string `json:"js_test_name"`
+		Repo           string `json:"repo"`
+		Path           string `json:"path"`
+		Runs           int    `json:"runs"`
+		Parallel       int    `json:"parallel"`
+		TimeoutSeconds int    `json:"timeout_seconds"`
+	}
+	argsData, _ := json.Marshal(request.Params.Arguments)
+	if err := json.Unmarshal(argsData, &params); err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Invalid parameters: %v", err)), nil
+	}
+	if params.TestName == "" && params.GoTestName == "" && params.JSTestName == "" {
+		return mcp.NewToolResultError("test_name is required (or go_test_name / js_test_name)"), nil
+	}
+	if params.Runs <= 0 {
+		params.Runs = 10
+	}
+	if params.Parallel <= 0 {
+		params.Parallel = 1
+	}
+	timeout := defaultTestTimeout
+	if params.TimeoutSeconds > 0 {
+		timeout = time.Duration(params.TimeoutSeconds) * time.Second
+	}
+	if params.Repo == "" {
+		params.Repo = "all"
+	}
+	repos, err := gitRepos(params.Repo)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	if params.Repo == "all" {
+		repos = repos[:2]
+	}
+	cfg, err := loadConfig()
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to load config: %v", err)), nil
+	}
+
+	var results strings.Builder
+	results.WriteString("# Flaky test check\n\n")
+	for _, repo := range repos {
+		name := params.TestName
+		if repo.name == "quickbase-go" && params.GoTestName != "" {
+			name = params.GoTestName
+		} else if repo.name == "quickbase-js" && params.JSTestName != "" {
+			name = params.JSTestName
+		}
+		results.WriteString(fmt.Sprintf("## %s\n\n", repo.name))
+		if name == "" {
+			results.WriteString("Skipped: no test name for this SDK\n\n")
+			continue
+		}
+		command, err := testCommand(cfg, repo)
+		if err != nil {
+			results.WriteString(fmt.Sprintf("⚠️ %v\n\n", err))
+			continue
+		}
+		runner := testRunner(repo.path, command)
+		command = withJSONReporter(command, runner)
+		if rest, ok := strings.CutPrefix(command, "go test"); ok {
+			// Cached results would make every run the same run
+			command = "go test -count=1" + rest
+		}
+		command = withTestFilter(withTestName(command, name), params.Path)
+
+		// A first run on its own checks the name matches something, so a
+		// typo doesn't cost N runs
+		runs := []flakyRun{runTestOnce(ctx, repo.path, command, runner, name, timeout)}
+		runs[0].n = 1
+		if runs[0].status == "error" {
+			results.WriteString(fmt.Sprintf("⚠️ %s\n\n", runs[0].output))
+			continue
+		}
+		if !runs[0].matched {
+			results.WriteString(fmt.Sprintf("⚠️ No test matched %q with `%s`\n\n```\n%s\n```\n\n", name, command, tailLines(runs[0].output, 20)))
+			continue
+		}
+		rest := make([]flakyRun, params.Runs-1)
+		var wg sync.WaitGroup
+		slots := make(chan struct{}, params.Parallel)
+		for i := range rest {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				slots <- struct{}{}
+				defer func() { <-slots }()
+				rest[i] = runTestOnce(ctx, repo.path, command, runner, name, timeout)
+				rest[i].n = i + 2
+			}(i)
+		}
+		wg.Wait()
+		runs = append(runs, rest...)
+
+		var passed, failed []flakyRun
+		var durations []time.Duration
+		for _, run := range runs {
+			if run.status == "passed" {
+				passed = append(passed, run)
+			} else {
+				failed = append(failed, run)
+			}
+			durations = append(durations, run.duration)
+		}
+		sort.Slice(durations, func(i, j int) bool { return durations[i] < durations[j] })
+		verdict := "✅ passed every run"
+		switch {
+		case len(passed) == 0:
+			verdict = "❌ failed every run: a real failure, not a flake"
+		case len(failed) > 0:
+			verdict = fmt.Sprintf("⚠️ flaky: failed %d of %d runs", len(failed), len(runs))
+		}
+		load := ""
+		if params.Parallel > 1 {
+			load = fmt.Sprintf(", %d at a time", params.Parallel)
+		}
+		results.WriteString(fmt.Sprintf("`%s` × %d%s: %s\n\n", command, len(runs), load, verdict))
+		results.WriteString(fmt.Sprintf("Run time: min %s, median %s, max %s\n\n",
+			durations[0].Round(10*time.Millisecond), durations[len(durations)/2].Round(10*time.Millisecond), durations[len(durations)-1].Round(10*time.Millisecond)))
+		if len(failed) == 0 {
+			continue
+		}
+
+		// Runs that failed the same way, most common first
+		var keys []string
+		groups := map[string][]flakyRun{}
+		for _, run := range failed {
+			key := failureKey(run)
+			if _, ok := groups[key]; !ok {
+				keys = append(keys, key)
+			}
+			groups[key] = append(groups[key], run)
+		}
+		sort.SliceStable(keys, func(i, j int) bool { return len(groups[keys[i]]) > len(groups[keys[j]]) })
+		for i, key := range keys {
+			group := groups[key]
+			var numbers []string
+			for _, run := range group {
+				numbers = append(numbers, fmt.Sprint(run.n))
+			}
+			results.WriteString(fmt.Sprintf("### Failure %d: %s in %d run(s) (run %s)\n\n", i+1, group[0].status, len(group), strings.Join(numbers, ", ")))
+			first := group[0]
+			message := tailLines(first.output, 20)
+			if len(first.failures) > 0 {
+				var parts []string
+				for _, f := range first.failures {
+					part := f.name
+					if f.file != "" {
+						part += fmt.Sprintf(" at %s:%d", f.file, f.line)
+					}
+					parts = append(parts, part+"\n"+f.message)
+				}
+				message, _ = truncateLines(strings.Join(parts, "\n\n"), 30)
+			}
+			results.WriteString(fmt.Sprintf("```\n%s\n```\n\n", strings.Trim(message, "\n")))
+		}
+
+		if len(passed) > 0 {
+			// Durations and pointers differ in every run, so they're
+			// masked before diffing
+			pass, fail := passed[0], failed[0]
+			diff, err := unifiedDiff(flakyNoise.ReplaceAllString(pass.output, "…")+"\n", flakyNoise.ReplaceAllString(fail.output, "…")+"\n",
+				fmt.Sprintf("run %d (passed)", pass.n), fmt.Sprintf("run %d (%s)", fail.n, fail.status))
+			if err == nil {
+				diff, more := truncateLines(strings.TrimRight(diff, "\n"), 60)
+				results.WriteString(fmt.Sprintf("### Passing vs failing output\n\n```diff\n%s\n```\n", diff))
+				if more > 0 {
+					results.WriteString(fmt.Sprintf("… %d more line(s)\n", more))
+				}
+				results.WriteString("\n")
+			}
+		}
+	}
+	return mcp.NewToolResultText(strings.TrimRight(results.String(), "\n") + "\n"), nil
+}
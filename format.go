@@ -0,0 +1,94 @@
+package main
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"html/template"
+	"strings"
+)
+
+// reportFormats are the output formats of the report tools. Markdown is
+// written by each handler; the others are built from reportTables (or,
+// for JSON, the handler's own data).
+var reportFormats = []string{"markdown", "json", "csv", "html"}
+
+// validReportFormat defaults an empty format to markdown and rejects
+// unknown ones.
+func validReportFormat(format string) (string, error) {
+	if format == "" {
+		return "markdown", nil
+	}
+	if !containsString(reportFormats, format) {
+		return "", fmt.Errorf("unknown format: %s (use %s)", format, strings.Join(reportFormats, ", "))
+	}
+	return format, nil
+}
+
+// reportTable is one table of a report, for CSV and HTML output.
+type reportTable struct {
+	Title   string
+	Columns []string
+	Rows    [][]string
+}
+
+// renderJSON indents v for reading and piping to jq.
+func renderJSON(v interface{}) (string, error) {
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
+
+// renderCSV writes a single table with a header row. Reports with several
+// tables pass their main one; the rest is summary a script can recompute.
+func renderCSV(t reportTable) (string, error) {
+	var out strings.Builder
+	w := csv.NewWriter(&out)
+	if err := w.Write(t.Columns); err != nil {
+		return "", err
+	}
+	if err := w.WriteAll(t.Rows); err != nil {
+		return "", err
+	}
+	return out.String(), nil
+}
+
+var reportPage = template.Must(template.New("report").Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<title>{{.Title}}</title>
+<style>
+body { font-family: -apple-system, system-ui, sans-serif; margin: 2rem; color: #222; }
+table { border-collapse: collapse; margin-bottom: 2rem; }
+th, td { border: 1px solid #ccc; padding: 0.3rem 0.6rem; text-align: left; vertical-align: top; }
+th { background: #f4f4f4; }
+p.meta { color: #666; }
+</style>
+</head>
+<body>
+<h1>{{.Title}}</h1>
+{{range .Intro}}<p class="meta">{{.}}</p>
+{{end}}{{range .Tables}}<h2>{{.Title}}</h2>
+<table>
+<thead><tr>{{range .Columns}}<th>{{.}}</th>{{end}}</tr></thead>
+<tbody>
+{{range .Rows}}<tr>{{range .}}<td>{{.}}</td>{{end}}</tr>
+{{end}}</tbody>
+</table>
+{{end}}</body>
+</html>
+`))
+
+// renderHTML builds a standalone page, suitable for publishing as is.
+func renderHTML(title string, intro []string, tables []reportTable) (string, error) {
+	var out strings.Builder
+	err := reportPage.Execute(&out, struct {
+		Title  string
+		Intro  []string
+		Tables []reportTable
+	}{title, intro, tables})
+	return out.String(), err
+}
@@ -0,0 +1,223 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+var (
+	// goGenerateDirective is a //go:generate line and its command
+	goGenerateDirective = regexp.MustCompile(`(?m)^//go:generate[ \t]+(.+?)\s*$`)
+	// generateScriptName is an npm script that generates code: generate,
+	// gen, codegen, or one of them as a part like generate:types
+	generateScriptName = regexp.MustCompile(`(?i)(^|:)(gen|generate|codegen)($|:)`)
+	// npmRunScript is a script calling another: "npm run generate:types"
+	npmRunScript = regexp.MustCompile(`\bnpm run ([\w:.-]+)`)
+)
+
+// generateStages sort steps by what they're called and run, checked in
+// order so a mock of the Client isn't taken for the client. Steps run
+// spec first, then types generated from it, then clients built on the
+// types, then anything else (mocks, stringers).
+var generateStages = []struct {
+	name    string
+	pattern *regexp.Regexp
+}{
+	{"other", regexp.MustCompile(`(?i)mock|stringer|enumer`)},
+	{"types", regexp.MustCompile(`(?i)\b(types?|schemas?|models?)\b|openapi-typescript|-generate[= ]types`)},
+	{"clients", regexp.MustCompile(`(?i)client|sdk|oapi-codegen|openapi-generator|\bapi\b`)},
+	{"spec", regexp.MustCompile(`(?i)spec|openapi|swagger|bundle|redocly|fetch|download`)},
+}
+
+// stageOrder ranks a stage for sorting.
+var stageOrder = map[string]int{"spec": 0, "types": 1, "clients": 2, "other": 3}
+
+// generateStep is one generator to run: a go:generate directive or an npm
+// script.
+type generateStep struct {
+	repo           gitRepo
+	stage, source  string
+	label, command string
+}
+
+// generateStage sorts a step into a stage by what it's called and runs.
+// The spec repo's steps are all spec.
+func generateStage(repo gitRepo, text string) string {
+	if repo.name == "quickbase-spec" {
+		return "spec"
+	}
+	for _, s := range generateStages {
+		if s.pattern.MatchString(text) {
+			return s.name
+		}
+	}
+	return "other"
+}
+
+// goGenerateSteps finds a repo's go:generate directives, each run alone
+// with go generate -run so its changes can be told apart.
+func goGenerateSteps(repo gitRepo) []generateStep {
+	var steps []generateStep
+	walkRepo(repo.path, func(rel string) error {
+		if !strings.HasSuffix(rel, ".go") {
+			return nil
+		}
+		data, err := os.ReadFile(filepath.Join(repo.path, rel))
+		if err != nil || !strings.Contains(string(data), "//go:generate") {
+			return nil
+		}
+		for _, m := range goGenerateDirective.FindAllStringSubmatch(string(data), -1) {
+			steps = append(steps, generateStep{
+				repo:    repo,
+				stage:   generateStage(repo, rel+" "+m[1]),
+				source:  rel,
+				label:   m[1],
+				command: fmt.Sprintf("go generate -run %s ./%s", shellQuote(regexp.QuoteMeta(m[1])), rel),
+			})
+		}
+		return nil
+	})
+	return steps
+}
+
+// npmGenerateSteps finds a repo's generate scripts. A script that only
+// runs other generate scripts is left out for its parts, so each part's
+// changes are reported on their own.
+func npmGenerateSteps(repo gitRepo) []generateStep {
+	data, err := os.ReadFile(filepath.Join(repo.path, "package.json"))
+	if err != nil {
+		return nil
+	}
+	var pkg struct {
+		Scripts map[string]string `json:"scripts"`
+	}
+	if json.Unmarshal(data, &pkg) != nil {
+		return nil
+	}
+	names := map[string]bool{}
+	for name := range pkg.Scripts {
+		// The spec repo's bundle and build produce the spec itself
+		if generateScriptName.MatchString(name) || (repo.name == "quickbase-spec" && (name == "bundle" || name == "build")) {
+			names[name] = true
+		}
+	}
+	var steps []generateStep
+	for _, name := range sortedKeys(names) {
+		aggregate := false
+		for _, m := range npmRunScript.FindAllStringSubmatch(pkg.Scripts[name], -1) {
+			if names[m[1]] && m[1] != name {
+				aggregate = true
+			}
+		}
+		if aggregate {
+			continue
+		}
+		steps = append(steps, generateStep{
+			repo:    repo,
+			stage:   generateStage(repo, name+" "+pkg.Scripts[name]),
+			source:  "package.json",
+			label:   name + ": " + pkg.Scripts[name],
+			command: "npm run " + name,
+		})
+	}
+	return steps
+}
+
+func (s *QuickBasePersonalMCPServer) handleRunGenerate(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	var params struct {
+		Repo           string `json:"repo"`
+		DryRun         bool   `json:"dry_run"`
+		TimeoutSeconds int    `json:"timeout_seconds"`
+	}
+	argsData, _ := json.Marshal(request.Params.Arguments)
+	if err := json.Unmarshal(argsData, &params); err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Invalid parameters: %v", err)), nil
+	}
+	timeout := defaultTestTimeout
+	if params.TimeoutSeconds > 0 {
+		timeout = time.Duration(params.TimeoutSeconds) * time.Second
+	}
+	if params.Repo == "" {
+		params.Repo = "all"
+	}
+	repos, err := gitRepos(params.Repo)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	if params.Repo == "all" {
+		// The spec's steps come first within their stage
+		repos = []gitRepo{repos[2], repos[0], repos[1]}
+	}
+
+	var steps []generateStep
+	for _, repo := range repos {
+		if fileExists(filepath.Join(repo.path, "go.mod")) {
+			steps = append(steps, goGenerateSteps(repo)...)
+		}
+		steps = append(steps, npmGenerateSteps(repo)...)
+	}
+	sort.SliceStable(steps, func(i, j int) bool { return stageOrder[steps[i].stage] < stageOrder[steps[j].stage] })
+
+	var results strings.Builder
+	results.WriteString("# Generate\n\n")
+	if len(steps) == 0 {
+		results.WriteString("No go:generate directives or generate scripts found.\n")
+		return mcp.NewToolResultText(results.String()), nil
+	}
+	if params.DryRun {
+		results.WriteString("| # | Stage | Repo | Source | Step |\n|---|---|---|---|---|\n")
+		for i, step := range steps {
+			results.WriteString(fmt.Sprintf("| %d | %s | %s | `%s` | `%s` |\n", i+1, step.stage, step.repo.name, step.source, strings.ReplaceAll(step.label, "|", `\|`)))
+		}
+		results.WriteString("\nDry run: nothing was run.\n")
+		return mcp.NewToolResultText(results.String()), nil
+	}
+
+	changedSteps := 0
+	for i, step := range steps {
+		results.WriteString(fmt.Sprintf("## %d. %s: %s (%s)\n\n", i+1, step.stage, step.repo.name, path.Base(step.source)))
+		results.WriteString(fmt.Sprintf("`%s`\n\n", step.command))
+		before := dirtyFiles(step.repo.path)
+		result, err := runShell(ctx, step.repo.path, timeout, step.command)
+		failure := ""
+		switch {
+		case err != nil:
+			failure = err.Error()
+		case result.timedOut:
+			failure = fmt.Sprintf("Timed out after %s", timeout)
+		case result.exitCode != 0:
+			failure = fmt.Sprintf("Failed (exit %d):\n\n```\n%s\n```", result.exitCode, tailLines(ansiEscape.ReplaceAllString(result.output, ""), 30))
+		}
+		if failure != "" {
+			// Later stages build on this one's output
+			results.WriteString(fmt.Sprintf("❌ %s\n\n", failure))
+			if rest := len(steps) - i - 1; rest > 0 {
+				results.WriteString(fmt.Sprintf("Stopped: %d later step(s) not run.\n", rest))
+			}
+			return mcp.NewToolResultText(strings.TrimRight(results.String(), "\n") + "\n"), nil
+		}
+		changes := codegenChanges(step.repo.path, before)
+		if len(changes) == 0 {
+			results.WriteString(fmt.Sprintf("✅ No changes (%s)\n\n", result.duration.Round(100*time.Millisecond)))
+			continue
+		}
+		changedSteps++
+		results.WriteString(fmt.Sprintf("📝 Changed %d file(s) (%s)\n\n", len(changes), result.duration.Round(100*time.Millisecond)))
+		for _, f := range changes {
+			results.WriteString(fmt.Sprintf("- `%s` (%s, +%s/-%s)\n", f.path, fileStatusNames[f.status], f.added, f.removed))
+		}
+		results.WriteString("\n")
+	}
+	results.WriteString(fmt.Sprintf("Ran %d step(s); %d changed files. Review with git_diff before committing.\n", len(steps), changedSteps))
+	return mcp.NewToolResultText(results.String()), nil
+}
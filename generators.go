@@ -0,0 +1,250 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// generatorPresets are known TypeScript generator command lines. {spec} and
+// {out} are replaced with the spec file and an empty output directory.
+var generatorPresets = map[string]string{
+	"openapi-generator-typescript": "npx --yes @openapitools/openapi-generator-cli generate -i {spec} -g typescript-fetch -o {out}",
+	"openapi-generator-axios":      "npx --yes @openapitools/openapi-generator-cli generate -i {spec} -g typescript-axios -o {out}",
+	"openapi-typescript":           "npx --yes openapi-typescript {spec} -o {out}/schema.ts",
+	"hey-api":                      "npx --yes @hey-api/openapi-ts -i {spec} -o {out}",
+	"orval":                        "npx --yes orval --input {spec} --output {out}/client.ts",
+}
+
+var jsMethodDecl = regexp.MustCompile(`(?m)^[ \t]+(?:public\s+|static\s+|async\s+|readonly\s+)*([A-Za-z_$][\w$]*)\s*(?:<[^>\n]*>)?\s*\([^)\n]*\)\s*(?::\s*[^{;\n]+)?\{`)
+
+// jsMethodKeywords look like method declarations to jsMethodDecl but aren't.
+var jsMethodKeywords = map[string]bool{
+	"if": true, "for": true, "while": true, "switch": true, "catch": true,
+	"function": true, "return": true, "constructor": true, "with": true,
+}
+
+// generatorOutput summarizes the client API produced by one generator run.
+type generatorOutput struct {
+	command string
+	result  commandResult
+	files   int
+	lines   int
+	exports map[string]string // normalized name -> original
+	methods map[string]string
+}
+
+// runGenerator executes a generator into a fresh temp directory and
+// collects the exported symbols and methods of what it produced.
+func runGenerator(ctx context.Context, command, specPath string, timeout time.Duration) (*generatorOutput, string, error) {
+	if preset, ok := generatorPresets[command]; ok {
+		command = preset
+	}
+	if !strings.Contains(command, "{out}") {
+		return nil, "", fmt.Errorf("generator command must contain {out} (or be one of: %s)", strings.Join(sortedKeys(generatorPresets), ", "))
+	}
+	outDir, err := os.MkdirTemp("", "qb-generator-")
+	if err != nil {
+		return nil, "", err
+	}
+	command = strings.NewReplacer("{spec}", shellQuote(specPath), "{out}", shellQuote(outDir)).Replace(command)
+
+	gen := &generatorOutput{command: command, exports: map[string]string{}, methods: map[string]string{}}
+	gen.result, err = runShell(ctx, quickbaseJSPath, timeout, command)
+	if err != nil {
+		return nil, outDir, err
+	}
+
+	walkRepo(outDir, func(rel string) error {
+		switch filepath.Ext(rel) {
+		case ".ts", ".tsx", ".js", ".mjs":
+		default:
+			return nil
+		}
+		data, err := os.ReadFile(filepath.Join(outDir, rel))
+		if err != nil {
+			return nil
+		}
+		src := string(data)
+		gen.files++
+		gen.lines += strings.Count(src, "\n")
+		for _, name := range jsExports(src) {
+			gen.exports[normalizeTerm(name)] = name
+		}
+		for _, m := range jsMethodDecl.FindAllStringSubmatch(src, -1) {
+			if !jsMethodKeywords[m[1]] {
+				gen.methods[normalizeTerm(m[1])] = m[1]
+			}
+		}
+		return nil
+	})
+	return gen, outDir, nil
+}
+
+// coversOperation reports whether a generator produced a symbol for opID.
+func (g *generatorOutput) coversOperation(opID string) bool {
+	key := normalizeTerm(opID)
+	for norm := range g.methods {
+		if strings.Contains(norm, key) {
+			return true
+		}
+	}
+	for norm := range g.exports {
+		if strings.Contains(norm, key) {
+			return true
+		}
+	}
+	return false
+}
+
+// onlyIn returns original names present in a but not b, sorted.
+func onlyIn(a, b map[string]string) []string {
+	var names []string
+	for norm, name := range a {
+		if _, ok := b[norm]; !ok {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+func (s *QuickBasePersonalMCPServer) handleCompareGenerators(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	var params struct {
+		GeneratorA     string `json:"generator_a"`
+		GeneratorB     string `json:"generator_b"`
+		TimeoutSeconds int    `json:"timeout_seconds"`
+		KeepOutput     bool   `json:"keep_output"`
+	}
+	argsData, _ := json.Marshal(request.Params.Arguments)
+	if err := json.Unmarshal(argsData, &params); err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Invalid parameters: %v", err)), nil
+	}
+	if params.GeneratorA == "" || params.GeneratorB == "" {
+		return mcp.NewToolResultError(fmt.Sprintf("generator_a and generator_b are required (presets: %s)", strings.Join(sortedKeys(generatorPresets), ", "))), nil
+	}
+	if params.TimeoutSeconds <= 0 {
+		params.TimeoutSeconds = 300
+	}
+	timeout := time.Duration(params.TimeoutSeconds) * time.Second
+
+	specPath, err := findSpecFile()
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	root, err := loadSpec()
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to load spec: %v", err)), nil
+	}
+
+	var outputs [2]*generatorOutput
+	var outDirs []string
+	defer func() {
+		if !params.KeepOutput {
+			for _, dir := range outDirs {
+				os.RemoveAll(dir)
+			}
+		}
+	}()
+	for i, command := range []string{params.GeneratorA, params.GeneratorB} {
+		gen, outDir, err := runGenerator(ctx, command, specPath, timeout)
+		if outDir != "" {
+			outDirs = append(outDirs, outDir)
+		}
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("Generator %c: %v", 'A'+i, err)), nil
+		}
+		outputs[i] = gen
+	}
+
+	a, b := outputs[0], outputs[1]
+	var results strings.Builder
+	results.WriteString("# Generator Comparison\n\n")
+	results.WriteString(fmt.Sprintf("- **A**: `%s`\n- **B**: `%s`\n\n", a.command, b.command))
+
+	for i, gen := range outputs {
+		if gen.result.timedOut || gen.result.exitCode != 0 {
+			status := fmt.Sprintf("exit code %d", gen.result.exitCode)
+			if gen.result.timedOut {
+				status = fmt.Sprintf("timed out after %s", timeout)
+			}
+			results.WriteString(fmt.Sprintf("⚠️ Generator %c failed (%s):\n\n```\n%s\n```\n\n", 'A'+i, status, tailLines(gen.result.output, 30)))
+		}
+	}
+
+	ops := specOperations(root)
+	coveredA, coveredB := 0, 0
+	var onlyA, onlyB []string
+	for _, op := range ops {
+		if op.OperationID == "" {
+			continue
+		}
+		inA, inB := a.coversOperation(op.OperationID), b.coversOperation(op.OperationID)
+		if inA {
+			coveredA++
+		}
+		if inB {
+			coveredB++
+		}
+		switch {
+		case inA && !inB:
+			onlyA = append(onlyA, op.OperationID)
+		case inB && !inA:
+			onlyB = append(onlyB, op.OperationID)
+		}
+	}
+
+	results.WriteString("| | A | B |\n|---|---|---|\n")
+	results.WriteString(fmt.Sprintf("| Duration | %s | %s |\n", a.result.duration.Round(time.Second), b.result.duration.Round(time.Second)))
+	results.WriteString(fmt.Sprintf("| Files | %d | %d |\n", a.files, b.files))
+	results.WriteString(fmt.Sprintf("| Lines | %d | %d |\n", a.lines, b.lines))
+	results.WriteString(fmt.Sprintf("| Exports | %d | %d |\n", len(a.exports), len(b.exports)))
+	results.WriteString(fmt.Sprintf("| Methods | %d | %d |\n", len(a.methods), len(b.methods)))
+	results.WriteString(fmt.Sprintf("| Operations covered | %d/%d | %d/%d |\n\n", coveredA, len(ops), coveredB, len(ops)))
+
+	writeList := func(title string, names []string, limit int) {
+		results.WriteString(fmt.Sprintf("## %s (%d)\n\n", title, len(names)))
+		if len(names) == 0 {
+			results.WriteString("None\n\n")
+			return
+		}
+		for i, name := range names {
+			if i == limit {
+				results.WriteString(fmt.Sprintf("- ... and %d more\n", len(names)-limit))
+				break
+			}
+			results.WriteString(fmt.Sprintf("- %s\n", name))
+		}
+		results.WriteString("\n")
+	}
+	writeList("Operations only A exposes", onlyA, 50)
+	writeList("Operations only B exposes", onlyB, 50)
+	writeList("Exports only in A", onlyIn(a.exports, b.exports), 50)
+	writeList("Exports only in B", onlyIn(b.exports, a.exports), 50)
+	writeList("Methods only in A", onlyIn(a.methods, b.methods), 50)
+	writeList("Methods only in B", onlyIn(b.methods, a.methods), 50)
+
+	results.WriteString("Names are compared case- and separator-insensitively, so runQuery and RunQuery match.\n")
+	if params.KeepOutput {
+		results.WriteString(fmt.Sprintf("\nOutput kept in: %s\n", strings.Join(outDirs, ", ")))
+	}
+
+	return mcp.NewToolResultText(results.String()), nil
+}
+
+// tailLines returns the last n lines of s.
+func tailLines(s string, n int) string {
+	lines := strings.Split(strings.TrimRight(s, "\n"), "\n")
+	if len(lines) > n {
+		lines = lines[len(lines)-n:]
+	}
+	return strings.Join(lines, "\n")
+}
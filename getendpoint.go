@@ -0,0 +1,195 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"gopkg.in/yaml.v3"
+)
+
+// operationParameters lists an operation's parameters with the path item's
+// shared ones merged in; the operation's own win on the same name and in.
+func operationParameters(root *yaml.Node, op specOperation) []*yaml.Node {
+	var params []*yaml.Node
+	seen := map[string]bool{}
+	key := func(p *yaml.Node) string {
+		in, name := mappingValue(p, "in"), mappingValue(p, "name")
+		if in == nil || name == nil {
+			return ""
+		}
+		return in.Value + " " + name.Value
+	}
+	item := mappingValue(mappingValue(root, "paths"), op.Path)
+	for _, list := range []*yaml.Node{mappingValue(op.Node, "parameters"), mappingValue(item, "parameters")} {
+		if list == nil {
+			continue
+		}
+		for _, p := range list.Content {
+			p = expandRefs(root, p, nil)
+			if k := key(p); k == "" || !seen[k] {
+				seen[k] = true
+				params = append(params, p)
+			}
+		}
+	}
+	return params
+}
+
+// scalarAt is the value of a scalar child, or "".
+func scalarAt(n *yaml.Node, key string) string {
+	if v := mappingValue(n, key); v != nil && v.Kind == yaml.ScalarNode {
+		return v.Value
+	}
+	return ""
+}
+
+// writeSchemaBlock renders an expanded schema in a fenced block.
+func writeSchemaBlock(results *strings.Builder, schema *yaml.Node, format string) error {
+	out, err := renderNode(schema, format)
+	if err != nil {
+		return err
+	}
+	results.WriteString(fmt.Sprintf("```%s\n%s\n```\n\n", format, out))
+	return nil
+}
+
+// writeContent renders each media type of a request body or response.
+func writeContent(results *strings.Builder, root, content *yaml.Node, format string) error {
+	for j := 0; j+1 < len(content.Content); j += 2 {
+		media := content.Content[j+1]
+		results.WriteString(fmt.Sprintf("`%s`\n\n", content.Content[j].Value))
+		schema := mappingValue(media, "schema")
+		if schema == nil {
+			results.WriteString("(no schema)\n\n")
+			continue
+		}
+		if err := writeSchemaBlock(results, expandRefs(root, schema, nil), format); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *QuickBasePersonalMCPServer) handleGetEndpoint(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	var params struct {
+		Operation string `json:"operation"`
+		Format    string `json:"format"`
+		Responses string `json:"responses"`
+	}
+	argsData, _ := json.Marshal(request.Params.Arguments)
+	if err := json.Unmarshal(argsData, &params); err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Invalid parameters: %v", err)), nil
+	}
+	if params.Format == "" {
+		params.Format = "yaml"
+	}
+	if params.Format != "yaml" && params.Format != "json" {
+		return mcp.NewToolResultError(fmt.Sprintf("Unknown format: %s (use yaml or json)", params.Format)), nil
+	}
+
+	root, err := loadSpec()
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to load spec: %v", err)), nil
+	}
+	ops := specOperations(root)
+	op, ok := findOperation(ops, strings.TrimSpace(params.Operation))
+	if !ok {
+		var ids []string
+		for _, o := range ops {
+			if o.OperationID != "" {
+				ids = append(ids, o.OperationID)
+			}
+		}
+		return mcp.NewToolResultError(fmt.Sprintf("Unknown operation: %q; give an operationId (%s) or 'METHOD /path', or use spec_search", params.Operation, strings.Join(ids, ", "))), nil
+	}
+
+	var results strings.Builder
+	results.WriteString(fmt.Sprintf("# %s %s", op.Method, op.Path))
+	if op.OperationID != "" {
+		results.WriteString(fmt.Sprintf(" (%s)", op.OperationID))
+	}
+	results.WriteString("\n\n")
+	if op.Deprecated {
+		results.WriteString("⚠️ **Deprecated**\n\n")
+	}
+	if op.Summary != "" {
+		results.WriteString(op.Summary + "\n\n")
+	}
+	if d := scalarAt(op.Node, "description"); d != "" && d != op.Summary {
+		results.WriteString(strings.TrimSpace(d) + "\n\n")
+	}
+	if len(op.Tags) > 0 {
+		results.WriteString(fmt.Sprintf("Tags: %s\n\n", strings.Join(op.Tags, ", ")))
+	}
+	results.WriteString("$refs are expanded in place; `x-ref` names the component each expanded schema came from.\n\n")
+
+	results.WriteString("## Parameters\n\n")
+	paramList := operationParameters(root, op)
+	if len(paramList) == 0 {
+		results.WriteString("None\n\n")
+	} else {
+		results.WriteString("| Name | In | Required | Type | Description |\n|---|---|---|---|---|\n")
+		for _, p := range paramList {
+			required := "no"
+			if scalarAt(p, "required") == "true" || scalarAt(p, "in") == "path" {
+				required = "yes"
+			}
+			results.WriteString(fmt.Sprintf("| %s | %s | %s | %s | %s |\n", scalarAt(p, "name"), scalarAt(p, "in"), required, schemaType(mappingValue(p, "schema")), strings.ReplaceAll(scalarAt(p, "description"), "\n", " ")))
+		}
+		results.WriteString("\n")
+		// Complex parameter schemas don't fit in a table cell
+		for _, p := range paramList {
+			schema := mappingValue(p, "schema")
+			if schema != nil && (mappingValue(schema, "properties") != nil || mappingValue(schema, "enum") != nil || mappingValue(schema, "items") != nil) {
+				results.WriteString(fmt.Sprintf("`%s` schema:\n\n", scalarAt(p, "name")))
+				if err := writeSchemaBlock(&results, schema, params.Format); err != nil {
+					return mcp.NewToolResultError(fmt.Sprintf("Failed to render schema: %v", err)), nil
+				}
+			}
+		}
+	}
+
+	results.WriteString("## Request body\n\n")
+	body := expandRefs(root, mappingValue(op.Node, "requestBody"), nil)
+	if content := mappingValue(body, "content"); content == nil {
+		results.WriteString("None\n\n")
+	} else {
+		if scalarAt(body, "required") == "true" {
+			results.WriteString("Required.\n\n")
+		}
+		if d := scalarAt(body, "description"); d != "" {
+			results.WriteString(strings.TrimSpace(d) + "\n\n")
+		}
+		if err := writeContent(&results, root, content, params.Format); err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("Failed to render request body: %v", err)), nil
+		}
+	}
+
+	results.WriteString("## Responses\n\n")
+	responses := mappingValue(op.Node, "responses")
+	if responses == nil {
+		results.WriteString("None\n")
+	}
+	for j := 0; responses != nil && j+1 < len(responses.Content); j += 2 {
+		status := responses.Content[j].Value
+		if params.Responses != "" && !strings.HasPrefix(status, params.Responses) {
+			continue
+		}
+		resp, _ := resolveRef(root, responses.Content[j+1])
+		results.WriteString(fmt.Sprintf("### %s", status))
+		if d := scalarAt(resp, "description"); d != "" {
+			results.WriteString(" — " + strings.TrimSpace(d))
+		}
+		results.WriteString("\n\n")
+		if content := mappingValue(resp, "content"); content != nil {
+			if err := writeContent(&results, root, content, params.Format); err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("Failed to render response: %v", err)), nil
+			}
+		}
+	}
+
+	return mcp.NewToolResultText(strings.TrimRight(results.String(), "\n") + "\n"), nil
+}
@@ -0,0 +1,94 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+// runGit runs git in repoPath and returns trimmed stdout. Stderr is folded
+// into the error so callers can surface git's own message.
+func runGit(repoPath string, args ...string) (string, error) {
+	cmd := exec.Command("git", append([]string{"-C", repoPath}, args...)...)
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		msg := strings.TrimSpace(stderr.String())
+		if msg == "" {
+			msg = err.Error()
+		}
+		return "", fmt.Errorf("git %s: %s", strings.Join(args, " "), msg)
+	}
+	return strings.TrimRight(stdout.String(), "\n"), nil
+}
+
+// verifyRef checks that ref names a commit in repoPath.
+func verifyRef(repoPath, ref string) error {
+	if strings.HasPrefix(ref, "-") {
+		return fmt.Errorf("invalid ref: %s", ref)
+	}
+	_, err := runGit(repoPath, "rev-parse", "--verify", "--quiet", ref+"^{commit}")
+	if err != nil {
+		return fmt.Errorf("unknown ref: %s", ref)
+	}
+	return nil
+}
+
+// readRepoFile reads rel from the working tree, or from ref via
+// `git show ref:path` when ref is set.
+func readRepoFile(repoPath, ref, rel string) ([]byte, error) {
+	if ref == "" {
+		return os.ReadFile(filepath.Join(repoPath, rel))
+	}
+	out, err := runGit(repoPath, "show", ref+":"+rel)
+	if err != nil {
+		return nil, err
+	}
+	return []byte(out + "\n"), nil
+}
+
+// previousTag is the latest tag before ref, the natural start of a
+// changelog ending at ref.
+func previousTag(repoPath, ref string) (string, error) {
+	if ref == "" {
+		// The working tree's changes start after whatever HEAD is tagged
+		return runGit(repoPath, "describe", "--tags", "--abbrev=0", "HEAD")
+	}
+	return runGit(repoPath, "describe", "--tags", "--abbrev=0", ref+"^")
+}
+
+// gitRepo is a repository the git tools work across.
+type gitRepo struct {
+	name, path string
+}
+
+// gitRepos lists the repos the git tools work across: the three Quickbase
+// repos, then any configured under repos. selection is 'all' (or empty),
+// 'js', 'go', 'spec' or a repo's name.
+func gitRepos(selection string) ([]gitRepo, error) {
+	repos := []gitRepo{{"quickbase-js", quickbaseJSPath}, {"quickbase-go", quickbaseGoPath}, {"quickbase-spec", quickbaseSpecPath}}
+	if cfg, err := loadConfig(); err == nil {
+		for _, name := range sortedKeys(cfg.Repos) {
+			path := cfg.Repos[name]
+			if rest, ok := strings.CutPrefix(path, "~/"); ok {
+				path = filepath.Join(os.Getenv("HOME"), rest)
+			}
+			repos = append(repos, gitRepo{name, path})
+		}
+	}
+	if selection == "" || selection == "all" {
+		return repos, nil
+	}
+	var names []string
+	for _, r := range repos {
+		if r.name == selection || r.name == "quickbase-"+selection {
+			return []gitRepo{r}, nil
+		}
+		names = append(names, r.name)
+	}
+	return nil, fmt.Errorf("unknown repo: %s (use js, go, spec, all or one of: %s)", selection, strings.Join(names, ", "))
+}
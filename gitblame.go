@@ -0,0 +1,280 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// blameCodeLimit is the most lines shown with their code; longer ranges
+// get the hunk table only.
+const blameCodeLimit = 200
+
+// blameCommit is a commit that last touched some of the blamed lines.
+type blameCommit struct {
+	Hash    string `json:"hash"`
+	Author  string `json:"author"`
+	Email   string `json:"email"`
+	Date    string `json:"date"`
+	Summary string `json:"summary"`
+	Message string `json:"message,omitempty"`
+	Lines   int    `json:"lines"`
+}
+
+// blameHunk is a run of consecutive lines last touched by one commit.
+type blameHunk struct {
+	Start  int      `json:"start"`
+	End    int      `json:"end"`
+	Commit string   `json:"commit"`
+	File   string   `json:"file,omitempty"` // where the lines were, when moved or renamed
+	Code   []string `json:"code,omitempty"`
+}
+
+// uncommitted is the hash git blame gives lines not committed yet.
+const uncommitted = "0000000000000000000000000000000000000000"
+
+// parseBlame reads `git blame --porcelain`: a header per line naming its
+// commit, the commit's details the first time it appears, then the line.
+func parseBlame(out, path string) ([]blameHunk, map[string]*blameCommit, []string) {
+	commits := map[string]*blameCommit{}
+	var order []string
+	var hunks []blameHunk
+	var current *blameCommit
+	var line int
+	var file string
+	for _, l := range strings.Split(out, "\n") {
+		if strings.HasPrefix(l, "\t") {
+			code := strings.TrimPrefix(l, "\t")
+			current.Lines++
+			if n := len(hunks); n > 0 && hunks[n-1].Commit == current.Hash && hunks[n-1].End == line-1 && hunks[n-1].File == file {
+				hunks[n-1].End = line
+				hunks[n-1].Code = append(hunks[n-1].Code, code)
+			} else {
+				hunks = append(hunks, blameHunk{Start: line, End: line, Commit: current.Hash, File: file, Code: []string{code}})
+			}
+			continue
+		}
+		key, value, _ := strings.Cut(l, " ")
+		if len(key) == 40 && strings.Trim(key, "0123456789abcdef") == "" {
+			if commits[key] == nil {
+				commits[key] = &blameCommit{Hash: key}
+				order = append(order, key)
+			}
+			current = commits[key]
+			if fields := strings.Fields(value); len(fields) >= 2 {
+				line, _ = strconv.Atoi(fields[1])
+			}
+			continue
+		}
+		switch key {
+		case "author":
+			current.Author = value
+		case "author-mail":
+			current.Email = strings.Trim(value, "<>")
+		case "author-time":
+			if sec, err := strconv.ParseInt(value, 10, 64); err == nil {
+				current.Date = time.Unix(sec, 0).UTC().Format("2006-01-02")
+			}
+		case "summary":
+			current.Summary = value
+		case "filename":
+			file = ""
+			if value != path {
+				file = value
+			}
+		}
+	}
+	return hunks, commits, order
+}
+
+// blameTarget works out which repo a blamed path is in: the one given,
+// the one named at the start of the path, or the only one that has it.
+func blameTarget(repo, path, ref string) (gitRepo, string, error) {
+	repo, path = splitRepoPath(repo, strings.Trim(path, "/"))
+	if repo != "" && repo != "all" {
+		repos, err := gitRepos(repo)
+		if err != nil {
+			return gitRepo{}, "", err
+		}
+		return repos[0], path, nil
+	}
+	repos, _ := gitRepos("all")
+	var found []gitRepo
+	for _, r := range repos {
+		if ref != "" {
+			if _, err := runGit(r.path, "cat-file", "-e", ref+":"+path); err == nil {
+				found = append(found, r)
+			}
+		} else if _, err := os.Stat(filepath.Join(r.path, path)); err == nil {
+			found = append(found, r)
+		}
+	}
+	switch len(found) {
+	case 0:
+		return gitRepo{}, "", fmt.Errorf("%s is not in any repo; give repo or start the path with the repo's name", path)
+	case 1:
+		return found[0], path, nil
+	}
+	var names []string
+	for _, r := range found {
+		names = append(names, r.name)
+	}
+	return gitRepo{}, "", fmt.Errorf("%s is in %s; give repo to pick one", path, strings.Join(names, " and "))
+}
+
+func (s *QuickBasePersonalMCPServer) handleGitBlame(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	var params struct {
+		Repo        string `json:"repo"`
+		Path        string `json:"path"`
+		StartLine   int    `json:"start_line"`
+		EndLine     int    `json:"end_line"`
+		Ref         string `json:"ref"`
+		FollowMoves bool   `json:"follow_moves"`
+		Format      string `json:"format"`
+	}
+	argsData, _ := json.Marshal(request.Params.Arguments)
+	if err := json.Unmarshal(argsData, &params); err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Invalid parameters: %v", err)), nil
+	}
+	if params.Path == "" {
+		return mcp.NewToolResultError("path is required"), nil
+	}
+	if params.Format == "" {
+		params.Format = "markdown"
+	}
+	if params.Format != "markdown" && params.Format != "json" {
+		return mcp.NewToolResultError(fmt.Sprintf("Unknown format: %s (use markdown or json)", params.Format)), nil
+	}
+	if params.StartLine < 0 || params.EndLine < 0 || (params.EndLine > 0 && params.EndLine < params.StartLine) {
+		return mcp.NewToolResultError(fmt.Sprintf("Invalid line range: %d-%d", params.StartLine, params.EndLine)), nil
+	}
+	repo, path, err := blameTarget(params.Repo, params.Path, params.Ref)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	args := []string{"blame", "--porcelain"}
+	if params.FollowMoves {
+		args = append(args, "-M", "-C")
+	}
+	if params.StartLine > 0 {
+		end := params.EndLine
+		if end == 0 {
+			end = params.StartLine
+		}
+		args = append(args, "-L", fmt.Sprintf("%d,%d", params.StartLine, end))
+	}
+	if params.Ref != "" {
+		if err := verifyRef(repo.path, params.Ref); err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		args = append(args, params.Ref)
+	}
+	out, err := runGit(repo.path, append(args, "--", path)...)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Blame failed in %s: %v", repo.name, err)), nil
+	}
+	hunks, commits, order := parseBlame(out, path)
+	if len(hunks) == 0 {
+		return mcp.NewToolResultText(fmt.Sprintf("%s/%s is empty\n", repo.name, path)), nil
+	}
+
+	// The full message answers "why"; skip ones that are just the subject
+	for _, hash := range order {
+		c := commits[hash]
+		if hash == uncommitted {
+			c.Summary = "Not committed yet"
+			continue
+		}
+		if msg, err := runGit(repo.path, "show", "-s", "--format=%B", hash); err == nil {
+			if body := strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(msg), c.Summary)); body != "" {
+				c.Message = body
+			}
+		}
+	}
+	first, last := hunks[0].Start, hunks[len(hunks)-1].End
+	showCode := last-first+1 <= blameCodeLimit
+
+	if params.Format == "json" {
+		list := make([]*blameCommit, 0, len(order))
+		for _, hash := range order {
+			list = append(list, commits[hash])
+		}
+		if !showCode {
+			for i := range hunks {
+				hunks[i].Code = nil
+			}
+		}
+		data, err := json.MarshalIndent(struct {
+			Repo    string         `json:"repo"`
+			Path    string         `json:"path"`
+			Ref     string         `json:"ref,omitempty"`
+			Start   int            `json:"start"`
+			End     int            `json:"end"`
+			Hunks   []blameHunk    `json:"hunks"`
+			Commits []*blameCommit `json:"commits"`
+		}{repo.name, path, params.Ref, first, last, hunks, list}, "", "  ")
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("Failed to encode blame: %v", err)), nil
+		}
+		return mcp.NewToolResultText(string(data)), nil
+	}
+
+	short := func(hash string) string {
+		if hash == uncommitted {
+			return "uncommitted"
+		}
+		return hash[:7]
+	}
+	var results strings.Builder
+	results.WriteString(fmt.Sprintf("# Blame: %s/%s lines %d-%d", repo.name, path, first, last))
+	if params.Ref != "" {
+		results.WriteString(" at " + params.Ref)
+	}
+	results.WriteString("\n\n## Commits\n\n| Commit | Date | Author | Lines | Summary |\n|---|---|---|---|---|\n")
+	for _, hash := range order {
+		c := commits[hash]
+		results.WriteString(fmt.Sprintf("| %s | %s | %s | %d | %s |\n", short(hash), c.Date, c.Author, c.Lines, docCell(c.Summary)))
+	}
+	results.WriteString("\n")
+	for _, hash := range order {
+		if c := commits[hash]; c.Message != "" {
+			results.WriteString(fmt.Sprintf("**%s %s**\n\n%s\n\n", short(hash), c.Summary, c.Message))
+		}
+	}
+
+	results.WriteString("## Lines\n\n")
+	if !showCode {
+		results.WriteString(fmt.Sprintf("The range is over %d lines, so code is left out; give start_line and end_line to see it.\n\n", blameCodeLimit))
+	}
+	for _, h := range hunks {
+		c := commits[h.Commit]
+		label := fmt.Sprintf("%d-%d", h.Start, h.End)
+		if h.Start == h.End {
+			label = fmt.Sprint(h.Start)
+		}
+		results.WriteString(fmt.Sprintf("**%s** · %s %s %s", label, short(h.Commit), c.Date, c.Author))
+		if h.File != "" {
+			results.WriteString(fmt.Sprintf(" (from %s)", h.File))
+		}
+		results.WriteString("\n")
+		if showCode {
+			fence := "```"
+			for _, code := range h.Code {
+				if strings.Contains(code, "```") {
+					fence = "````"
+				}
+			}
+			results.WriteString(fmt.Sprintf("%s\n%s\n%s\n", fence, strings.Join(h.Code, "\n"), fence))
+		}
+		results.WriteString("\n")
+	}
+	return mcp.NewToolResultText(strings.TrimRight(results.String(), "\n") + "\n"), nil
+}
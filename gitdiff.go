@@ -0,0 +1,189 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// untrackedDiff renders a new file as a diff against nothing, the way
+// git diff shows an added file. Binaries get no body.
+func untrackedDiff(repoPath, rel string) (string, int) {
+	header := fmt.Sprintf("diff --git a/%s b/%s\nnew file (untracked)\n", rel, rel)
+	data, err := os.ReadFile(filepath.Join(repoPath, rel))
+	if err != nil {
+		return header + "(directory or unreadable)\n", 0
+	}
+	if bytes.IndexByte(data, 0) >= 0 {
+		return header + "Binary file\n", 0
+	}
+	lines := strings.Split(strings.TrimSuffix(string(data), "\n"), "\n")
+	var b strings.Builder
+	b.WriteString(header)
+	b.WriteString(fmt.Sprintf("--- /dev/null\n+++ b/%s\n@@ -0,0 +1,%d @@\n", rel, len(lines)))
+	for _, l := range lines {
+		b.WriteString("+" + l + "\n")
+	}
+	return b.String(), len(lines)
+}
+
+// truncateLines keeps the first max lines of text, saying how many were
+// cut.
+func truncateLines(text string, max int) (string, int) {
+	lines := strings.Split(strings.TrimRight(text, "\n"), "\n")
+	if len(lines) <= max {
+		return strings.Join(lines, "\n"), 0
+	}
+	return strings.Join(lines[:max], "\n"), len(lines) - max
+}
+
+func (s *QuickBasePersonalMCPServer) handleGitDiff(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	var params struct {
+		Repo      string `json:"repo"`
+		Path      string `json:"path"`
+		Changes   string `json:"changes"`
+		Untracked *bool  `json:"untracked"`
+		StatOnly  bool   `json:"stat_only"`
+		Context   *int   `json:"context"`
+		MaxLines  int    `json:"max_lines"`
+	}
+	argsData, _ := json.Marshal(request.Params.Arguments)
+	if err := json.Unmarshal(argsData, &params); err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Invalid parameters: %v", err)), nil
+	}
+	if params.Changes == "" {
+		params.Changes = "all"
+	}
+	var diffArgs []string
+	switch params.Changes {
+	case "all":
+		diffArgs = []string{"diff", "HEAD"}
+	case "staged":
+		diffArgs = []string{"diff", "--cached"}
+	case "unstaged":
+		diffArgs = []string{"diff"}
+	default:
+		return mcp.NewToolResultError(fmt.Sprintf("Unknown changes: %s (use all, staged or unstaged)", params.Changes)), nil
+	}
+	if params.MaxLines <= 0 {
+		params.MaxLines = 400
+	}
+	if params.Context != nil {
+		if *params.Context < 0 {
+			return mcp.NewToolResultError("context must be 0 or more"), nil
+		}
+		diffArgs = append(diffArgs, fmt.Sprintf("--unified=%d", *params.Context))
+	}
+	// Staged changes can't include files git doesn't track yet
+	includeUntracked := (params.Untracked == nil || *params.Untracked) && params.Changes != "staged"
+	params.Repo, params.Path = splitRepoPath(params.Repo, strings.Trim(params.Path, "/"))
+	repos, err := gitRepos(params.Repo)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	var pathArgs []string
+	if params.Path != "" {
+		pathArgs = []string{"--", params.Path}
+	}
+
+	titles := map[string]string{"all": "staged and unstaged", "staged": "staged", "unstaged": "unstaged"}
+	var results strings.Builder
+	results.WriteString(fmt.Sprintf("# Git Diff (%s)\n\n", titles[params.Changes]))
+	if params.Path != "" {
+		results.WriteString(fmt.Sprintf("Path: %s\n\n", params.Path))
+	}
+	changed := 0
+	for _, repo := range repos {
+		st, err := readRepoStatus(repo.path)
+		if err != nil {
+			if len(repos) == 1 {
+				return mcp.NewToolResultError(fmt.Sprintf("Failed to read %s: %v", repo.name, err)), nil
+			}
+			continue
+		}
+		numstat, err := runGit(repo.path, append(append(append([]string{}, diffArgs...), "--numstat"), pathArgs...)...)
+		if err != nil {
+			// A repo with no commits has no HEAD to diff against
+			results.WriteString(fmt.Sprintf("## %s\n\n⚠️ %v\n\n", repo.name, err))
+			continue
+		}
+		var untracked []string
+		if includeUntracked {
+			for _, f := range st.untracked {
+				if params.Path == "" || f == params.Path || strings.HasPrefix(f, params.Path+"/") || strings.HasPrefix(params.Path, f) {
+					untracked = append(untracked, f)
+				}
+			}
+		}
+		if numstat == "" && len(untracked) == 0 {
+			continue
+		}
+		changed++
+
+		results.WriteString(fmt.Sprintf("## %s\n\n| File | Change | + | - |\n|---|---|---|---|\n", repo.name))
+		for _, line := range strings.Split(numstat, "\n") {
+			fields := strings.SplitN(line, "\t", 3)
+			if len(fields) != 3 {
+				continue
+			}
+			file := fields[2]
+			where := "unstaged"
+			switch {
+			case containsString(st.staged, file) && containsString(st.modified, file):
+				where = "staged + unstaged"
+			case containsString(st.staged, file):
+				where = "staged"
+			}
+			if fields[0] == "-" {
+				fields[0], fields[1] = "binary", ""
+			}
+			results.WriteString(fmt.Sprintf("| `%s` | %s | %s | %s |\n", file, where, fields[0], fields[1]))
+		}
+		var untrackedDiffs strings.Builder
+		for _, f := range untracked {
+			diff, n := untrackedDiff(repo.path, f)
+			count := fmt.Sprint(n)
+			if strings.HasSuffix(f, "/") {
+				count = "directory"
+			}
+			results.WriteString(fmt.Sprintf("| `%s` | untracked | %s | |\n", f, count))
+			if !strings.HasSuffix(f, "/") {
+				untrackedDiffs.WriteString(diff)
+			}
+		}
+		results.WriteString("\n")
+		if params.StatOnly {
+			continue
+		}
+
+		diff, err := runGit(repo.path, append(append([]string{}, diffArgs...), pathArgs...)...)
+		if err != nil {
+			results.WriteString(fmt.Sprintf("⚠️ %v\n\n", err))
+			continue
+		}
+		if untrackedDiffs.Len() > 0 {
+			if diff != "" {
+				diff += "\n"
+			}
+			diff += untrackedDiffs.String()
+		}
+		if diff == "" {
+			continue
+		}
+		text, cut := truncateLines(diff, params.MaxLines)
+		results.WriteString(fmt.Sprintf("```diff\n%s\n```\n\n", text))
+		if cut > 0 {
+			results.WriteString(fmt.Sprintf("… %d more line(s); narrow it with path or raise max_lines.\n\n", cut))
+		}
+	}
+	if changed == 0 {
+		results.WriteString("No uncommitted changes\n")
+	}
+	return mcp.NewToolResultText(strings.TrimRight(results.String(), "\n") + "\n"), nil
+}
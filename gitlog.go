@@ -0,0 +1,160 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// gitCommit is one commit from git log, with the files it touched when
+// asked for.
+type gitCommit struct {
+	hash, date, author, subject string
+	files                       []string // "M path", "A path", …
+}
+
+// gitLog runs git log with the given filter args and parses the commits.
+// Records are split on RS and fields on US so subjects can hold anything.
+func gitLog(repoPath string, files bool, args ...string) ([]gitCommit, error) {
+	cmdArgs := []string{"log", "--date=short", "--format=%x1e%h%x1f%ad%x1f%an%x1f%s"}
+	if files {
+		cmdArgs = append(cmdArgs, "--name-status")
+	}
+	out, err := runGit(repoPath, append(cmdArgs, args...)...)
+	if err != nil {
+		return nil, err
+	}
+	var commits []gitCommit
+	for _, record := range strings.Split(out, "\x1e") {
+		lines := strings.Split(strings.TrimSpace(record), "\n")
+		fields := strings.Split(lines[0], "\x1f")
+		if len(fields) != 4 {
+			continue
+		}
+		c := gitCommit{hash: fields[0], date: fields[1], author: fields[2], subject: fields[3]}
+		for _, line := range lines[1:] {
+			if status, path, ok := strings.Cut(line, "\t"); ok {
+				// Renames and copies list old and new paths
+				c.files = append(c.files, status[:1]+" "+strings.ReplaceAll(path, "\t", " → "))
+			}
+		}
+		commits = append(commits, c)
+	}
+	return commits, nil
+}
+
+// splitRepoPath lets a path name its repo, as in quickbase-go/client:
+// the repo is picked from the prefix and the rest is the path in it.
+func splitRepoPath(repo, path string) (string, string) {
+	if repo != "" && repo != "all" {
+		return repo, path
+	}
+	repos, _ := gitRepos("all")
+	for _, r := range repos {
+		if path == r.name || strings.HasPrefix(path, r.name+"/") {
+			return r.name, strings.TrimPrefix(strings.TrimPrefix(path, r.name), "/")
+		}
+	}
+	return repo, path
+}
+
+func (s *QuickBasePersonalMCPServer) handleGitLog(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	var params struct {
+		Repo   string `json:"repo"`
+		Since  string `json:"since"`
+		Until  string `json:"until"`
+		Author string `json:"author"`
+		Path   string `json:"path"`
+		Grep   string `json:"grep"`
+		Ref    string `json:"ref"`
+		Files  bool   `json:"files"`
+		Limit  int    `json:"limit"`
+	}
+	argsData, _ := json.Marshal(request.Params.Arguments)
+	if err := json.Unmarshal(argsData, &params); err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Invalid parameters: %v", err)), nil
+	}
+	if params.Limit <= 0 {
+		params.Limit = 20
+	}
+	params.Repo, params.Path = splitRepoPath(params.Repo, strings.Trim(params.Path, "/"))
+	repos, err := gitRepos(params.Repo)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	args := []string{"-n", fmt.Sprint(params.Limit)}
+	var filters []string
+	for _, f := range []struct{ flag, value, label string }{
+		{"--since=", params.Since, "since"},
+		{"--until=", params.Until, "until"},
+		{"--author=", params.Author, "author"},
+		{"--grep=", params.Grep, "message matches"},
+	} {
+		if f.value != "" {
+			args = append(args, f.flag+f.value)
+			filters = append(filters, fmt.Sprintf("%s %q", f.label, f.value))
+		}
+	}
+	if params.Grep != "" {
+		args = append(args, "--regexp-ignore-case")
+	}
+	if params.Ref != "" {
+		filters = append(filters, "ref "+params.Ref)
+	}
+	if params.Path != "" {
+		filters = append(filters, "path "+params.Path)
+	}
+
+	var results strings.Builder
+	results.WriteString("# Git Log\n\n")
+	if len(filters) > 0 {
+		results.WriteString(fmt.Sprintf("Filters: %s\n\n", strings.Join(filters, ", ")))
+	}
+	total := 0
+	for _, repo := range repos {
+		repoArgs := args
+		if params.Ref != "" {
+			if err := verifyRef(repo.path, params.Ref); err != nil {
+				results.WriteString(fmt.Sprintf("## %s\n\n%v\n\n", repo.name, err))
+				continue
+			}
+			repoArgs = append(repoArgs, params.Ref)
+		}
+		if params.Path != "" {
+			repoArgs = append(repoArgs, "--", params.Path)
+		}
+		commits, err := gitLog(repo.path, params.Files, repoArgs...)
+		if err != nil {
+			results.WriteString(fmt.Sprintf("## %s\n\n⚠️ %v\n\n", repo.name, err))
+			continue
+		}
+		total += len(commits)
+		// With several repos, only the ones with matches are worth a section
+		if len(commits) == 0 && len(repos) > 1 {
+			continue
+		}
+		results.WriteString(fmt.Sprintf("## %s (%d)\n\n", repo.name, len(commits)))
+		if len(commits) == 0 {
+			results.WriteString("No matching commits\n\n")
+			continue
+		}
+		for _, c := range commits {
+			results.WriteString(fmt.Sprintf("- %s %s %s — %s\n", c.hash, c.date, c.author, c.subject))
+			for _, f := range c.files {
+				results.WriteString(fmt.Sprintf("  - %s\n", f))
+			}
+		}
+		if len(commits) == params.Limit {
+			results.WriteString(fmt.Sprintf("\nShowing the latest %d; raise limit or narrow the filters for more.\n", params.Limit))
+		}
+		results.WriteString("\n")
+	}
+	if total == 0 && len(repos) > 1 {
+		results.WriteString("No matching commits in any repo\n")
+	}
+	return mcp.NewToolResultText(strings.TrimRight(results.String(), "\n") + "\n"), nil
+}
@@ -0,0 +1,200 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// repoStatus is a repo's branch and working tree state, from
+// `git status --porcelain=v2 --branch`.
+type repoStatus struct {
+	branch, upstream, operation string
+	ahead, behind               string
+	staged, modified, untracked []string
+	conflicts                   []string
+	stashes                     int
+}
+
+// dirty reports whether the repo has uncommitted work.
+func (st repoStatus) dirty() bool {
+	return len(st.staged)+len(st.modified)+len(st.untracked)+len(st.conflicts) > 0
+}
+
+// inProgress names a merge, rebase, cherry-pick, revert or bisect that is
+// underway, from the marker files git leaves in its directory.
+func inProgress(repoPath string) string {
+	for _, m := range []struct{ file, name string }{
+		{"rebase-merge", "rebase"}, {"rebase-apply", "rebase"}, {"MERGE_HEAD", "merge"},
+		{"CHERRY_PICK_HEAD", "cherry-pick"}, {"REVERT_HEAD", "revert"}, {"BISECT_LOG", "bisect"},
+	} {
+		path, err := runGit(repoPath, "rev-parse", "--git-path", m.file)
+		if err != nil {
+			continue
+		}
+		if !strings.HasPrefix(path, "/") {
+			path = repoPath + "/" + path
+		}
+		if _, err := os.Stat(path); err == nil {
+			return m.name
+		}
+	}
+	return ""
+}
+
+// readRepoStatus parses porcelain v2 output. A file can be both staged and
+// modified (changed again after git add).
+func readRepoStatus(repoPath string) (repoStatus, error) {
+	out, err := runGit(repoPath, "status", "--porcelain=v2", "--branch")
+	if err != nil {
+		return repoStatus{}, err
+	}
+	var st repoStatus
+	for _, line := range strings.Split(out, "\n") {
+		switch {
+		case strings.HasPrefix(line, "# branch.head "):
+			st.branch = strings.TrimPrefix(line, "# branch.head ")
+		case strings.HasPrefix(line, "# branch.upstream "):
+			st.upstream = strings.TrimPrefix(line, "# branch.upstream ")
+		case strings.HasPrefix(line, "# branch.ab "):
+			if ab := strings.Fields(strings.TrimPrefix(line, "# branch.ab ")); len(ab) == 2 {
+				st.ahead, st.behind = strings.TrimPrefix(ab[0], "+"), strings.TrimPrefix(ab[1], "-")
+			}
+		case strings.HasPrefix(line, "1 "), strings.HasPrefix(line, "2 "):
+			// Renames carry "path<tab>origPath" after nine fields
+			n := 9
+			if line[0] == '2' {
+				n = 10
+			}
+			fields := strings.SplitN(line, " ", n)
+			if len(fields) < n {
+				continue
+			}
+			path, _, _ := strings.Cut(fields[n-1], "\t")
+			if xy := fields[1]; xy[0] != '.' {
+				st.staged = append(st.staged, path)
+				if xy[1] != '.' {
+					st.modified = append(st.modified, path)
+				}
+			} else {
+				st.modified = append(st.modified, path)
+			}
+		case strings.HasPrefix(line, "u "):
+			if fields := strings.SplitN(line, " ", 11); len(fields) == 11 {
+				st.conflicts = append(st.conflicts, fields[10])
+			}
+		case strings.HasPrefix(line, "? "):
+			st.untracked = append(st.untracked, strings.TrimPrefix(line, "? "))
+		}
+	}
+	if stashes, err := runGit(repoPath, "stash", "list"); err == nil && stashes != "" {
+		st.stashes = len(strings.Split(stashes, "\n"))
+	}
+	st.operation = inProgress(repoPath)
+	return st, nil
+}
+
+// writeFileList writes a capped list of paths under a label.
+func writeFileList(results *strings.Builder, label string, files []string, limit int) {
+	if len(files) == 0 {
+		return
+	}
+	results.WriteString(fmt.Sprintf("- %s (%d):", label, len(files)))
+	for i, f := range files {
+		if i == limit {
+			results.WriteString(fmt.Sprintf(" … %d more", len(files)-limit))
+			break
+		}
+		results.WriteString(" `" + f + "`")
+	}
+	results.WriteString("\n")
+}
+
+func (s *QuickBasePersonalMCPServer) handleGitStatus(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	var params struct {
+		Repo  string `json:"repo"`
+		Fetch bool   `json:"fetch"`
+		Limit int    `json:"limit"`
+	}
+	argsData, _ := json.Marshal(request.Params.Arguments)
+	if err := json.Unmarshal(argsData, &params); err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Invalid parameters: %v", err)), nil
+	}
+	if params.Limit <= 0 {
+		params.Limit = 10
+	}
+	repos, err := gitRepos(params.Repo)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	var results, details strings.Builder
+	results.WriteString("# Git Status\n\n")
+	results.WriteString("| Repo | Branch | Upstream | Ahead / behind | Staged | Modified | Untracked | Stashes |\n|---|---|---|---|---|---|---|---|\n")
+	var attention []string
+	for _, repo := range repos {
+		if params.Fetch {
+			if _, err := runGit(repo.path, "fetch", "--quiet"); err != nil {
+				attention = append(attention, fmt.Sprintf("%s: fetch failed, ahead/behind may be stale (%v)", repo.name, err))
+			}
+		}
+		st, err := readRepoStatus(repo.path)
+		if err != nil {
+			results.WriteString(fmt.Sprintf("| %s | ⚠️ not a git repo | | | | | | |\n", repo.name))
+			continue
+		}
+		upstream, ab := st.upstream, "—"
+		if upstream == "" {
+			upstream = "none"
+			if st.branch != "(detached)" {
+				attention = append(attention, fmt.Sprintf("%s: %s has no upstream, so nothing on it is pushed", repo.name, st.branch))
+			}
+		} else {
+			ab = fmt.Sprintf("%s / %s", st.ahead, st.behind)
+		}
+		branch := st.branch
+		if st.operation != "" {
+			branch += fmt.Sprintf(" (%s in progress)", st.operation)
+			attention = append(attention, fmt.Sprintf("%s: a %s is in progress", repo.name, st.operation))
+		}
+		if len(st.conflicts) > 0 {
+			attention = append(attention, fmt.Sprintf("%s: %d file(s) with merge conflicts", repo.name, len(st.conflicts)))
+		}
+		if st.ahead != "" && st.ahead != "0" {
+			attention = append(attention, fmt.Sprintf("%s: %s commit(s) not pushed", repo.name, st.ahead))
+		}
+		if st.behind != "" && st.behind != "0" {
+			attention = append(attention, fmt.Sprintf("%s: %s commit(s) to pull", repo.name, st.behind))
+		}
+		if st.dirty() {
+			attention = append(attention, fmt.Sprintf("%s: uncommitted work", repo.name))
+		}
+		results.WriteString(fmt.Sprintf("| %s | %s | %s | %s | %d | %d | %d | %d |\n", repo.name, branch, upstream, ab, len(st.staged), len(st.modified), len(st.untracked), st.stashes))
+
+		if st.dirty() {
+			details.WriteString(fmt.Sprintf("## %s\n\n", repo.name))
+			writeFileList(&details, "Conflicts", st.conflicts, params.Limit)
+			writeFileList(&details, "Staged", st.staged, params.Limit)
+			writeFileList(&details, "Modified", st.modified, params.Limit)
+			writeFileList(&details, "Untracked", st.untracked, params.Limit)
+			details.WriteString("\n")
+		}
+	}
+	results.WriteString("\n")
+
+	if len(attention) == 0 {
+		results.WriteString("✅ Everything is committed and in sync with its upstream.\n")
+		return mcp.NewToolResultText(results.String()), nil
+	}
+	results.WriteString("## Needs attention\n\n")
+	for _, a := range attention {
+		results.WriteString("- " + a + "\n")
+	}
+	results.WriteString("\n")
+	results.WriteString(details.String())
+	return mcp.NewToolResultText(strings.TrimRight(results.String(), "\n") + "\n"), nil
+}
@@ -0,0 +1,349 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"unicode"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// glossaryTerm is a concept shared by both SDKs and how each should spell
+// it. Docs is the prose spelling (defaults to the term itself), JS and Go
+// are identifier spellings, and Avoid lists synonyms that should not be
+// used anywhere.
+type glossaryTerm struct {
+	Definition string   `json:"definition" yaml:"definition"`
+	Docs       string   `json:"docs,omitempty" yaml:"docs,omitempty"`
+	JS         string   `json:"js,omitempty" yaml:"js,omitempty"`
+	Go         string   `json:"go,omitempty" yaml:"go,omitempty"`
+	Avoid      []string `json:"avoid,omitempty" yaml:"avoid,omitempty"`
+
+	source string
+}
+
+var defaultGlossary = map[string]glossaryTerm{
+	"app":             {Definition: "A Quickbase application, the container for tables.", Avoid: []string{"application"}},
+	"app ID":          {Definition: "The dbid of an app.", JS: "appId", Go: "AppID", Avoid: []string{"application ID", "application id"}},
+	"table ID":        {Definition: "The dbid of a table.", JS: "tableId", Go: "TableID", Avoid: []string{"table dbid"}},
+	"record ID":       {Definition: "The built-in Record ID# field (field 3) identifying a record.", JS: "recordId", Go: "RecordID", Avoid: []string{"rid"}},
+	"field ID":        {Definition: "The numeric id of a field within a table.", JS: "fieldId", Go: "FieldID", Avoid: []string{"fid"}},
+	"user token":      {Definition: "A long-lived token tied to a user, sent as QB-USER-TOKEN.", JS: "userToken", Go: "UserToken", Avoid: []string{"usertoken"}},
+	"temporary token": {Definition: "A short-lived token scoped to one dbid, obtained from a user token or browser session.", JS: "tempToken", Go: "TempToken", Avoid: []string{"temp token"}},
+}
+
+// glossaryFileNames are looked up in configDir, first match wins.
+var glossaryFileNames = []string{"glossary.yaml", "glossary.yml", "glossary.json"}
+
+// loadGlossary returns the default glossary merged with the user's glossary
+// file, if any.
+func loadGlossary() (map[string]glossaryTerm, error) {
+	glossary := make(map[string]glossaryTerm, len(defaultGlossary))
+	for name, term := range defaultGlossary {
+		term.source = "built-in"
+		glossary[name] = term
+	}
+
+	var custom map[string]glossaryTerm
+	path, err := readConfigFile(glossaryFileNames, &custom)
+	if err != nil {
+		return nil, err
+	}
+	for name, term := range custom {
+		term.source = path
+		glossary[name] = term
+	}
+
+	for name, term := range glossary {
+		if term.Docs == "" {
+			term.Docs = name
+			glossary[name] = term
+		}
+	}
+	return glossary, nil
+}
+
+// proseSegment is a comment or string literal found in source code.
+type proseSegment struct {
+	line int
+	text string
+	kind string // "comment" or "error message"
+}
+
+// errorCallPattern marks lines whose string literals are error messages.
+var errorCallPattern = regexp.MustCompile(`errors\.New\(|fmt\.Errorf\(|Error\(|throw |reject\(`)
+
+// sourceProse extracts comments and error-message strings from TS or Go
+// source, skipping other string literals (JSON tags, URLs, keys).
+func sourceProse(src string) []proseSegment {
+	lines := strings.Split(src, "\n")
+	var segments []proseSegment
+	line := 1
+	for i := 0; i < len(src); i++ {
+		c := src[i]
+		switch {
+		case c == '\n':
+			line++
+		case c == '"' || c == '\'' || c == '`':
+			start, startLine := i+1, line
+			for i++; i < len(src) && src[i] != c; i++ {
+				if src[i] == '\\' && c != '`' {
+					i++
+				} else if src[i] == '\n' {
+					line++
+				}
+			}
+			if i > len(src) {
+				i = len(src)
+			}
+			if errorCallPattern.MatchString(lines[startLine-1]) {
+				segments = append(segments, proseSegment{line: startLine, text: src[start:i], kind: "error message"})
+			}
+		case c == '/' && i+1 < len(src) && src[i+1] == '/':
+			start := i + 2
+			for i < len(src) && src[i] != '\n' {
+				i++
+			}
+			segments = append(segments, proseSegment{line: line, text: src[start:i], kind: "comment"})
+			line++
+		case c == '/' && i+1 < len(src) && src[i+1] == '*':
+			end := strings.Index(src[i+2:], "*/")
+			if end < 0 {
+				end = len(src) - i - 2
+			}
+			// Report block comments line by line so line numbers stay exact
+			for j, text := range strings.Split(src[i+2:i+2+end], "\n") {
+				segments = append(segments, proseSegment{line: line + j, text: text, kind: "comment"})
+			}
+			line += strings.Count(src[i+2:i+2+end], "\n")
+			i += end + 3
+		}
+	}
+	return segments
+}
+
+var inlineCode = regexp.MustCompile("`[^`]*`")
+
+// docProse returns the prose lines of a markdown file, skipping fenced
+// code blocks and inline code.
+func docProse(src string) []proseSegment {
+	var segments []proseSegment
+	inFence := false
+	for i, text := range strings.Split(src, "\n") {
+		if strings.HasPrefix(strings.TrimSpace(text), "```") {
+			inFence = !inFence
+			continue
+		}
+		if !inFence {
+			segments = append(segments, proseSegment{line: i + 1, text: inlineCode.ReplaceAllString(text, ""), kind: "docs"})
+		}
+	}
+	return segments
+}
+
+// termViolation is one non-canonical use of a glossary term.
+type termViolation struct {
+	file  string
+	line  int
+	kind  string
+	found string
+	use   string
+	text  string
+}
+
+// sameIdentifier reports whether a and b differ at most in the case of
+// their first letter, as exported and unexported Go names do.
+func sameIdentifier(a, b string) bool {
+	if a == "" || b == "" {
+		return a == b
+	}
+	ra, rb := []rune(a), []rune(b)
+	return unicode.ToLower(ra[0]) == unicode.ToLower(rb[0]) && string(ra[1:]) == string(rb[1:])
+}
+
+// terminologyChecker finds glossary violations in prose segments.
+type terminologyChecker struct {
+	name    string
+	term    glossaryTerm
+	avoid   *regexp.Regexp
+	variant string // normalized identifier spelling
+}
+
+func newTerminologyChecker(name string, term glossaryTerm) terminologyChecker {
+	c := terminologyChecker{name: name, term: term}
+	if len(term.Avoid) > 0 {
+		c.avoid = keywordPattern(term.Avoid)
+	}
+	return c
+}
+
+// check reports violations in one segment. spelling is the identifier
+// spelling for the segment's language ("" for docs).
+func (c terminologyChecker) check(seg proseSegment, spelling string) []termViolation {
+	var found []termViolation
+	report := func(word, use string) {
+		found = append(found, termViolation{line: seg.line, kind: seg.kind, found: word, use: use, text: strings.TrimSpace(seg.text)})
+	}
+
+	if c.avoid != nil {
+		for _, loc := range c.avoid.FindAllStringIndex(seg.text, -1) {
+			word := seg.text[loc[0]:loc[1]]
+			// Skip media types and paths like application/json
+			if (loc[0] > 0 && seg.text[loc[0]-1] == '/') || (loc[1] < len(seg.text) && seg.text[loc[1]] == '/') {
+				continue
+			}
+			if sameIdentifier(word, c.term.JS) || sameIdentifier(word, c.term.Go) || word == c.term.Docs {
+				continue
+			}
+			use := c.term.Docs
+			if spelling != "" {
+				use = fmt.Sprintf("%s (or `%s`)", c.term.Docs, spelling)
+			}
+			report(word, use)
+		}
+	}
+
+	if spelling != "" {
+		key := normalizeTerm(spelling)
+		for _, word := range identPattern.FindAllString(seg.text, -1) {
+			if normalizeTerm(word) == key && !sameIdentifier(word, spelling) {
+				report(word, "`"+spelling+"`")
+			}
+		}
+	}
+	return found
+}
+
+// checkTerminology scans one SDK's docs and source prose for violations,
+// keyed by glossary term.
+func checkTerminology(repoPath string, keep func(string) bool, lang string, checkers []terminologyChecker) map[string][]termViolation {
+	violations := map[string][]termViolation{}
+	scan := func(file string, segments []proseSegment, docs bool) {
+		for _, c := range checkers {
+			spelling := c.term.Go
+			if lang == "js" {
+				spelling = c.term.JS
+			}
+			if docs {
+				spelling = ""
+			}
+			for _, seg := range segments {
+				for _, v := range c.check(seg, spelling) {
+					v.file = file
+					violations[c.name] = append(violations[c.name], v)
+				}
+			}
+		}
+	}
+
+	for _, file := range docFiles(repoPath) {
+		if data, err := os.ReadFile(filepath.Join(repoPath, file)); err == nil {
+			scan(file, docProse(string(data)), true)
+		}
+	}
+	for _, file := range listSourceFiles(repoPath, keep) {
+		data, err := os.ReadFile(filepath.Join(repoPath, file))
+		if err != nil || isGenerated(data) {
+			continue
+		}
+		scan(file, sourceProse(string(data)), false)
+	}
+	return violations
+}
+
+func (s *QuickBasePersonalMCPServer) handleCheckTerminology(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	var params struct {
+		Repo  string `json:"repo"`
+		Term  string `json:"term"`
+		Limit int    `json:"limit"`
+	}
+	argsData, _ := json.Marshal(request.Params.Arguments)
+	if err := json.Unmarshal(argsData, &params); err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Invalid parameters: %v", err)), nil
+	}
+	if params.Repo == "" {
+		params.Repo = "all"
+	}
+	if params.Limit <= 0 {
+		params.Limit = 20
+	}
+
+	glossary, err := loadGlossary()
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to load glossary: %v", err)), nil
+	}
+	if params.Term != "" {
+		if _, ok := glossary[params.Term]; !ok {
+			return mcp.NewToolResultError(fmt.Sprintf("Unknown term: %s\n\nAvailable terms: %s", params.Term, strings.Join(sortedKeys(glossary), ", "))), nil
+		}
+	}
+
+	var checkers []terminologyChecker
+	for _, name := range sortedKeys(glossary) {
+		if params.Term == "" || params.Term == name {
+			checkers = append(checkers, newTerminologyChecker(name, glossary[name]))
+		}
+	}
+
+	var results strings.Builder
+	results.WriteString("# Terminology Check\n\n")
+	results.WriteString("| Term | Docs | JS | Go | Avoid | Definition |\n|---|---|---|---|---|---|\n")
+	for _, c := range checkers {
+		results.WriteString(fmt.Sprintf("| %s | %s | %s | %s | %s | %s |\n", c.name, c.term.Docs, c.term.JS, c.term.Go, strings.Join(c.term.Avoid, ", "), c.term.Definition))
+	}
+	results.WriteString(fmt.Sprintf("\nAdd or override terms in %s.\n\n", filepath.Join(configDir, glossaryFileNames[0])))
+
+	repos := []struct {
+		name string
+		lang string
+		path string
+		keep func(string) bool
+	}{
+		{"quickbase-js", "js", quickbaseJSPath, isJSSource},
+		{"quickbase-go", "go", quickbaseGoPath, isGoSource},
+	}
+	total := 0
+	for _, repo := range repos {
+		if params.Repo != "all" && params.Repo != repo.lang {
+			continue
+		}
+		violations := checkTerminology(repo.path, repo.keep, repo.lang, checkers)
+		count := 0
+		for _, v := range violations {
+			count += len(v)
+		}
+		total += count
+		results.WriteString(fmt.Sprintf("## %s (%d)\n\n", repo.name, count))
+		if count == 0 {
+			results.WriteString("No violations\n\n")
+			continue
+		}
+		for _, name := range sortedKeys(violations) {
+			list := violations[name]
+			results.WriteString(fmt.Sprintf("### %s\n\n| Location | Kind | Found | Use | Text |\n|---|---|---|---|---|\n", name))
+			for i, v := range list {
+				if i == params.Limit {
+					results.WriteString(fmt.Sprintf("\n... and %d more\n", len(list)-params.Limit))
+					break
+				}
+				text := v.text
+				if len(text) > 80 {
+					text = text[:77] + "..."
+				}
+				text = strings.ReplaceAll(text, "|", "\\|")
+				results.WriteString(fmt.Sprintf("| %s:%d | %s | %s | %s | %s |\n", v.file, v.line, v.kind, v.found, v.use, text))
+			}
+			results.WriteString("\n")
+		}
+	}
+	if total == 0 {
+		results.WriteString("✅ Terminology is consistent with the glossary.\n")
+	}
+
+	return mcp.NewToolResultText(results.String()), nil
+}
@@ -0,0 +1,280 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// inventoryIgnoredStems are file names that hold plumbing rather than a
+// feature of their own.
+var inventoryIgnoredStems = map[string]bool{
+	"index": true, "types": true, "doc": true, "util": true, "utils": true,
+	"internal": true, "constants": true, "version": true, "main": true,
+}
+
+// inventoryItem is one feature and the files implementing it per SDK.
+type inventoryItem struct {
+	name     string
+	category string
+	jsFiles  []string
+	goFiles  []string
+	sources  map[string]bool // "feature map", "annotation", "layout"
+}
+
+// inventorySide is one SDK's source files and how to read them.
+type inventorySide struct {
+	root    string
+	files   []string
+	exports func(src []byte) []string
+	trim    string // prefix dropped before taking the category ("src/")
+}
+
+// category is the first directory of a file, below trim.
+func (side inventorySide) category(rel string) string {
+	dir := path.Dir(strings.TrimPrefix(rel, side.trim))
+	if dir == "." {
+		return "core"
+	}
+	return strings.SplitN(dir, "/", 2)[0]
+}
+
+// inventorySides lists the source files of both SDKs, JS first.
+func inventorySides() [2]inventorySide {
+	return [2]inventorySide{
+		{root: quickbaseJSPath, files: listSourceFiles(quickbaseJSPath, isJSSource), exports: func(src []byte) []string { return jsExports(string(src)) }, trim: "src/"},
+		{root: quickbaseGoPath, files: listSourceFiles(quickbaseGoPath, isGoSource), exports: goExports},
+	}
+}
+
+// buildInventory assembles the feature list from the feature map (which
+// includes qb:feature annotations), then the directory layout for files it
+// doesn't claim. Features from the layout are paired across SDKs by file
+// name, so user-token.ts and user_token.go are one feature.
+func buildInventory(features map[string]featureEntry, sides [2]inventorySide) map[string]*inventoryItem {
+	items := map[string]*inventoryItem{}
+	claimed := [2]map[string]bool{{}, {}}
+	item := func(name, category string) *inventoryItem {
+		key := normalizeTerm(name)
+		if items[key] == nil {
+			items[key] = &inventoryItem{name: name, category: category, sources: map[string]bool{}}
+		}
+		return items[key]
+	}
+	add := func(it *inventoryItem, i int, rel, source string) {
+		if i == 0 {
+			it.jsFiles = append(it.jsFiles, rel)
+		} else {
+			it.goFiles = append(it.goFiles, rel)
+		}
+		it.sources[source] = true
+		claimed[i][rel] = true
+	}
+
+	for _, name := range featureNames(features) {
+		entry := features[name]
+		it := item(name, "")
+		if entry.source != "annotations" {
+			it.sources["feature map"] = true
+		}
+		for i, patterns := range [][]string{entry.JS, entry.Go} {
+			if it.category == "" && len(patterns) > 0 {
+				it.category = sides[i].category(patterns[0])
+			}
+			for _, rel := range resolveFeatureFiles(sides[i].root, patterns) {
+				// Plain paths are returned whether or not they exist
+				if _, err := os.Stat(filepath.Join(sides[i].root, rel)); err != nil {
+					continue
+				}
+				source := "feature map"
+				if entry.annotated[rel] {
+					source = "annotation"
+				}
+				add(it, i, rel, source)
+			}
+		}
+	}
+
+	for i, side := range sides {
+		for _, rel := range side.files {
+			stem := strings.TrimSuffix(path.Base(rel), path.Ext(rel))
+			if claimed[i][rel] || inventoryIgnoredStems[normalizeTerm(stem)] {
+				continue
+			}
+			if data, err := os.ReadFile(filepath.Join(side.root, rel)); err != nil || isGenerated(data) {
+				continue
+			}
+			add(item(strings.ReplaceAll(stem, "_", "-"), side.category(rel)), i, rel, "layout")
+		}
+	}
+	for _, it := range items {
+		if it.category == "" {
+			it.category = "other"
+		}
+	}
+	return items
+}
+
+// exportCount counts the exported symbols across files.
+func exportCount(side inventorySide, files []string) int {
+	n := 0
+	for _, rel := range files {
+		if data, err := os.ReadFile(filepath.Join(side.root, rel)); err == nil {
+			n += len(side.exports(data))
+		}
+	}
+	return n
+}
+
+// status is both, js-only, go-only or none.
+func (it *inventoryItem) status() string {
+	switch {
+	case len(it.jsFiles) > 0 && len(it.goFiles) > 0:
+		return "both"
+	case len(it.jsFiles) > 0:
+		return "js-only"
+	case len(it.goFiles) > 0:
+		return "go-only"
+	}
+	return "none"
+}
+
+// renderInventory writes the feature list as JSON, CSV (one row per
+// feature) or an HTML page with a table per category.
+func renderInventory(format string, byCategory map[string][]*inventoryItem, sides [2]inventorySide) (string, error) {
+	type feature struct {
+		Name      string   `json:"name"`
+		Category  string   `json:"category"`
+		Status    string   `json:"status"`
+		JSFiles   []string `json:"js_files"`
+		GoFiles   []string `json:"go_files"`
+		JSExports int      `json:"js_exports"`
+		GoExports int      `json:"go_exports"`
+		Sources   []string `json:"sources"`
+	}
+	var list []feature
+	for _, category := range sortedKeys(byCategory) {
+		for _, it := range byCategory[category] {
+			list = append(list, feature{
+				Name: it.name, Category: it.category, Status: it.status(),
+				JSFiles: it.jsFiles, GoFiles: it.goFiles,
+				JSExports: exportCount(sides[0], it.jsFiles), GoExports: exportCount(sides[1], it.goFiles),
+				Sources: sortedKeys(it.sources),
+			})
+		}
+	}
+	if format == "json" {
+		return renderJSON(list)
+	}
+
+	columns := []string{"category", "feature", "status", "js_files", "go_files", "sources"}
+	row := func(f feature) []string {
+		return []string{f.Category, f.Name, f.Status, strings.Join(f.JSFiles, " "), strings.Join(f.GoFiles, " "), strings.Join(f.Sources, " ")}
+	}
+	if format == "csv" {
+		t := reportTable{Columns: columns}
+		for _, f := range list {
+			t.Rows = append(t.Rows, row(f))
+		}
+		return renderCSV(t)
+	}
+	var tables []reportTable
+	for _, f := range list {
+		if len(tables) == 0 || tables[len(tables)-1].Title != f.Category {
+			tables = append(tables, reportTable{Title: f.Category, Columns: columns[1:]})
+		}
+		tables[len(tables)-1].Rows = append(tables[len(tables)-1].Rows, row(f)[1:])
+	}
+	intro := []string{"Built from the feature map, qb:feature annotation comments and each SDK's directory layout."}
+	return renderHTML("QuickBase SDK Features", intro, tables)
+}
+
+func (s *QuickBasePersonalMCPServer) handleListFeatures(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	var params struct {
+		Category string `json:"category"`
+		Format   string `json:"format"`
+	}
+	argsData, _ := json.Marshal(request.Params.Arguments)
+	if err := json.Unmarshal(argsData, &params); err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Invalid parameters: %v", err)), nil
+	}
+	format, err := validReportFormat(params.Format)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	if params.Category == "" {
+		params.Category = "all"
+	}
+
+	features, err := loadFeatureMap()
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to load feature map: %v", err)), nil
+	}
+	sides := inventorySides()
+	items := buildInventory(features, sides)
+
+	byCategory := map[string][]*inventoryItem{}
+	for _, key := range sortedKeys(items) {
+		it := items[key]
+		if params.Category == "all" || strings.EqualFold(it.category, params.Category) {
+			byCategory[it.category] = append(byCategory[it.category], it)
+		}
+	}
+	if len(byCategory) == 0 {
+		categories := map[string]bool{}
+		for _, it := range items {
+			categories[it.category] = true
+		}
+		return mcp.NewToolResultError(fmt.Sprintf("No features in category %q (available: %s)", params.Category, strings.Join(sortedKeys(categories), ", "))), nil
+	}
+
+	if format != "markdown" {
+		out, err := renderInventory(format, byCategory, sides)
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("Failed to render %s: %v", format, err)), nil
+		}
+		return mcp.NewToolResultText(out), nil
+	}
+
+	var results strings.Builder
+	results.WriteString("# QuickBase SDK Features\n\n")
+	results.WriteString("Built from the feature map, `qb:feature` annotation comments and each SDK's directory layout.\n\n")
+	both, jsOnly, goOnly := 0, 0, 0
+	for _, category := range sortedKeys(byCategory) {
+		results.WriteString(fmt.Sprintf("## %s\n\n", category))
+		results.WriteString("| Feature | Status | JS | Go | Source |\n|---|---|---|---|---|\n")
+		for _, it := range byCategory[category] {
+			status := it.status()
+			switch status {
+			case "js-only":
+				status = "⚠️ JS only"
+				jsOnly++
+			case "go-only":
+				status = "⚠️ Go only"
+				goOnly++
+			case "none":
+				status = "❌ no files"
+			default:
+				status = "✅ both"
+				both++
+			}
+			cell := func(side inventorySide, files []string) string {
+				if len(files) == 0 {
+					return "—"
+				}
+				return fmt.Sprintf("%s (%d exported)", strings.Join(files, ", "), exportCount(side, files))
+			}
+			results.WriteString(fmt.Sprintf("| %s | %s | %s | %s | %s |\n", it.name, status, cell(sides[0], it.jsFiles), cell(sides[1], it.goFiles), strings.Join(sortedKeys(it.sources), ", ")))
+		}
+		results.WriteString("\n")
+	}
+	results.WriteString(fmt.Sprintf("**Summary:** %d in both SDKs, %d JS only, %d Go only\n", both, jsOnly, goOnly))
+
+	return mcp.NewToolResultText(results.String()), nil
+}
@@ -0,0 +1,367 @@
+package main
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+const (
+	investigationBucket      = "investigations"
+	investigationStateBucket = "investigation_state"
+	activeInvestigationKey   = "active"
+	investigationEventLimit  = 1000
+)
+
+// traceEvent is one step of an investigation: a tool call made while it
+// was active, a file looked at, or a note or conclusion.
+type traceEvent struct {
+	Time    time.Time `json:"time"`
+	Kind    string    `json:"kind"` // "tool", "file", "note" or "conclusion"
+	Tool    string    `json:"tool,omitempty"`
+	Args    string    `json:"args,omitempty"`
+	Files   []string  `json:"files,omitempty"`
+	Summary string    `json:"summary,omitempty"`
+	Failed  bool      `json:"failed,omitempty"`
+}
+
+// investigation is a recorded debugging session.
+type investigation struct {
+	ID         uint64       `json:"id"`
+	Title      string       `json:"title"`
+	TimeBox    int          `json:"time_box_minutes,omitempty"`
+	StartedAt  time.Time    `json:"started_at"`
+	EndedAt    time.Time    `json:"ended_at,omitempty"`
+	Conclusion string       `json:"conclusion,omitempty"`
+	Events     []traceEvent `json:"events"`
+}
+
+// overTimeBox returns how far past its time box the investigation is.
+func (inv investigation) overTimeBox(now time.Time) time.Duration {
+	if inv.TimeBox <= 0 {
+		return 0
+	}
+	return now.Sub(inv.StartedAt.Add(time.Duration(inv.TimeBox) * time.Minute))
+}
+
+// activeInvestigation returns the running investigation, if any.
+func (s *QuickBasePersonalMCPServer) activeInvestigation() (*investigation, error) {
+	var id uint64
+	found, err := s.store.get(investigationStateBucket, activeInvestigationKey, &id)
+	if err != nil || !found || id == 0 {
+		return nil, err
+	}
+	var inv investigation
+	found, err = s.store.get(investigationBucket, sequenceKey(id), &inv)
+	if err != nil || !found {
+		return nil, err
+	}
+	return &inv, nil
+}
+
+// addTraceEvent appends an event to the active investigation, if any.
+func (s *QuickBasePersonalMCPServer) addTraceEvent(event traceEvent) (*investigation, error) {
+	inv, err := s.activeInvestigation()
+	if err != nil || inv == nil {
+		return nil, err
+	}
+	if len(inv.Events) >= investigationEventLimit {
+		return inv, nil
+	}
+	inv.Events = append(inv.Events, event)
+	return inv, s.store.put(investigationBucket, sequenceKey(inv.ID), inv)
+}
+
+// argFiles picks the file and directory arguments out of a tool call.
+func argFiles(args map[string]interface{}) []string {
+	var files []string
+	for _, key := range sortedKeys(args) {
+		if !strings.HasSuffix(key, "path") && !strings.HasSuffix(key, "dir") && !strings.HasSuffix(key, "file") {
+			continue
+		}
+		if v, ok := args[key].(string); ok && v != "" {
+			files = append(files, v)
+		}
+	}
+	return files
+}
+
+// isLiveTool reports whether a tool calls a real realm.
+func isLiveTool(name string) bool {
+	return strings.HasPrefix(name, "qb_")
+}
+
+// traceArgs is a call's arguments as the trace keeps them. A live tool's
+// can hold record data, where clauses and local paths, so as in the live
+// audit log only their names and a hash are kept.
+func traceArgs(tool string, args map[string]any) string {
+	data, err := json.Marshal(args)
+	if err != nil {
+		return ""
+	}
+	if !isLiveTool(tool) {
+		return string(data)
+	}
+	sum := fmt.Sprintf("%x", sha256.Sum256(data))
+	return fmt.Sprintf("keys: %s; sha256 %s", strings.Join(sortedKeys(args), ", "), sum[:12])
+}
+
+// traceMiddleware records every tool call made while an investigation is
+// running, and warns once the investigation is past its time box.
+func (s *QuickBasePersonalMCPServer) traceMiddleware(next server.ToolHandlerFunc) server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		result, err := next(ctx, request)
+		if request.Params.Name == "trace_investigation" {
+			return result, err
+		}
+
+		event := traceEvent{Time: time.Now(), Kind: "tool", Tool: request.Params.Name}
+		args := request.GetArguments()
+		event.Args = traceArgs(request.Params.Name, args)
+		if !isLiveTool(request.Params.Name) {
+			event.Files = argFiles(args)
+		}
+		if err != nil {
+			event.Failed, event.Summary = true, err.Error()
+		} else if result != nil {
+			event.Failed = result.IsError
+			for _, content := range result.Content {
+				if text, ok := content.(mcp.TextContent); ok {
+					event.Summary = fmt.Sprintf("%s (%d bytes)", firstLine(text.Text), len(text.Text))
+					break
+				}
+			}
+		}
+
+		inv, traceErr := s.addTraceEvent(event)
+		if traceErr != nil {
+			s.logger.Printf("Failed to record trace event: %v", traceErr)
+		}
+		if inv != nil && result != nil {
+			if over := inv.overTimeBox(time.Now()); over > 0 {
+				result.Content = append(result.Content, mcp.NewTextContent(fmt.Sprintf(
+					"\n⏱️ Investigation #%d %q is %s past its %d minute time box. Note a conclusion and stop, or start a fresh investigation.",
+					inv.ID, inv.Title, over.Round(time.Minute), inv.TimeBox)))
+			}
+		}
+		return result, err
+	}
+}
+
+// firstLine returns the first non-empty line of s, trimmed of markdown
+// heading markers.
+func firstLine(s string) string {
+	for _, line := range strings.Split(s, "\n") {
+		if line = strings.TrimSpace(strings.TrimLeft(line, "# ")); line != "" {
+			if runes := []rune(line); len(runes) > 120 {
+				line = string(runes[:117]) + "..."
+			}
+			return line
+		}
+	}
+	return ""
+}
+
+// writeTimeline renders an investigation as a markdown timeline. Tool
+// calls include their arguments so the session can be replayed.
+func writeTimeline(results *strings.Builder, inv investigation) {
+	results.WriteString(fmt.Sprintf("# Investigation #%d: %s\n\n", inv.ID, inv.Title))
+	status := "in progress"
+	if !inv.EndedAt.IsZero() {
+		status = fmt.Sprintf("closed %s after %s", inv.EndedAt.Local().Format("2006-01-02 15:04"), inv.EndedAt.Sub(inv.StartedAt).Round(time.Minute))
+	}
+	results.WriteString(fmt.Sprintf("Started %s, %s", inv.StartedAt.Local().Format("2006-01-02 15:04"), status))
+	if inv.TimeBox > 0 {
+		results.WriteString(fmt.Sprintf(", time box %d minutes", inv.TimeBox))
+	}
+	results.WriteString("\n\n")
+	if inv.Conclusion != "" {
+		results.WriteString(fmt.Sprintf("**Conclusion:** %s\n\n", inv.Conclusion))
+	}
+
+	results.WriteString("## Timeline\n\n")
+	if len(inv.Events) == 0 {
+		results.WriteString("No events recorded\n")
+	}
+	files := map[string]bool{}
+	for i, e := range inv.Events {
+		offset := e.Time.Sub(inv.StartedAt).Round(time.Second)
+		switch e.Kind {
+		case "tool":
+			mark := ""
+			if e.Failed {
+				mark = " ❌"
+			}
+			results.WriteString(fmt.Sprintf("%d. `+%s` **%s**%s `%s`\n", i+1, offset, e.Tool, mark, e.Args))
+			if e.Summary != "" {
+				results.WriteString(fmt.Sprintf("   → %s\n", e.Summary))
+			}
+		case "file":
+			results.WriteString(fmt.Sprintf("%d. `+%s` 📄 viewed %s\n", i+1, offset, strings.Join(e.Files, ", ")))
+		case "conclusion":
+			results.WriteString(fmt.Sprintf("%d. `+%s` ✅ **Concluded:** %s\n", i+1, offset, e.Summary))
+		default:
+			results.WriteString(fmt.Sprintf("%d. `+%s` 📝 %s\n", i+1, offset, e.Summary))
+		}
+		for _, f := range e.Files {
+			files[f] = true
+		}
+	}
+	if len(files) > 0 {
+		results.WriteString("\n## Files\n\n")
+		for _, f := range sortedKeys(files) {
+			results.WriteString(fmt.Sprintf("- %s\n", f))
+		}
+	}
+	results.WriteString("\n")
+}
+
+func (s *QuickBasePersonalMCPServer) handleTraceInvestigation(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	var params struct {
+		Action  string   `json:"action"`
+		Title   string   `json:"title"`
+		TimeBox int      `json:"time_box_minutes"`
+		Text    string   `json:"text"`
+		Files   []string `json:"files"`
+		ID      uint64   `json:"id"`
+		Query   string   `json:"query"`
+	}
+	argsData, _ := json.Marshal(request.Params.Arguments)
+	if err := json.Unmarshal(argsData, &params); err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Invalid parameters: %v", err)), nil
+	}
+
+	active, err := s.activeInvestigation()
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to read investigation state: %v", err)), nil
+	}
+
+	var results strings.Builder
+	switch params.Action {
+	case "start":
+		if params.Title == "" {
+			return mcp.NewToolResultError("title is required to start an investigation"), nil
+		}
+		if active != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("Investigation #%d %q is still running; stop it first", active.ID, active.Title)), nil
+		}
+		id, err := s.store.insert(investigationBucket, func(id uint64) interface{} {
+			return investigation{ID: id, Title: params.Title, TimeBox: params.TimeBox, StartedAt: time.Now(), Events: []traceEvent{}}
+		})
+		if err == nil {
+			err = s.store.put(investigationStateBucket, activeInvestigationKey, id)
+		}
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("Failed to start investigation: %v", err)), nil
+		}
+		results.WriteString(fmt.Sprintf("Started investigation #%d: %s\n\nEvery tool call is now recorded. Use action 'note' for findings and files viewed elsewhere, and 'stop' with a conclusion when done.\n", id, params.Title))
+
+	case "note", "conclusion":
+		if active == nil {
+			return mcp.NewToolResultError("No investigation is running; start one first"), nil
+		}
+		if params.Text == "" && len(params.Files) == 0 {
+			return mcp.NewToolResultError("text or files is required"), nil
+		}
+		event := traceEvent{Time: time.Now(), Kind: params.Action, Summary: params.Text, Files: params.Files}
+		if params.Text == "" {
+			event.Kind = "file"
+		}
+		if _, err := s.addTraceEvent(event); err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("Failed to record note: %v", err)), nil
+		}
+		results.WriteString(fmt.Sprintf("Recorded %s in investigation #%d\n", event.Kind, active.ID))
+
+	case "stop":
+		if active == nil {
+			return mcp.NewToolResultError("No investigation is running"), nil
+		}
+		active.EndedAt = time.Now()
+		if params.Text != "" {
+			active.Conclusion = params.Text
+			active.Events = append(active.Events, traceEvent{Time: active.EndedAt, Kind: "conclusion", Summary: params.Text})
+		}
+		if err := s.store.put(investigationBucket, sequenceKey(active.ID), active); err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("Failed to stop investigation: %v", err)), nil
+		}
+		if _, err := s.store.delete(investigationStateBucket, activeInvestigationKey); err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("Failed to stop investigation: %v", err)), nil
+		}
+		writeTimeline(&results, *active)
+
+	case "show":
+		inv := active
+		if params.ID != 0 {
+			var found bool
+			inv = &investigation{}
+			found, err = s.store.get(investigationBucket, sequenceKey(params.ID), inv)
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("Failed to read investigation: %v", err)), nil
+			}
+			if !found {
+				return mcp.NewToolResultError(fmt.Sprintf("Unknown investigation: #%d", params.ID)), nil
+			}
+		}
+		if inv == nil {
+			return mcp.NewToolResultError("No investigation is running; pass id to show a past one"), nil
+		}
+		writeTimeline(&results, *inv)
+
+	case "", "list":
+		var all []investigation
+		s.store.each(investigationBucket, func(key string, data []byte) error {
+			var inv investigation
+			if json.Unmarshal(data, &inv) == nil && investigationMatches(inv, params.Query) {
+				all = append(all, inv)
+			}
+			return nil
+		})
+		sort.Slice(all, func(i, j int) bool { return all[i].ID > all[j].ID })
+		results.WriteString("# Investigations\n\n")
+		if len(all) == 0 {
+			results.WriteString("No matching investigations\n")
+		}
+		for _, inv := range all {
+			state := "closed"
+			if active != nil && inv.ID == active.ID {
+				state = "running"
+			}
+			results.WriteString(fmt.Sprintf("- #%d %s (%s, %s, %d events)", inv.ID, inv.Title, inv.StartedAt.Local().Format("2006-01-02"), state, len(inv.Events)))
+			if inv.Conclusion != "" {
+				results.WriteString(": " + inv.Conclusion)
+			}
+			results.WriteString("\n")
+		}
+
+	default:
+		return mcp.NewToolResultError(fmt.Sprintf("Unknown action: %s (use start, note, conclusion, stop, show or list)", params.Action)), nil
+	}
+
+	return mcp.NewToolResultText(results.String()), nil
+}
+
+// investigationMatches reports whether every word of query appears in the
+// investigation's title, conclusion, notes or files.
+func investigationMatches(inv investigation, query string) bool {
+	parts := []string{inv.Title, inv.Conclusion}
+	for _, e := range inv.Events {
+		if e.Kind != "tool" {
+			parts = append(parts, e.Summary)
+		}
+		parts = append(parts, e.Files...)
+	}
+	haystack := strings.ToLower(strings.Join(parts, " "))
+	for _, word := range strings.Fields(strings.ToLower(query)) {
+		if !strings.Contains(haystack, word) {
+			return false
+		}
+	}
+	return true
+}
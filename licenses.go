@@ -0,0 +1,412 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// defaultLicenseAllowlist is what a published package can depend on
+// without asking: permissive licenses that only require attribution.
+var defaultLicenseAllowlist = []string{"MIT", "Apache-2.0", "BSD-2-Clause", "BSD-3-Clause", "ISC", "0BSD", "Unlicense", "CC0-1.0", "BlueOak-1.0.0"}
+
+// licenseTexts recognise a license file's text, checked in order so the
+// more specific GPL variants win over the general one.
+var licenseTexts = []struct {
+	spdx    string
+	pattern *regexp.Regexp
+}{
+	{"AGPL-3.0", regexp.MustCompile(`(?i)GNU AFFERO GENERAL PUBLIC LICENSE`)},
+	{"LGPL", regexp.MustCompile(`(?i)GNU LESSER GENERAL PUBLIC LICENSE|GNU LIBRARY GENERAL PUBLIC LICENSE`)},
+	{"GPL", regexp.MustCompile(`(?i)GNU GENERAL PUBLIC LICENSE`)},
+	{"MPL-2.0", regexp.MustCompile(`(?i)Mozilla Public License,? (Version )?2\.0`)},
+	{"Apache-2.0", regexp.MustCompile(`(?i)Apache License,?\s+Version 2\.0`)},
+	{"BSD-3-Clause", regexp.MustCompile(`(?is)Redistribution and use in source and binary forms.*Neither the name`)},
+	{"BSD-2-Clause", regexp.MustCompile(`(?i)Redistribution and use in source and binary forms`)},
+	{"ISC", regexp.MustCompile(`(?i)ISC License|Permission to use, copy, modify, and/or distribute this software for any`)},
+	{"MIT", regexp.MustCompile(`(?i)MIT License|Permission is hereby granted, free of charge`)},
+	{"Unlicense", regexp.MustCompile(`(?i)This is free and unencumbered software released into the public domain`)},
+	{"CC0-1.0", regexp.MustCompile(`(?i)CC0 1\.0 Universal`)},
+}
+
+// depLicense is one dependency's license, as declared or read from its
+// license file, and whether it ships a NOTICE file.
+type depLicense struct {
+	name, version, license, source string
+	notice                         bool
+}
+
+// isLegalFile reports whether name is one of the given legal files, bare
+// or as .md or .txt, or a variant like LICENSE-MIT.
+func isLegalFile(name string, bases ...string) bool {
+	upper := strings.ToUpper(name)
+	switch filepath.Ext(upper) {
+	case ".MD", ".TXT":
+		upper = strings.TrimSuffix(upper, filepath.Ext(upper))
+	case "":
+	default:
+		return false
+	}
+	for _, base := range bases {
+		if upper == base || strings.HasPrefix(upper, base+"-") || strings.HasPrefix(upper, base+"_") {
+			return true
+		}
+	}
+	return false
+}
+
+// licenseFile finds a LICENSE, LICENCE or COPYING file in dir.
+func licenseFile(dir string) string {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return ""
+	}
+	for _, e := range entries {
+		if !e.IsDir() && isLegalFile(e.Name(), "LICENSE", "LICENCE", "COPYING") {
+			return filepath.Join(dir, e.Name())
+		}
+	}
+	return ""
+}
+
+// hasNotice reports whether dir has a NOTICE file, which Apache-2.0 asks
+// redistributors to pass on.
+func hasNotice(dir string) bool {
+	entries, _ := os.ReadDir(dir)
+	for _, e := range entries {
+		if !e.IsDir() && isLegalFile(e.Name(), "NOTICE") {
+			return true
+		}
+	}
+	return false
+}
+
+// detectLicense classifies the license file in dir, or "" when there is
+// none or it isn't recognised.
+func detectLicense(dir string) string {
+	file := licenseFile(dir)
+	if file == "" {
+		return ""
+	}
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return ""
+	}
+	for _, l := range licenseTexts {
+		if l.pattern.Match(data) {
+			return l.spdx
+		}
+	}
+	return ""
+}
+
+// licenseAllowed checks an SPDX expression against the allowlist: any
+// alternative of an OR will do, every part of an AND must be allowed.
+func licenseAllowed(expr string, allow map[string]bool) bool {
+	expr = strings.TrimSpace(strings.NewReplacer("(", "", ")", "").Replace(expr))
+	if expr == "" {
+		return false
+	}
+	for _, alternative := range strings.Split(expr, " OR ") {
+		ok := true
+		for _, part := range strings.Split(alternative, " AND ") {
+			if !allow[strings.ToLower(strings.TrimSpace(part))] {
+				ok = false
+			}
+		}
+		if ok {
+			return true
+		}
+	}
+	return false
+}
+
+// goLicenses lists the modules the Go SDK's packages build with (and their
+// tests', with tests), classified from the license file in the module
+// cache.
+func goLicenses(ctx context.Context, repoPath string, tests bool, timeout time.Duration) ([]depLicense, error) {
+	format := `{{with .Module}}{{if not .Main}}{{.Path}}{{"\t"}}{{.Version}}{{"\t"}}{{if .Replace}}{{.Replace.Dir}}{{else}}{{.Dir}}{{end}}{{end}}{{end}}`
+	command := "go list -deps -f " + shellQuote(format)
+	if tests {
+		command += " -test"
+	}
+	result, err := runShell(ctx, repoPath, timeout, command+" ./...")
+	if err != nil {
+		return nil, err
+	}
+	if result.timedOut || result.exitCode != 0 {
+		return nil, fmt.Errorf("go list -deps failed: %s", tailLines(result.output, 5))
+	}
+	seen := map[string]bool{}
+	var deps []depLicense
+	for _, line := range strings.Split(result.output, "\n") {
+		fields := strings.Split(line, "\t")
+		if len(fields) != 3 || seen[fields[0]] {
+			continue
+		}
+		seen[fields[0]] = true
+		d := depLicense{name: fields[0], version: fields[1], source: "license file"}
+		if fields[2] == "" {
+			d.source = "not downloaded"
+		} else {
+			d.license = detectLicense(fields[2])
+			d.notice = hasNotice(fields[2])
+		}
+		deps = append(deps, d)
+	}
+	sort.Slice(deps, func(i, j int) bool { return deps[i].name < deps[j].name })
+	return deps, nil
+}
+
+// npmPackage is the part of an installed package.json the audit reads.
+type npmPackage struct {
+	Name                 string                  `json:"name"`
+	Version              string                  `json:"version"`
+	License              json.RawMessage         `json:"license"`
+	Licenses             []struct{ Type string } `json:"licenses"`
+	Dependencies         map[string]string       `json:"dependencies"`
+	OptionalDependencies map[string]string       `json:"optionalDependencies"`
+	DevDependencies      map[string]string       `json:"devDependencies"`
+}
+
+// declaredLicense is package.json's license: a string, the older
+// {type} object, or a licenses array read as OR.
+func (p npmPackage) declaredLicense() string {
+	var s string
+	if json.Unmarshal(p.License, &s) == nil && s != "" {
+		return s
+	}
+	var obj struct{ Type string }
+	if json.Unmarshal(p.License, &obj) == nil && obj.Type != "" {
+		return obj.Type
+	}
+	var types []string
+	for _, l := range p.Licenses {
+		types = append(types, l.Type)
+	}
+	return strings.Join(types, " OR ")
+}
+
+// npmLicenses walks quickbase-js's installed dependency tree from
+// package.json the way Node resolves it: a package's own node_modules
+// first, then each directory above it up to the repo.
+func npmLicenses(repoPath string, dev bool) ([]depLicense, []string, error) {
+	data, err := os.ReadFile(filepath.Join(repoPath, "package.json"))
+	if err != nil {
+		return nil, nil, err
+	}
+	var root npmPackage
+	if err := json.Unmarshal(data, &root); err != nil {
+		return nil, nil, fmt.Errorf("package.json: %w", err)
+	}
+	if !fileExists(filepath.Join(repoPath, "node_modules")) {
+		return nil, nil, fmt.Errorf("no node_modules; run npm install first")
+	}
+
+	var deps []depLicense
+	var missing []string
+	visited := map[string]bool{}
+	var visit func(from, name string, optional bool)
+	visit = func(from, name string, optional bool) {
+		dir := ""
+		for d := from; strings.HasPrefix(d, repoPath); d = filepath.Dir(d) {
+			if candidate := filepath.Join(d, "node_modules", name); fileExists(filepath.Join(candidate, "package.json")) {
+				dir = candidate
+				break
+			}
+			if d == repoPath {
+				break
+			}
+		}
+		if dir == "" {
+			// Optional dependencies are often platform builds left out
+			if !optional {
+				missing = append(missing, name)
+			}
+			return
+		}
+		if visited[dir] {
+			return
+		}
+		visited[dir] = true
+		var pkg npmPackage
+		if data, err := os.ReadFile(filepath.Join(dir, "package.json")); err == nil {
+			json.Unmarshal(data, &pkg)
+		}
+		d := depLicense{name: name, version: pkg.Version, license: pkg.declaredLicense(), source: "package.json", notice: hasNotice(dir)}
+		if d.license == "" || strings.HasPrefix(strings.ToUpper(d.license), "SEE LICENSE") {
+			d.license, d.source = detectLicense(dir), "license file"
+		}
+		deps = append(deps, d)
+		for _, next := range sortedKeys(pkg.Dependencies) {
+			visit(dir, next, false)
+		}
+		for _, next := range sortedKeys(pkg.OptionalDependencies) {
+			visit(dir, next, true)
+		}
+	}
+	names := sortedKeys(root.Dependencies)
+	if dev {
+		names = append(names, sortedKeys(root.DevDependencies)...)
+	}
+	for _, name := range names {
+		visit(repoPath, name, false)
+	}
+	for _, name := range sortedKeys(root.OptionalDependencies) {
+		visit(repoPath, name, true)
+	}
+	sort.Slice(deps, func(i, j int) bool {
+		if deps[i].name != deps[j].name {
+			return deps[i].name < deps[j].name
+		}
+		return deps[i].version < deps[j].version
+	})
+	return deps, missing, nil
+}
+
+func (s *QuickBasePersonalMCPServer) handleAuditLicenses(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	var params struct {
+		Repo           string   `json:"repo"`
+		IncludeDev     bool     `json:"include_dev"`
+		Allow          []string `json:"allow"`
+		TimeoutSeconds int      `json:"timeout_seconds"`
+	}
+	argsData, _ := json.Marshal(request.Params.Arguments)
+	if err := json.Unmarshal(argsData, &params); err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Invalid parameters: %v", err)), nil
+	}
+	timeout := 2 * time.Minute
+	if params.TimeoutSeconds > 0 {
+		timeout = time.Duration(params.TimeoutSeconds) * time.Second
+	}
+	if params.Repo == "" {
+		params.Repo = "all"
+	}
+	repos, err := gitRepos(params.Repo)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	if params.Repo == "all" {
+		repos = repos[:2]
+	}
+	cfg, err := loadConfig()
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to load config: %v", err)), nil
+	}
+	allowlist := defaultLicenseAllowlist
+	if len(cfg.LicenseAllowlist) > 0 {
+		allowlist = cfg.LicenseAllowlist
+	}
+	allowlist = append(append([]string{}, allowlist...), params.Allow...)
+	allow := map[string]bool{}
+	for _, l := range allowlist {
+		allow[strings.ToLower(l)] = true
+	}
+
+	var results strings.Builder
+	results.WriteString("# License audit\n\n")
+	results.WriteString(fmt.Sprintf("Allowed: %s\n\n", strings.Join(allowlist, ", ")))
+	flaggedTotal := 0
+	for _, repo := range repos {
+		results.WriteString(fmt.Sprintf("## %s\n\n", repo.name))
+		var deps []depLicense
+		var missing []string
+		var own string
+		switch {
+		case fileExists(filepath.Join(repo.path, "go.mod")):
+			deps, err = goLicenses(ctx, repo.path, params.IncludeDev, timeout)
+			own = detectLicense(repo.path)
+		case fileExists(filepath.Join(repo.path, "package.json")):
+			deps, missing, err = npmLicenses(repo.path, params.IncludeDev)
+			var pkg npmPackage
+			if data, readErr := os.ReadFile(filepath.Join(repo.path, "package.json")); readErr == nil {
+				json.Unmarshal(data, &pkg)
+			}
+			own = pkg.declaredLicense()
+		default:
+			results.WriteString("No go.mod or package.json\n\n")
+			continue
+		}
+		if err != nil {
+			results.WriteString(fmt.Sprintf("⚠️ %v\n\n", err))
+			continue
+		}
+
+		// The package's own license, which consumers see first
+		switch {
+		case licenseFile(repo.path) == "":
+			results.WriteString("⚠️ No LICENSE file at the repo root\n\n")
+		case own == "":
+			results.WriteString("Own license: unrecognised LICENSE file\n\n")
+		default:
+			results.WriteString(fmt.Sprintf("Own license: %s\n\n", own))
+		}
+
+		counts := map[string]int{}
+		var flagged, notices []depLicense
+		for _, d := range deps {
+			label := d.license
+			if label == "" {
+				label = "unknown"
+			}
+			counts[label]++
+			if !licenseAllowed(d.license, allow) {
+				flagged = append(flagged, d)
+			}
+			if d.notice {
+				notices = append(notices, d)
+			}
+		}
+		scope := "runtime"
+		if params.IncludeDev {
+			scope = "runtime and dev/test"
+		}
+		results.WriteString(fmt.Sprintf("%d %s dependencies\n\n", len(deps), scope))
+		if len(deps) > 0 {
+			results.WriteString("| License | Dependencies |\n|---|---|\n")
+			labels := sortedKeys(counts)
+			sort.SliceStable(labels, func(i, j int) bool { return counts[labels[i]] > counts[labels[j]] })
+			for _, label := range labels {
+				results.WriteString(fmt.Sprintf("| %s | %d |\n", label, counts[label]))
+			}
+			results.WriteString("\n")
+		}
+
+		flaggedTotal += len(flagged)
+		if len(flagged) == 0 {
+			results.WriteString("✅ Every dependency is on the allowlist.\n\n")
+		} else {
+			results.WriteString(fmt.Sprintf("### ❌ Outside the allowlist (%d)\n\n| Dependency | Version | License | From |\n|---|---|---|---|\n", len(flagged)))
+			for _, d := range flagged {
+				license := d.license
+				if license == "" {
+					license = "unknown"
+				}
+				results.WriteString(fmt.Sprintf("| %s | %s | %s | %s |\n", d.name, dash(d.version), license, d.source))
+			}
+			results.WriteString("\n")
+		}
+		if len(notices) > 0 {
+			var names []string
+			for _, d := range notices {
+				names = append(names, d.name)
+			}
+			results.WriteString(fmt.Sprintf("NOTICE files to carry if bundled: %s\n\n", strings.Join(names, ", ")))
+		}
+		if len(missing) > 0 {
+			results.WriteString(fmt.Sprintf("⚠️ Not installed: %s\n\n", strings.Join(missing, ", ")))
+		}
+	}
+	if flaggedTotal > 0 {
+		results.WriteString("Unknown means no license was declared and the license file wasn't recognised; check those by hand. Allow more with license_allowlist in config.yaml, or allow for one call.\n")
+	}
+	return mcp.NewToolResultText(strings.TrimRight(results.String(), "\n") + "\n"), nil
+}
@@ -0,0 +1,187 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// Tags and x- extensions that mark an operation as not yet stable.
+var (
+	unstableTags       = []string{"beta", "preview", "experimental"}
+	unstableExtensions = []string{"x-beta", "x-preview", "x-experimental", "x-stability", "x-status"}
+)
+
+// Doc markers an SDK uses for the same states: JSDoc tags in JS and the
+// "Deprecated:" paragraph convention (or a Beta note) in Go.
+var (
+	deprecatedMarker = regexp.MustCompile(`(?i)@deprecated\b|^\s*(//\s*)?Deprecated:`)
+	unstableMarker   = regexp.MustCompile(`(?i)@(beta|alpha|experimental)\b|\b(beta|preview|experimental)\b`)
+)
+
+// stability is why an operation counts as unstable, or "".
+func stability(op specOperation) string {
+	for _, tag := range op.Tags {
+		if containsFold(unstableTags, tag) {
+			return "tag " + tag
+		}
+	}
+	for _, ext := range unstableExtensions {
+		v := scalarAt(op.Node, ext)
+		if v == "" || v == "false" {
+			continue
+		}
+		if v == "true" || containsFold(unstableTags, v) {
+			return fmt.Sprintf("%s: %s", ext, v)
+		}
+	}
+	return ""
+}
+
+// declarationDoc returns the doc comment above a function or method's
+// declaration: the run of comment (and decorator) lines directly before
+// the first line that declares name.
+func declarationDoc(repoPath, rel, name string, isGo bool) string {
+	data, err := os.ReadFile(filepath.Join(repoPath, rel))
+	if err != nil {
+		return ""
+	}
+	decl := regexp.MustCompile(`^\s*(export\s+)?((public|private|protected|static|async|readonly|function)\s+)*` + regexp.QuoteMeta(name) + `\s*[(<:=]`)
+	if isGo {
+		decl = regexp.MustCompile(`^func\s+(\([^)]*\)\s*)?` + regexp.QuoteMeta(name) + `\s*[(\[]`)
+	}
+	lines := strings.Split(string(data), "\n")
+	for i, line := range lines {
+		if !decl.MatchString(line) {
+			continue
+		}
+		start := i
+		for start > 0 {
+			prev := strings.TrimSpace(lines[start-1])
+			if !strings.HasPrefix(prev, "//") && !strings.HasPrefix(prev, "*") && !strings.HasPrefix(prev, "/*") && !strings.HasPrefix(prev, "@") {
+				break
+			}
+			start--
+		}
+		return strings.Join(lines[start:i], "\n")
+	}
+	return ""
+}
+
+// markedIn describes how an SDK exposes an operation for the lifecycle
+// table, and whether its docs carry the marker.
+func markedIn(m sdkMethod, repoPath string, isGo bool, marker *regexp.Regexp, word string) (string, bool) {
+	if !m.found {
+		return "— not exposed", false
+	}
+	doc := declarationDoc(repoPath, m.file, m.name, isGo)
+	for _, line := range strings.Split(doc, "\n") {
+		if marker.MatchString(line) {
+			return fmt.Sprintf("✅ `%s` (marked %s)", m.name, word), true
+		}
+	}
+	return fmt.Sprintf("⚠️ `%s` (not marked %s)", m.name, word), false
+}
+
+func (s *QuickBasePersonalMCPServer) handleEndpointLifecycle(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	var params struct {
+		Status string `json:"status"`
+	}
+	argsData, _ := json.Marshal(request.Params.Arguments)
+	if err := json.Unmarshal(argsData, &params); err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Invalid parameters: %v", err)), nil
+	}
+	if params.Status == "" {
+		params.Status = "all"
+	}
+	if !containsString([]string{"all", "deprecated", "beta"}, params.Status) {
+		return mcp.NewToolResultError(fmt.Sprintf("Unknown status: %s (use all, deprecated or beta)", params.Status)), nil
+	}
+
+	root, err := loadSpec()
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to load spec: %v", err)), nil
+	}
+	var deprecated, unstable []specOperation
+	for _, op := range specOperations(root) {
+		if op.Deprecated {
+			deprecated = append(deprecated, op)
+		} else if stability(op) != "" {
+			unstable = append(unstable, op)
+		}
+	}
+
+	jsIndex := sdkMethodIndex(quickbaseJSPath, isJSSource, false)
+	goIndex := sdkMethodIndex(quickbaseGoPath, isGoSource, true)
+	lookup := func(op specOperation) (sdkMethod, sdkMethod) {
+		if op.OperationID == "" {
+			return sdkMethod{}, sdkMethod{}
+		}
+		return lookupOperation(jsIndex, op.OperationID), lookupOperation(goIndex, op.OperationID)
+	}
+	label := func(op specOperation) string {
+		name := op.OperationID
+		if name == "" {
+			name = "(no operationId)"
+		}
+		return fmt.Sprintf("%s | `%s %s`", name, op.Method, op.Path)
+	}
+
+	var results strings.Builder
+	results.WriteString("# Endpoint Lifecycle\n\n")
+
+	if params.Status != "beta" {
+		results.WriteString(fmt.Sprintf("## Deprecated (%d)\n\n", len(deprecated)))
+		if len(deprecated) == 0 {
+			results.WriteString("Nothing in the spec is deprecated\n\n")
+		} else {
+			results.WriteString("| Operation | Endpoint | JS | Go | Next step |\n|---|---|---|---|---|\n")
+			for _, op := range deprecated {
+				js, g := lookup(op)
+				jsCell, jsMarked := markedIn(js, quickbaseJSPath, false, deprecatedMarker, "deprecated")
+				goCell, goMarked := markedIn(g, quickbaseGoPath, true, deprecatedMarker, "deprecated")
+				var steps []string
+				if js.found && !jsMarked {
+					steps = append(steps, "add @deprecated to "+js.name)
+				}
+				if g.found && !goMarked {
+					steps = append(steps, "add a Deprecated: note to "+g.name)
+				}
+				next := strings.Join(steps, "; ")
+				switch {
+				case !js.found && !g.found:
+					next = "gone from both SDKs"
+				case next == "":
+					next = "remove in the next major version"
+				}
+				results.WriteString(fmt.Sprintf("| %s | %s | %s | %s |\n", label(op), jsCell, goCell, next))
+			}
+			results.WriteString("\n")
+		}
+	}
+
+	if params.Status != "deprecated" {
+		results.WriteString(fmt.Sprintf("## Beta / preview (%d)\n\n", len(unstable)))
+		if len(unstable) == 0 {
+			results.WriteString(fmt.Sprintf("No operations are tagged %s or carry %s\n\n", strings.Join(unstableTags, "/"), strings.Join(unstableExtensions, "/")))
+		} else {
+			results.WriteString("| Operation | Endpoint | Marked by | JS | Go |\n|---|---|---|---|---|\n")
+			for _, op := range unstable {
+				js, g := lookup(op)
+				jsCell, _ := markedIn(js, quickbaseJSPath, false, unstableMarker, "beta")
+				goCell, _ := markedIn(g, quickbaseGoPath, true, unstableMarker, "beta")
+				results.WriteString(fmt.Sprintf("| %s | %s | %s | %s |\n", label(op), stability(op), jsCell, goCell))
+			}
+			results.WriteString("\n")
+		}
+	}
+
+	results.WriteString("An SDK method is marked when its doc comment says so: `@deprecated`/`@beta` in JS, a `Deprecated:` paragraph or a beta note in Go.\n")
+	return mcp.NewToolResultText(results.String()), nil
+}
@@ -0,0 +1,264 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// lintIssue is one linter finding.
+type lintIssue struct {
+	file          string
+	line, column  int
+	rule, message string
+	severity      string
+}
+
+// golangciVersion is the major version in "golangci-lint has version 2.1.6".
+var golangciVersion = regexp.MustCompile(`version v?(\d+)\.`)
+
+// lintCommand picks the linter run_lint runs in a repo: the configured
+// one, else golangci-lint or eslint with JSON output. The repo's own
+// .golangci.yml or eslint config applies either way.
+func lintCommand(ctx context.Context, cfg serverConfig, repo gitRepo) (string, error) {
+	for _, key := range []string{repo.name, strings.TrimPrefix(repo.name, "quickbase-")} {
+		if command := cfg.LintCommands[key]; command != "" {
+			return command, nil
+		}
+	}
+	if _, err := os.Stat(filepath.Join(repo.path, "go.mod")); err == nil {
+		// v2 replaced --out-format with one flag per output
+		format := "--out-format=json"
+		if result, err := runShell(ctx, repo.path, 10*time.Second, "golangci-lint --version"); err == nil {
+			if m := golangciVersion.FindStringSubmatch(result.output); m != nil && m[1] != "1" {
+				format = "--output.json.path=stdout"
+			}
+		}
+		return "golangci-lint run " + format + " ./...", nil
+	}
+	if _, err := os.Stat(filepath.Join(repo.path, "package.json")); err == nil {
+		return "npx --no-install eslint -f json .", nil
+	}
+	return "", fmt.Errorf("no go.mod or package.json in %s; set lint_commands.%s in config.yaml", repo.path, repo.name)
+}
+
+// parseGolangciJSON reads golangci-lint's JSON report.
+func parseGolangciJSON(output string) ([]lintIssue, bool) {
+	start := strings.Index(output, `{"Issues"`)
+	if start < 0 {
+		return nil, false
+	}
+	var report struct {
+		Issues []struct {
+			FromLinter string `json:"FromLinter"`
+			Text       string `json:"Text"`
+			Severity   string `json:"Severity"`
+			Pos        struct {
+				Filename string `json:"Filename"`
+				Line     int    `json:"Line"`
+				Column   int    `json:"Column"`
+			} `json:"Pos"`
+		} `json:"Issues"`
+	}
+	if err := json.NewDecoder(strings.NewReader(output[start:])).Decode(&report); err != nil {
+		return nil, false
+	}
+	issues := []lintIssue{}
+	for _, i := range report.Issues {
+		issues = append(issues, lintIssue{file: i.Pos.Filename, line: i.Pos.Line, column: i.Pos.Column, rule: i.FromLinter, message: i.Text, severity: i.Severity})
+	}
+	return issues, true
+}
+
+// parseESLintJSON reads eslint's json formatter output, with files made
+// relative to the repo.
+func parseESLintJSON(output, repoPath string) ([]lintIssue, bool) {
+	start := strings.Index(output, `[{"filePath"`)
+	if start < 0 {
+		start = strings.Index(output, "[]")
+	}
+	if start < 0 {
+		return nil, false
+	}
+	var files []struct {
+		FilePath string `json:"filePath"`
+		Messages []struct {
+			RuleID   string `json:"ruleId"`
+			Severity int    `json:"severity"`
+			Message  string `json:"message"`
+			Line     int    `json:"line"`
+			Column   int    `json:"column"`
+		} `json:"messages"`
+	}
+	if err := json.NewDecoder(strings.NewReader(output[start:])).Decode(&files); err != nil {
+		return nil, false
+	}
+	issues := []lintIssue{}
+	for _, f := range files {
+		rel := f.FilePath
+		if r, err := filepath.Rel(repoPath, f.FilePath); err == nil && !strings.HasPrefix(r, "..") {
+			rel = filepath.ToSlash(r)
+		}
+		for _, m := range f.Messages {
+			severity := "warning"
+			if m.Severity == 2 {
+				severity = "error"
+			}
+			rule := m.RuleID
+			if rule == "" {
+				// Parse errors have no rule
+				rule = "parse"
+			}
+			issues = append(issues, lintIssue{file: rel, line: m.Line, column: m.Column, rule: rule, message: m.Message, severity: severity})
+		}
+	}
+	return issues, true
+}
+
+// lintTextLine is the file:line:col: message shape most linters can print,
+// with an optional trailing (rule).
+var lintTextLine = regexp.MustCompile(`^(\S+?):(\d+)(?::(\d+))?:\s*(.+?)(?:\s+\(([\w@/-]+)\))?$`)
+
+// parseLintText reads issues from plain text output, for configured
+// commands that don't write JSON.
+func parseLintText(output string) []lintIssue {
+	var issues []lintIssue
+	for _, line := range strings.Split(output, "\n") {
+		m := lintTextLine.FindStringSubmatch(strings.TrimSpace(line))
+		if m == nil {
+			continue
+		}
+		issue := lintIssue{file: m[1], rule: m[5], message: m[4]}
+		issue.line, _ = strconv.Atoi(m[2])
+		issue.column, _ = strconv.Atoi(m[3])
+		issues = append(issues, issue)
+	}
+	return issues
+}
+
+func (s *QuickBasePersonalMCPServer) handleRunLint(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	var params struct {
+		Repo           string `json:"repo"`
+		Path           string `json:"path"`
+		Limit          int    `json:"limit"`
+		TimeoutSeconds int    `json:"timeout_seconds"`
+	}
+	argsData, _ := json.Marshal(request.Params.Arguments)
+	if err := json.Unmarshal(argsData, &params); err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Invalid parameters: %v", err)), nil
+	}
+	if params.Limit <= 0 {
+		params.Limit = 100
+	}
+	timeout := defaultTestTimeout
+	if params.TimeoutSeconds > 0 {
+		timeout = time.Duration(params.TimeoutSeconds) * time.Second
+	}
+	if params.Repo == "" {
+		params.Repo = "all"
+	}
+	repos, err := gitRepos(params.Repo)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	if params.Repo == "all" {
+		repos = repos[:2]
+	}
+	cfg, err := loadConfig()
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to load config: %v", err)), nil
+	}
+
+	var results strings.Builder
+	results.WriteString("# Lint results\n\n")
+	for _, repo := range repos {
+		results.WriteString(fmt.Sprintf("## %s\n\n", repo.name))
+		command, err := lintCommand(ctx, cfg, repo)
+		if err != nil {
+			results.WriteString(fmt.Sprintf("⚠️ %v\n\n", err))
+			continue
+		}
+		if params.Path != "" {
+			switch {
+			case strings.HasSuffix(command, " ./..."), strings.HasSuffix(command, " ."):
+				command = command[:strings.LastIndex(command, " ")] + " " + shellQuote(params.Path)
+			default:
+				command += " " + shellQuote(params.Path)
+			}
+		}
+		result, err := runShell(ctx, repo.path, timeout, command)
+		if err != nil {
+			results.WriteString(fmt.Sprintf("⚠️ %v\n\n", err))
+			continue
+		}
+		if result.timedOut {
+			results.WriteString(fmt.Sprintf("`%s`: ⏱️ timed out after %s\n\n", command, timeout))
+			continue
+		}
+		output := ansiEscape.ReplaceAllString(result.output, "")
+		issues, ok := parseGolangciJSON(output)
+		if !ok {
+			issues, ok = parseESLintJSON(output, repo.path)
+		}
+		if !ok {
+			issues = parseLintText(output)
+			// Linters exit non-zero for findings too, so only an exit
+			// with nothing parsed is a failed run
+			ok = len(issues) > 0 || result.exitCode == 0
+		}
+		if !ok {
+			results.WriteString(fmt.Sprintf("`%s`: ❌ failed (exit %d)\n\n```\n%s\n```\n\n", command, result.exitCode, tailLines(output, 30)))
+			continue
+		}
+		results.WriteString(fmt.Sprintf("`%s`: %d issue(s) in %s\n\n", command, len(issues), result.duration.Round(100*time.Millisecond)))
+		if len(issues) == 0 {
+			continue
+		}
+
+		sort.SliceStable(issues, func(i, j int) bool {
+			if issues[i].file != issues[j].file {
+				return issues[i].file < issues[j].file
+			}
+			return issues[i].line < issues[j].line
+		})
+		byRule := map[string]int{}
+		for _, issue := range issues {
+			byRule[issue.rule]++
+		}
+		rules := sortedKeys(byRule)
+		sort.SliceStable(rules, func(i, j int) bool { return byRule[rules[i]] > byRule[rules[j]] })
+		var counts []string
+		for _, rule := range rules {
+			name := rule
+			if name == "" {
+				name = "(no rule)"
+			}
+			counts = append(counts, fmt.Sprintf("%s %d", name, byRule[rule]))
+		}
+		results.WriteString(fmt.Sprintf("By rule: %s\n\n", strings.Join(counts, ", ")))
+
+		results.WriteString("| File | Line | Rule | Severity | Message |\n|---|---|---|---|---|\n")
+		for i, issue := range issues {
+			if i == params.Limit {
+				results.WriteString(fmt.Sprintf("\n… %d more; raise limit or narrow path to see them.\n", len(issues)-params.Limit))
+				break
+			}
+			line := strconv.Itoa(issue.line)
+			if issue.column > 0 {
+				line += fmt.Sprintf(":%d", issue.column)
+			}
+			results.WriteString(fmt.Sprintf("| `%s` | %s | %s | %s | %s |\n", issue.file, line, issue.rule, issue.severity, docCell(issue.message)))
+		}
+		results.WriteString("\n")
+	}
+	return mcp.NewToolResultText(strings.TrimRight(results.String(), "\n") + "\n"), nil
+}
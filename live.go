@@ -0,0 +1,360 @@
+package main
+
+import (
+	"context"
+	"crypto/sha256"
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// liveDriverSource is the program live calls run through. It is built
+// against the local quickbase-go checkout, so the live tools exercise the
+// SDK's working tree, not a published release.
+//
+//go:embed templates/live/driver.go.tmpl
+var liveDriverSource string
+
+// defaultLiveTimeout covers one live call; the first build of the driver
+// gets defaultExampleTimeout on top.
+const defaultLiveTimeout = 60 * time.Second
+
+// liveClient makes calls against a real realm through the live driver.
+type liveClient struct {
+	realm       string
+	token       string
+	tokenSource string
+	driver      string
+
+	// In sandbox mode, the sandbox app and the dbids calls may name. The
+	// app's tables are looked up the first time a table isn't in allowed.
+	sandboxApp   string
+	allowed      map[string]bool
+	tablesListed bool
+
+	// store caches read-only replies; audit records each call, or each
+	// call the sandbox or rate limit refused
+	store *stateStore
+	tool  string
+	audit func(req map[string]any, duration time.Duration, status string, err error)
+}
+
+// loadLiveConfig loads the config for a live tool. With sandbox: true the
+// sandbox realm and IDs replace the regular ones, so defaults point into
+// the sandbox too, and a sandbox without a realm or app is an error rather
+// than a fall back to production.
+func loadLiveConfig() (serverConfig, error) {
+	cfg, err := loadConfig()
+	if err != nil || !cfg.Sandbox {
+		return cfg, err
+	}
+	sandbox, ok := cfg.sandbox()
+	if !ok {
+		return cfg, fmt.Errorf("sandbox is on but %s has no sandbox_realm", cfg.source)
+	}
+	if sandbox.AppID == defaultConfig.AppID {
+		return cfg, fmt.Errorf("sandbox is on but %s has no sandbox_app_id, the app the live tools are confined to", cfg.source)
+	}
+	return sandbox, nil
+}
+
+// liveToken finds the user token: the environment variable named by
+// user_token_env, then the same name in the config directory's .env, then
+// the keychain item named by user_token_keychain. It returns where the
+// token came from, for the report.
+func liveToken(ctx context.Context, cfg serverConfig) (string, string, error) {
+	if token := os.Getenv(cfg.UserTokenEnv); token != "" {
+		return token, "env " + cfg.UserTokenEnv, nil
+	}
+	envPath := filepath.Join(configDir, envFileName)
+	if env, err := loadEnvFile(envPath); err == nil && env[cfg.UserTokenEnv] != "" {
+		return env[cfg.UserTokenEnv], envPath, nil
+	}
+	if cfg.UserTokenKeychain != "" {
+		token, err := keychainSecret(ctx, cfg.UserTokenKeychain)
+		if err != nil {
+			return "", "", err
+		}
+		return token, "keychain " + cfg.UserTokenKeychain, nil
+	}
+	return "", "", fmt.Errorf("no user token: set %s, add it to %s, or set user_token_keychain in config.yaml", cfg.UserTokenEnv, envPath)
+}
+
+// keychainSecret reads a password from the macOS keychain, or from the
+// Secret Service through secret-tool elsewhere, by service name.
+func keychainSecret(ctx context.Context, service string) (string, error) {
+	var command string
+	switch {
+	case commandExists("security"):
+		command = "security find-generic-password -w -s " + shellQuote(service)
+	case commandExists("secret-tool"):
+		command = "secret-tool lookup service " + shellQuote(service)
+	default:
+		return "", fmt.Errorf("no keychain tool (security or secret-tool) to read %s", service)
+	}
+	result, err := runShell(ctx, "", 10*time.Second, command)
+	if err != nil {
+		return "", err
+	}
+	secret := strings.TrimSpace(result.output)
+	if result.exitCode != 0 || secret == "" {
+		return "", fmt.Errorf("no keychain item for service %s", service)
+	}
+	return secret, nil
+}
+
+func commandExists(name string) bool {
+	_, err := exec.LookPath(name)
+	return err == nil
+}
+
+// buildLiveDriver builds the driver into the config directory, wired to
+// the local SDK checkout the way run_example wires templates. It is only
+// rebuilt when the driver or the SDK's working tree has changed.
+func buildLiveDriver(ctx context.Context) (string, error) {
+	dir := filepath.Join(configDir, "live")
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return "", err
+	}
+	head, err := runGit(quickbaseGoPath, "rev-parse", "HEAD")
+	if err != nil {
+		return "", fmt.Errorf("quickbase-go checkout at %s: %w", quickbaseGoPath, err)
+	}
+	// Changes to tracked files, and new files, which git diff leaves out
+	hash := sha256.New()
+	diff, _ := runGit(quickbaseGoPath, "diff", "HEAD")
+	hash.Write([]byte(liveDriverSource + head + diff))
+	untracked, _ := runGit(quickbaseGoPath, "ls-files", "--others", "--exclude-standard")
+	for _, rel := range strings.Fields(untracked) {
+		if data, err := os.ReadFile(filepath.Join(quickbaseGoPath, rel)); err == nil {
+			hash.Write([]byte(rel))
+			hash.Write(data)
+		}
+	}
+	stamp := fmt.Sprintf("%x", hash.Sum(nil))
+	bin := filepath.Join(dir, "qb-live")
+	stampPath := filepath.Join(dir, "stamp")
+	if old, err := os.ReadFile(stampPath); err == nil && string(old) == stamp && fileExists(bin) {
+		return bin, nil
+	}
+
+	gomod := fmt.Sprintf("module qblive\n\ngo 1.21\n\nrequire github.com/DrewBradfordXYZ/quickbase-go v0.0.0\n\nreplace github.com/DrewBradfordXYZ/quickbase-go => %s\n", quickbaseGoPath)
+	if err := os.WriteFile(filepath.Join(dir, "go.mod"), []byte(gomod), 0644); err != nil {
+		return "", err
+	}
+	if err := os.WriteFile(filepath.Join(dir, "main.go"), []byte(liveDriverSource), 0644); err != nil {
+		return "", err
+	}
+	result, err := runShell(ctx, dir, defaultExampleTimeout, "go mod tidy >/dev/null && go build -o qb-live .")
+	if err != nil {
+		return "", err
+	}
+	if result.timedOut || result.exitCode != 0 {
+		// The SDK's working tree doesn't build, or its API moved
+		return "", fmt.Errorf("building the live driver against %s failed:\n\n```\n%s\n```", quickbaseGoPath, tailLines(result.output, 30))
+	}
+	return bin, os.WriteFile(stampPath, []byte(stamp), 0644)
+}
+
+// newLiveClient checks there is a real realm and a token, and builds the
+// driver. Calls are recorded in the audit log under tool.
+func (s *QuickBasePersonalMCPServer) newLiveClient(ctx context.Context, cfg serverConfig, tool string) (*liveClient, error) {
+	if cfg.Realm == defaultConfig.Realm {
+		return nil, fmt.Errorf("no realm in %s; the live tools need a real one", filepath.Join(configDir, configFileNames[0]))
+	}
+	token, source, err := liveToken(ctx, cfg)
+	if err != nil {
+		return nil, err
+	}
+	driver, err := buildLiveDriver(ctx)
+	if err != nil {
+		return nil, err
+	}
+	client := &liveClient{realm: cfg.Hostname(), token: token, tokenSource: source, driver: driver, store: s.store, tool: tool, audit: s.auditLive(tool)}
+	if cfg.Sandbox {
+		client.sandboxApp = cfg.AppID
+		client.allowed = map[string]bool{cfg.AppID: true}
+		if cfg.TableID != defaultConfig.TableID {
+			client.allowed[cfg.TableID] = true
+		}
+	}
+	return client, nil
+}
+
+// checkSandbox refuses a dbid outside the sandbox app. Outside sandbox
+// mode every dbid is allowed.
+func (c *liveClient) checkSandbox(ctx context.Context, timeout time.Duration, dbid string) error {
+	if c.allowed == nil || dbid == "" || c.allowed[dbid] {
+		return nil
+	}
+	if !c.tablesListed {
+		var reply struct {
+			Tables []struct {
+				ID string `json:"id"`
+			} `json:"tables"`
+		}
+		if err := c.run(ctx, timeout, map[string]any{"op": "list_tables", "app": c.sandboxApp}, &reply); err != nil {
+			return fmt.Errorf("sandbox: listing the tables of %s: %w", c.sandboxApp, err)
+		}
+		for _, t := range reply.Tables {
+			c.allowed[t.ID] = true
+		}
+		c.tablesListed = true
+	}
+	if !c.allowed[dbid] {
+		return fmt.Errorf("sandbox mode: %s is not the sandbox app %s or one of its tables; refusing the call", dbid, c.sandboxApp)
+	}
+	return nil
+}
+
+// call runs one driver op and decodes its reply into out, after checking
+// any app or table it names against the sandbox.
+func (c *liveClient) call(ctx context.Context, timeout time.Duration, req map[string]any, out any) error {
+	if err := c.check(ctx, timeout, req); err != nil {
+		return err
+	}
+	return c.run(ctx, timeout, req, out)
+}
+
+// check checks the app and table a request names against the sandbox, and
+// records a refusal in the audit log.
+func (c *liveClient) check(ctx context.Context, timeout time.Duration, req map[string]any) error {
+	for _, key := range []string{"app", "table"} {
+		dbid, _ := req[key].(string)
+		if err := c.checkSandbox(ctx, timeout, dbid); err != nil {
+			req["realm"] = c.realm
+			c.audit(req, 0, "refused", err)
+			return err
+		}
+	}
+	return nil
+}
+
+// run runs one driver op unchecked, and records it in the audit log.
+// Errors the SDK returns come back with their Go type; anything else the
+// driver printed (a panic, say) is returned with the token redacted.
+func (c *liveClient) run(ctx context.Context, timeout time.Duration, req map[string]any, out any) (err error) {
+	req["realm"] = c.realm
+	req["max_retries"] = liveMaxRetries
+	req["throttle"] = liveRateLimit
+	data, err := json.Marshal(req)
+	if err != nil {
+		return err
+	}
+	start := time.Now()
+	if err := c.throttle(ctx, timeout); err != nil {
+		c.audit(req, time.Since(start), "refused", err)
+		return err
+	}
+	timedOut := false
+	defer func() {
+		status := "ok"
+		switch {
+		case timedOut:
+			status = "timeout"
+		case err != nil:
+			status = "error"
+		}
+		c.audit(req, time.Since(start), status, err)
+	}()
+	env := map[string]string{"QB_USER_TOKEN": c.token}
+	result, err := runShellEnv(ctx, filepath.Dir(c.driver), timeout, []string{"QB_LIVE_REQUEST=" + string(data), "QB_USER_TOKEN=" + c.token}, shellQuote(c.driver))
+	if err != nil {
+		return err
+	}
+	if result.timedOut {
+		timedOut = true
+		c.recordRate(1, nil)
+		return fmt.Errorf("timed out after %s", timeout)
+	}
+	// Redacting could break the JSON, so the reply is decoded first
+	lines := strings.Split(strings.TrimSpace(result.output), "\n")
+	last := lines[len(lines)-1]
+	var cost struct {
+		Requests   *int             `json:"requests"`
+		RateLimits []map[string]any `json:"rate_limits"`
+	}
+	// A driver that died before replying may still have got a request out
+	requests := 1
+	if json.Unmarshal([]byte(last), &cost) == nil && cost.Requests != nil {
+		requests = *cost.Requests
+	}
+	c.recordRate(requests, cost.RateLimits)
+	var failure struct {
+		Error string `json:"error"`
+		Type  string `json:"type"`
+	}
+	if json.Unmarshal([]byte(last), &failure) == nil && failure.Error != "" {
+		return fmt.Errorf("%s (%s)", redactOutput(failure.Error, env), failure.Type)
+	}
+	if result.exitCode != 0 || json.Unmarshal([]byte(last), out) != nil {
+		return fmt.Errorf("driver failed (exit %d):\n\n```\n%s\n```", result.exitCode, tailLines(redactOutput(result.output, env), 30))
+	}
+	return nil
+}
+
+func (s *QuickBasePersonalMCPServer) handleQBQueryRecords(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	var params struct {
+		Table          string `json:"table"`
+		Select         []int  `json:"select"`
+		Where          string `json:"where"`
+		Limit          int    `json:"limit"`
+		Skip           int    `json:"skip"`
+		TimeoutSeconds int    `json:"timeout_seconds"`
+	}
+	argsData, _ := json.Marshal(request.Params.Arguments)
+	if err := json.Unmarshal(argsData, &params); err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Invalid parameters: %v", err)), nil
+	}
+	timeout := defaultLiveTimeout
+	if params.TimeoutSeconds > 0 {
+		timeout = time.Duration(params.TimeoutSeconds) * time.Second
+	}
+	if params.Limit <= 0 {
+		params.Limit = 100
+	}
+
+	cfg, err := loadLiveConfig()
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to load config: %v", err)), nil
+	}
+	if params.Table == "" {
+		if cfg.TableID == defaultConfig.TableID {
+			return mcp.NewToolResultError("table is required (no table_id in config.yaml)"), nil
+		}
+		params.Table = cfg.TableID
+	}
+	client, err := s.newLiveClient(ctx, cfg, request.Params.Name)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	var reply struct {
+		Records []map[string]any `json:"records"`
+		Total   int              `json:"total"`
+	}
+	start := time.Now()
+	req := map[string]any{"op": "query", "table": params.Table, "select": params.Select, "where": params.Where, "limit": params.Limit, "skip": params.Skip}
+	if err := client.call(ctx, timeout, req, &reply); err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Query failed: %v", err)), nil
+	}
+
+	var results strings.Builder
+	results.WriteString(fmt.Sprintf("# qb_query_records: %s\n\n", params.Table))
+	results.WriteString(fmt.Sprintf("Realm %s; user token from %s; through quickbase-go at %s.\n\n", client.realm, client.tokenSource, quickbaseGoPath))
+	shown := fmt.Sprintf("%d record(s)", len(reply.Records))
+	if reply.Total > len(reply.Records) {
+		shown = fmt.Sprintf("First %d of %d records (raise limit for more)", len(reply.Records), reply.Total)
+	}
+	results.WriteString(fmt.Sprintf("%s in %s, keyed by field ID\n\n", shown, time.Since(start).Round(time.Millisecond)))
+	data, _ := json.MarshalIndent(reply.Records, "", "  ")
+	results.WriteString(fmt.Sprintf("```json\n%s\n```\n", data))
+	return mcp.NewToolResultText(results.String()), nil
+}
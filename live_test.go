@@ -0,0 +1,37 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// sandboxClient is a liveClient in sandbox mode with its tables already
+// listed, so the sandbox check runs without a driver.
+func sandboxClient(t *testing.T) (*QuickBasePersonalMCPServer, *liveClient) {
+	s := &QuickBasePersonalMCPServer{store: newStateStore(filepath.Join(t.TempDir(), "state.db"))}
+	client := &liveClient{
+		realm:        "sandbox.quickbase.com",
+		sandboxApp:   "bqsandbox",
+		allowed:      map[string]bool{"bqsandbox": true, "bqsandtbl": true},
+		tablesListed: true,
+		store:        s.store,
+		tool:         "qb_upsert_records",
+		audit:        s.auditLive("qb_upsert_records"),
+	}
+	return s, client
+}
+
+func TestSandboxRefusesUpsertOutsideSandbox(t *testing.T) {
+	_, client := sandboxClient(t)
+	payload, _ := json.Marshal(map[string]any{"to": "bqprodtbl", "data": []any{}})
+	for _, dryRun := range []bool{true, false} {
+		err := client.call(context.Background(), time.Second, upsertRequest("bqprodtbl", payload, dryRun), nil)
+		if err == nil || !strings.Contains(err.Error(), "sandbox mode") {
+			t.Errorf("dry_run %v: upsert to bqprodtbl got %v, want a sandbox refusal", dryRun, err)
+		}
+	}
+}
@@ -0,0 +1,137 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// liveApp is an app the user token can see.
+type liveApp struct {
+	Name string `json:"name"`
+	ID   string `json:"id"`
+}
+
+func (s *QuickBasePersonalMCPServer) handleQBListApps(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	var params struct {
+		Filter         string `json:"filter"`
+		TimeoutSeconds int    `json:"timeout_seconds"`
+	}
+	argsData, _ := json.Marshal(request.Params.Arguments)
+	if err := json.Unmarshal(argsData, &params); err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Invalid parameters: %v", err)), nil
+	}
+	timeout := defaultLiveTimeout
+	if params.TimeoutSeconds > 0 {
+		timeout = time.Duration(params.TimeoutSeconds) * time.Second
+	}
+
+	cfg, err := loadLiveConfig()
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to load config: %v", err)), nil
+	}
+	client, err := s.newLiveClient(ctx, cfg, request.Params.Name)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	var reply struct {
+		Apps []liveApp `json:"apps"`
+	}
+	if err := client.call(ctx, timeout, map[string]any{"op": "list_apps"}, &reply); err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Listing apps failed: %v", err)), nil
+	}
+
+	apps := reply.Apps[:0]
+	for _, app := range reply.Apps {
+		if client.sandboxApp != "" && app.ID != client.sandboxApp {
+			// Apps outside the sandbox can't be used, so aren't offered
+			continue
+		}
+		if params.Filter == "" || strings.Contains(strings.ToLower(app.Name), strings.ToLower(params.Filter)) {
+			apps = append(apps, app)
+		}
+	}
+	sort.Slice(apps, func(i, j int) bool { return strings.ToLower(apps[i].Name) < strings.ToLower(apps[j].Name) })
+
+	var results strings.Builder
+	results.WriteString(fmt.Sprintf("# Apps in %s\n\n", client.realm))
+	if len(apps) == 0 {
+		switch {
+		case client.sandboxApp != "" && params.Filter == "":
+			results.WriteString(fmt.Sprintf("Sandbox mode, and the token can't see the sandbox app %s.\n", client.sandboxApp))
+		case params.Filter != "":
+			results.WriteString(fmt.Sprintf("No apps matching %q (of %d).\n", params.Filter, len(reply.Apps)))
+		default:
+			results.WriteString("The token can't see any apps.\n")
+		}
+		return mcp.NewToolResultText(results.String()), nil
+	}
+	results.WriteString("| App | ID |\n|---|---|\n")
+	for _, app := range apps {
+		marker := ""
+		switch {
+		case app.ID == client.sandboxApp:
+			marker = " (sandbox app)"
+		case app.ID == cfg.AppID:
+			marker = " (configured app_id)"
+		}
+		results.WriteString(fmt.Sprintf("| %s%s | `%s` |\n", strings.ReplaceAll(app.Name, "|", `\|`), marker, app.ID))
+	}
+	results.WriteString(fmt.Sprintf("\n%d app(s). Use qb_get_app for an app's details.\n", len(apps)))
+	return mcp.NewToolResultText(results.String()), nil
+}
+
+func (s *QuickBasePersonalMCPServer) handleQBGetApp(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	var params struct {
+		App            string `json:"app"`
+		Refresh        bool   `json:"refresh"`
+		TimeoutSeconds int    `json:"timeout_seconds"`
+	}
+	argsData, _ := json.Marshal(request.Params.Arguments)
+	if err := json.Unmarshal(argsData, &params); err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Invalid parameters: %v", err)), nil
+	}
+	timeout := defaultLiveTimeout
+	if params.TimeoutSeconds > 0 {
+		timeout = time.Duration(params.TimeoutSeconds) * time.Second
+	}
+
+	cfg, err := loadLiveConfig()
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to load config: %v", err)), nil
+	}
+	if params.App == "" {
+		if cfg.AppID == defaultConfig.AppID {
+			return mcp.NewToolResultError("app is required (no app_id in config.yaml)"), nil
+		}
+		params.App = cfg.AppID
+	}
+	client, err := s.newLiveClient(ctx, cfg, request.Params.Name)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	var reply struct {
+		App json.RawMessage `json:"app"`
+	}
+	cached, err := client.callCached(ctx, timeout, map[string]any{"op": "get_app", "app": params.App}, &reply, params.Refresh)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Getting app %s failed: %v", params.App, err)), nil
+	}
+
+	var results strings.Builder
+	results.WriteString(fmt.Sprintf("# App %s\n\n", params.App))
+	results.WriteString(fmt.Sprintf("Realm %s; from the SDK's GetApp.\n\n", client.realm))
+	results.WriteString(cacheNote(cached))
+	var app any
+	if err := json.Unmarshal(reply.App, &app); err != nil {
+		app = string(reply.App)
+	}
+	data, _ := json.MarshalIndent(app, "", "  ")
+	results.WriteString(fmt.Sprintf("```json\n%s\n```\n", data))
+	return mcp.NewToolResultText(results.String()), nil
+}
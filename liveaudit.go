@@ -0,0 +1,196 @@
+package main
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// liveAuditBucket records every live call. Entries are only ever added:
+// nothing trims or rewrites the bucket.
+const liveAuditBucket = "live_audit"
+
+// liveAuditEntry is one live call, or one refused by the sandbox or the
+// rate limit.
+type liveAuditEntry struct {
+	ID          uint64        `json:"id"`
+	Time        time.Time     `json:"time"`
+	Tool        string        `json:"tool"`
+	Op          string        `json:"op"`
+	Endpoint    string        `json:"endpoint"`
+	Realm       string        `json:"realm"`
+	DBID        string        `json:"dbid,omitempty"`
+	PayloadHash string        `json:"payload_hash"`
+	Duration    time.Duration `json:"duration"`
+	Status      string        `json:"status"`
+	Error       string        `json:"error,omitempty"`
+}
+
+// liveEndpoint names the API call a driver op makes. Dry-run upserts are
+// only encoded by the SDK and never sent.
+func liveEndpoint(req map[string]any) string {
+	dryRun, _ := req["dry_run"].(bool)
+	switch req["op"] {
+	case "query":
+		return "POST /v1/records/query"
+	case "list_apps":
+		return "POST /db/main API_GrantedDBs"
+	case "get_app":
+		return "GET /v1/apps/{appId}"
+	case "list_tables":
+		return "GET /v1/tables"
+	case "get_fields":
+		return "GET /v1/fields"
+	case "upsert":
+		if dryRun {
+			return "none (dry run)"
+		}
+		return "POST /v1/records"
+	case "delete":
+		if dryRun {
+			return "POST /v1/records/query"
+		}
+		return "DELETE /v1/records"
+	case "run_report":
+		return "POST /v1/reports/{reportId}/run"
+	case "create_field":
+		return "POST /v1/fields"
+	case "delete_fields":
+		return "DELETE /v1/fields"
+	case "relationships":
+		return "GET /v1/tables/{tableId}/relationships"
+	case "schema":
+		return "GET /v1/apps, /v1/tables, /v1/fields, /v1/reports and relationships"
+	case "file_info":
+		return "POST /v1/records/query"
+	case "download":
+		return "GET /v1/files/{tableId}/{recordId}/{fieldId}/{versionNumber}"
+	case "upload":
+		return "POST /v1/records"
+	}
+	return fmt.Sprint(req["op"])
+}
+
+// liveDBID is the dbid a request targets: its table, else its app.
+func liveDBID(req map[string]any) string {
+	for _, key := range []string{"table", "app"} {
+		if dbid, _ := req[key].(string); dbid != "" {
+			return dbid
+		}
+	}
+	return ""
+}
+
+// auditLive appends a live call to the audit log. The payload is kept only
+// as a hash, so record data and where clauses stay out of the log while
+// identical calls can still be matched up.
+func (s *QuickBasePersonalMCPServer) auditLive(tool string) func(req map[string]any, duration time.Duration, status string, err error) {
+	return func(req map[string]any, duration time.Duration, status string, err error) {
+		data, _ := json.Marshal(req)
+		entry := liveAuditEntry{
+			Time:        time.Now().UTC(),
+			Tool:        tool,
+			Op:          fmt.Sprint(req["op"]),
+			Endpoint:    liveEndpoint(req),
+			Realm:       fmt.Sprint(req["realm"]),
+			DBID:        liveDBID(req),
+			PayloadHash: fmt.Sprintf("%x", sha256.Sum256(data)),
+			Duration:    duration,
+			Status:      status,
+		}
+		if err != nil {
+			entry.Error = firstLine(err.Error())
+		}
+		_, werr := s.store.insert(liveAuditBucket, func(id uint64) interface{} {
+			entry.ID = id
+			return entry
+		})
+		if werr != nil {
+			s.logger.Printf("Failed to record live call: %v", werr)
+		}
+	}
+}
+
+func (s *QuickBasePersonalMCPServer) handleAPIAuditLog(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	var params struct {
+		Tool       string `json:"tool"`
+		DBID       string `json:"dbid"`
+		Status     string `json:"status"`
+		SinceHours int    `json:"since_hours"`
+		Limit      int    `json:"limit"`
+	}
+	argsData, _ := json.Marshal(request.Params.Arguments)
+	if err := json.Unmarshal(argsData, &params); err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Invalid parameters: %v", err)), nil
+	}
+	if params.Limit <= 0 {
+		params.Limit = 50
+	}
+	var since time.Time
+	if params.SinceHours > 0 {
+		since = time.Now().Add(-time.Duration(params.SinceHours) * time.Hour)
+	}
+
+	var entries []liveAuditEntry
+	total := 0
+	err := s.store.each(liveAuditBucket, func(key string, data []byte) error {
+		var entry liveAuditEntry
+		if json.Unmarshal(data, &entry) != nil {
+			return nil
+		}
+		total++
+		switch {
+		case params.Tool != "" && entry.Tool != params.Tool,
+			params.DBID != "" && entry.DBID != params.DBID,
+			params.Status != "" && entry.Status != params.Status,
+			entry.Time.Before(since):
+			return nil
+		}
+		entries = append(entries, entry)
+		return nil
+	})
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to read the audit log: %v", err)), nil
+	}
+
+	var results strings.Builder
+	results.WriteString("# Live API Audit Log\n\n")
+	if len(entries) == 0 {
+		if total == 0 {
+			results.WriteString("No live calls recorded yet. Every call the qb_* tools make is recorded here.\n")
+		} else {
+			results.WriteString(fmt.Sprintf("No calls match (of %d recorded).\n", total))
+		}
+		return mcp.NewToolResultText(results.String()), nil
+	}
+	matched := len(entries)
+	if len(entries) > params.Limit {
+		entries = entries[len(entries)-params.Limit:]
+	}
+	results.WriteString(fmt.Sprintf("%d of %d matching call(s), newest first (%d recorded in all).\n\n", len(entries), matched, total))
+	results.WriteString("| # | Time (UTC) | Tool | Endpoint | Realm | dbid | Payload | Duration | Status |\n|---|---|---|---|---|---|---|---|---|\n")
+	counts := map[string]int{}
+	for i := len(entries) - 1; i >= 0; i-- {
+		e := entries[i]
+		counts[e.Status]++
+		status := "✅ ok"
+		switch e.Status {
+		case "error", "timeout":
+			status = "❌ " + e.Status
+		case "refused":
+			status = "⚠️ refused"
+		}
+		if e.Error != "" {
+			status += ": " + strings.ReplaceAll(e.Error, "|", `\|`)
+		}
+		results.WriteString(fmt.Sprintf("| %d | %s | %s | `%s` | %s | %s | `%s` | %s | %s |\n",
+			e.ID, e.Time.Format("2006-01-02 15:04:05"), e.Tool, e.Endpoint, e.Realm, e.DBID, e.PayloadHash[:12], e.Duration.Round(time.Millisecond), status))
+	}
+	results.WriteString(fmt.Sprintf("\nShown: %d ok, %d error, %d timeout, %d refused (sandbox or rate limit).\n", counts["ok"], counts["error"], counts["timeout"], counts["refused"]))
+	return mcp.NewToolResultText(results.String()), nil
+}
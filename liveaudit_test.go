@@ -0,0 +1,30 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestAuditRecordsUpsertDBID(t *testing.T) {
+	s, client := sandboxClient(t)
+	payload, _ := json.Marshal(map[string]any{"to": "bqprodtbl", "data": []any{}})
+	client.call(context.Background(), time.Second, upsertRequest("bqprodtbl", payload, false), nil)
+
+	var entries []liveAuditEntry
+	s.store.each(liveAuditBucket, func(key string, data []byte) error {
+		var entry liveAuditEntry
+		if err := json.Unmarshal(data, &entry); err != nil {
+			return err
+		}
+		entries = append(entries, entry)
+		return nil
+	})
+	if len(entries) != 1 {
+		t.Fatalf("got %d audit entries, want 1", len(entries))
+	}
+	if e := entries[0]; e.Op != "upsert" || e.DBID != "bqprodtbl" || e.Status != "refused" {
+		t.Errorf("audit entry is op %q dbid %q status %q, want upsert bqprodtbl refused", e.Op, e.DBID, e.Status)
+	}
+}
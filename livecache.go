@@ -0,0 +1,97 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// liveCacheBucket holds replies to the read-only schema calls (get_app,
+// list_tables, get_fields), keyed by realm, op and dbid.
+const liveCacheBucket = "live_cache"
+
+// liveCacheTTL is how long a cached schema reply is used. Schemas change
+// rarely, and each lookup counts against the realm's rate limit.
+const liveCacheTTL = 15 * time.Minute
+
+type liveCacheEntry struct {
+	Reply  json.RawMessage `json:"reply"`
+	Stored time.Time       `json:"stored"`
+}
+
+// callCached is call for read-only ops, answered from the cache while the
+// stored reply is younger than liveCacheTTL, unless refresh is set. The
+// sandbox still checks every request, cached or not. It returns when the
+// reply was stored, or the zero time for a fresh one.
+func (c *liveClient) callCached(ctx context.Context, timeout time.Duration, req map[string]any, out any, refresh bool) (time.Time, error) {
+	if err := c.check(ctx, timeout, req); err != nil {
+		return time.Time{}, err
+	}
+	key := fmt.Sprintf("%s %s %s", c.realm, req["op"], liveDBID(req))
+	var entry liveCacheEntry
+	if !refresh {
+		if found, _ := c.store.get(liveCacheBucket, key, &entry); found && time.Since(entry.Stored) < liveCacheTTL {
+			if json.Unmarshal(entry.Reply, out) == nil {
+				return entry.Stored, nil
+			}
+		}
+	}
+
+	var reply json.RawMessage
+	if err := c.run(ctx, timeout, req, &reply); err != nil {
+		return time.Time{}, err
+	}
+	if err := json.Unmarshal(reply, out); err != nil {
+		return time.Time{}, err
+	}
+	// Expired replies are cleared as new ones are stored
+	var expired []string
+	c.store.each(liveCacheBucket, func(key string, data []byte) error {
+		var e liveCacheEntry
+		if json.Unmarshal(data, &e) != nil || time.Since(e.Stored) >= liveCacheTTL {
+			expired = append(expired, key)
+		}
+		return nil
+	})
+	for _, key := range expired {
+		c.store.delete(liveCacheBucket, key)
+	}
+	c.store.put(liveCacheBucket, key, liveCacheEntry{Reply: reply, Stored: time.Now()})
+	return time.Time{}, nil
+}
+
+// invalidateCache drops the realm's cached replies for the given dbids, so
+// the next lookup sees a schema just changed.
+func (c *liveClient) invalidateCache(dbids ...string) error {
+	drop := map[string]bool{}
+	for _, id := range dbids {
+		drop[id] = true
+	}
+	var keys []string
+	err := c.store.each(liveCacheBucket, func(key string, data []byte) error {
+		// Keys are "realm op dbid"
+		if parts := strings.Fields(key); len(parts) == 3 && parts[0] == c.realm && drop[parts[2]] {
+			keys = append(keys, key)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	for _, key := range keys {
+		if _, err := c.store.delete(liveCacheBucket, key); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// cacheNote says a reply came from the cache, and how to skip it.
+func cacheNote(stored time.Time) string {
+	if stored.IsZero() {
+		return ""
+	}
+	return fmt.Sprintf("Cached %s ago (kept for %s); pass refresh: true for a fresh copy.\n\n", time.Since(stored).Round(time.Second), liveCacheTTL)
+}
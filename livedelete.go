@@ -0,0 +1,170 @@
+package main
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+const deleteTokenBucket = "delete_tokens"
+
+// deleteTokenTTL is how long a dry run's token can be used.
+const deleteTokenTTL = 10 * time.Minute
+
+// deleteToken is what a dry run of qb_delete_records saw. The token
+// authorizes exactly that delete, once.
+type deleteToken struct {
+	Realm   string    `json:"realm"`
+	Table   string    `json:"table"`
+	Where   string    `json:"where"`
+	IDs     []string  `json:"ids"`
+	Expires time.Time `json:"expires"`
+}
+
+// deleteIDs is record IDs as sorted strings, so the set a dry run saw can
+// be compared with the set matched now.
+func deleteIDs(ids []any) []string {
+	out := make([]string, len(ids))
+	for i, id := range ids {
+		out[i] = fmt.Sprint(id)
+	}
+	sort.Strings(out)
+	return out
+}
+
+func newDeleteToken() (string, error) {
+	b := make([]byte, 8)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
+
+func (s *QuickBasePersonalMCPServer) handleQBDeleteRecords(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	var params struct {
+		Table          string `json:"table"`
+		Where          string `json:"where"`
+		Token          string `json:"token"`
+		TimeoutSeconds int    `json:"timeout_seconds"`
+	}
+	argsData, _ := json.Marshal(request.Params.Arguments)
+	if err := json.Unmarshal(argsData, &params); err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Invalid parameters: %v", err)), nil
+	}
+	timeout := defaultLiveTimeout
+	if params.TimeoutSeconds > 0 {
+		timeout = time.Duration(params.TimeoutSeconds) * time.Second
+	}
+	if strings.TrimSpace(params.Where) == "" {
+		return mcp.NewToolResultError("where is required, e.g. {3.EX.'42'}"), nil
+	}
+
+	cfg, err := loadLiveConfig()
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to load config: %v", err)), nil
+	}
+	if params.Table == "" {
+		if cfg.TableID == defaultConfig.TableID {
+			return mcp.NewToolResultError("table is required (no table_id in config.yaml)"), nil
+		}
+		params.Table = cfg.TableID
+	}
+	client, err := s.newLiveClient(ctx, cfg, request.Params.Name)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	// A token is used up whether or not the delete goes ahead
+	var entry deleteToken
+	if params.Token != "" {
+		found, err := s.store.get(deleteTokenBucket, params.Token, &entry)
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("Failed to read the token: %v", err)), nil
+		}
+		if deleted, _ := s.store.delete(deleteTokenBucket, params.Token); !found || !deleted {
+			return mcp.NewToolResultError("Unknown or already used token; do a dry run (no token) for a new one"), nil
+		}
+		switch {
+		case time.Now().After(entry.Expires):
+			return mcp.NewToolResultError("The token expired; do a dry run for a new one"), nil
+		case entry.Realm != client.realm || entry.Table != params.Table || entry.Where != params.Where:
+			return mcp.NewToolResultError(fmt.Sprintf("The token is for deleting %s from %s on %s, not this delete; do a dry run for a new one", entry.Where, entry.Table, entry.Realm)), nil
+		}
+	}
+
+	// The records the where clause matches now
+	var matched struct {
+		IDs []any `json:"ids"`
+	}
+	req := map[string]any{"op": "delete", "table": params.Table, "where": params.Where, "dry_run": true}
+	if err := client.call(ctx, timeout, req, &matched); err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Query failed: %v", err)), nil
+	}
+
+	var results strings.Builder
+	if params.Token == "" {
+		results.WriteString(fmt.Sprintf("# qb_delete_records: dry run\n\n%s matches %d record(s) in %s on %s.\n\n", params.Where, len(matched.IDs), params.Table, client.realm))
+		if len(matched.IDs) == 0 {
+			results.WriteString("Nothing to delete.\n")
+			return mcp.NewToolResultText(results.String()), nil
+		}
+		ids := make([]string, 0, 20)
+		for i, id := range matched.IDs {
+			if i == 20 {
+				ids = append(ids, fmt.Sprintf("… %d more", len(matched.IDs)-20))
+				break
+			}
+			ids = append(ids, fmt.Sprint(id))
+		}
+		results.WriteString(fmt.Sprintf("Record IDs: %s\n\n", strings.Join(ids, ", ")))
+
+		// Expired tokens are cleared as new ones are made
+		var expired []string
+		s.store.each(deleteTokenBucket, func(key string, data []byte) error {
+			var t deleteToken
+			if json.Unmarshal(data, &t) != nil || time.Now().After(t.Expires) {
+				expired = append(expired, key)
+			}
+			return nil
+		})
+		for _, key := range expired {
+			s.store.delete(deleteTokenBucket, key)
+		}
+		token, err := newDeleteToken()
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("Failed to make a token: %v", err)), nil
+		}
+		entry := deleteToken{Realm: client.realm, Table: params.Table, Where: params.Where, IDs: deleteIDs(matched.IDs), Expires: time.Now().Add(deleteTokenTTL)}
+		if err := s.store.put(deleteTokenBucket, token, entry); err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("Failed to store the token: %v", err)), nil
+		}
+		results.WriteString(fmt.Sprintf("Nothing was deleted. To delete these records, call again with the same table and where and token `%s`. It works once, for %s.\n", token, deleteTokenTTL))
+		return mcp.NewToolResultText(results.String()), nil
+	}
+
+	// The same count isn't enough: records added and removed since the
+	// dry run would be deleted unseen
+	if !slices.Equal(deleteIDs(matched.IDs), entry.IDs) {
+		return mcp.NewToolResultError(fmt.Sprintf("%s now matches different records from the %d the dry run showed (%d now); do a dry run again", params.Where, len(entry.IDs), len(matched.IDs))), nil
+	}
+
+	var reply struct {
+		Result struct {
+			NumberDeleted int `json:"numberDeleted"`
+		} `json:"result"`
+	}
+	req = map[string]any{"op": "delete", "table": params.Table, "where": params.Where}
+	if err := client.call(ctx, timeout, req, &reply); err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Delete failed: %v", err)), nil
+	}
+	results.WriteString(fmt.Sprintf("# qb_delete_records: %s\n\nDeleted %d record(s) matching %s on %s.\n", params.Table, reply.Result.NumberDeleted, params.Where, client.realm))
+	return mcp.NewToolResultText(results.String()), nil
+}
@@ -0,0 +1,281 @@
+package main
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// liveFileMaxBytes is the default cap on a download or upload. Attachments
+// travel base64-encoded in JSON, so big ones are slow and costly.
+const liveFileMaxBytes = 10 << 20
+
+// Downloads without save_to go to a directory each under liveFileTempDir,
+// cleared once they're older than liveFileTempTTL.
+var liveFileTempDir = filepath.Join(os.TempDir(), "quickbase-files")
+
+const liveFileTempTTL = 24 * time.Hour
+
+// liveFile is a file attachment field's value: its versions, oldest first.
+type liveFile struct {
+	URL      string `json:"url"`
+	Versions []struct {
+		VersionNumber int    `json:"versionNumber"`
+		FileName      string `json:"fileName"`
+		Uploaded      string `json:"uploaded"`
+		Creator       struct {
+			Name  string `json:"name"`
+			Email string `json:"email"`
+		} `json:"creator"`
+	} `json:"versions"`
+}
+
+// writeFileVersions lists a file field's versions, newest first.
+func writeFileVersions(b *strings.Builder, file liveFile) {
+	if len(file.Versions) == 0 {
+		b.WriteString("No file attached yet.\n\n")
+		return
+	}
+	b.WriteString("| Version | File | Uploaded | By |\n|---|---|---|---|\n")
+	for i := len(file.Versions) - 1; i >= 0; i-- {
+		v := file.Versions[i]
+		by := v.Creator.Name
+		if by == "" {
+			by = v.Creator.Email
+		}
+		b.WriteString(fmt.Sprintf("| %d | %s | %s | %s |\n", v.VersionNumber, strings.ReplaceAll(v.FileName, "|", `\|`), dash(v.Uploaded), dash(by)))
+	}
+	b.WriteString("\n")
+}
+
+// fileSize formats a byte count, in bytes below a kilobyte.
+func fileSize(n int) string {
+	if n < 1000 {
+		return fmt.Sprintf("%d bytes", n)
+	}
+	return kb(n)
+}
+
+// fileSHA256 is the hex SHA-256 of a file's contents.
+func fileSHA256(path string) (string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%x", sha256.Sum256(data)), nil
+}
+
+// liveDownloadDir makes a fresh directory for a download, clearing out
+// old ones first.
+func liveDownloadDir() (string, error) {
+	if err := os.MkdirAll(liveFileTempDir, 0o700); err != nil {
+		return "", err
+	}
+	entries, _ := os.ReadDir(liveFileTempDir)
+	for _, e := range entries {
+		if info, err := e.Info(); err == nil && time.Since(info.ModTime()) > liveFileTempTTL {
+			os.RemoveAll(filepath.Join(liveFileTempDir, e.Name()))
+		}
+	}
+	return os.MkdirTemp(liveFileTempDir, "download-")
+}
+
+func (s *QuickBasePersonalMCPServer) handleQBDownloadFile(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	var params struct {
+		Table          string `json:"table"`
+		Record         int    `json:"record"`
+		Field          int    `json:"field"`
+		Version        int    `json:"version"`
+		SaveTo         string `json:"save_to"`
+		MaxBytes       int    `json:"max_bytes"`
+		TimeoutSeconds int    `json:"timeout_seconds"`
+	}
+	argsData, _ := json.Marshal(request.Params.Arguments)
+	if err := json.Unmarshal(argsData, &params); err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Invalid parameters: %v", err)), nil
+	}
+	if params.Record <= 0 || params.Field <= 0 {
+		return mcp.NewToolResultError("record and field are required (the record ID and the file attachment field's ID)"), nil
+	}
+	timeout := defaultLiveTimeout
+	if params.TimeoutSeconds > 0 {
+		timeout = time.Duration(params.TimeoutSeconds) * time.Second
+	}
+	if params.MaxBytes <= 0 {
+		params.MaxBytes = liveFileMaxBytes
+	}
+
+	cfg, err := loadLiveConfig()
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to load config: %v", err)), nil
+	}
+	if params.Table == "" {
+		if cfg.TableID == defaultConfig.TableID {
+			return mcp.NewToolResultError("table is required (no table_id in config.yaml)"), nil
+		}
+		params.Table = cfg.TableID
+	}
+	client, err := s.newLiveClient(ctx, cfg, request.Params.Name)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	// The driver runs in its own directory, so the path must be absolute
+	dir, err := filepath.Abs(params.SaveTo)
+	if params.SaveTo == "" {
+		dir, err = liveDownloadDir()
+	} else if err == nil {
+		err = os.MkdirAll(dir, 0o755)
+	}
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to make a download directory: %v", err)), nil
+	}
+	var reply struct {
+		Path     string `json:"path"`
+		FileName string `json:"file_name"`
+		Version  int    `json:"version"`
+		Size     int    `json:"size"`
+	}
+	req := map[string]any{"op": "download", "table": params.Table, "record": params.Record, "field": params.Field, "version": params.Version, "path": dir, "max_bytes": params.MaxBytes}
+	if err := client.call(ctx, timeout, req, &reply); err != nil {
+		if params.SaveTo == "" {
+			os.RemoveAll(dir)
+		}
+		return mcp.NewToolResultError(fmt.Sprintf("Download failed: %v", err)), nil
+	}
+	sum, _ := fileSHA256(reply.Path)
+
+	var results strings.Builder
+	results.WriteString(fmt.Sprintf("# qb_download_file: %s record %d, field %d\n\n", params.Table, params.Record, params.Field))
+	results.WriteString(fmt.Sprintf("Version %d of **%s** from %s: %s, SHA-256 `%s`.\n\n", reply.Version, reply.FileName, client.realm, fileSize(reply.Size), sum))
+	results.WriteString(fmt.Sprintf("Saved to `%s`", reply.Path))
+	if params.SaveTo == "" {
+		results.WriteString(", a temporary copy cleared after a day")
+	}
+	results.WriteString(".\n")
+	// Small text files are shown, so a quick look needs no second step
+	if data, err := os.ReadFile(reply.Path); err == nil && len(data) <= 4000 && utf8.Valid(data) && !strings.ContainsRune(string(data), 0) {
+		results.WriteString(fmt.Sprintf("\n```\n%s\n```\n", strings.TrimRight(string(data), "\n")))
+	}
+	return mcp.NewToolResultText(results.String()), nil
+}
+
+func (s *QuickBasePersonalMCPServer) handleQBUploadFile(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	var params struct {
+		Table          string `json:"table"`
+		Record         int    `json:"record"`
+		Field          int    `json:"field"`
+		Path           string `json:"path"`
+		FileName       string `json:"file_name"`
+		MaxBytes       int    `json:"max_bytes"`
+		DryRun         *bool  `json:"dry_run"`
+		Confirm        bool   `json:"confirm"`
+		TimeoutSeconds int    `json:"timeout_seconds"`
+	}
+	argsData, _ := json.Marshal(request.Params.Arguments)
+	if err := json.Unmarshal(argsData, &params); err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Invalid parameters: %v", err)), nil
+	}
+	if params.Record <= 0 || params.Field <= 0 || params.Path == "" {
+		return mcp.NewToolResultError("record, field and path are required"), nil
+	}
+	timeout := defaultLiveTimeout
+	if params.TimeoutSeconds > 0 {
+		timeout = time.Duration(params.TimeoutSeconds) * time.Second
+	}
+	if params.MaxBytes <= 0 {
+		params.MaxBytes = liveFileMaxBytes
+	}
+	// The same two switches as qb_upsert_records
+	dryRun := params.DryRun == nil || *params.DryRun
+	if !dryRun && !params.Confirm {
+		return mcp.NewToolResultError("Uploading needs confirm: true as well as dry_run: false. Do a dry run first and check the record and file."), nil
+	}
+
+	path, err := filepath.Abs(params.Path)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Can't read %s: %v", path, err)), nil
+	}
+	if info.IsDir() {
+		return mcp.NewToolResultError(fmt.Sprintf("%s is a directory", path)), nil
+	}
+	if info.Size() > int64(params.MaxBytes) {
+		return mcp.NewToolResultError(fmt.Sprintf("%s is %s, over the %s limit (raise max_bytes to send it)", path, fileSize(int(info.Size())), fileSize(params.MaxBytes))), nil
+	}
+	if params.FileName == "" {
+		params.FileName = filepath.Base(path)
+	}
+	sum, err := fileSHA256(path)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Can't read %s: %v", path, err)), nil
+	}
+
+	cfg, err := loadLiveConfig()
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to load config: %v", err)), nil
+	}
+	if params.Table == "" {
+		if cfg.TableID == defaultConfig.TableID {
+			return mcp.NewToolResultError("table is required (no table_id in config.yaml)"), nil
+		}
+		params.Table = cfg.TableID
+	}
+	client, err := s.newLiveClient(ctx, cfg, request.Params.Name)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	var results strings.Builder
+	if dryRun {
+		// The record's current versions, which also shows it exists
+		var reply struct {
+			File liveFile `json:"file"`
+		}
+		req := map[string]any{"op": "file_info", "table": params.Table, "record": params.Record, "field": params.Field}
+		if err := client.call(ctx, timeout, req, &reply); err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("Reading record %d failed: %v", params.Record, err)), nil
+		}
+		results.WriteString("# qb_upload_file: dry run\n\n")
+		results.WriteString(fmt.Sprintf("Would upload `%s` (%s, SHA-256 `%s`) as **%s** to field %d of record %d in %s on %s, as a new version.\n\n", path, fileSize(int(info.Size())), sum, params.FileName, params.Field, params.Record, params.Table, client.realm))
+		results.WriteString("The field now:\n\n")
+		writeFileVersions(&results, reply.File)
+		results.WriteString("Nothing was sent. To upload, call again with dry_run: false and confirm: true.\n")
+		return mcp.NewToolResultText(results.String()), nil
+	}
+
+	var reply struct {
+		Result upsertResult `json:"result"`
+		Size   int          `json:"size"`
+	}
+	req := map[string]any{"op": "upload", "table": params.Table, "record": params.Record, "field": params.Field, "path": path, "file_name": params.FileName, "max_bytes": params.MaxBytes}
+	if err := client.call(ctx, timeout, req, &reply); err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Upload failed: %v", err)), nil
+	}
+	m := reply.Result.Metadata
+	if len(m.LineErrors) > 0 {
+		var errs []string
+		for _, line := range sortedKeys(m.LineErrors) {
+			errs = append(errs, strings.Join(m.LineErrors[line], "; "))
+		}
+		return mcp.NewToolResultError(fmt.Sprintf("Quickbase rejected the upload: %s", strings.Join(errs, "; "))), nil
+	}
+	results.WriteString(fmt.Sprintf("# qb_upload_file: %s record %d, field %d\n\n", params.Table, params.Record, params.Field))
+	results.WriteString(fmt.Sprintf("✅ Uploaded **%s** (%s, SHA-256 `%s`) on %s.\n\n", params.FileName, fileSize(reply.Size), sum, client.realm))
+	if len(m.CreatedRecordIDs) > 0 {
+		results.WriteString(fmt.Sprintf("⚠️ Record %d didn't exist, so a new record was created: %s.\n\n", params.Record, recordIDList(m.CreatedRecordIDs)))
+	}
+	results.WriteString("Download it again with qb_download_file to check it round-trips.\n")
+	return mcp.NewToolResultText(results.String()), nil
+}
@@ -0,0 +1,161 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// liveRateBucket holds each realm's rate-limit state, keyed by hostname.
+const liveRateBucket = "live_rate"
+
+// Quickbase allows a user token 100 requests per 10 seconds. Each live
+// call is its own driver process, so the SDK's throttle only sees one
+// call's requests; the window across calls is kept here.
+const (
+	liveRateLimit  = 100
+	liveRateWindow = 10 * time.Second
+	liveMaxRetries = 3
+	liveRateEvents = 20
+)
+
+// liveRateState is a realm's requests in the current window, the latest
+// 429s the SDK reported, and how often calls were held back.
+type liveRateState struct {
+	Requests   []time.Time    `json:"requests"`
+	Events     []liveRateHit  `json:"events"`
+	Waits      int            `json:"waits"`
+	Waited     time.Duration  `json:"waited"`
+	HitsByTool map[string]int `json:"hits_by_tool,omitempty"`
+}
+
+// liveRateHit is one 429, as the SDK's rate-limit callback described it.
+type liveRateHit struct {
+	Time time.Time      `json:"time"`
+	Tool string         `json:"tool"`
+	Info map[string]any `json:"info"`
+}
+
+// rateState loads the realm's state with requests older than the window
+// dropped.
+func (c *liveClient) rateState() liveRateState {
+	var st liveRateState
+	c.store.get(liveRateBucket, c.realm, &st)
+	cutoff := time.Now().Add(-liveRateWindow)
+	kept := st.Requests[:0]
+	for _, t := range st.Requests {
+		if t.After(cutoff) {
+			kept = append(kept, t)
+		}
+	}
+	st.Requests = kept
+	return st
+}
+
+// throttle holds a call back while the window is full, or fails if the
+// window won't free up within timeout.
+func (c *liveClient) throttle(ctx context.Context, timeout time.Duration) error {
+	st := c.rateState()
+	if len(st.Requests) < liveRateLimit {
+		return nil
+	}
+	wait := time.Until(st.Requests[len(st.Requests)-liveRateLimit].Add(liveRateWindow))
+	if wait > timeout {
+		return fmt.Errorf("rate limit: %d requests in the last %s; the window frees up in %s", len(st.Requests), liveRateWindow, wait.Round(time.Millisecond))
+	}
+	st.Waits++
+	st.Waited += wait
+	c.store.put(liveRateBucket, c.realm, st)
+	select {
+	case <-time.After(wait):
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// recordRate adds a call's requests to the window and keeps the 429s the
+// SDK reported.
+func (c *liveClient) recordRate(requests int, hits []map[string]any) {
+	st := c.rateState()
+	now := time.Now()
+	for i := 0; i < requests; i++ {
+		st.Requests = append(st.Requests, now)
+	}
+	for _, info := range hits {
+		st.Events = append(st.Events, liveRateHit{Time: now.UTC(), Tool: c.tool, Info: info})
+	}
+	if len(hits) > 0 {
+		if st.HitsByTool == nil {
+			st.HitsByTool = map[string]int{}
+		}
+		st.HitsByTool[c.tool] += len(hits)
+	}
+	if len(st.Events) > liveRateEvents {
+		st.Events = st.Events[len(st.Events)-liveRateEvents:]
+	}
+	c.store.put(liveRateBucket, c.realm, st)
+}
+
+func (s *QuickBasePersonalMCPServer) handleQBRateLimitStatus(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	cfg, err := loadLiveConfig()
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to load config: %v", err)), nil
+	}
+	// No token or driver needed: the state is all local
+	client := &liveClient{realm: cfg.Hostname(), store: s.store}
+	st := client.rateState()
+
+	var results strings.Builder
+	results.WriteString(fmt.Sprintf("# Rate Limit: %s\n\n", client.realm))
+	results.WriteString(fmt.Sprintf("Quickbase allows %d requests per %s per user token. Live calls go through the SDK with its proactive throttle at that rate and up to %d retries on a 429; across calls, this server holds a call back while the window is full.\n\n", liveRateLimit, liveRateWindow, liveMaxRetries))
+
+	remaining := liveRateLimit - len(st.Requests)
+	if remaining < 0 {
+		remaining = 0
+	}
+	reset := "now"
+	if len(st.Requests) > 0 {
+		reset = "in " + time.Until(st.Requests[0].Add(liveRateWindow)).Round(100*time.Millisecond).String()
+	}
+	mark := "✅"
+	switch {
+	case remaining == 0:
+		mark = "❌"
+	case remaining < liveRateLimit/5:
+		mark = "⚠️"
+	}
+	results.WriteString("| Used (last 10s) | Remaining | Oldest request leaves the window |\n|---|---|---|\n")
+	results.WriteString(fmt.Sprintf("| %d | %s %d | %s |\n\n", len(st.Requests), mark, remaining, reset))
+	if st.Waits > 0 {
+		results.WriteString(fmt.Sprintf("Calls held back for the window: %d, %s in all.\n\n", st.Waits, st.Waited.Round(time.Millisecond)))
+	}
+
+	results.WriteString("## Recent 429s\n\n")
+	if len(st.Events) == 0 {
+		results.WriteString("None recorded. The SDK reports each 429 it retries, so one appears here as soon as the throttle lets a burst through.\n")
+		return mcp.NewToolResultText(results.String()), nil
+	}
+	for i := len(st.Events) - 1; i >= 0; i-- {
+		e := st.Events[i]
+		var fields []string
+		for _, k := range sortedKeys(e.Info) {
+			data, _ := json.Marshal(e.Info[k])
+			fields = append(fields, fmt.Sprintf("%s=%s", k, data))
+		}
+		results.WriteString(fmt.Sprintf("- %s %s: %s\n", e.Time.Format("2006-01-02 15:04:05"), e.Tool, strings.Join(fields, ", ")))
+	}
+	tools := sortedKeys(st.HitsByTool)
+	sort.SliceStable(tools, func(i, j int) bool { return st.HitsByTool[tools[i]] > st.HitsByTool[tools[j]] })
+	var byTool []string
+	for _, t := range tools {
+		byTool = append(byTool, fmt.Sprintf("%s %d", t, st.HitsByTool[t]))
+	}
+	results.WriteString(fmt.Sprintf("\n429s by tool: %s\n", strings.Join(byTool, ", ")))
+	return mcp.NewToolResultText(results.String()), nil
+}
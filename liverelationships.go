@@ -0,0 +1,189 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// liveRelField is a field as getRelationships lists it.
+type liveRelField struct {
+	ID    int    `json:"id"`
+	Label string `json:"label"`
+	Type  string `json:"type"`
+}
+
+// liveRelationship is a parent/child relationship: the reference field in
+// the child, the lookups it pulls from the parent and the summaries the
+// parent takes of it.
+type liveRelationship struct {
+	ID              int            `json:"id"`
+	ParentTableID   string         `json:"parentTableId"`
+	ChildTableID    string         `json:"childTableId"`
+	ForeignKeyField liveRelField   `json:"foreignKeyField"`
+	IsCrossApp      bool           `json:"isCrossApp"`
+	LookupFields    []liveRelField `json:"lookupFields"`
+	SummaryFields   []liveRelField `json:"summaryFields"`
+}
+
+// relFieldList is fields as "Label (id)", or a dash.
+func relFieldList(fields []liveRelField) string {
+	if len(fields) == 0 {
+		return "—"
+	}
+	parts := make([]string, len(fields))
+	for i, f := range fields {
+		parts[i] = fmt.Sprintf("%s (%d)", strings.ReplaceAll(f.Label, "|", `\|`), f.ID)
+	}
+	return strings.Join(parts, ", ")
+}
+
+var mermaidUnsafe = regexp.MustCompile(`[^A-Za-z0-9_]+`)
+
+// relationshipDiagram renders the relationships as a mermaid ER diagram:
+// each parent has many children through the child's reference field.
+func relationshipDiagram(tables []liveTable, rels []liveRelationship) string {
+	names := map[string]string{}
+	for _, t := range tables {
+		names[t.ID] = t.Name
+	}
+	entity := func(id string) string {
+		// Tables in other apps are only known by ID
+		name := strings.Trim(mermaidUnsafe.ReplaceAllString(names[id], "_"), "_")
+		if name == "" {
+			return id
+		}
+		return name + "_" + id
+	}
+
+	var d strings.Builder
+	d.WriteString("```mermaid\nerDiagram\n")
+	related := map[string]bool{}
+	for _, r := range rels {
+		related[r.ParentTableID], related[r.ChildTableID] = true, true
+		label := strings.ReplaceAll(fmt.Sprintf("%s (%d)", r.ForeignKeyField.Label, r.ForeignKeyField.ID), `"`, "'")
+		d.WriteString(fmt.Sprintf("    %s ||--o{ %s : \"%s\"\n", entity(r.ParentTableID), entity(r.ChildTableID), label))
+	}
+	// Tables on their own still belong in the picture
+	for _, t := range tables {
+		if !related[t.ID] {
+			d.WriteString(fmt.Sprintf("    %s {\n    }\n", entity(t.ID)))
+		}
+	}
+	d.WriteString("```\n")
+	return d.String()
+}
+
+func (s *QuickBasePersonalMCPServer) handleQBGetRelationships(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	var params struct {
+		App            string `json:"app"`
+		Format         string `json:"format"`
+		Diagram        bool   `json:"diagram"`
+		Refresh        bool   `json:"refresh"`
+		TimeoutSeconds int    `json:"timeout_seconds"`
+	}
+	argsData, _ := json.Marshal(request.Params.Arguments)
+	if err := json.Unmarshal(argsData, &params); err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Invalid parameters: %v", err)), nil
+	}
+	if params.Format == "" {
+		params.Format = "markdown"
+	}
+	if params.Format != "markdown" && params.Format != "json" {
+		return mcp.NewToolResultError("format must be markdown or json"), nil
+	}
+	timeout := defaultLiveTimeout
+	if params.TimeoutSeconds > 0 {
+		timeout = time.Duration(params.TimeoutSeconds) * time.Second
+	}
+
+	cfg, err := loadLiveConfig()
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to load config: %v", err)), nil
+	}
+	if params.App == "" {
+		if cfg.AppID == defaultConfig.AppID {
+			return mcp.NewToolResultError("app is required (no app_id in config.yaml)"), nil
+		}
+		params.App = cfg.AppID
+	}
+	client, err := s.newLiveClient(ctx, cfg, request.Params.Name)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	var reply struct {
+		Tables        []liveTable                   `json:"tables"`
+		Relationships map[string][]liveRelationship `json:"relationships"`
+	}
+	cached, err := client.callCached(ctx, timeout, map[string]any{"op": "relationships", "app": params.App}, &reply, params.Refresh)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Getting relationships in %s failed: %v", params.App, err)), nil
+	}
+
+	names := map[string]string{}
+	for _, t := range reply.Tables {
+		names[t.ID] = t.Name
+	}
+	var rels []liveRelationship
+	for _, id := range sortedKeys(reply.Relationships) {
+		rels = append(rels, reply.Relationships[id]...)
+	}
+	sort.SliceStable(rels, func(i, j int) bool {
+		a, b := rels[i], rels[j]
+		if names[a.ParentTableID] != names[b.ParentTableID] {
+			return names[a.ParentTableID] < names[b.ParentTableID]
+		}
+		return names[a.ChildTableID] < names[b.ChildTableID]
+	})
+
+	if params.Format == "json" {
+		data, _ := json.MarshalIndent(map[string]any{"app": params.App, "realm": client.realm, "tables": reply.Tables, "relationships": rels}, "", "  ")
+		out := string(data) + "\n"
+		if params.Diagram {
+			out += "\n" + relationshipDiagram(reply.Tables, rels)
+		}
+		return mcp.NewToolResultText(out), nil
+	}
+
+	table := func(id string) string {
+		if name := names[id]; name != "" {
+			return fmt.Sprintf("%s (`%s`)", strings.ReplaceAll(name, "|", `\|`), id)
+		}
+		return fmt.Sprintf("`%s` (another app)", id)
+	}
+	var results strings.Builder
+	results.WriteString(fmt.Sprintf("# Relationships in %s\n\n", params.App))
+	results.WriteString(cacheNote(cached))
+	results.WriteString(fmt.Sprintf("%d table(s) and %d relationship(s) on %s.\n\n", len(reply.Tables), len(rels), client.realm))
+	if len(rels) > 0 {
+		results.WriteString("Each child record points at one parent through its reference field. Lookups bring parent fields into the child; summaries total the children in the parent.\n\n")
+		results.WriteString("| Parent | Child | Reference field | Lookups (in child) | Summaries (in parent) |\n|---|---|---|---|---|\n")
+		for _, r := range rels {
+			results.WriteString(fmt.Sprintf("| %s | %s | %s (%d) | %s | %s |\n", table(r.ParentTableID), table(r.ChildTableID), strings.ReplaceAll(r.ForeignKeyField.Label, "|", `\|`), r.ForeignKeyField.ID, relFieldList(r.LookupFields), relFieldList(r.SummaryFields)))
+		}
+		results.WriteString("\n")
+	}
+	related := map[string]bool{}
+	for _, r := range rels {
+		related[r.ParentTableID], related[r.ChildTableID] = true, true
+	}
+	var alone []string
+	for _, t := range reply.Tables {
+		if !related[t.ID] {
+			alone = append(alone, table(t.ID))
+		}
+	}
+	if len(alone) > 0 {
+		results.WriteString(fmt.Sprintf("Not related to any other table: %s\n\n", strings.Join(alone, ", ")))
+	}
+	if params.Diagram {
+		results.WriteString(relationshipDiagram(reply.Tables, rels))
+	}
+	return mcp.NewToolResultText(strings.TrimRight(results.String(), "\n") + "\n"), nil
+}
@@ -0,0 +1,125 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// liveReport is a page of a report run: its columns, rows keyed by field
+// ID, and where the page sits in the whole report.
+type liveReport struct {
+	Fields []struct {
+		ID    int    `json:"id"`
+		Label string `json:"label"`
+		Type  string `json:"type"`
+	} `json:"fields"`
+	Data []map[string]struct {
+		Value any `json:"value"`
+	} `json:"data"`
+	Metadata struct {
+		NumRecords   int `json:"numRecords"`
+		Skip         int `json:"skip"`
+		TotalRecords int `json:"totalRecords"`
+	} `json:"metadata"`
+}
+
+// reportCell renders a value for a markdown table cell.
+func reportCell(v any) string {
+	var text string
+	switch v := v.(type) {
+	case nil:
+		return ""
+	case string:
+		text = v
+	case float64:
+		text = strconv.FormatFloat(v, 'f', -1, 64)
+	default:
+		// Users, multi-selects and the like
+		data, _ := json.Marshal(v)
+		text = string(data)
+	}
+	text = strings.ReplaceAll(strings.Join(strings.Fields(text), " "), "|", `\|`)
+	if runes := []rune(text); len(runes) > 60 {
+		text = string(runes[:57]) + "..."
+	}
+	return text
+}
+
+func (s *QuickBasePersonalMCPServer) handleQBRunReport(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	var params struct {
+		Report         string `json:"report"`
+		Table          string `json:"table"`
+		Skip           int    `json:"skip"`
+		Limit          int    `json:"limit"`
+		TimeoutSeconds int    `json:"timeout_seconds"`
+	}
+	argsData, _ := json.Marshal(request.Params.Arguments)
+	if err := json.Unmarshal(argsData, &params); err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Invalid parameters: %v", err)), nil
+	}
+	if params.Report == "" {
+		return mcp.NewToolResultError("report is required (the report ID, shown in its URL as qid)"), nil
+	}
+	timeout := defaultLiveTimeout
+	if params.TimeoutSeconds > 0 {
+		timeout = time.Duration(params.TimeoutSeconds) * time.Second
+	}
+	if params.Limit <= 0 {
+		params.Limit = 100
+	}
+
+	cfg, err := loadLiveConfig()
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to load config: %v", err)), nil
+	}
+	if params.Table == "" {
+		if cfg.TableID == defaultConfig.TableID {
+			return mcp.NewToolResultError("table is required (no table_id in config.yaml)"), nil
+		}
+		params.Table = cfg.TableID
+	}
+	client, err := s.newLiveClient(ctx, cfg, request.Params.Name)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	var reply struct {
+		Report liveReport `json:"report"`
+	}
+	req := map[string]any{"op": "run_report", "report": params.Report, "table": params.Table, "skip": params.Skip, "limit": params.Limit}
+	if err := client.call(ctx, timeout, req, &reply); err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Running report %s failed: %v", params.Report, err)), nil
+	}
+	report := reply.Report
+
+	var results strings.Builder
+	results.WriteString(fmt.Sprintf("# Report %s in %s\n\n", params.Report, params.Table))
+	if len(report.Data) == 0 {
+		results.WriteString(fmt.Sprintf("No rows (skip %d, %d in the report).\n", params.Skip, report.Metadata.TotalRecords))
+		return mcp.NewToolResultText(results.String()), nil
+	}
+	results.WriteString(fmt.Sprintf("Rows %d–%d of %d on %s, with the report's own filter, sort and columns.\n\n", params.Skip+1, params.Skip+len(report.Data), report.Metadata.TotalRecords, client.realm))
+
+	var header, rule []string
+	for _, f := range report.Fields {
+		header = append(header, fmt.Sprintf("%s (%d)", strings.ReplaceAll(f.Label, "|", `\|`), f.ID))
+		rule = append(rule, "---")
+	}
+	results.WriteString("| " + strings.Join(header, " | ") + " |\n|" + strings.Join(rule, "|") + "|\n")
+	for _, row := range report.Data {
+		cells := make([]string, len(report.Fields))
+		for i, f := range report.Fields {
+			cells[i] = reportCell(row[strconv.Itoa(f.ID)].Value)
+		}
+		results.WriteString("| " + strings.Join(cells, " | ") + " |\n")
+	}
+	if next := params.Skip + len(report.Data); next < report.Metadata.TotalRecords {
+		results.WriteString(fmt.Sprintf("\nMore rows: call again with skip %d.\n", next))
+	}
+	return mcp.NewToolResultText(results.String()), nil
+}
@@ -0,0 +1,429 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"gopkg.in/yaml.v3"
+)
+
+// resetTable is one canonical table's part of a sandbox reset: which live
+// fields its fields are, what has to change, and the records it's seeded
+// with.
+type resetTable struct {
+	Table  schemaTable
+	Live   bool
+	Fields map[int]int // canonical field ID to live field ID
+	Create []schemaField
+	Delete []liveField
+	Drift  []string // differences a reset leaves alone
+	Seed   []map[string]any
+	Seeded bool
+}
+
+// loadCanonicalSchema reads the app's export from qb_export_schema, YAML
+// or JSON, as the schema a reset restores.
+func loadCanonicalSchema(dir, app string) (schemaExport, string, error) {
+	var export schemaExport
+	for _, format := range []string{"yaml", "json"} {
+		path := filepath.Join(dir, app+"."+format)
+		data, err := os.ReadFile(path)
+		if err != nil {
+			continue
+		}
+		if format == "json" {
+			err = json.Unmarshal(data, &export)
+		} else {
+			err = yaml.Unmarshal(data, &export)
+		}
+		if err != nil {
+			return export, path, fmt.Errorf("%s: %w", path, err)
+		}
+		return export, path, nil
+	}
+	return export, "", fmt.Errorf("no canonical schema for %s in %s; run qb_export_schema while the sandbox is as it should be", app, dir)
+}
+
+// loadSeed reads the seed dataset: table IDs to records keyed by field ID,
+// in the canonical schema's IDs. A missing file is no seed at all.
+func loadSeed(path string) (map[string][]map[string]any, error) {
+	seed := map[string][]map[string]any{}
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return seed, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := yaml.Unmarshal(data, &seed); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return seed, nil
+}
+
+// planReset matches the canonical schema against the sandbox's fields.
+// A field matches by ID, or else by label and type: a field deleted and
+// recreated comes back under a new ID, and Quickbase never reuses one.
+// Built-in fields (1 to 5) are never created or deleted.
+func planReset(export schemaExport, live map[string][]liveField, seed map[string][]map[string]any) []*resetTable {
+	var plan []*resetTable
+	for _, t := range export.Tables {
+		rt := &resetTable{Table: t, Fields: map[int]int{}}
+		rt.Seed, rt.Seeded = seed[t.ID]
+		fields, ok := live[t.ID]
+		rt.Live = ok
+		plan = append(plan, rt)
+		if !ok {
+			continue
+		}
+		byID := map[int]liveField{}
+		for _, f := range fields {
+			byID[f.ID] = f
+		}
+		canonical := map[int]bool{}
+		for _, f := range t.Fields {
+			canonical[f.ID] = true
+		}
+		used := map[int]bool{}
+		var unmatched []schemaField
+		for _, f := range t.Fields {
+			lf, ok := byID[f.ID]
+			if !ok {
+				unmatched = append(unmatched, f)
+				continue
+			}
+			rt.Fields[f.ID], used[lf.ID] = lf.ID, true
+			if lf.Label != f.Label || lf.FieldType != f.FieldType {
+				rt.Drift = append(rt.Drift, fmt.Sprintf("field %d is %s (%s), not %s (%s)", f.ID, lf.Label, lf.FieldType, f.Label, f.FieldType))
+			}
+		}
+		for _, f := range unmatched {
+			var match *liveField
+			for i, lf := range fields {
+				if !used[lf.ID] && !canonical[lf.ID] && lf.Label == f.Label && lf.FieldType == f.FieldType {
+					match = &fields[i]
+					break
+				}
+			}
+			switch {
+			case match != nil:
+				rt.Fields[f.ID], used[match.ID] = match.ID, true
+			case f.Mode == "lookup" || f.Mode == "summary":
+				// These belong to a relationship and can't be made alone
+				rt.Drift = append(rt.Drift, fmt.Sprintf("%s (%d) is a missing %s field; recreate it through its relationship", f.Label, f.ID, f.Mode))
+			default:
+				rt.Create = append(rt.Create, f)
+			}
+		}
+		for _, lf := range fields {
+			if !used[lf.ID] && lf.ID > 5 {
+				rt.Delete = append(rt.Delete, lf)
+			}
+		}
+	}
+	return plan
+}
+
+// checkSeed checks every seeded field is in the canonical table.
+func (rt *resetTable) checkSeed() error {
+	ids := map[string]bool{}
+	for _, f := range rt.Table.Fields {
+		ids[strconv.Itoa(f.ID)] = true
+	}
+	for i, record := range rt.Seed {
+		for key := range record {
+			if !ids[key] {
+				return fmt.Errorf("seed record %d for %s: field %s isn't in the canonical schema", i+1, rt.Table.ID, key)
+			}
+		}
+	}
+	return nil
+}
+
+// seedRecords is the seed with its fields moved to their live IDs, in the
+// REST API's shape.
+func (rt *resetTable) seedRecords() ([]map[string]any, error) {
+	records := make([]map[string]any, 0, len(rt.Seed))
+	for i, record := range rt.Seed {
+		row := map[string]any{}
+		for key, value := range record {
+			id, _ := strconv.Atoi(key)
+			live, ok := rt.Fields[id]
+			if !ok {
+				return nil, fmt.Errorf("seed record %d for %s: field %s isn't in the sandbox", i+1, rt.Table.ID, key)
+			}
+			row[strconv.Itoa(live)] = value
+		}
+		records = append(records, row)
+	}
+	return upsertRecords(records)
+}
+
+// fieldNames lists fields as "Label (id)".
+func fieldNames(fields []liveField) string {
+	parts := make([]string, len(fields))
+	for i, f := range fields {
+		parts[i] = fmt.Sprintf("%s (%d)", f.Label, f.ID)
+	}
+	return strings.Join(parts, ", ")
+}
+
+func (s *QuickBasePersonalMCPServer) handleResetSandbox(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	var params struct {
+		DryRun         *bool `json:"dry_run"`
+		Confirm        bool  `json:"confirm"`
+		TimeoutSeconds int   `json:"timeout_seconds"`
+	}
+	argsData, _ := json.Marshal(request.Params.Arguments)
+	if err := json.Unmarshal(argsData, &params); err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Invalid parameters: %v", err)), nil
+	}
+	timeout := defaultLiveTimeout
+	if params.TimeoutSeconds > 0 {
+		timeout = time.Duration(params.TimeoutSeconds) * time.Second
+	}
+	// The same two switches as qb_upsert_records
+	dryRun := params.DryRun == nil || *params.DryRun
+	if !dryRun && !params.Confirm {
+		return mcp.NewToolResultError("Resetting needs confirm: true as well as dry_run: false. Do a dry run first and check the plan."), nil
+	}
+
+	cfg, err := loadLiveConfig()
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to load config: %v", err)), nil
+	}
+	// Only ever the sandbox: a reset deletes fields and every record
+	if !cfg.Sandbox {
+		return mcp.NewToolResultError(fmt.Sprintf("reset_sandbox only runs in sandbox mode; set sandbox: true, sandbox_realm and sandbox_app_id in %s", cfg.source)), nil
+	}
+	dir := schemaDir(cfg)
+	export, schemaPath, err := loadCanonicalSchema(dir, cfg.AppID)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	seedPath := filepath.Join(dir, cfg.AppID+".seed.yaml")
+	seed, err := loadSeed(seedPath)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to read the seed: %v", err)), nil
+	}
+	client, err := s.newLiveClient(ctx, cfg, request.Params.Name)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	// The sandbox as it is now, fresh: the cache could predate the damage
+	var tables struct {
+		Tables []liveTable `json:"tables"`
+	}
+	if _, err := client.callCached(ctx, timeout, map[string]any{"op": "list_tables", "app": cfg.AppID}, &tables, true); err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Listing the tables of %s failed: %v", cfg.AppID, err)), nil
+	}
+	canonical := map[string]bool{}
+	for _, t := range export.Tables {
+		canonical[t.ID] = true
+	}
+	live := map[string][]liveField{}
+	var extra []string
+	for _, t := range tables.Tables {
+		if !canonical[t.ID] {
+			extra = append(extra, fmt.Sprintf("%s (`%s`)", t.Name, t.ID))
+			continue
+		}
+		var reply struct {
+			Fields []liveField `json:"fields"`
+		}
+		if _, err := client.callCached(ctx, timeout, map[string]any{"op": "get_fields", "table": t.ID}, &reply, true); err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("Getting fields of %s failed: %v", t.ID, err)), nil
+		}
+		live[t.ID] = reply.Fields
+	}
+	for id := range seed {
+		if !canonical[id] {
+			return mcp.NewToolResultError(fmt.Sprintf("%s seeds table %s, which isn't in the canonical schema", seedPath, id)), nil
+		}
+	}
+	plan := planReset(export, live, seed)
+	for _, rt := range plan {
+		if err := rt.checkSeed(); err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("%s: %v", seedPath, err)), nil
+		}
+	}
+
+	var results strings.Builder
+	if dryRun {
+		results.WriteString("# reset_sandbox: dry run\n\n")
+	} else {
+		results.WriteString("# reset_sandbox\n\n")
+	}
+	seedNote := fmt.Sprintf("seed `%s`", seedPath)
+	if len(seed) == 0 {
+		seedNote = fmt.Sprintf("no seed (`%s` doesn't exist, so records are left alone)", seedPath)
+	}
+	results.WriteString(fmt.Sprintf("%s (`%s`) on %s, against the canonical schema `%s` and %s.\n\n", export.App.Name, cfg.AppID, client.realm, schemaPath, seedNote))
+
+	results.WriteString("## Schema\n\n")
+	for _, rt := range plan {
+		name := fmt.Sprintf("%s (`%s`)", rt.Table.Name, rt.Table.ID)
+		switch {
+		case !rt.Live:
+			results.WriteString(fmt.Sprintf("- ❌ %s is gone. A recreated table gets a new ID, so recreate it by hand and export the schema again.\n", name))
+			continue
+		case len(rt.Create) == 0 && len(rt.Delete) == 0:
+			results.WriteString(fmt.Sprintf("- ✅ %s matches\n", name))
+		default:
+			results.WriteString(fmt.Sprintf("- ⚠️ %s\n", name))
+		}
+		if len(rt.Create) > 0 {
+			var names []string
+			for _, f := range rt.Create {
+				names = append(names, fmt.Sprintf("%s (%s, was %d)", f.Label, f.FieldType, f.ID))
+			}
+			results.WriteString(fmt.Sprintf("  - create %s\n", strings.Join(names, ", ")))
+		}
+		if len(rt.Delete) > 0 {
+			results.WriteString(fmt.Sprintf("  - delete %s\n", fieldNames(rt.Delete)))
+		}
+		for _, d := range rt.Drift {
+			results.WriteString(fmt.Sprintf("  - not reset: %s\n", d))
+		}
+	}
+	if len(extra) > 0 {
+		results.WriteString(fmt.Sprintf("- Not in the canonical schema, left alone: %s\n", strings.Join(extra, ", ")))
+	}
+	results.WriteString("\n")
+
+	if dryRun {
+		if len(seed) > 0 {
+			results.WriteString("## Data\n\n")
+		}
+		for _, rt := range plan {
+			if !rt.Seeded || !rt.Live {
+				continue
+			}
+			var matched struct {
+				IDs []any `json:"ids"`
+			}
+			req := map[string]any{"op": "delete", "table": rt.Table.ID, "where": "{3.GT.0}", "dry_run": true}
+			if err := client.call(ctx, timeout, req, &matched); err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("Counting the records in %s failed: %v", rt.Table.ID, err)), nil
+			}
+			results.WriteString(fmt.Sprintf("- %s (`%s`): delete all %d record(s), then add %d from the seed\n", rt.Table.Name, rt.Table.ID, len(matched.IDs), len(rt.Seed)))
+		}
+		if len(seed) > 0 {
+			results.WriteString("\n")
+		}
+		results.WriteString("Nothing was changed. To reset, call again with dry_run: false and confirm: true.\n")
+		return mcp.NewToolResultText(results.String()), nil
+	}
+
+	results.WriteString("## Done\n\n")
+	seeded, err := applyReset(ctx, client, timeout, cfg.AppID, plan, &results)
+	if err != nil {
+		// Half a reset is still a failure, whatever it got done
+		results.WriteString(fmt.Sprintf("\n❌ %v\n\nThe reset stopped here. Fix the cause and run it again; fields it already recreated are matched by label.\n", err))
+		return mcp.NewToolResultError(results.String()), nil
+	}
+	results.WriteString(fmt.Sprintf("\n✅ The sandbox is reset (%d table(s) reseeded).", seeded))
+	for _, rt := range plan {
+		if len(rt.Create) > 0 {
+			// The canonical IDs stay; fields are matched by label from now on
+			results.WriteString(" Recreated fields have new IDs; the seed still uses the canonical ones, matched by label.")
+			break
+		}
+	}
+	results.WriteString("\n")
+	return mcp.NewToolResultText(results.String()), nil
+}
+
+// applyReset carries out a reset plan, noting each step in results, and
+// returns how many tables it reseeded. It stops at the first failure.
+// Schema goes first, so the seed's fields exist before it goes in, and in
+// each table extra fields are deleted before missing ones are created: a
+// field whose type changed keeps its label, and labels are unique.
+func applyReset(ctx context.Context, client *liveClient, timeout time.Duration, app string, plan []*resetTable, results *strings.Builder) (int, error) {
+	for _, rt := range plan {
+		if !rt.Live {
+			continue
+		}
+		if len(rt.Delete) > 0 {
+			ids := make([]int, len(rt.Delete))
+			for i, f := range rt.Delete {
+				ids[i] = f.ID
+			}
+			var reply json.RawMessage
+			req := map[string]any{"op": "delete_fields", "table": rt.Table.ID, "field_ids": ids}
+			if err := client.call(ctx, timeout, req, &reply); err != nil {
+				return 0, fmt.Errorf("deleting fields from %s failed: %w", rt.Table.ID, err)
+			}
+			results.WriteString(fmt.Sprintf("- Deleted %s from %s\n", fieldNames(rt.Delete), rt.Table.ID))
+		}
+		for _, f := range rt.Create {
+			payload, _ := json.Marshal(map[string]any{"label": f.Label, "fieldType": f.FieldType, "fieldHelp": f.FieldHelp, "properties": f.Properties})
+			var reply struct {
+				Field struct {
+					ID int `json:"id"`
+				} `json:"field"`
+			}
+			req := map[string]any{"op": "create_field", "table": rt.Table.ID, "payload": json.RawMessage(payload)}
+			if err := client.call(ctx, timeout, req, &reply); err != nil {
+				return 0, fmt.Errorf("creating %s in %s failed: %w", f.Label, rt.Table.ID, err)
+			}
+			rt.Fields[f.ID] = reply.Field.ID
+			results.WriteString(fmt.Sprintf("- Created %s in %s as field %d\n", f.Label, rt.Table.ID, reply.Field.ID))
+		}
+		if len(rt.Create) > 0 || len(rt.Delete) > 0 {
+			// Fields, relationships and the app's tables are all cached
+			if err := client.invalidateCache(rt.Table.ID, app); err != nil {
+				results.WriteString(fmt.Sprintf("- ⚠️ Couldn't clear the cached schema of %s (%v); pass refresh: true to the qb_* tools for the next 15 minutes\n", rt.Table.ID, err))
+			}
+		}
+	}
+	seeded := 0
+	for _, rt := range plan {
+		if !rt.Live || !rt.Seeded {
+			continue
+		}
+		var deleted struct {
+			Result struct {
+				NumberDeleted int `json:"numberDeleted"`
+			} `json:"result"`
+		}
+		req := map[string]any{"op": "delete", "table": rt.Table.ID, "where": "{3.GT.0}"}
+		if err := client.call(ctx, timeout, req, &deleted); err != nil {
+			return seeded, fmt.Errorf("deleting the records in %s failed: %w", rt.Table.ID, err)
+		}
+		added := 0
+		if len(rt.Seed) > 0 {
+			data, err := rt.seedRecords()
+			if err != nil {
+				return seeded, err
+			}
+			payload, _ := json.Marshal(map[string]any{"to": rt.Table.ID, "data": data})
+			var reply struct {
+				Result upsertResult `json:"result"`
+			}
+			if err := client.call(ctx, timeout, upsertRequest(rt.Table.ID, payload, false), &reply); err != nil {
+				return seeded, fmt.Errorf("seeding %s failed: %w", rt.Table.ID, err)
+			}
+			m := reply.Result.Metadata
+			if len(m.LineErrors) > 0 {
+				var errs []string
+				for _, line := range sortedKeys(m.LineErrors) {
+					errs = append(errs, fmt.Sprintf("record %s: %s", line, strings.Join(m.LineErrors[line], "; ")))
+				}
+				return seeded, fmt.Errorf("Quickbase rejected seed records for %s: %s", rt.Table.ID, strings.Join(errs, "; "))
+			}
+			added = len(m.CreatedRecordIDs)
+		}
+		seeded++
+		results.WriteString(fmt.Sprintf("- %s (`%s`): deleted %d record(s), added %d from the seed\n", rt.Table.Name, rt.Table.ID, deleted.Result.NumberDeleted, added))
+	}
+	return seeded, nil
+}
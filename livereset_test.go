@@ -0,0 +1,72 @@
+package main
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// fakeDriver is a live driver script that logs each request's op and
+// answers create_field with field 20.
+func fakeDriver(t *testing.T) (driver, log string) {
+	dir := t.TempDir()
+	driver, log = filepath.Join(dir, "driver"), filepath.Join(dir, "requests.log")
+	script := `#!/bin/sh
+case "$QB_LIVE_REQUEST" in
+*'"op":"create_field"'*) echo create_field >>requests.log; echo '{"field":{"id":20},"requests":1}' ;;
+*'"op":"delete_fields"'*) echo delete_fields >>requests.log; echo '{"requests":1}' ;;
+*) echo '{"error":"unexpected request","type":"test"}' ;;
+esac
+`
+	if err := os.WriteFile(driver, []byte(script), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	return driver, log
+}
+
+func TestResetDeletesRetypedFieldBeforeCreatingIt(t *testing.T) {
+	export := schemaExport{Tables: []schemaTable{{ID: "bqsandtbl", Name: "Tasks", Fields: []schemaField{
+		{ID: 3, Label: "Record ID#", FieldType: "recordid"},
+		{ID: 6, Label: "Status", FieldType: "text"},
+	}}}}
+	live := map[string][]liveField{"bqsandtbl": {
+		{ID: 3, Label: "Record ID#", FieldType: "recordid"},
+		{ID: 7, Label: "Status", FieldType: "numeric"},
+	}}
+	plan := planReset(export, live, nil)
+	if rt := plan[0]; len(rt.Create) != 1 || rt.Create[0].ID != 6 || len(rt.Delete) != 1 || rt.Delete[0].ID != 7 {
+		t.Fatalf("plan creates %v and deletes %v, want to replace field 7 with field 6", rt.Create, rt.Delete)
+	}
+
+	s, client := sandboxClient(t)
+	driver, log := fakeDriver(t)
+	client.driver = driver
+	s.store.put(liveCacheBucket, client.realm+" get_fields bqsandtbl", liveCacheEntry{Stored: time.Now()})
+	s.store.put(liveCacheBucket, client.realm+" get_relationships bqsandbox", liveCacheEntry{Stored: time.Now()})
+
+	var results strings.Builder
+	if _, err := applyReset(context.Background(), client, 10*time.Second, "bqsandbox", plan, &results); err != nil {
+		t.Fatalf("applyReset: %v\n%s", err, results.String())
+	}
+	data, err := os.ReadFile(log)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ops := strings.Fields(string(data)); strings.Join(ops, " ") != "delete_fields create_field" {
+		t.Errorf("driver got %v, want delete_fields then create_field", ops)
+	}
+	if got := plan[0].Fields[6]; got != 20 {
+		t.Errorf("field 6 maps to live field %d, want 20", got)
+	}
+	var cached []string
+	s.store.each(liveCacheBucket, func(key string, data []byte) error {
+		cached = append(cached, key)
+		return nil
+	})
+	if len(cached) != 0 {
+		t.Errorf("cache still holds %v after the schema changed", cached)
+	}
+}
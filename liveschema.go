@@ -0,0 +1,271 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"gopkg.in/yaml.v3"
+)
+
+// schemaExport is an app's schema as qb_export_schema writes it. Only what
+// describes the schema is kept: record counts, next IDs and dates change
+// with the data and would make every export a diff.
+type schemaExport struct {
+	App    schemaApp     `json:"app" yaml:"app"`
+	Tables []schemaTable `json:"tables" yaml:"tables"`
+}
+
+type schemaApp struct {
+	ID          string `json:"id" yaml:"id"`
+	Name        string `json:"name" yaml:"name"`
+	Description string `json:"description,omitempty" yaml:"description,omitempty"`
+	DateFormat  string `json:"dateFormat,omitempty" yaml:"dateFormat,omitempty"`
+	TimeZone    string `json:"timeZone,omitempty" yaml:"timeZone,omitempty"`
+	Variables   []struct {
+		Name  string `json:"name" yaml:"name"`
+		Value string `json:"value" yaml:"value"`
+	} `json:"variables,omitempty" yaml:"variables,omitempty"`
+}
+
+type schemaTable struct {
+	ID               string               `json:"id" yaml:"id"`
+	Name             string               `json:"name" yaml:"name"`
+	Alias            string               `json:"alias,omitempty" yaml:"alias,omitempty"`
+	Description      string               `json:"description,omitempty" yaml:"description,omitempty"`
+	SingleRecordName string               `json:"singleRecordName,omitempty" yaml:"singleRecordName,omitempty"`
+	PluralRecordName string               `json:"pluralRecordName,omitempty" yaml:"pluralRecordName,omitempty"`
+	KeyFieldID       int                  `json:"keyFieldId" yaml:"keyFieldId"`
+	Fields           []schemaField        `json:"fields" yaml:"fields"`
+	Relationships    []schemaRelationship `json:"relationships,omitempty" yaml:"relationships,omitempty"`
+	Reports          []schemaReport       `json:"reports,omitempty" yaml:"reports,omitempty"`
+}
+
+type schemaField struct {
+	ID         int            `json:"id" yaml:"id"`
+	Label      string         `json:"label" yaml:"label"`
+	FieldType  string         `json:"fieldType" yaml:"fieldType"`
+	Mode       string         `json:"mode,omitempty" yaml:"mode,omitempty"`
+	Required   bool           `json:"required,omitempty" yaml:"required,omitempty"`
+	Unique     bool           `json:"unique,omitempty" yaml:"unique,omitempty"`
+	FieldHelp  string         `json:"fieldHelp,omitempty" yaml:"fieldHelp,omitempty"`
+	Properties map[string]any `json:"properties,omitempty" yaml:"properties,omitempty"`
+}
+
+// schemaRelationship is a relationship the table is the child in.
+type schemaRelationship struct {
+	ParentTableID   string `json:"parentTableId" yaml:"parentTableId"`
+	ForeignKeyField int    `json:"foreignKeyField" yaml:"foreignKeyField"`
+	IsCrossApp      bool   `json:"isCrossApp,omitempty" yaml:"isCrossApp,omitempty"`
+	LookupFields    []int  `json:"lookupFields,omitempty" yaml:"lookupFields,omitempty"`
+	SummaryFields   []int  `json:"summaryFields,omitempty" yaml:"summaryFields,omitempty"`
+}
+
+type schemaReport struct {
+	ID          string         `json:"id" yaml:"id"`
+	Name        string         `json:"name" yaml:"name"`
+	Type        string         `json:"type" yaml:"type"`
+	Description string         `json:"description,omitempty" yaml:"description,omitempty"`
+	Query       map[string]any `json:"query,omitempty" yaml:"query,omitempty"`
+}
+
+// relFieldIDs is the IDs of fields, in order.
+func relFieldIDs(fields []liveRelField) []int {
+	var ids []int
+	for _, f := range fields {
+		ids = append(ids, f.ID)
+	}
+	sort.Ints(ids)
+	return ids
+}
+
+// numericLess orders IDs numerically when both are numbers, so report 10
+// follows report 9.
+func numericLess(a, b string) bool {
+	x, errA := strconv.Atoi(a)
+	y, errB := strconv.Atoi(b)
+	if errA == nil && errB == nil {
+		return x < y
+	}
+	return a < b
+}
+
+// normalizeSchema builds the export from the driver's reply, everything
+// sorted by ID so unchanged parts of the schema export identically.
+func normalizeSchema(reply schemaReply) schemaExport {
+	export := schemaExport{App: reply.App}
+	sort.Slice(export.App.Variables, func(i, j int) bool { return export.App.Variables[i].Name < export.App.Variables[j].Name })
+	for _, t := range reply.Tables {
+		fields := reply.Fields[t.ID]
+		sort.Slice(fields, func(i, j int) bool { return fields[i].ID < fields[j].ID })
+		var rels []schemaRelationship
+		for _, r := range reply.Relationships[t.ID] {
+			rels = append(rels, schemaRelationship{
+				ParentTableID:   r.ParentTableID,
+				ForeignKeyField: r.ForeignKeyField.ID,
+				IsCrossApp:      r.IsCrossApp,
+				LookupFields:    relFieldIDs(r.LookupFields),
+				SummaryFields:   relFieldIDs(r.SummaryFields),
+			})
+		}
+		sort.Slice(rels, func(i, j int) bool { return rels[i].ForeignKeyField < rels[j].ForeignKeyField })
+		reports := reply.Reports[t.ID]
+		sort.Slice(reports, func(i, j int) bool { return numericLess(reports[i].ID, reports[j].ID) })
+		export.Tables = append(export.Tables, schemaTable{
+			ID:               t.ID,
+			Name:             t.Name,
+			Alias:            t.Alias,
+			Description:      t.Description,
+			SingleRecordName: t.SingleRecordName,
+			PluralRecordName: t.PluralRecordName,
+			KeyFieldID:       t.KeyFieldID,
+			Fields:           fields,
+			Relationships:    rels,
+			Reports:          reports,
+		})
+	}
+	sort.Slice(export.Tables, func(i, j int) bool { return export.Tables[i].ID < export.Tables[j].ID })
+	return export
+}
+
+// schemaDir is where app schemas are exported: schema_dir, by default
+// schemas in the config directory.
+func schemaDir(cfg serverConfig) string {
+	dir := cfg.SchemaDir
+	if dir == "" {
+		dir = filepath.Join(configDir, "schemas")
+	}
+	if rest, ok := strings.CutPrefix(dir, "~/"); ok {
+		dir = filepath.Join(os.Getenv("HOME"), rest)
+	}
+	return dir
+}
+
+// schemaReply is the driver's reply to a schema op, per table ID.
+type schemaReply struct {
+	App    schemaApp `json:"app"`
+	Tables []struct {
+		ID               string `json:"id"`
+		Name             string `json:"name"`
+		Alias            string `json:"alias"`
+		Description      string `json:"description"`
+		SingleRecordName string `json:"singleRecordName"`
+		PluralRecordName string `json:"pluralRecordName"`
+		KeyFieldID       int    `json:"keyFieldId"`
+	} `json:"tables"`
+	Fields        map[string][]schemaField      `json:"fields"`
+	Relationships map[string][]liveRelationship `json:"relationships"`
+	Reports       map[string][]schemaReport     `json:"reports"`
+}
+
+func (s *QuickBasePersonalMCPServer) handleQBExportSchema(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	var params struct {
+		App            string `json:"app"`
+		Format         string `json:"format"`
+		TimeoutSeconds int    `json:"timeout_seconds"`
+	}
+	argsData, _ := json.Marshal(request.Params.Arguments)
+	if err := json.Unmarshal(argsData, &params); err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Invalid parameters: %v", err)), nil
+	}
+	if params.Format == "" {
+		params.Format = "yaml"
+	}
+	if params.Format != "yaml" && params.Format != "json" {
+		return mcp.NewToolResultError("format must be yaml or json"), nil
+	}
+	// Three calls per table add up in a big app
+	timeout := 2 * defaultLiveTimeout
+	if params.TimeoutSeconds > 0 {
+		timeout = time.Duration(params.TimeoutSeconds) * time.Second
+	}
+
+	cfg, err := loadLiveConfig()
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to load config: %v", err)), nil
+	}
+	if params.App == "" {
+		if cfg.AppID == defaultConfig.AppID {
+			return mcp.NewToolResultError("app is required (no app_id in config.yaml)"), nil
+		}
+		params.App = cfg.AppID
+	}
+	dir := schemaDir(cfg)
+	client, err := s.newLiveClient(ctx, cfg, request.Params.Name)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	var reply schemaReply
+	start := time.Now()
+	if err := client.call(ctx, timeout, map[string]any{"op": "schema", "app": params.App}, &reply); err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Reading the schema of %s failed: %v", params.App, err)), nil
+	}
+	export := normalizeSchema(reply)
+
+	var data []byte
+	if params.Format == "json" {
+		data, _ = json.MarshalIndent(export, "", "  ")
+		data = append(data, '\n')
+	} else {
+		var buf bytes.Buffer
+		enc := yaml.NewEncoder(&buf)
+		enc.SetIndent(2)
+		if err := enc.Encode(export); err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("Failed to encode the schema: %v", err)), nil
+		}
+		enc.Close()
+		data = buf.Bytes()
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to create %s: %v", dir, err)), nil
+	}
+	// Named by app ID alone, so renaming the app doesn't start a new file
+	path := filepath.Join(dir, params.App+"."+params.Format)
+	old, readErr := os.ReadFile(path)
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to write %s: %v", path, err)), nil
+	}
+
+	fields, rels, reports := 0, 0, 0
+	for _, t := range export.Tables {
+		fields += len(t.Fields)
+		rels += len(t.Relationships)
+		reports += len(t.Reports)
+	}
+	var results strings.Builder
+	results.WriteString(fmt.Sprintf("# qb_export_schema: %s\n\n", export.App.Name))
+	results.WriteString(fmt.Sprintf("Exported %s (`%s`) from %s in %s: %d table(s), %d field(s), %d relationship(s), %d report(s).\n\n", export.App.Name, params.App, client.realm, time.Since(start).Round(time.Millisecond), len(export.Tables), fields, rels, reports))
+	results.WriteString(fmt.Sprintf("Written to `%s`.\n\n", path))
+	switch {
+	case readErr != nil:
+		results.WriteString("➖ First export of this app.\n")
+	case bytes.Equal(old, data):
+		results.WriteString("✅ Unchanged since the last export.\n")
+	default:
+		diff, err := unifiedDiff(string(old), string(data), "previous export", "this export")
+		if err != nil {
+			results.WriteString(fmt.Sprintf("⚠️ Changed since the last export (diff failed: %v).\n", err))
+			break
+		}
+		text, more := truncateLines(diff, 80)
+		results.WriteString(fmt.Sprintf("⚠️ Changed since the last export:\n\n```diff\n%s\n```\n", text))
+		if more > 0 {
+			results.WriteString(fmt.Sprintf("… %d more diff line(s)\n", more))
+		}
+	}
+	if top, err := runGit(dir, "rev-parse", "--show-toplevel"); err == nil {
+		rel, _ := filepath.Rel(strings.TrimSpace(top), path)
+		results.WriteString(fmt.Sprintf("\n`%s` is in the git repo at `%s`; commit it to keep the history.\n", rel, strings.TrimSpace(top)))
+	} else {
+		results.WriteString(fmt.Sprintf("\n`%s` isn't a git repo. Run `git init` there, or set schema_dir in config.yaml to one, to diff schemas over time.\n", dir))
+	}
+	return mcp.NewToolResultText(results.String()), nil
+}
@@ -0,0 +1,163 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// liveTable is a table as getAppTables returns it. JSON field names match
+// case-insensitively, so the SDK's types decode whether or not they carry
+// the API's json tags.
+type liveTable struct {
+	ID               string `json:"id"`
+	Name             string `json:"name"`
+	Alias            string `json:"alias"`
+	KeyFieldID       int    `json:"keyFieldId"`
+	PluralRecordName string `json:"pluralRecordName"`
+}
+
+// liveField is a field as getFields returns it.
+type liveField struct {
+	ID        int    `json:"id"`
+	Label     string `json:"label"`
+	FieldType string `json:"fieldType"`
+	Mode      string `json:"mode"`
+	Required  bool   `json:"required"`
+	Unique    bool   `json:"unique"`
+}
+
+func (s *QuickBasePersonalMCPServer) handleQBListTables(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	var params struct {
+		App            string `json:"app"`
+		Refresh        bool   `json:"refresh"`
+		TimeoutSeconds int    `json:"timeout_seconds"`
+	}
+	argsData, _ := json.Marshal(request.Params.Arguments)
+	if err := json.Unmarshal(argsData, &params); err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Invalid parameters: %v", err)), nil
+	}
+	timeout := defaultLiveTimeout
+	if params.TimeoutSeconds > 0 {
+		timeout = time.Duration(params.TimeoutSeconds) * time.Second
+	}
+
+	cfg, err := loadLiveConfig()
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to load config: %v", err)), nil
+	}
+	if params.App == "" {
+		if cfg.AppID == defaultConfig.AppID {
+			return mcp.NewToolResultError("app is required (no app_id in config.yaml)"), nil
+		}
+		params.App = cfg.AppID
+	}
+	client, err := s.newLiveClient(ctx, cfg, request.Params.Name)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	var reply struct {
+		Tables []liveTable `json:"tables"`
+	}
+	cached, err := client.callCached(ctx, timeout, map[string]any{"op": "list_tables", "app": params.App}, &reply, params.Refresh)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Listing tables in %s failed: %v", params.App, err)), nil
+	}
+
+	var results strings.Builder
+	results.WriteString(fmt.Sprintf("# Tables in %s\n\n", params.App))
+	results.WriteString(cacheNote(cached))
+	if len(reply.Tables) == 0 {
+		results.WriteString("No tables.\n")
+		return mcp.NewToolResultText(results.String()), nil
+	}
+	results.WriteString("| Table | ID | Alias | Key field |\n|---|---|---|---|\n")
+	for _, t := range reply.Tables {
+		name := strings.ReplaceAll(t.Name, "|", `\|`)
+		if t.ID == cfg.TableID {
+			name += " (configured table_id)"
+		}
+		results.WriteString(fmt.Sprintf("| %s | `%s` | %s | %d |\n", name, t.ID, dash(t.Alias), t.KeyFieldID))
+	}
+	results.WriteString(fmt.Sprintf("\n%d table(s). Use qb_get_fields for a table's field IDs.\n", len(reply.Tables)))
+	return mcp.NewToolResultText(results.String()), nil
+}
+
+func (s *QuickBasePersonalMCPServer) handleQBGetFields(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	var params struct {
+		Table          string `json:"table"`
+		Filter         string `json:"filter"`
+		Refresh        bool   `json:"refresh"`
+		TimeoutSeconds int    `json:"timeout_seconds"`
+	}
+	argsData, _ := json.Marshal(request.Params.Arguments)
+	if err := json.Unmarshal(argsData, &params); err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Invalid parameters: %v", err)), nil
+	}
+	timeout := defaultLiveTimeout
+	if params.TimeoutSeconds > 0 {
+		timeout = time.Duration(params.TimeoutSeconds) * time.Second
+	}
+
+	cfg, err := loadLiveConfig()
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to load config: %v", err)), nil
+	}
+	if params.Table == "" {
+		if cfg.TableID == defaultConfig.TableID {
+			return mcp.NewToolResultError("table is required (no table_id in config.yaml)"), nil
+		}
+		params.Table = cfg.TableID
+	}
+	client, err := s.newLiveClient(ctx, cfg, request.Params.Name)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	var reply struct {
+		Fields []liveField `json:"fields"`
+	}
+	cached, err := client.callCached(ctx, timeout, map[string]any{"op": "get_fields", "table": params.Table}, &reply, params.Refresh)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Getting fields of %s failed: %v", params.Table, err)), nil
+	}
+
+	var results strings.Builder
+	results.WriteString(fmt.Sprintf("# Fields in %s\n\n", params.Table))
+	results.WriteString(cacheNote(cached))
+	shown := 0
+	for _, f := range reply.Fields {
+		if params.Filter != "" && !strings.Contains(strings.ToLower(f.Label), strings.ToLower(params.Filter)) {
+			continue
+		}
+		if shown == 0 {
+			results.WriteString("| ID | Label | Type | Required | Unique |\n|---|---|---|---|---|\n")
+		}
+		shown++
+		// Formula, lookup and summary fields can't be written
+		fieldType := f.FieldType
+		if f.Mode != "" {
+			fieldType += " (" + f.Mode + ")"
+		}
+		results.WriteString(fmt.Sprintf("| %d | %s | %s | %s | %s |\n", f.ID, strings.ReplaceAll(f.Label, "|", `\|`), fieldType, yesNo(f.Required), yesNo(f.Unique)))
+	}
+	switch {
+	case shown == 0 && params.Filter != "":
+		results.WriteString(fmt.Sprintf("No fields matching %q (of %d).\n", params.Filter, len(reply.Fields)))
+	case shown == 0:
+		results.WriteString("No fields.\n")
+	default:
+		results.WriteString(fmt.Sprintf("\n%d field(s).\n", shown))
+	}
+	return mcp.NewToolResultText(results.String()), nil
+}
+
+func yesNo(b bool) string {
+	if b {
+		return "yes"
+	}
+	return "no"
+}
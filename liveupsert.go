@@ -0,0 +1,186 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// upsertRecords puts records in the REST API's shape: keys are field IDs
+// and each value is wrapped as {"value": ...} unless it already is.
+func upsertRecords(records []map[string]any) ([]map[string]any, error) {
+	data := make([]map[string]any, 0, len(records))
+	for i, record := range records {
+		if len(record) == 0 {
+			return nil, fmt.Errorf("record %d is empty", i+1)
+		}
+		row := map[string]any{}
+		for key, value := range record {
+			if id, err := strconv.Atoi(key); err != nil || id <= 0 {
+				return nil, fmt.Errorf("record %d: %q is not a field ID (see qb_get_fields)", i+1, key)
+			}
+			if wrapped, ok := value.(map[string]any); ok && len(wrapped) == 1 && wrapped["value"] != nil {
+				row[key] = wrapped
+			} else {
+				row[key] = map[string]any{"value": value}
+			}
+		}
+		data = append(data, row)
+	}
+	return data, nil
+}
+
+// upsertRequest is the driver request for an upsert. The table is named
+// alongside the payload so the sandbox check and the audit log see it.
+func upsertRequest(table string, payload []byte, dryRun bool) map[string]any {
+	req := map[string]any{"op": "upsert", "table": table, "payload": json.RawMessage(payload)}
+	if dryRun {
+		req["dry_run"] = true
+	}
+	return req
+}
+
+// upsertResult is the metadata an upsert returns.
+type upsertResult struct {
+	Data     []map[string]any `json:"data"`
+	Metadata struct {
+		CreatedRecordIDs   []int               `json:"createdRecordIds"`
+		UpdatedRecordIDs   []int               `json:"updatedRecordIds"`
+		UnchangedRecordIDs []int               `json:"unchangedRecordIds"`
+		LineErrors         map[string][]string `json:"lineErrors"`
+		TotalProcessed     int                 `json:"totalNumberRecordsProcessed"`
+	} `json:"metadata"`
+}
+
+func (s *QuickBasePersonalMCPServer) handleQBUpsertRecords(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	var params struct {
+		Table          string           `json:"table"`
+		Records        []map[string]any `json:"records"`
+		MergeFieldID   int              `json:"merge_field_id"`
+		FieldsToReturn []int            `json:"fields_to_return"`
+		DryRun         *bool            `json:"dry_run"`
+		Confirm        bool             `json:"confirm"`
+		TimeoutSeconds int              `json:"timeout_seconds"`
+	}
+	argsData, _ := json.Marshal(request.Params.Arguments)
+	if err := json.Unmarshal(argsData, &params); err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Invalid parameters: %v", err)), nil
+	}
+	timeout := defaultLiveTimeout
+	if params.TimeoutSeconds > 0 {
+		timeout = time.Duration(params.TimeoutSeconds) * time.Second
+	}
+	// Writing takes both switches, so no single flag flipped by mistake
+	// changes data
+	dryRun := params.DryRun == nil || *params.DryRun
+	if !dryRun && !params.Confirm {
+		return mcp.NewToolResultError("Writing needs confirm: true as well as dry_run: false. Do a dry run first and check the payload."), nil
+	}
+	if len(params.Records) == 0 {
+		return mcp.NewToolResultError("records is required"), nil
+	}
+
+	cfg, err := loadLiveConfig()
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to load config: %v", err)), nil
+	}
+	if params.Table == "" {
+		if cfg.TableID == defaultConfig.TableID {
+			return mcp.NewToolResultError("table is required (no table_id in config.yaml)"), nil
+		}
+		params.Table = cfg.TableID
+	}
+	data, err := upsertRecords(params.Records)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	payload := map[string]any{"to": params.Table, "data": data}
+	if params.MergeFieldID > 0 {
+		payload["mergeFieldId"] = params.MergeFieldID
+	}
+	if len(params.FieldsToReturn) > 0 {
+		payload["fieldsToReturn"] = params.FieldsToReturn
+	}
+	payloadJSON, _ := json.Marshal(payload)
+
+	client, err := s.newLiveClient(ctx, cfg, request.Params.Name)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	var results strings.Builder
+	merge := "creating records, or updating those whose key field is set"
+	if params.MergeFieldID > 0 {
+		merge = fmt.Sprintf("merging on field %d", params.MergeFieldID)
+	}
+	if dryRun {
+		var reply struct {
+			Request json.RawMessage `json:"request"`
+		}
+		if err := client.call(ctx, timeout, upsertRequest(params.Table, payloadJSON, true), &reply); err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("The SDK couldn't take the payload: %v", err)), nil
+		}
+		results.WriteString(fmt.Sprintf("# qb_upsert_records: dry run\n\nWould upsert %d record(s) into %s on %s, %s.\n\n", len(data), params.Table, client.realm, merge))
+		pretty, _ := json.MarshalIndent(payload, "", "  ")
+		results.WriteString(fmt.Sprintf("Payload for POST /v1/records:\n\n```json\n%s\n```\n\n", pretty))
+		// The SDK's request type can drop or rename what it doesn't know
+		var sent, encoded any
+		json.Unmarshal(payloadJSON, &sent)
+		json.Unmarshal(reply.Request, &encoded)
+		if reflect.DeepEqual(sent, encoded) {
+			results.WriteString("✅ quickbase-go's UpsertRequest encodes it the same.\n\n")
+		} else {
+			pretty, _ := json.MarshalIndent(encoded, "", "  ")
+			results.WriteString(fmt.Sprintf("⚠️ quickbase-go's UpsertRequest encodes it differently, and that is what would be sent:\n\n```json\n%s\n```\n\n", pretty))
+		}
+		results.WriteString("Nothing was sent. To write, call again with dry_run: false and confirm: true.\n")
+		return mcp.NewToolResultText(results.String()), nil
+	}
+
+	var reply struct {
+		Result upsertResult `json:"result"`
+	}
+	if err := client.call(ctx, timeout, upsertRequest(params.Table, payloadJSON, false), &reply); err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Upsert failed: %v", err)), nil
+	}
+	m := reply.Result.Metadata
+	results.WriteString(fmt.Sprintf("# qb_upsert_records: %s\n\nUpserted on %s, %s.\n\n", params.Table, client.realm, merge))
+	results.WriteString(fmt.Sprintf("| | Records |\n|---|---|\n| Processed | %d |\n| Created | %s |\n| Updated | %s |\n| Unchanged | %s |\n\n", m.TotalProcessed, recordIDList(m.CreatedRecordIDs), recordIDList(m.UpdatedRecordIDs), recordIDList(m.UnchangedRecordIDs)))
+	if len(m.LineErrors) > 0 {
+		results.WriteString(fmt.Sprintf("❌ %d record(s) rejected:\n\n", len(m.LineErrors)))
+		lines := sortedKeys(m.LineErrors)
+		sort.Slice(lines, func(i, j int) bool {
+			a, _ := strconv.Atoi(lines[i])
+			b, _ := strconv.Atoi(lines[j])
+			return a < b
+		})
+		for _, line := range lines {
+			results.WriteString(fmt.Sprintf("- Record %s: %s\n", line, strings.Join(m.LineErrors[line], "; ")))
+		}
+		results.WriteString("\n")
+	}
+	if len(reply.Result.Data) > 0 {
+		pretty, _ := json.MarshalIndent(reply.Result.Data, "", "  ")
+		results.WriteString(fmt.Sprintf("Returned fields:\n\n```json\n%s\n```\n", pretty))
+	}
+	return mcp.NewToolResultText(strings.TrimRight(results.String(), "\n") + "\n"), nil
+}
+
+// recordIDList is a count and the IDs, or a dash.
+func recordIDList(ids []int) string {
+	if len(ids) == 0 {
+		return "—"
+	}
+	parts := make([]string, len(ids))
+	for i, id := range ids {
+		parts[i] = strconv.Itoa(id)
+	}
+	return fmt.Sprintf("%d: %s", len(ids), strings.Join(parts, ", "))
+}
@@ -9,6 +9,7 @@ import (
 	"os/exec"
 	"path/filepath"
 	"strings"
+	"time"
 
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/mark3labs/mcp-go/server"
@@ -26,9 +27,19 @@ var (
 	quickbaseSpecPath = filepath.Join(os.Getenv("HOME"), "Projects", "Personal", "quickbase-spec")
 )
 
+// configDir holds the server's state store and user-editable config files.
+// Override with QB_MCP_CONFIG_DIR.
+var configDir = func() string {
+	if dir := os.Getenv("QB_MCP_CONFIG_DIR"); dir != "" {
+		return dir
+	}
+	return filepath.Join(os.Getenv("HOME"), ".config", serverName)
+}()
+
 // Main server struct
 type QuickBasePersonalMCPServer struct {
 	logger *log.Logger
+	store  *stateStore
 }
 
 func main() {
@@ -38,6 +49,7 @@ func main() {
 	// Create server instance
 	s := &QuickBasePersonalMCPServer{
 		logger: logger,
+		store:  newStateStore(filepath.Join(configDir, "state.db")),
 	}
 
 	// Setup MCP tools
@@ -48,6 +60,7 @@ func main() {
 		serverName,
 		serverVersion,
 		server.WithToolCapabilities(true),
+		server.WithToolHandlerMiddleware(s.traceMiddleware),
 	)
 
 	// Register tool handlers
@@ -56,6 +69,90 @@ func main() {
 	mcpServer.AddTool(tools[2], s.handleGetAuthExample)
 	mcpServer.AddTool(tools[3], s.handleListFeatures)
 	mcpServer.AddTool(tools[4], s.handleCheckParity)
+	mcpServer.AddTool(tools[5], s.handleSpecQuery)
+	mcpServer.AddTool(tools[6], s.handleSearchHistory)
+	mcpServer.AddTool(tools[7], s.handleSaveSearch)
+	mcpServer.AddTool(tools[8], s.handleRunSavedSearch)
+	mcpServer.AddTool(tools[9], s.handleDocsCoverage)
+	mcpServer.AddTool(tools[10], s.handleSetTagMapping)
+	mcpServer.AddTool(tools[11], s.handleListTagMappings)
+	mcpServer.AddTool(tools[12], s.handleLearnCorrespondences)
+	mcpServer.AddTool(tools[13], s.handleListComparableFeatures)
+	mcpServer.AddTool(tools[14], s.handleQBStatus)
+	mcpServer.AddTool(tools[15], s.handleCompareGenerators)
+	mcpServer.AddTool(tools[16], s.handleRecordDecision)
+	mcpServer.AddTool(tools[17], s.handleSearchDecisions)
+	mcpServer.AddTool(tools[18], s.handleCheckTerminology)
+	mcpServer.AddTool(tools[19], s.handleTraceInvestigation)
+	mcpServer.AddTool(tools[20], s.handleCompareErrors)
+	mcpServer.AddTool(tools[21], s.handleRenderExample)
+	mcpServer.AddTool(tools[22], s.handleRunExample)
+	mcpServer.AddTool(tools[23], s.handleGetEndpointExample)
+	mcpServer.AddTool(tools[24], s.handleCompareAPISurface)
+	mcpServer.AddTool(tools[25], s.handleParityDrift)
+	mcpServer.AddTool(tools[26], s.handleVersionReport)
+	mcpServer.AddTool(tools[27], s.handleCheckFixtureParity)
+	mcpServer.AddTool(tools[28], s.handleSpecSearch)
+	mcpServer.AddTool(tools[29], s.handleGetEndpoint)
+	mcpServer.AddTool(tools[30], s.handleSpecDiff)
+	mcpServer.AddTool(tools[31], s.handleValidateSpec)
+	mcpServer.AddTool(tools[32], s.handleEndpointLifecycle)
+	mcpServer.AddTool(tools[33], s.handleGeneratePayload)
+	mcpServer.AddTool(tools[34], s.handleGenerateTypes)
+	mcpServer.AddTool(tools[35], s.handleSpecStatus)
+	mcpServer.AddTool(tools[36], s.handleSchemaUsage)
+	mcpServer.AddTool(tools[37], s.handleExtractSpec)
+	mcpServer.AddTool(tools[38], s.handleGenerateAPIDocs)
+	mcpServer.AddTool(tools[39], s.handleSpecChangelog)
+	mcpServer.AddTool(tools[40], s.handleGitStatus)
+	mcpServer.AddTool(tools[41], s.handleGitLog)
+	mcpServer.AddTool(tools[42], s.handleGitBlame)
+	mcpServer.AddTool(tools[43], s.handleGitDiff)
+	mcpServer.AddTool(tools[44], s.handleCompareBranches)
+	mcpServer.AddTool(tools[45], s.handleRecentChanges)
+	mcpServer.AddTool(tools[46], s.handleGitPickaxe)
+	mcpServer.AddTool(tools[47], s.handleShowFileAtRef)
+	mcpServer.AddTool(tools[48], s.handleBumpSpecPin)
+	mcpServer.AddTool(tools[49], s.handleFindRelatedCommits)
+	mcpServer.AddTool(tools[50], s.handleDraftChangelog)
+	mcpServer.AddTool(tools[51], s.handleDraftPRDescription)
+	mcpServer.AddTool(tools[52], s.handleListWIP)
+	mcpServer.AddTool(tools[53], s.handleListReleases)
+	mcpServer.AddTool(tools[54], s.handleRunTests)
+	mcpServer.AddTool(tools[55], s.handleCoverageReport)
+	mcpServer.AddTool(tools[56], s.handleRunLint)
+	mcpServer.AddTool(tools[57], s.handleTypeCheck)
+	mcpServer.AddTool(tools[58], s.handleRunCodegen)
+	mcpServer.AddTool(tools[59], s.handleCheckCodegenStale)
+	mcpServer.AddTool(tools[60], s.handleCheckDependencies)
+	mcpServer.AddTool(tools[61], s.handleRunRaceTests)
+	mcpServer.AddTool(tools[62], s.handleDetectFlaky)
+	mcpServer.AddTool(tools[63], s.handleProfileBenchmark)
+	mcpServer.AddTool(tools[64], s.handleBundleSize)
+	mcpServer.AddTool(tools[65], s.handleCompileSnippet)
+	mcpServer.AddTool(tools[66], s.handleGetDocs)
+	mcpServer.AddTool(tools[67], s.handleReleaseReadiness)
+	mcpServer.AddTool(tools[68], s.handleAuditLicenses)
+	mcpServer.AddTool(tools[69], s.handleBuild)
+	mcpServer.AddTool(tools[70], s.handleRunGenerate)
+	mcpServer.AddTool(tools[71], s.handleAPISnapshot)
+	mcpServer.AddTool(tools[72], s.handleAPIBreakingCheck)
+	mcpServer.AddTool(tools[73], s.handleQBQueryRecords)
+	mcpServer.AddTool(tools[74], s.handleQBListApps)
+	mcpServer.AddTool(tools[75], s.handleQBGetApp)
+	mcpServer.AddTool(tools[76], s.handleQBListTables)
+	mcpServer.AddTool(tools[77], s.handleQBGetFields)
+	mcpServer.AddTool(tools[78], s.handleQBUpsertRecords)
+	mcpServer.AddTool(tools[79], s.handleQBDeleteRecords)
+	mcpServer.AddTool(tools[80], s.handleQBRunReport)
+	mcpServer.AddTool(tools[81], s.handleQBBuildQuery)
+	mcpServer.AddTool(tools[82], s.handleAPIAuditLog)
+	mcpServer.AddTool(tools[83], s.handleQBRateLimitStatus)
+	mcpServer.AddTool(tools[84], s.handleQBDownloadFile)
+	mcpServer.AddTool(tools[85], s.handleQBUploadFile)
+	mcpServer.AddTool(tools[86], s.handleQBGetRelationships)
+	mcpServer.AddTool(tools[87], s.handleQBExportSchema)
+	mcpServer.AddTool(tools[88], s.handleResetSandbox)
 
 	// Start server
 	if err := server.ServeStdio(mcpServer); err != nil {
@@ -68,92 +165,2332 @@ func (s *QuickBasePersonalMCPServer) setupTools() []mcp.Tool {
 	return []mcp.Tool{
 		// 1. search_code
 		{
-			Name:        "search_code",
-			Description: "Search across your QuickBase SDK repositories (JS, Go, and spec).",
+			Name:        "search_code",
+			Description: "Search across your QuickBase SDK repositories (JS, Go, and spec).",
+			InputSchema: mcp.ToolInputSchema{
+				Type: "object",
+				Properties: map[string]interface{}{
+					"query": map[string]interface{}{
+						"type":        "string",
+						"description": "Search query (e.g., 'ticket auth', 'pagination', 'rate limit')",
+					},
+					"repo": map[string]interface{}{
+						"type":        "string",
+						"description": "Limit to specific repo: 'js', 'go', 'spec', 'all' (default: 'all')",
+						"enum":        []string{"js", "go", "spec", "all"},
+					},
+					"expand_synonyms": map[string]interface{}{
+						"type":        "boolean",
+						"description": "Also match QuickBase synonyms (dbid/tableId, fid/fieldId, upsert/insert, realm/hostname, ...) (default: true)",
+					},
+				},
+				Required: []string{"query"},
+			},
+		},
+		// 2. compare_implementations
+		{
+			Name:        "compare_implementations",
+			Description: "Compare how a feature is implemented in JavaScript vs Go SDK, or summarize a whole directory pair file by file.",
+			InputSchema: mcp.ToolInputSchema{
+				Type: "object",
+				Properties: map[string]interface{}{
+					"feature": map[string]interface{}{
+						"type":        "string",
+						"description": "Feature to compare (e.g., 'ticket-auth', 'temp-token', 'pagination', 'retry'); see list_comparable_features",
+					},
+					"js_path": map[string]interface{}{
+						"type":        "string",
+						"description": "Compare this file in quickbase-js instead of a feature (relative path, e.g., 'src/client/retry.ts')",
+					},
+					"go_path": map[string]interface{}{
+						"type":        "string",
+						"description": "Compare this file in quickbase-go instead of a feature (relative path, e.g., 'client/client.go')",
+					},
+					"js_dir": map[string]interface{}{
+						"type":        "string",
+						"description": "Directory in quickbase-js to compare against go_dir instead of a feature (e.g., 'src/auth')",
+					},
+					"go_dir": map[string]interface{}{
+						"type":        "string",
+						"description": "Directory in quickbase-go to compare against js_dir (e.g., 'auth')",
+					},
+					"js_ref": map[string]interface{}{
+						"type":        "string",
+						"description": "Read the JS side at this git ref (tag, branch or commit, e.g., 'v2.1.0') instead of the working tree",
+					},
+					"go_ref": map[string]interface{}{
+						"type":        "string",
+						"description": "Read the Go side at this git ref (e.g., 'v1.2.0') instead of the working tree",
+					},
+					"include_contents": map[string]interface{}{
+						"type":        "boolean",
+						"description": "In directory mode, append the full contents of every file after the summary",
+					},
+					"max_bytes": map[string]interface{}{
+						"type":        "integer",
+						"description": "Byte budget for file contents; when exceeded, large files are replaced by an outline of imports, exported signatures and doc comments",
+					},
+					"max_tokens": map[string]interface{}{
+						"type":        "integer",
+						"description": "Like max_bytes, in approximate tokens (4 bytes each)",
+					},
+					"include_tests": map[string]interface{}{
+						"type":        "boolean",
+						"description": "Include the full contents of the matching test files, not just their case and assertion counts",
+					},
+					"output": map[string]interface{}{
+						"type":        "string",
+						"description": "'full' returns both files; 'diff' returns a unified diff after stripping comments and normalizing naming conventions; 'symbols' compares exported functions, types and their parameters, option fields and error returns; 'todos' reports TODO/FIXME/HACK comments found on only one side (default: 'full')",
+						"enum":        []string{"full", "diff", "symbols", "todos"},
+					},
+				},
+			},
+		},
+		// 3. get_auth_example
+		{
+			Name:        "get_auth_example",
+			Description: "Get runnable authentication examples from your SDKs, taken from examples/, README/docs code blocks or test setup.",
+			InputSchema: mcp.ToolInputSchema{
+				Type: "object",
+				Properties: map[string]interface{}{
+					"auth_type": map[string]interface{}{
+						"type":        "string",
+						"description": "Authentication type: " + strings.Join(authTypeNames(), ", ") + " (more can be added under auth_types in config.yaml)",
+						"enum":        authTypeNames(),
+					},
+					"language": map[string]interface{}{
+						"type":        "string",
+						"description": "SDK language: 'js', 'go', 'both' (default: 'both')",
+						"enum":        []string{"js", "go", "both"},
+					},
+					"all": map[string]interface{}{
+						"type":        "boolean",
+						"description": "Return every example found, not just the best one per language",
+					},
+					"diagram": map[string]interface{}{
+						"type":        "boolean",
+						"description": "Also emit a mermaid sequence diagram of the auth flow (token fetch, caching, header injection, refresh) as each SDK's code implements it",
+					},
+				},
+				Required: []string{"auth_type"},
+			},
+		},
+		// 4. list_features
+		{
+			Name:        "list_features",
+			Description: "List the features implemented in your SDKs, built at call time from the feature map, qb:feature annotation comments and each SDK's directory layout, with per-SDK presence.",
+			InputSchema: mcp.ToolInputSchema{
+				Type: "object",
+				Properties: map[string]interface{}{
+					"category": map[string]interface{}{
+						"type":        "string",
+						"description": "Feature category, taken from the SDK directory a feature lives in (e.g., 'auth', 'client'), or 'all' (default: 'all')",
+					},
+					"format": map[string]interface{}{
+						"type":        "string",
+						"description": "Output format: markdown, json, csv (one row per feature) or html (a standalone page) (default: 'markdown')",
+						"enum":        reportFormats,
+					},
+				},
+			},
+		},
+		// 5. check_parity
+		{
+			Name:        "check_parity",
+			Description: "Check endpoint parity between the JavaScript and Go SDKs: for every operationId in the spec, whether each SDK exposes a client method for it, with counts, the operations each SDK is missing, and a severity-ranked work queue of gaps (endpoints, options, error types, tests) with suggested actions.",
+			InputSchema: mcp.ToolInputSchema{
+				Type: "object",
+				Properties: map[string]interface{}{
+					"tag": map[string]interface{}{
+						"type":        "string",
+						"description": "Only check operations with this spec tag (e.g., 'Records')",
+					},
+					"missing_only": map[string]interface{}{
+						"type":        "boolean",
+						"description": "Leave operations both SDKs expose out of the matrix (default: false)",
+					},
+					"gaps_only": map[string]interface{}{
+						"type":        "boolean",
+						"description": "Return only the coverage counts and the severity-ranked work queue of gaps (default: false)",
+					},
+					"format": map[string]interface{}{
+						"type":        "string",
+						"description": "Output format: markdown, json, csv (the matrix, or the work queue with gaps_only) or html (a standalone page) (default: 'markdown')",
+						"enum":        reportFormats,
+					},
+				},
+			},
+		},
+		// 6. spec_query
+		{
+			Name:        "spec_query",
+			Description: "Query the OpenAPI spec with a jq/yq-style path and return the matching subtree.",
+			InputSchema: mcp.ToolInputSchema{
+				Type: "object",
+				Properties: map[string]interface{}{
+					"path": map[string]interface{}{
+						"type":        "string",
+						"description": "Path expression (e.g., '.paths.\"/records/query\".post', '.components.schemas.QueryRequest', '.tags[].name')",
+					},
+					"format": map[string]interface{}{
+						"type":        "string",
+						"description": "Output format: 'yaml', 'json' (default: 'yaml')",
+						"enum":        []string{"yaml", "json"},
+					},
+					"keys_only": map[string]interface{}{
+						"type":        "boolean",
+						"description": "Only list the keys at the matched node (useful for exploring large sections like .paths)",
+					},
+				},
+				Required: []string{"path"},
+			},
+		},
+		// 7. search_history
+		{
+			Name:        "search_history",
+			Description: "Show recent search_code queries and saved searches.",
+			InputSchema: mcp.ToolInputSchema{
+				Type: "object",
+				Properties: map[string]interface{}{
+					"limit": map[string]interface{}{
+						"type":        "integer",
+						"description": "Number of recent searches to show (default: 20)",
+					},
+					"filter": map[string]interface{}{
+						"type":        "string",
+						"description": "Only show searches whose query contains this text",
+					},
+				},
+			},
+		},
+		// 8. save_search
+		{
+			Name:        "save_search",
+			Description: "Save a search_code query under a name so it can be rerun with run_saved_search.",
+			InputSchema: mcp.ToolInputSchema{
+				Type: "object",
+				Properties: map[string]interface{}{
+					"name": map[string]interface{}{
+						"type":        "string",
+						"description": "Name for the saved search (e.g., 'temp-token-cache')",
+					},
+					"query": map[string]interface{}{
+						"type":        "string",
+						"description": "Search query",
+					},
+					"repo": map[string]interface{}{
+						"type":        "string",
+						"description": "Limit to specific repo: 'js', 'go', 'spec', 'all' (default: 'all')",
+						"enum":        []string{"js", "go", "spec", "all"},
+					},
+					"description": map[string]interface{}{
+						"type":        "string",
+						"description": "What this search is for",
+					},
+					"delete": map[string]interface{}{
+						"type":        "boolean",
+						"description": "Delete the saved search with this name instead of saving",
+					},
+				},
+				Required: []string{"name"},
+			},
+		},
+		// 9. run_saved_search
+		{
+			Name:        "run_saved_search",
+			Description: "Run a search previously stored with save_search.",
+			InputSchema: mcp.ToolInputSchema{
+				Type: "object",
+				Properties: map[string]interface{}{
+					"name": map[string]interface{}{
+						"type":        "string",
+						"description": "Name of the saved search",
+					},
+				},
+				Required: []string{"name"},
+			},
+		},
+		// 10. docs_coverage
+		{
+			Name:        "docs_coverage",
+			Description: "Report which features are implemented but undocumented (README, docs/, examples/) in either SDK.",
+			InputSchema: mcp.ToolInputSchema{
+				Type: "object",
+				Properties: map[string]interface{}{
+					"feature": map[string]interface{}{
+						"type":        "string",
+						"description": "Limit the report to one feature (e.g., 'temp-token'); default is all features",
+					},
+				},
+			},
+		},
+		// 11. set_tag_mapping
+		{
+			Name:        "set_tag_mapping",
+			Description: "Record which SDK modules implement an OpenAPI spec tag (Records, Tables, Fields, ...). Consulted by spec coverage and porting tools.",
+			InputSchema: mcp.ToolInputSchema{
+				Type: "object",
+				Properties: map[string]interface{}{
+					"tag": map[string]interface{}{
+						"type":        "string",
+						"description": "Spec tag name (e.g., 'Records')",
+					},
+					"js": map[string]interface{}{
+						"type":        "array",
+						"items":       map[string]interface{}{"type": "string"},
+						"description": "Directories or files in quickbase-js (e.g., ['src/records'])",
+					},
+					"go": map[string]interface{}{
+						"type":        "array",
+						"items":       map[string]interface{}{"type": "string"},
+						"description": "Directories or files in quickbase-go (e.g., ['records'])",
+					},
+					"notes": map[string]interface{}{
+						"type":        "string",
+						"description": "Optional notes about the mapping",
+					},
+					"delete": map[string]interface{}{
+						"type":        "boolean",
+						"description": "Delete the mapping for this tag",
+					},
+				},
+				Required: []string{"tag"},
+			},
+		},
+		// 12. list_tag_mappings
+		{
+			Name:        "list_tag_mappings",
+			Description: "List spec tag → SDK module mappings, including spec tags that are not mapped yet.",
+			InputSchema: mcp.ToolInputSchema{
+				Type:       "object",
+				Properties: map[string]interface{}{},
+			},
+		},
+		// 13. learn_correspondences
+		{
+			Name:        "learn_correspondences",
+			Description: "Propose JS ↔ Go file pairs missing from the feature map, based on file naming and git co-change history.",
+			InputSchema: mcp.ToolInputSchema{
+				Type: "object",
+				Properties: map[string]interface{}{
+					"min_score": map[string]interface{}{
+						"type":        "number",
+						"description": "Minimum combined score from 0 to 1 (default: 0.5)",
+					},
+					"window_hours": map[string]interface{}{
+						"type":        "integer",
+						"description": "Commits in the two repos this close together count as a co-change (default: 72)",
+					},
+					"max_commits": map[string]interface{}{
+						"type":        "integer",
+						"description": "How many recent commits per repo to analyze (default: 500)",
+					},
+					"limit": map[string]interface{}{
+						"type":        "integer",
+						"description": "Maximum number of proposals (default: 25)",
+					},
+				},
+			},
+		},
+		// 14. list_comparable_features
+		{
+			Name:        "list_comparable_features",
+			Description: "List every feature compare_implementations accepts, with resolved file paths and whether each side exists.",
+			InputSchema: mcp.ToolInputSchema{
+				Type: "object",
+				Properties: map[string]interface{}{
+					"include_discovered": map[string]interface{}{
+						"type":        "boolean",
+						"description": "Also list likely feature pairs discovered from naming and git history that aren't mapped yet",
+					},
+				},
+			},
+		},
+		// 15. qb_status
+		{
+			Name:        "qb_status",
+			Description: "Check the Quickbase status page and probe the API before running live tests or seed jobs. Reports DEGRADED when the platform itself is the problem.",
+			InputSchema: mcp.ToolInputSchema{
+				Type:       "object",
+				Properties: map[string]interface{}{},
+			},
+		},
+		// 16. compare_generators
+		{
+			Name:        "compare_generators",
+			Description: "Run two TypeScript code generators against the spec and compare the client APIs they produce (exports, methods, operation coverage).",
+			InputSchema: mcp.ToolInputSchema{
+				Type: "object",
+				Properties: map[string]interface{}{
+					"generator_a": map[string]interface{}{
+						"type":        "string",
+						"description": "Preset name ('openapi-generator-typescript', 'openapi-generator-axios', 'openapi-typescript', 'hey-api', 'orval') or a command using {spec} and {out}",
+					},
+					"generator_b": map[string]interface{}{
+						"type":        "string",
+						"description": "Second generator, same format as generator_a",
+					},
+					"timeout_seconds": map[string]interface{}{
+						"type":        "integer",
+						"description": "Timeout per generator run (default: 300)",
+					},
+					"keep_output": map[string]interface{}{
+						"type":        "boolean",
+						"description": "Keep the generated output directories for manual inspection",
+					},
+				},
+				Required: []string{"generator_a", "generator_b"},
+			},
+		},
+		// 17. record_decision
+		{
+			Name:        "record_decision",
+			Description: "Record a settled architecture decision (e.g. 'we retry only idempotent methods') so it isn't re-litigated. Can supersede an earlier decision.",
+			InputSchema: mcp.ToolInputSchema{
+				Type: "object",
+				Properties: map[string]interface{}{
+					"title": map[string]interface{}{
+						"type":        "string",
+						"description": "Short title for the decision",
+					},
+					"decision": map[string]interface{}{
+						"type":        "string",
+						"description": "What was decided",
+					},
+					"rationale": map[string]interface{}{
+						"type":        "string",
+						"description": "Why it was decided",
+					},
+					"features": map[string]interface{}{
+						"type":        "array",
+						"items":       map[string]interface{}{"type": "string"},
+						"description": "Feature names the decision applies to (see list_comparable_features)",
+					},
+					"tags": map[string]interface{}{
+						"type":        "array",
+						"items":       map[string]interface{}{"type": "string"},
+						"description": "Free-form tags, e.g. 'retry', 'auth'",
+					},
+					"supersedes": map[string]interface{}{
+						"type":        "integer",
+						"description": "ID of an earlier decision this one replaces",
+					},
+				},
+				Required: []string{"title", "decision"},
+			},
+		},
+		// 18. search_decisions
+		{
+			Name:        "search_decisions",
+			Description: "Search recorded architecture decisions before proposing a change. Check here first when a difference between the SDKs looks deliberate.",
+			InputSchema: mcp.ToolInputSchema{
+				Type: "object",
+				Properties: map[string]interface{}{
+					"query": map[string]interface{}{
+						"type":        "string",
+						"description": "Words that must all appear in the decision (empty lists everything)",
+					},
+					"feature": map[string]interface{}{
+						"type":        "string",
+						"description": "Only decisions linked to this feature",
+					},
+					"include_superseded": map[string]interface{}{
+						"type":        "boolean",
+						"description": "Also show decisions that have been superseded",
+					},
+				},
+			},
+		},
+		// 19. check_terminology
+		{
+			Name:        "check_terminology",
+			Description: "Check docs, comments and error messages in both SDKs against the shared glossary and report non-canonical terms (e.g. 'application' instead of 'app', 'rid' instead of 'record ID').",
+			InputSchema: mcp.ToolInputSchema{
+				Type: "object",
+				Properties: map[string]interface{}{
+					"repo": map[string]interface{}{
+						"type":        "string",
+						"description": "Which repo to check: 'js', 'go', or 'all' (default: all)",
+						"enum":        []string{"js", "go", "all"},
+					},
+					"term": map[string]interface{}{
+						"type":        "string",
+						"description": "Only check this glossary term",
+					},
+					"limit": map[string]interface{}{
+						"type":        "integer",
+						"description": "Maximum violations shown per term (default: 20)",
+					},
+				},
+			},
+		},
+		// 20. trace_investigation
+		{
+			Name:        "trace_investigation",
+			Description: "Record a debugging session as a replayable timeline: while an investigation runs, every tool call is logged, and notes, files viewed and conclusions can be added. Search past investigations when a similar API quirk resurfaces.",
+			InputSchema: mcp.ToolInputSchema{
+				Type: "object",
+				Properties: map[string]interface{}{
+					"action": map[string]interface{}{
+						"type":        "string",
+						"description": "'start', 'note', 'conclusion', 'stop', 'show' or 'list' (default: list)",
+						"enum":        []string{"start", "note", "conclusion", "stop", "show", "list"},
+					},
+					"title": map[string]interface{}{
+						"type":        "string",
+						"description": "Investigation title (start)",
+					},
+					"time_box_minutes": map[string]interface{}{
+						"type":        "integer",
+						"description": "Warn on every tool call once the investigation runs longer than this (start)",
+					},
+					"text": map[string]interface{}{
+						"type":        "string",
+						"description": "Note or conclusion text (note, conclusion, stop)",
+					},
+					"files": map[string]interface{}{
+						"type":        "array",
+						"items":       map[string]interface{}{"type": "string"},
+						"description": "Files viewed outside this server (note)",
+					},
+					"id": map[string]interface{}{
+						"type":        "integer",
+						"description": "Investigation to show (default: the running one)",
+					},
+					"query": map[string]interface{}{
+						"type":        "string",
+						"description": "Words to match in titles, notes, conclusions and files (list)",
+					},
+				},
+			},
+		},
+		// 21. compare_errors
+		{
+			Name:        "compare_errors",
+			Description: "Compare custom error types across SDKs: Go types implementing error and sentinel errors vs JS classes extending Error, matched by name or HTTP status, with gaps and field differences.",
+			InputSchema: mcp.ToolInputSchema{
+				Type:       "object",
+				Properties: map[string]interface{}{},
+			},
+		},
+		// 22. render_example
+		{
+			Name:        "render_example",
+			Description: "Render a copy-pasteable SDK example with your realm, app and table IDs filled in from config.yaml.",
+			InputSchema: mcp.ToolInputSchema{
+				Type: "object",
+				Properties: map[string]interface{}{
+					"name": map[string]interface{}{
+						"type":        "string",
+						"description": "Example to render: " + strings.Join(exampleTemplateNames(), ", "),
+						"enum":        exampleTemplateNames(),
+					},
+					"language": map[string]interface{}{
+						"type":        "string",
+						"description": "SDK language: 'js', 'go', 'both' (default: 'both')",
+						"enum":        []string{"js", "go", "both"},
+					},
+					"realm": map[string]interface{}{
+						"type":        "string",
+						"description": "Override the configured realm (name or hostname)",
+					},
+					"app_id": map[string]interface{}{
+						"type":        "string",
+						"description": "Override the configured app ID",
+					},
+					"table_id": map[string]interface{}{
+						"type":        "string",
+						"description": "Override the configured table ID",
+					},
+				},
+				Required: []string{"name"},
+			},
+		},
+		// 23. run_example
+		{
+			Name:        "run_example",
+			Description: "Run an example against the sandbox realm with credentials from the local .env, returning its output with tokens redacted. A quick smoke test for either SDK.",
+			InputSchema: mcp.ToolInputSchema{
+				Type: "object",
+				Properties: map[string]interface{}{
+					"name": map[string]interface{}{
+						"type":        "string",
+						"description": "Embedded template to run: " + strings.Join(exampleTemplateNames(), ", "),
+						"enum":        exampleTemplateNames(),
+					},
+					"path": map[string]interface{}{
+						"type":        "string",
+						"description": "Or an example file from the SDK's examples/ directory (e.g., 'examples/basic/main.go')",
+					},
+					"language": map[string]interface{}{
+						"type":        "string",
+						"description": "SDK to run with: 'js' or 'go'",
+						"enum":        []string{"js", "go"},
+					},
+					"timeout_seconds": map[string]interface{}{
+						"type":        "number",
+						"description": "Kill the example after this long (default: 120)",
+					},
+				},
+				Required: []string{"language"},
+			},
+		},
+		// 24. get_endpoint_example
+		{
+			Name:        "get_endpoint_example",
+			Description: "Generate usage snippets for any API operation in both SDKs, using the client method names the SDKs declare and a request built from the spec's schemas.",
+			InputSchema: mcp.ToolInputSchema{
+				Type: "object",
+				Properties: map[string]interface{}{
+					"operation_id": map[string]interface{}{
+						"type":        "string",
+						"description": "OpenAPI operationId (e.g., 'runQuery', 'upsert') or 'METHOD /path'",
+					},
+					"language": map[string]interface{}{
+						"type":        "string",
+						"description": "SDK language: 'js', 'go', 'both' (default: 'both')",
+						"enum":        []string{"js", "go", "both"},
+					},
+					"include_optional": map[string]interface{}{
+						"type":        "boolean",
+						"description": "Include optional parameters and body properties, not just required ones (default: false)",
+					},
+				},
+				Required: []string{"operation_id"},
+			},
+		},
+		// 25. compare_api_surface
+		{
+			Name:        "compare_api_surface",
+			Description: "Diff the public API of the SDKs symbol by symbol: exported Go identifiers against the JS package's public exports, matched across naming conventions, reporting symbols only one SDK has.",
+			InputSchema: mcp.ToolInputSchema{
+				Type: "object",
+				Properties: map[string]interface{}{
+					"show_matched": map[string]interface{}{
+						"type":        "boolean",
+						"description": "Also list the symbols both SDKs have, paired (default: false)",
+					},
+				},
+			},
+		},
+		// 26. parity_drift
+		{
+			Name:        "parity_drift",
+			Description: "Compare the latest parity report (endpoints, features and public API symbols) against a stored baseline and highlight newly introduced gaps, such as a JS-only feature added during a burst of work. Every check_parity and parity_drift run is stored.",
+			InputSchema: mcp.ToolInputSchema{
+				Type: "object",
+				Properties: map[string]interface{}{
+					"action": map[string]interface{}{
+						"type":        "string",
+						"description": "'diff' (default) or 'list' to show stored reports",
+						"enum":        []string{"diff", "list"},
+					},
+					"baseline": map[string]interface{}{
+						"type":        "string",
+						"description": "Report to compare against: an ID, a label, 'previous' (default) or 'first'",
+					},
+					"label": map[string]interface{}{
+						"type":        "string",
+						"description": "Name this run so it can be used as a baseline later (e.g., 'v2.1 release')",
+					},
+					"refresh": map[string]interface{}{
+						"type":        "boolean",
+						"description": "Take and store a new report as the latest (default: true); false compares the most recent stored one",
+					},
+				},
+			},
+		},
+		// 27. version_report
+		{
+			Name:        "version_report",
+			Description: "Report the real versions of the SDKs and spec: package.json, go.mod module path, git describe and latest tag of each repo, the spec's info.version, and the commit of any spec submodule, with warnings when they disagree.",
+			InputSchema: mcp.ToolInputSchema{
+				Type:       "object",
+				Properties: map[string]interface{}{},
+			},
+		},
+		// 28. check_fixture_parity
+		{
+			Name:        "check_fixture_parity",
+			Description: "Compare the test fixture directories of the JS and Go SDKs by file path and content hash, reporting fixtures missing from one side or diverged between them (with the differing JSON paths).",
+			InputSchema: mcp.ToolInputSchema{
+				Type: "object",
+				Properties: map[string]interface{}{
+					"js_dir": map[string]interface{}{
+						"type":        "string",
+						"description": "Fixtures directory in quickbase-js (default: js_fixtures_dir from config.yaml, else tests/fixtures, test/fixtures, __fixtures__ ...)",
+					},
+					"go_dir": map[string]interface{}{
+						"type":        "string",
+						"description": "Fixtures directory in quickbase-go (default: go_fixtures_dir from config.yaml, else testdata/fixtures, testdata, fixtures)",
+					},
+				},
+			},
+		},
+		// 29. spec_search
+		{
+			Name:        "spec_search",
+			Description: "Search the OpenAPI spec for operations (by operationId, path or tag) and component schemas (by name), ranked, with summaries and the spec_query path to each match.",
+			InputSchema: mcp.ToolInputSchema{
+				Type: "object",
+				Properties: map[string]interface{}{
+					"query": map[string]interface{}{
+						"type":        "string",
+						"description": "Text to look for (e.g., 'upsert', '/records', 'Apps', 'QueryRequest'); case, dashes and underscores are ignored",
+					},
+					"in": map[string]interface{}{
+						"type":        "string",
+						"description": "Only match this field (default: 'any', which also matches operation summaries)",
+						"enum":        specSearchFields,
+					},
+					"limit": map[string]interface{}{
+						"type":        "integer",
+						"description": "Maximum results per section (default: 20)",
+					},
+				},
+				Required: []string{"query"},
+			},
+		},
+		// 30. get_endpoint
+		{
+			Name:        "get_endpoint",
+			Description: "Show one spec operation with every $ref expanded: parameters (including shared path-level ones), request body and response schemas, as YAML or JSON.",
+			InputSchema: mcp.ToolInputSchema{
+				Type: "object",
+				Properties: map[string]interface{}{
+					"operation": map[string]interface{}{
+						"type":        "string",
+						"description": "operationId (any casing) or 'METHOD /path' (e.g., 'runQuery', 'POST /records/query')",
+					},
+					"format": map[string]interface{}{
+						"type":        "string",
+						"description": "Schema format: 'yaml' or 'json' (default: 'yaml')",
+						"enum":        []string{"yaml", "json"},
+					},
+					"responses": map[string]interface{}{
+						"type":        "string",
+						"description": "Only show responses whose status starts with this (e.g., '2' or '404'); default all",
+					},
+				},
+				Required: []string{"operation"},
+			},
+		},
+		// 31. spec_diff
+		{
+			Name:        "spec_diff",
+			Description: "Compare two versions of quickbase-spec and report added, removed and changed operations (parameters, request body, responses) and component schemas, each classified as breaking, additive or needing review: the SDK work a spec update creates, and whether it's safe to move a pin.",
+			InputSchema: mcp.ToolInputSchema{
+				Type: "object",
+				Properties: map[string]interface{}{
+					"base": map[string]interface{}{
+						"type":        "string",
+						"description": "Older version: a git ref of quickbase-spec, 'js-pin'/'go-pin' for the commit an SDK's spec submodule pins, or 'working' (default: the JS pin, else the Go pin)",
+					},
+					"head": map[string]interface{}{
+						"type":        "string",
+						"description": "Newer version, same forms as base (e.g., 'origin/main'; default: 'HEAD')",
+					},
+					"breaking_only": map[string]interface{}{
+						"type":        "boolean",
+						"description": "Only show the verdict and the breaking changes (default: false)",
+					},
+				},
+			},
+		},
+		// 32. validate_spec
+		{
+			Name:        "validate_spec",
+			Description: "Validate the structure of the quickbase-spec OpenAPI document: OpenAPI 3.x shape, unresolved $refs, duplicate operationIds, path parameters that don't match the path, unknown fields, and missing descriptions. Findings are reported with file and line.",
+			InputSchema: mcp.ToolInputSchema{
+				Type: "object",
+				Properties: map[string]interface{}{
+					"ref": map[string]interface{}{
+						"type":        "string",
+						"description": "Git ref of quickbase-spec to validate (default: the working tree)",
+					},
+					"errors_only": map[string]interface{}{
+						"type":        "boolean",
+						"description": "Leave out warnings such as missing descriptions and unused schemas (default: false)",
+					},
+				},
+			},
+		},
+		// 33. endpoint_lifecycle
+		{
+			Name:        "endpoint_lifecycle",
+			Description: "List spec operations that are deprecated or beta/preview (by tag or x-beta/x-stability extensions), with whether each SDK still exposes them and whether its docs mark them. Use it to plan deprecations across both SDKs.",
+			InputSchema: mcp.ToolInputSchema{
+				Type: "object",
+				Properties: map[string]interface{}{
+					"status": map[string]interface{}{
+						"type":        "string",
+						"description": "Which operations to list: 'deprecated', 'beta' or 'all' (default: 'all')",
+						"enum":        []string{"all", "deprecated", "beta"},
+					},
+				},
+			},
+		},
+		// 34. generate_payload
+		{
+			Name:        "generate_payload",
+			Description: "Build a realistic example request body for an operation (or any component schema) from the spec, respecting required fields, enums, formats and examples, as raw JSON, a Go struct literal and a TypeScript object literal.",
+			InputSchema: mcp.ToolInputSchema{
+				Type: "object",
+				Properties: map[string]interface{}{
+					"operation": map[string]interface{}{
+						"type":        "string",
+						"description": "operationId (e.g., 'upsert') or 'METHOD /path' whose request body to build",
+					},
+					"schema": map[string]interface{}{
+						"type":        "string",
+						"description": "Component schema name to build instead of an operation's body (e.g., 'QueryRequest')",
+					},
+					"include_optional": map[string]interface{}{
+						"type":        "boolean",
+						"description": "Include optional properties, not just required ones (default: false)",
+					},
+				},
+			},
+		},
+		// 35. generate_types
+		{
+			Name:        "generate_types",
+			Description: "Emit a Go struct (json tags matching the spec) and a TypeScript interface for a component schema, plus the schemas it references and its inline objects, and compare each against the type the SDKs declare.",
+			InputSchema: mcp.ToolInputSchema{
+				Type: "object",
+				Properties: map[string]interface{}{
+					"schema": map[string]interface{}{
+						"type":        "string",
+						"description": "Component schema name (e.g., 'QueryRequest'; any casing)",
+					},
+					"only": map[string]interface{}{
+						"type":        "boolean",
+						"description": "Emit just this schema and its inline objects, not the component schemas it references (default: false)",
+					},
+				},
+				Required: []string{"schema"},
+			},
+		},
+		// 36. spec_status
+		{
+			Name:        "spec_status",
+			Description: "Report the quickbase-spec commit each SDK pins as a submodule against the latest spec commit: commits behind, operations added, removed or changed since the pin (with breaking counts), and whether the SDKs pin the same commit.",
+			InputSchema: mcp.ToolInputSchema{
+				Type: "object",
+				Properties: map[string]interface{}{
+					"target": map[string]interface{}{
+						"type":        "string",
+						"description": "Spec ref to measure the pins against (default: the branch's upstream, e.g. 'origin/main', else HEAD)",
+					},
+					"fetch": map[string]interface{}{
+						"type":        "boolean",
+						"description": "Run git fetch in quickbase-spec first (default: false)",
+					},
+				},
+			},
+		},
+		// 37. schema_usage
+		{
+			Name:        "schema_usage",
+			Description: "Assess the blast radius of a schema change: which operations and schemas use a spec schema or field, and where each SDK declares, converts, references and tests the corresponding types and fields.",
+			InputSchema: mcp.ToolInputSchema{
+				Type: "object",
+				Properties: map[string]interface{}{
+					"name": map[string]interface{}{
+						"type":        "string",
+						"description": "A schema ('QueryRequest'), a field ('mergeFieldId') or a schema's field ('QueryRequest.where')",
+					},
+					"limit": map[string]interface{}{
+						"type":        "integer",
+						"description": "Maximum lines listed per group in each SDK (default: 15)",
+					},
+				},
+				Required: []string{"name"},
+			},
+		},
+		// 38. extract_spec
+		{
+			Name:        "extract_spec",
+			Description: "Extract a self-contained mini-spec for one tag or a list of operations, with only the components, security schemes and tags they need, to feed a focused spec to code generators or into context instead of the full document.",
+			InputSchema: mcp.ToolInputSchema{
+				Type: "object",
+				Properties: map[string]interface{}{
+					"tag": map[string]interface{}{
+						"type":        "string",
+						"description": "Extract every operation with this tag (e.g. 'Records')",
+					},
+					"operations": map[string]interface{}{
+						"type":        "array",
+						"items":       map[string]interface{}{"type": "string"},
+						"description": "Extract these operations: operationIds or 'METHOD /path'",
+					},
+					"format": map[string]interface{}{
+						"type":        "string",
+						"enum":        []string{"yaml", "json"},
+						"description": "Output format (default: yaml)",
+					},
+					"save": map[string]interface{}{
+						"type":        "boolean",
+						"description": "Write the mini-spec to a temp file and return its path instead of the content (default: false)",
+					},
+				},
+			},
+		},
+		// 39. generate_api_docs
+		{
+			Name:        "generate_api_docs",
+			Description: "Render the spec (or one tag) as markdown reference pages: operations with parameter, request body and response tables, the schemas they use, and optionally JS and Go usage snippets for each operation.",
+			InputSchema: mcp.ToolInputSchema{
+				Type: "object",
+				Properties: map[string]interface{}{
+					"tag": map[string]interface{}{
+						"type":        "string",
+						"description": "Only render this tag's page (default: every tag)",
+					},
+					"snippets": map[string]interface{}{
+						"type":        "boolean",
+						"description": "Add quickbase-js and quickbase-go usage snippets to each operation (default: false)",
+					},
+					"save": map[string]interface{}{
+						"type":        "boolean",
+						"description": "Write one markdown file per tag, plus an index, to a temp directory instead of returning the pages (default: false)",
+					},
+				},
+			},
+		},
+		// 40. spec_changelog
+		{
+			Name:        "spec_changelog",
+			Description: "Write release-notes-ready markdown of the spec changes between two tags or commits: operations added, changed, deprecated and removed, grouped by tag, with breaking changes called out first and model (schema) changes after.",
+			InputSchema: mcp.ToolInputSchema{
+				Type: "object",
+				Properties: map[string]interface{}{
+					"from": map[string]interface{}{
+						"type":        "string",
+						"description": "Start of the range: a spec tag or commit, 'js-pin' or 'go-pin' (default: the latest tag before 'to')",
+					},
+					"to": map[string]interface{}{
+						"type":        "string",
+						"description": "End of the range: a spec tag or commit, 'js-pin', 'go-pin' or 'working' (default: HEAD)",
+					},
+					"include_schemas": map[string]interface{}{
+						"type":        "boolean",
+						"description": "Add a Models section for component schema changes (default: true)",
+					},
+				},
+			},
+		},
+		// 41. git_status
+		{
+			Name:        "git_status",
+			Description: "Show branch, upstream, ahead/behind, staged, modified and untracked files, stashes and any merge or rebase in progress across all the repos at once, with what needs attention first.",
+			InputSchema: mcp.ToolInputSchema{
+				Type: "object",
+				Properties: map[string]interface{}{
+					"repo": map[string]interface{}{
+						"type":        "string",
+						"description": "Limit to one repo: 'js', 'go', 'spec' or a configured repo's name (default: all)",
+					},
+					"fetch": map[string]interface{}{
+						"type":        "boolean",
+						"description": "Run git fetch first so ahead/behind is current (default: false)",
+					},
+					"limit": map[string]interface{}{
+						"type":        "integer",
+						"description": "Maximum files listed per group for each repo (default: 10)",
+					},
+				},
+			},
+		},
+		// 42. git_log
+		{
+			Name:        "git_log",
+			Description: "List recent commits in one or all repos, filtered by date range, author, path and commit message, optionally with the files each commit touched. Answers questions like 'what changed in quickbase-go/client last week'.",
+			InputSchema: mcp.ToolInputSchema{
+				Type: "object",
+				Properties: map[string]interface{}{
+					"repo": map[string]interface{}{
+						"type":        "string",
+						"description": "Limit to one repo: 'js', 'go', 'spec' or a configured repo's name (default: all, or the repo named at the start of path)",
+					},
+					"since": map[string]interface{}{
+						"type":        "string",
+						"description": "Only commits after this date, as git takes it: '2026-10-01', '1 week ago', 'yesterday'",
+					},
+					"until": map[string]interface{}{
+						"type":        "string",
+						"description": "Only commits before this date",
+					},
+					"author": map[string]interface{}{
+						"type":        "string",
+						"description": "Only commits by authors matching this (name or email, a regex)",
+					},
+					"path": map[string]interface{}{
+						"type":        "string",
+						"description": "Only commits touching this file or directory, e.g. 'client' or 'quickbase-go/client'",
+					},
+					"grep": map[string]interface{}{
+						"type":        "string",
+						"description": "Only commits whose message matches this regex (case-insensitive)",
+					},
+					"ref": map[string]interface{}{
+						"type":        "string",
+						"description": "Branch, tag or commit to list from (default: HEAD)",
+					},
+					"files": map[string]interface{}{
+						"type":        "boolean",
+						"description": "List the files each commit added, modified, deleted or renamed (default: false)",
+					},
+					"limit": map[string]interface{}{
+						"type":        "integer",
+						"description": "Maximum commits per repo (default: 20)",
+					},
+				},
+			},
+		},
+		// 43. git_blame
+		{
+			Name:        "git_blame",
+			Description: "Show who last changed each line of a file or line range in any repo, and when and why: consecutive lines are grouped by commit, and each commit's full message is included. Output as markdown or structured JSON.",
+			InputSchema: mcp.ToolInputSchema{
+				Type: "object",
+				Properties: map[string]interface{}{
+					"path": map[string]interface{}{
+						"type":        "string",
+						"description": "File to blame, e.g. 'client/client.go' or 'quickbase-go/client/client.go'",
+					},
+					"repo": map[string]interface{}{
+						"type":        "string",
+						"description": "Repo the file is in: 'js', 'go', 'spec' or a configured repo's name (default: the repo named at the start of path, or the only repo with the file)",
+					},
+					"start_line": map[string]interface{}{
+						"type":        "integer",
+						"description": "First line to blame (default: the whole file)",
+					},
+					"end_line": map[string]interface{}{
+						"type":        "integer",
+						"description": "Last line to blame (default: start_line)",
+					},
+					"ref": map[string]interface{}{
+						"type":        "string",
+						"description": "Blame the file as of this branch, tag or commit (default: the working tree)",
+					},
+					"follow_moves": map[string]interface{}{
+						"type":        "boolean",
+						"description": "Look through lines moved or copied from other files to the commit that wrote them (default: false)",
+					},
+					"format": map[string]interface{}{
+						"type":        "string",
+						"enum":        []string{"markdown", "json"},
+						"description": "Output format (default: markdown)",
+					},
+				},
+				Required: []string{"path"},
+			},
+		},
+		// 44. git_diff
+		{
+			Name:        "git_diff",
+			Description: "Show uncommitted changes in one or all repos: a per-file table (staged, unstaged, untracked, lines added and removed) and the diff itself, optionally limited to a path or to staged or unstaged changes.",
+			InputSchema: mcp.ToolInputSchema{
+				Type: "object",
+				Properties: map[string]interface{}{
+					"repo": map[string]interface{}{
+						"type":        "string",
+						"description": "Limit to one repo: 'js', 'go', 'spec' or a configured repo's name (default: all, or the repo named at the start of path)",
+					},
+					"path": map[string]interface{}{
+						"type":        "string",
+						"description": "Only changes under this file or directory, e.g. 'auth' or 'quickbase-go/auth'",
+					},
+					"changes": map[string]interface{}{
+						"type":        "string",
+						"enum":        []string{"all", "staged", "unstaged"},
+						"description": "'all' diffs the working tree against HEAD; 'staged' is what the next commit holds; 'unstaged' is what isn't added yet (default: all)",
+					},
+					"untracked": map[string]interface{}{
+						"type":        "boolean",
+						"description": "Include new files git doesn't track yet (default: true; never for staged)",
+					},
+					"stat_only": map[string]interface{}{
+						"type":        "boolean",
+						"description": "Only the per-file table, without the diff (default: false)",
+					},
+					"context": map[string]interface{}{
+						"type":        "integer",
+						"description": "Lines of context around each change (default: git's, 3)",
+					},
+					"max_lines": map[string]interface{}{
+						"type":        "integer",
+						"description": "Maximum diff lines per repo (default: 400)",
+					},
+				},
+			},
+		},
+		// 45. compare_branches
+		{
+			Name:        "compare_branches",
+			Description: "Compare two branches of one repo: merge base, commits ahead and behind, the commits only on each side, the files the head branch changed (with status and line counts, grouped by directory) and optionally the diff.",
+			InputSchema: mcp.ToolInputSchema{
+				Type: "object",
+				Properties: map[string]interface{}{
+					"repo": map[string]interface{}{
+						"type":        "string",
+						"description": "Repo: 'js', 'go', 'spec' or a configured repo's name",
+					},
+					"head": map[string]interface{}{
+						"type":        "string",
+						"description": "Branch (or any ref) to compare, e.g. 'generated'",
+					},
+					"base": map[string]interface{}{
+						"type":        "string",
+						"description": "Branch to compare against (default: the repo's main branch)",
+					},
+					"path": map[string]interface{}{
+						"type":        "string",
+						"description": "Only commits and files under this path",
+					},
+					"diff": map[string]interface{}{
+						"type":        "boolean",
+						"description": "Include the diff of head's changes since the merge base (default: false)",
+					},
+					"limit": map[string]interface{}{
+						"type":        "integer",
+						"description": "Maximum commits listed per side (default: 20)",
+					},
+					"max_lines": map[string]interface{}{
+						"type":        "integer",
+						"description": "Maximum diff lines (default: 400)",
+					},
+				},
+				Required: []string{"repo", "head"},
+			},
+		},
+		// 46. recent_changes
+		{
+			Name:        "recent_changes",
+			Description: "Summarize the last N days of commits across all repos, grouped by feature area from the feature map (plus the spec and everything else), with features changed in only one SDK flagged for parity.",
+			InputSchema: mcp.ToolInputSchema{
+				Type: "object",
+				Properties: map[string]interface{}{
+					"days": map[string]interface{}{
+						"type":        "integer",
+						"description": "How many days back to look (default: 7)",
+					},
+					"repo": map[string]interface{}{
+						"type":        "string",
+						"description": "Limit to one repo: 'js', 'go', 'spec' or a configured repo's name (default: all)",
+					},
+					"limit": map[string]interface{}{
+						"type":        "integer",
+						"description": "Maximum commits listed per area (default: 10)",
+					},
+				},
+			},
+		},
+		// 47. git_pickaxe
+		{
+			Name:        "git_pickaxe",
+			Description: "Find the commits that added or removed a string or regex (git log -S/-G) in one or all repos, with the matching lines each changed and whether it's still there. Answers 'when did this appear or disappear', which search_code can't since it only sees the working tree.",
+			InputSchema: mcp.ToolInputSchema{
+				Type: "object",
+				Properties: map[string]interface{}{
+					"query": map[string]interface{}{
+						"type":        "string",
+						"description": "String to look for, e.g. 'retryAfter' (a regex when regex is true)",
+					},
+					"regex": map[string]interface{}{
+						"type":        "boolean",
+						"description": "Treat query as an extended regex and find commits with any added or removed line matching it (git log -G). By default query is a plain string and only commits changing how often it occurs count (git log -S) (default: false)",
+					},
+					"ignore_case": map[string]interface{}{
+						"type":        "boolean",
+						"description": "Match regardless of case (default: false)",
+					},
+					"repo": map[string]interface{}{
+						"type":        "string",
+						"description": "Limit to one repo: 'js', 'go', 'spec' or a configured repo's name (default: all, or the repo named at the start of path)",
+					},
+					"path": map[string]interface{}{
+						"type":        "string",
+						"description": "Only look in this file or directory, e.g. 'client' or 'quickbase-go/client'",
+					},
+					"ref": map[string]interface{}{
+						"type":        "string",
+						"description": "Branch, tag or commit to search back from (default: HEAD)",
+					},
+					"limit": map[string]interface{}{
+						"type":        "integer",
+						"description": "Maximum commits per repo (default: 20)",
+					},
+				},
+				Required: []string{"query"},
+			},
+		},
+		// 48. show_file_at_ref
+		{
+			Name:        "show_file_at_ref",
+			Description: "Show a file (or list a directory) as it was at a tag, branch or commit in one of the repos, e.g. what client/pagination.go looked like in v1.1.0. Paths are checked the same way as for working tree reads.",
+			InputSchema: mcp.ToolInputSchema{
+				Type: "object",
+				Properties: map[string]interface{}{
+					"ref": map[string]interface{}{
+						"type":        "string",
+						"description": "Tag, branch or commit, e.g. 'v1.1.0' or 'HEAD~3'",
+					},
+					"path": map[string]interface{}{
+						"type":        "string",
+						"description": "File or directory in the repo, e.g. 'client/pagination.go' or 'quickbase-go/client/pagination.go'",
+					},
+					"repo": map[string]interface{}{
+						"type":        "string",
+						"description": "Repo: 'js', 'go', 'spec' or a configured repo's name (default: the repo named at the start of path, else the only one with the path at ref)",
+					},
+					"start_line": map[string]interface{}{
+						"type":        "integer",
+						"description": "First line to show (default: 1)",
+					},
+					"end_line": map[string]interface{}{
+						"type":        "integer",
+						"description": "Last line to show (default: the end of the file)",
+					},
+					"max_lines": map[string]interface{}{
+						"type":        "integer",
+						"description": "Maximum lines to show (default: 500)",
+					},
+				},
+				Required: []string{"ref", "path"},
+			},
+		},
+		// 49. bump_spec_pin
+		{
+			Name:        "bump_spec_pin",
+			Description: "Move the quickbase-spec submodule pin in one or both SDKs to a spec commit. By default it only previews: the current pin, how far it moves and the resulting spec changes (breaking ones listed). With confirm it checks out the target in each submodule and stages it, and with commit it also commits the bump. Use spec_status to see where the pins stand.",
+			InputSchema: mcp.ToolInputSchema{
+				Type: "object",
+				Properties: map[string]interface{}{
+					"sdk": map[string]interface{}{
+						"type":        "string",
+						"description": "SDK to bump: 'js', 'go' or 'both' (default: both)",
+						"enum":        []string{"js", "go", "both"},
+					},
+					"to": map[string]interface{}{
+						"type":        "string",
+						"description": "quickbase-spec ref to pin (default: the spec branch's upstream, e.g. 'origin/main', else HEAD)",
+					},
+					"fetch": map[string]interface{}{
+						"type":        "boolean",
+						"description": "Fetch quickbase-spec first (default: false)",
+					},
+					"confirm": map[string]interface{}{
+						"type":        "boolean",
+						"description": "Actually move the pins; without it nothing is changed (default: false)",
+					},
+					"commit": map[string]interface{}{
+						"type":        "boolean",
+						"description": "With confirm, also commit the bump in each SDK (default: false, leaving it staged)",
+					},
+				},
+			},
+		},
+		// 50. find_related_commits
+		{
+			Name:        "find_related_commits",
+			Description: "Find the other SDK's counterparts of a commit, or pair up both SDKs' commits to a feature, scoring candidates by shared feature-map paths, similar messages and closeness in time. Use it to check that a change was actually ported to the other language.",
+			InputSchema: mcp.ToolInputSchema{
+				Type: "object",
+				Properties: map[string]interface{}{
+					"commit": map[string]interface{}{
+						"type":        "string",
+						"description": "Commit in either SDK to find counterparts for",
+					},
+					"repo": map[string]interface{}{
+						"type":        "string",
+						"description": "SDK the commit is in: 'js' or 'go' (default: whichever has it)",
+						"enum":        []string{"js", "go"},
+					},
+					"feature": map[string]interface{}{
+						"type":        "string",
+						"description": "Feature-map feature whose commits to pair up across the SDKs (instead of commit)",
+					},
+					"since": map[string]interface{}{
+						"type":        "string",
+						"description": "With feature, how far back to look, as git takes it (default: '90 days ago')",
+					},
+					"window_days": map[string]interface{}{
+						"type":        "integer",
+						"description": "How many days apart a counterpart can be (default: 14)",
+					},
+					"limit": map[string]interface{}{
+						"type":        "integer",
+						"description": "Maximum counterparts listed for a commit (default: 5)",
+					},
+				},
+			},
+		},
+		// 51. draft_changelog
+		{
+			Name:        "draft_changelog",
+			Description: "Draft a CHANGELOG section from the commits since a repo's latest tag, grouped by conventional commit type (breaking changes, features, fixes, …) with scopes, and suggest the next semver version. Defaults to both SDKs.",
+			InputSchema: mcp.ToolInputSchema{
+				Type: "object",
+				Properties: map[string]interface{}{
+					"repo": map[string]interface{}{
+						"type":        "string",
+						"description": "Repo: 'js', 'go', 'spec' or a configured repo's name (default: both SDKs)",
+					},
+					"from": map[string]interface{}{
+						"type":        "string",
+						"description": "Start after this ref (default: the latest tag before to)",
+					},
+					"to": map[string]interface{}{
+						"type":        "string",
+						"description": "End at this ref (default: HEAD)",
+					},
+					"version": map[string]interface{}{
+						"type":        "string",
+						"description": "Version for the heading (default: the suggested next version, else 'Unreleased')",
+					},
+				},
+			},
+		},
+		// 52. draft_pr_description
+		{
+			Name:        "draft_pr_description",
+			Description: "Draft a PR description from a repo's changes: what changed (commits, features and files by kind), why (from commit bodies), parity impact (whether the other SDK has a matching change to each feature touched) and test coverage (source changes without test changes), cross-referenced with the feature map and the spec operations the diff mentions.",
+			InputSchema: mcp.ToolInputSchema{
+				Type: "object",
+				Properties: map[string]interface{}{
+					"repo": map[string]interface{}{
+						"type":        "string",
+						"description": "Repo: 'js', 'go', 'spec' or a configured repo's name",
+					},
+					"base": map[string]interface{}{
+						"type":        "string",
+						"description": "Branch the PR targets, e.g. 'main'; the branch's commits since it left base are included too (default: only uncommitted changes)",
+					},
+				},
+				Required: []string{"repo"},
+			},
+		},
+		// 53. list_wip
+		{
+			Name:        "list_wip",
+			Description: "List unfinished work across the repos: uncommitted files (staged, modified, untracked, conflicted) with when each was last modified, and stashes with their age, message and files. Filter with query to answer 'do I have half-finished temp-token work somewhere?'.",
+			InputSchema: mcp.ToolInputSchema{
+				Type: "object",
+				Properties: map[string]interface{}{
+					"repo": map[string]interface{}{
+						"type":        "string",
+						"description": "Limit to one repo: 'js', 'go', 'spec' or a configured repo's name (default: all)",
+					},
+					"query": map[string]interface{}{
+						"type":        "string",
+						"description": "Only files and stashes whose path, message or stashed files match this, ignoring case, spaces, '-' and '_' (e.g. 'temp token' matches temp_token.go)",
+					},
+				},
+			},
+		},
+		// 54. list_releases
+		{
+			Name:        "list_releases",
+			Description: "List each SDK's tags newest first with date, commit and tag message, plus the spec commit each release was built against (its spec submodule at the tag). Give operation to see which releases' spec includes it, for version-support questions.",
+			InputSchema: mcp.ToolInputSchema{
+				Type: "object",
+				Properties: map[string]interface{}{
+					"repo": map[string]interface{}{
+						"type":        "string",
+						"description": "Limit to one repo: 'js', 'go', 'spec' or a configured repo's name (default: both SDKs)",
+					},
+					"operation": map[string]interface{}{
+						"type":        "string",
+						"description": "operationId or 'METHOD /path' to check for in each release's pinned spec",
+					},
+					"limit": map[string]interface{}{
+						"type":        "integer",
+						"description": "Maximum tags per repo (default: 10)",
+					},
+				},
+			},
+		},
+		// 55. run_tests
+		{
+			Name:        "run_tests",
+			Description: "Run an SDK's test suite (go test or npm test, or the command set in test_commands in config.yaml) and report pass/fail counts plus each failing test's name, file and line, message and expected/actual diff, read from go test -json and vitest/jest JSON output. Give path to run one Go package pattern (./client/...) or JS test file or directory, and test_name to run a single test and get its own output.",
+			InputSchema: mcp.ToolInputSchema{
+				Type: "object",
+				Properties: map[string]interface{}{
+					"repo": map[string]interface{}{
+						"type":        "string",
+						"description": "'js', 'go' or a configured repo's name (default: both SDKs)",
+					},
+					"path": map[string]interface{}{
+						"type":        "string",
+						"description": "Go package pattern or JS test path to limit the run to",
+					},
+					"test_name": map[string]interface{}{
+						"type":        "string",
+						"description": "Run only this test and return its output: a Go test or subtest (TestPaginate, TestTable/empty) or a vitest/jest test name",
+					},
+					"timeout_seconds": map[string]interface{}{
+						"type":        "integer",
+						"description": "Timeout per repo (default: 300)",
+					},
+				},
+			},
+		},
+		// 56. coverage_report
+		{
+			Name:        "coverage_report",
+			Description: "Run both SDKs' tests with coverage and roll statement coverage up by feature, using the feature map's paths, side by side. Features where one SDK's coverage is materially lower than the other's are listed with their least covered files.",
+			InputSchema: mcp.ToolInputSchema{
+				Type: "object",
+				Properties: map[string]interface{}{
+					"feature": map[string]interface{}{
+						"type":        "string",
+						"description": "Limit the rollup to one feature",
+					},
+					"threshold": map[string]interface{}{
+						"type":        "number",
+						"description": "Percentage points of difference that count as lagging (default: 20)",
+					},
+					"timeout_seconds": map[string]interface{}{
+						"type":        "integer",
+						"description": "Timeout per repo (default: 300)",
+					},
+				},
+			},
+		},
+		// 57. run_lint
+		{
+			Name:        "run_lint",
+			Description: "Run golangci-lint on quickbase-go and eslint on quickbase-js (or the command set in lint_commands in config.yaml), using each repo's own lint config, and return the findings as issues with file, line, rule, severity and message.",
+			InputSchema: mcp.ToolInputSchema{
+				Type: "object",
+				Properties: map[string]interface{}{
+					"repo": map[string]interface{}{
+						"type":        "string",
+						"description": "'js', 'go' or a configured repo's name (default: both SDKs)",
+					},
+					"path": map[string]interface{}{
+						"type":        "string",
+						"description": "Go package pattern or JS file or directory to lint instead of the whole repo",
+					},
+					"limit": map[string]interface{}{
+						"type":        "integer",
+						"description": "Maximum issues listed per repo (default: 100)",
+					},
+					"timeout_seconds": map[string]interface{}{
+						"type":        "integer",
+						"description": "Timeout per repo (default: 300)",
+					},
+				},
+			},
+		},
+		// 58. type_check
+		{
+			Name:        "type_check",
+			Description: "Type-check the SDKs: tsc --noEmit for quickbase-js, go build then go vet for quickbase-go. Errors are grouped by file with line, column, code and message.",
+			InputSchema: mcp.ToolInputSchema{
+				Type: "object",
+				Properties: map[string]interface{}{
+					"repo": map[string]interface{}{
+						"type":        "string",
+						"description": "'js', 'go' or a configured repo's name (default: both SDKs)",
+					},
+					"timeout_seconds": map[string]interface{}{
+						"type":        "integer",
+						"description": "Timeout per command (default: 300)",
+					},
+				},
+			},
+		},
+		// 59. run_codegen
+		{
+			Name:        "run_codegen",
+			Description: "Regenerate the SDK clients from the spec in their working trees: oapi-codegen with quickbase-go's config and quickbase-js's package.json generate script, or the commands set in codegen_commands in config.yaml. Reports the files the run changed.",
+			InputSchema: mcp.ToolInputSchema{
+				Type: "object",
+				Properties: map[string]interface{}{
+					"repo": map[string]interface{}{
+						"type":        "string",
+						"description": "'js' or 'go' (default: both SDKs)",
+					},
+					"spec": map[string]interface{}{
+						"type":        "string",
+						"description": "Spec to generate from: 'working' (the quickbase-spec working tree, default), 'pin' (the commit the SDK's spec submodule pins) or a quickbase-spec ref",
+					},
+					"timeout_seconds": map[string]interface{}{
+						"type":        "integer",
+						"description": "Timeout per repo (default: 300)",
+					},
+				},
+			},
+		},
+		// 60. check_codegen_stale
+		{
+			Name:        "check_codegen_stale",
+			Description: "Check whether each SDK's committed generated code is stale: regenerate in a scratch worktree of HEAD from the spec HEAD pins and diff the result against what's committed. The working tree is not touched.",
+			InputSchema: mcp.ToolInputSchema{
+				Type: "object",
+				Properties: map[string]interface{}{
+					"repo": map[string]interface{}{
+						"type":        "string",
+						"description": "'js' or 'go' (default: both SDKs)",
+					},
+					"spec": map[string]interface{}{
+						"type":        "string",
+						"description": "Spec to generate from: 'pin' (default), 'working' or a quickbase-spec ref",
+					},
+					"timeout_seconds": map[string]interface{}{
+						"type":        "integer",
+						"description": "Timeout per repo (default: 300)",
+					},
+				},
+			},
+		},
+		// 61. check_dependencies
+		{
+			Name:        "check_dependencies",
+			Description: "List outdated and vulnerable dependencies per SDK: go list -m -u and govulncheck for Go, npm outdated and npm audit for JS. Vulnerabilities whose code is called (Go) or that are direct dependencies (JS) are listed first.",
+			InputSchema: mcp.ToolInputSchema{
+				Type: "object",
+				Properties: map[string]interface{}{
+					"repo": map[string]interface{}{
+						"type":        "string",
+						"description": "'js' or 'go' (default: both SDKs)",
+					},
+					"include_indirect": map[string]interface{}{
+						"type":        "boolean",
+						"description": "Also list outdated indirect Go modules (default: false, only counted)",
+					},
+					"vulnerabilities": map[string]interface{}{
+						"type":        "boolean",
+						"description": "Run govulncheck / npm audit (default: true)",
+					},
+					"timeout_seconds": map[string]interface{}{
+						"type":        "integer",
+						"description": "Timeout per command (default: 300)",
+					},
+				},
+			},
+		},
+		// 62. run_race_tests
+		{
+			Name:        "run_race_tests",
+			Description: "Run the Go SDK's tests with the race detector (-race), optionally several times over with count to shake out flaky tests. Reports each data race once with its two conflicting accesses and where their goroutines started, tests that passed in some runs and failed in others, and any other failures.",
+			InputSchema: mcp.ToolInputSchema{
+				Type: "object",
+				Properties: map[string]interface{}{
+					"path": map[string]interface{}{
+						"type":        "string",
+						"description": "Package pattern to test, e.g. './throttle/...' (default: ./...)",
+					},
+					"test_name": map[string]interface{}{
+						"type":        "string",
+						"description": "Run only this test, e.g. 'TestTokenRefresh' or 'TestThrottle/burst'",
+					},
+					"count": map[string]interface{}{
+						"type":        "integer",
+						"description": "How many times to run each test (default: 1)",
+					},
+					"timeout_seconds": map[string]interface{}{
+						"type":        "integer",
+						"description": "Timeout for the whole run (default: 300 per count)",
+					},
+				},
+			},
+		},
+		// 63. detect_flaky
+		{
+			Name:        "detect_flaky",
+			Description: "Run one test many times in each SDK to prove or rule out flakiness. Reports how many runs failed, groups the failing runs by how they failed, and diffs a passing run's output against a failing one. parallel runs several copies at once to put the machine under load.",
+			InputSchema: mcp.ToolInputSchema{
+				Type: "object",
+				Properties: map[string]interface{}{
+					"test_name": map[string]interface{}{
+						"type":        "string",
+						"description": "Test to run in both SDKs: a Go test name (subtests as 'TestRetry/backoff') or a vitest/jest -t pattern",
+					},
+					"go_test_name": map[string]interface{}{
+						"type":        "string",
+						"description": "Test name for the Go SDK when it differs from test_name",
+					},
+					"js_test_name": map[string]interface{}{
+						"type":        "string",
+						"description": "Test name pattern for the JS SDK when it differs from test_name",
+					},
+					"repo": map[string]interface{}{
+						"type":        "string",
+						"description": "'js' or 'go' (default: both SDKs)",
+					},
+					"path": map[string]interface{}{
+						"type":        "string",
+						"description": "Package pattern or test file to narrow the run to",
+					},
+					"runs": map[string]interface{}{
+						"type":        "integer",
+						"description": "How many times to run the test (default: 10)",
+					},
+					"parallel": map[string]interface{}{
+						"type":        "integer",
+						"description": "Runs to have going at once, to test under load (default: 1)",
+					},
+					"timeout_seconds": map[string]interface{}{
+						"type":        "integer",
+						"description": "Timeout per run (default: 300)",
+					},
+				},
+			},
+		},
+		// 64. profile_benchmark
+		{
+			Name:        "profile_benchmark",
+			Description: "Run a Go SDK benchmark with -cpuprofile and -memprofile and return its results with the `go tool pprof -top` tables, for performance work on throttling, pagination and the like. The package is found from the benchmark name unless path is given.",
+			InputSchema: mcp.ToolInputSchema{
+				Type: "object",
+				Properties: map[string]interface{}{
+					"benchmark": map[string]interface{}{
+						"type":        "string",
+						"description": "Benchmark name or -bench pattern, e.g. 'BenchmarkPaginate'",
+					},
+					"path": map[string]interface{}{
+						"type":        "string",
+						"description": "Package to benchmark, e.g. './client' (default: the one package with a matching benchmark)",
+					},
+					"profile": map[string]interface{}{
+						"type":        "string",
+						"description": "'cpu', 'mem' or 'both' (default: both)",
+					},
+					"benchtime": map[string]interface{}{
+						"type":        "string",
+						"description": "go test -benchtime, e.g. '5s' or '1000x' (default: go's 1s)",
+					},
+					"focus": map[string]interface{}{
+						"type":        "string",
+						"description": "Only count samples through functions matching this regex (pprof -focus)",
+					},
+					"top": map[string]interface{}{
+						"type":        "integer",
+						"description": "Functions to list per profile (default: 20)",
+					},
+					"timeout_seconds": map[string]interface{}{
+						"type":        "integer",
+						"description": "Timeout for the benchmark run (default: 300)",
+					},
+				},
+				Required: []string{"benchmark"},
+			},
+		},
+		// 65. bundle_size
+		{
+			Name:        "bundle_size",
+			Description: "Build quickbase-js and report the size of each bundle it ships (esm, cjs, browser), raw and gzipped, with the change against the last tag, built the same way in a scratch worktree.",
+			InputSchema: mcp.ToolInputSchema{
+				Type: "object",
+				Properties: map[string]interface{}{
+					"ref": map[string]interface{}{
+						"type":        "string",
+						"description": "Tag or commit to compare against (default: the latest tag)",
+					},
+					"timeout_seconds": map[string]interface{}{
+						"type":        "integer",
+						"description": "Timeout per build (default: 300)",
+					},
+				},
+			},
+		},
+		// 66. compile_snippet
+		{
+			Name:        "compile_snippet",
+			Description: "Compile a code snippet against the local SDK source and return the diagnostics, to validate examples or try out API designs before committing anything. Go snippets build inside quickbase-go's module through an overlay; TypeScript snippets are type-checked with tsc, with 'quickbase-js' resolving to quickbase-js/src. Nothing in the repos is written.",
+			InputSchema: mcp.ToolInputSchema{
+				Type: "object",
+				Properties: map[string]interface{}{
+					"language": map[string]interface{}{
+						"type":        "string",
+						"description": "'go' or 'ts'",
+					},
+					"code": map[string]interface{}{
+						"type":        "string",
+						"description": "The snippet. Go: a whole file, top-level declarations, or statements (wrapped in main, with SDK and common standard imports added). TS: a module; import from 'quickbase-js'.",
+					},
+					"timeout_seconds": map[string]interface{}{
+						"type":        "integer",
+						"description": "Timeout for the compile (default: 120)",
+					},
+				},
+				Required: []string{"language", "code"},
+			},
+		},
+		// 67. get_docs
+		{
+			Name:        "get_docs",
+			Description: "Get the doc comment and signature of a named symbol in either SDK, without reading whole files: go doc output for Go, and the declaration with its TSDoc comment for JS (from the shipped .d.ts when there is one). Names are matched across case conventions, so 'queryRecords' finds both QueryRecords and queryRecords.",
+			InputSchema: mcp.ToolInputSchema{
+				Type: "object",
+				Properties: map[string]interface{}{
+					"symbol": map[string]interface{}{
+						"type":        "string",
+						"description": "Symbol name: 'Paginate', 'Client.QueryRecords', or package-qualified for Go like 'client.Paginate'",
+					},
+					"repo": map[string]interface{}{
+						"type":        "string",
+						"description": "'js', 'go' or 'all' (default: all)",
+					},
+				},
+				Required: []string{"symbol"},
+			},
+		},
+		// 68. release_readiness
+		{
+			Name:        "release_readiness",
+			Description: "Check whether an SDK is ready to release and return a pass/fail checklist: clean working tree, tests passing, generated code fresh against the pinned spec, changelog updated, spec pin consistent with the other SDK, and version bumped past the latest tag. quickbase-js's version is read from package.json; quickbase-go's is the tag it's about to get, so pass version to check it.",
+			InputSchema: mcp.ToolInputSchema{
+				Type: "object",
+				Properties: map[string]interface{}{
+					"sdk": map[string]interface{}{
+						"type":        "string",
+						"description": "'js' or 'go'",
+					},
+					"version": map[string]interface{}{
+						"type":        "string",
+						"description": "Version about to be released, e.g. 'v1.4.0' (default: package.json's for JS)",
+					},
+					"skip_tests": map[string]interface{}{
+						"type":        "boolean",
+						"description": "Don't run the test suite (default: false)",
+					},
+					"skip_codegen": map[string]interface{}{
+						"type":        "boolean",
+						"description": "Don't regenerate code to check it's fresh (default: false)",
+					},
+					"timeout_seconds": map[string]interface{}{
+						"type":        "number",
+						"description": "Timeout for the tests and the generator, each (default: 300)",
+					},
+				},
+				Required: []string{"sdk"},
+			},
+		},
+		// 69. audit_licenses
+		{
+			Name:        "audit_licenses",
+			Description: "Inventory the licenses of the SDKs' dependencies and flag any outside an allowlist (MIT, Apache-2.0, BSD, ISC and similar by default; license_allowlist in config.yaml replaces it). quickbase-go's modules come from go list -deps with licenses read from the module cache; quickbase-js's come from node_modules, walked from package.json. Also reports each SDK's own license and dependencies with NOTICE files.",
+			InputSchema: mcp.ToolInputSchema{
+				Type: "object",
+				Properties: map[string]interface{}{
+					"repo": map[string]interface{}{
+						"type":        "string",
+						"description": "'js', 'go' or 'all' (default: all)",
+					},
+					"include_dev": map[string]interface{}{
+						"type":        "boolean",
+						"description": "Include devDependencies and Go test-only dependencies, which aren't shipped (default: false)",
+					},
+					"allow": map[string]interface{}{
+						"type":        "array",
+						"items":       map[string]interface{}{"type": "string"},
+						"description": "Extra SPDX licenses to allow for this call, e.g. ['MPL-2.0']",
+					},
+					"timeout_seconds": map[string]interface{}{
+						"type":        "number",
+						"description": "Timeout for go list (default: 120)",
+					},
+				},
+			},
+		},
+		// 70. build
+		{
+			Name:        "build",
+			Description: "Run the canonical build for an SDK (go build ./... for quickbase-go, npm run build for quickbase-js; build_commands in config.yaml overrides) and summarize failures as errors grouped by file with line references. Understands go, tsc, esbuild/tsup, rollup and vite output. A JS build that passes but doesn't write the files package.json points at is flagged.",
+			InputSchema: mcp.ToolInputSchema{
+				Type: "object",
+				Properties: map[string]interface{}{
+					"repo": map[string]interface{}{
+						"type":        "string",
+						"description": "'js', 'go', 'all' or a configured repo's name (default: all)",
+					},
+					"timeout_seconds": map[string]interface{}{
+						"type":        "number",
+						"description": "Timeout per build (default: 300)",
+					},
+				},
+			},
+		},
+		// 71. run_generate
+		{
+			Name:        "run_generate",
+			Description: "Discover and run the code generation steps across the repos in dependency order: spec, then types, then clients, then anything else (mocks, stringers). Steps are quickbase-go's //go:generate directives (each run alone) and npm scripts named generate, gen or codegen (or with those as a part, like generate:types), plus the spec repo's bundle/build scripts. Reports which steps changed files and stops at the first failure. Use dry_run to see the plan.",
+			InputSchema: mcp.ToolInputSchema{
+				Type: "object",
+				Properties: map[string]interface{}{
+					"repo": map[string]interface{}{
+						"type":        "string",
+						"description": "'js', 'go', 'spec' or 'all' (default: all)",
+					},
+					"dry_run": map[string]interface{}{
+						"type":        "boolean",
+						"description": "List the steps in order without running them (default: false)",
+					},
+					"timeout_seconds": map[string]interface{}{
+						"type":        "number",
+						"description": "Timeout per step (default: 300)",
+					},
+				},
+			},
+		},
+		// 72. api_snapshot
+		{
+			Name:        "api_snapshot",
+			Description: "Record the exported API surface of an SDK at a ref or the working tree: for Go, every exported func, method, type, struct field, interface method, const and var of the importable packages (gorelease-style, parameter names ignored); for TypeScript, the functions, class methods, types and fields exported from src/index (the .d.ts surface). Snapshots are stored for api_breaking_check.",
+			InputSchema: mcp.ToolInputSchema{
+				Type: "object",
+				Properties: map[string]interface{}{
+					"sdk": map[string]interface{}{
+						"type":        "string",
+						"description": "'js', 'go' or 'all' (default: all)",
+					},
+					"ref": map[string]interface{}{
+						"type":        "string",
+						"description": "Tag, branch or commit to snapshot (default: the working tree)",
+					},
+					"summary": map[string]interface{}{
+						"type":        "boolean",
+						"description": "Only report counts, not the entries (default: false)",
+					},
+				},
+			},
+		},
+		// 73. api_breaking_check
+		{
+			Name:        "api_breaking_check",
+			Description: "Compare an SDK's exported API in the working tree against the last tagged release (or base) and flag breaking changes before tagging: removed or changed functions, methods, types and fields, and methods added to Go interfaces. JS calls that only gain optional parameters count as compatible; fields added to existing TS types are listed for review. Reports the smallest version the changes allow and whether the planned version is enough.",
+			InputSchema: mcp.ToolInputSchema{
+				Type: "object",
+				Properties: map[string]interface{}{
+					"sdk": map[string]interface{}{
+						"type":        "string",
+						"description": "'js', 'go' or 'all' (default: all)",
+					},
+					"base": map[string]interface{}{
+						"type":        "string",
+						"description": "Ref to compare against (default: the latest tag)",
+					},
+					"version": map[string]interface{}{
+						"type":        "string",
+						"description": "The version you plan to tag, e.g. v1.3.0, checked against the changes",
+					},
+				},
+			},
+		},
+		// 74. qb_query_records
+		{
+			Name:        "qb_query_records",
+			Description: "Run a query against a real Quickbase table through the quickbase-go SDK itself (built from the local checkout) and return the records as JSON, keyed by field ID. Uses the configured realm and a user token from the environment, .env in the config directory, or the keychain. SDK errors are returned with their Go type, to surface rough edges in the SDK.",
 			InputSchema: mcp.ToolInputSchema{
 				Type: "object",
 				Properties: map[string]interface{}{
-					"query": map[string]interface{}{
+					"table": map[string]interface{}{
 						"type":        "string",
-						"description": "Search query (e.g., 'ticket auth', 'pagination', 'rate limit')",
+						"description": "Table ID (default: table_id from config.yaml)",
 					},
-					"repo": map[string]interface{}{
+					"select": map[string]interface{}{
+						"type":        "array",
+						"items":       map[string]interface{}{"type": "number"},
+						"description": "Field IDs to return (default: the table's default columns)",
+					},
+					"where": map[string]interface{}{
 						"type":        "string",
-						"description": "Limit to specific repo: 'js', 'go', 'spec', 'all' (default: 'all')",
-						"enum":        []string{"js", "go", "spec", "all"},
+						"description": "Quickbase query string, e.g. {6.EX.'open'}",
+					},
+					"limit": map[string]interface{}{
+						"type":        "number",
+						"description": "Maximum records to return (default: 100)",
+					},
+					"skip": map[string]interface{}{
+						"type":        "number",
+						"description": "Records to skip, for paging (default: 0)",
+					},
+					"timeout_seconds": map[string]interface{}{
+						"type":        "number",
+						"description": "Timeout for the call (default: 60)",
 					},
 				},
-				Required: []string{"query"},
 			},
 		},
-		// 2. compare_implementations
+		// 75. qb_list_apps
 		{
-			Name:        "compare_implementations",
-			Description: "Compare how a feature is implemented in JavaScript vs Go SDK.",
+			Name:        "qb_list_apps",
+			Description: "List the apps the configured user token can see in the realm, with their real app IDs (dbids), for use in example code. The REST API has no list call, so this uses the XML API's API_GrantedDBs from the live driver.",
 			InputSchema: mcp.ToolInputSchema{
 				Type: "object",
 				Properties: map[string]interface{}{
-					"feature": map[string]interface{}{
+					"filter": map[string]interface{}{
 						"type":        "string",
-						"description": "Feature to compare (e.g., 'ticket-auth', 'temp-token', 'pagination', 'retry')",
+						"description": "Only apps whose name contains this (case-insensitive)",
+					},
+					"timeout_seconds": map[string]interface{}{
+						"type":        "number",
+						"description": "Timeout for the call (default: 60)",
 					},
 				},
-				Required: []string{"feature"},
 			},
 		},
-		// 3. get_auth_example
+		// 76. qb_get_app
 		{
-			Name:        "get_auth_example",
-			Description: "Get authentication examples from your SDKs.",
+			Name:        "qb_get_app",
+			Description: "Dump a real app's metadata (name, description, dates, time zone, variables, ...) through the quickbase-go SDK's GetApp, so examples can use real IDs and context.",
 			InputSchema: mcp.ToolInputSchema{
 				Type: "object",
 				Properties: map[string]interface{}{
-					"auth_type": map[string]interface{}{
+					"app": map[string]interface{}{
 						"type":        "string",
-						"description": "Authentication type: 'user-token', 'temp-token', 'sso', 'ticket'",
-						"enum":        []string{"user-token", "temp-token", "sso", "ticket"},
+						"description": "App ID (default: app_id from config.yaml)",
 					},
-					"language": map[string]interface{}{
+					"refresh": map[string]interface{}{
+						"type":        "boolean",
+						"description": "Skip the cache and fetch a fresh copy (cached for 15 minutes otherwise)",
+					},
+					"timeout_seconds": map[string]interface{}{
+						"type":        "number",
+						"description": "Timeout for the call (default: 60)",
+					},
+				},
+			},
+		},
+		// 77. qb_list_tables
+		{
+			Name:        "qb_list_tables",
+			Description: "List the tables in a real app with their table IDs, aliases and key fields, through the quickbase-go SDK's GetAppTables.",
+			InputSchema: mcp.ToolInputSchema{
+				Type: "object",
+				Properties: map[string]interface{}{
+					"app": map[string]interface{}{
 						"type":        "string",
-						"description": "SDK language: 'js', 'go', 'both' (default: 'both')",
-						"enum":        []string{"js", "go", "both"},
+						"description": "App ID (default: app_id from config.yaml)",
+					},
+					"refresh": map[string]interface{}{
+						"type":        "boolean",
+						"description": "Skip the cache and fetch a fresh copy (cached for 15 minutes otherwise)",
+					},
+					"timeout_seconds": map[string]interface{}{
+						"type":        "number",
+						"description": "Timeout for the call (default: 60)",
 					},
 				},
-				Required: []string{"auth_type"},
 			},
 		},
-		// 4. list_features
+		// 78. qb_get_fields
 		{
-			Name:        "list_features",
-			Description: "List what features are implemented in your SDKs.",
+			Name:        "qb_get_fields",
+			Description: "List the fields of a real table: field ID, label, type (with formula/lookup/summary mode), required and unique, through the quickbase-go SDK's GetFields. Use it to look up the field IDs queries need.",
 			InputSchema: mcp.ToolInputSchema{
 				Type: "object",
 				Properties: map[string]interface{}{
-					"category": map[string]interface{}{
+					"table": map[string]interface{}{
 						"type":        "string",
-						"description": "Feature category: 'auth', 'client', 'pagination', 'all' (default: 'all')",
-						"enum":        []string{"auth", "client", "pagination", "all"},
+						"description": "Table ID (default: table_id from config.yaml)",
+					},
+					"filter": map[string]interface{}{
+						"type":        "string",
+						"description": "Only fields whose label contains this (case-insensitive)",
+					},
+					"refresh": map[string]interface{}{
+						"type":        "boolean",
+						"description": "Skip the cache and fetch a fresh copy (cached for 15 minutes otherwise)",
+					},
+					"timeout_seconds": map[string]interface{}{
+						"type":        "number",
+						"description": "Timeout for the call (default: 60)",
 					},
 				},
 			},
 		},
-		// 5. check_parity
+		// 79. qb_upsert_records
 		{
-			Name:        "check_parity",
-			Description: "Check feature parity between JavaScript and Go SDKs.",
+			Name:        "qb_upsert_records",
+			Description: "Insert or update records in a real table through the quickbase-go SDK's Upsert. Dry run by default: prints the exact payload and checks the SDK's request type encodes it the same, without sending anything. Writing needs both dry_run: false and confirm: true.",
+			InputSchema: mcp.ToolInputSchema{
+				Type: "object",
+				Properties: map[string]interface{}{
+					"table": map[string]interface{}{
+						"type":        "string",
+						"description": "Table ID (default: table_id from config.yaml)",
+					},
+					"records": map[string]interface{}{
+						"type":        "array",
+						"items":       map[string]interface{}{"type": "object"},
+						"description": "Records keyed by field ID, e.g. [{\"6\": \"open\", \"7\": 12}]; values may also be given as {\"value\": ...}",
+					},
+					"merge_field_id": map[string]interface{}{
+						"type":        "number",
+						"description": "Unique field to match existing records on (default: the key field)",
+					},
+					"fields_to_return": map[string]interface{}{
+						"type":        "array",
+						"items":       map[string]interface{}{"type": "number"},
+						"description": "Field IDs to return for the upserted records",
+					},
+					"dry_run": map[string]interface{}{
+						"type":        "boolean",
+						"description": "Only show the payload (default: true)",
+					},
+					"confirm": map[string]interface{}{
+						"type":        "boolean",
+						"description": "Must be true, with dry_run false, to write",
+					},
+					"timeout_seconds": map[string]interface{}{
+						"type":        "number",
+						"description": "Timeout for the call (default: 60)",
+					},
+				},
+				Required: []string{"records"},
+			},
+			Annotations: mcp.ToolAnnotation{
+				Title:           "Upsert Quickbase records",
+				ReadOnlyHint:    mcp.ToBoolPtr(false),
+				DestructiveHint: mcp.ToBoolPtr(true),
+				IdempotentHint:  mcp.ToBoolPtr(false),
+				OpenWorldHint:   mcp.ToBoolPtr(true),
+			},
+		},
+		// 80. qb_delete_records
+		{
+			Name:        "qb_delete_records",
+			Description: "Delete the records matching a where clause from a real table through the quickbase-go SDK's DeleteRecords, e.g. test records left by example runs. A call without token is a dry run: it lists the matching record IDs and returns a one-time token, valid for 10 minutes. Deleting takes a second call with the same table and where and that token, and is refused if the number of matches has changed.",
+			InputSchema: mcp.ToolInputSchema{
+				Type: "object",
+				Properties: map[string]interface{}{
+					"table": map[string]interface{}{
+						"type":        "string",
+						"description": "Table ID (default: table_id from config.yaml)",
+					},
+					"where": map[string]interface{}{
+						"type":        "string",
+						"description": "Quickbase query string selecting the records, e.g. {3.EX.'42'}",
+					},
+					"token": map[string]interface{}{
+						"type":        "string",
+						"description": "The token from the dry run, to delete",
+					},
+					"timeout_seconds": map[string]interface{}{
+						"type":        "number",
+						"description": "Timeout for each call (default: 60)",
+					},
+				},
+				Required: []string{"where"},
+			},
+			Annotations: mcp.ToolAnnotation{
+				Title:           "Delete Quickbase records",
+				ReadOnlyHint:    mcp.ToBoolPtr(false),
+				DestructiveHint: mcp.ToBoolPtr(true),
+				IdempotentHint:  mcp.ToBoolPtr(false),
+				OpenWorldHint:   mcp.ToBoolPtr(true),
+			},
+		},
+		// 81. qb_run_report
+		{
+			Name:        "qb_run_report",
+			Description: "Run a saved Quickbase report by ID through the quickbase-go SDK's RunReport and return a page of its rows, with the report's own filters, sorting and columns. Page through with skip and limit, as in qb_query_records.",
+			InputSchema: mcp.ToolInputSchema{
+				Type: "object",
+				Properties: map[string]interface{}{
+					"report": map[string]interface{}{
+						"type":        "string",
+						"description": "Report ID (the qid in the report's URL)",
+					},
+					"table": map[string]interface{}{
+						"type":        "string",
+						"description": "Table ID the report belongs to (default: table_id from config.yaml)",
+					},
+					"skip": map[string]interface{}{
+						"type":        "number",
+						"description": "Rows to skip (default: 0)",
+					},
+					"limit": map[string]interface{}{
+						"type":        "number",
+						"description": "Rows to return (default: 100)",
+					},
+					"timeout_seconds": map[string]interface{}{
+						"type":        "number",
+						"description": "Timeout for the call (default: 60)",
+					},
+				},
+				Required: []string{"report"},
+			},
+		},
+		// 82. qb_build_query
+		{
+			Name:        "qb_build_query",
+			Description: "Build or check a Quickbase query string ({fid.OP.'value'} comparisons joined with AND/OR). Give filters to build one (fields by ID or, with the live schema, label; operators as codes like EX/CT/GT or =, <, contains), or a query to check. Catches syntax errors, unknown operators, field IDs not in the table, operators that don't suit the field type and values that don't parse as the field's type, before the query reaches the API.",
+			InputSchema: mcp.ToolInputSchema{
+				Type: "object",
+				Properties: map[string]interface{}{
+					"query": map[string]interface{}{
+						"type":        "string",
+						"description": "Query string to check, e.g. {6.EX.'open'} AND {7.GT.'10'}",
+					},
+					"filters": map[string]interface{}{
+						"type": "array",
+						"items": map[string]interface{}{
+							"type": "object",
+							"properties": map[string]interface{}{
+								"field": map[string]interface{}{"type": []string{"number", "string"}, "description": "Field ID or label"},
+								"op":    map[string]interface{}{"type": "string", "description": "Operator: EX, XEX, CT, XCT, SW, XSW, LT, LTE, GT, GTE, BF, OBF, AF, OAF, IR, XIR, HAS, XHAS, TV, XTV, or =, !=, <, <=, >, >=, contains"},
+								"value": map[string]interface{}{"description": "Value to compare with"},
+							},
+						},
+						"description": "Comparisons to build a query from",
+					},
+					"join": map[string]interface{}{
+						"type":        "string",
+						"description": "How filters are joined: AND or OR (default: AND)",
+					},
+					"table": map[string]interface{}{
+						"type":        "string",
+						"description": "Table whose live schema to check against (default: table_id from config.yaml)",
+					},
+					"offline": map[string]interface{}{
+						"type":        "boolean",
+						"description": "Only check syntax, without the live schema (default: false)",
+					},
+					"refresh": map[string]interface{}{
+						"type":        "boolean",
+						"description": "Skip the cache and fetch a fresh copy (cached for 15 minutes otherwise)",
+					},
+					"timeout_seconds": map[string]interface{}{
+						"type":        "number",
+						"description": "Timeout for fetching the schema (default: 60)",
+					},
+				},
+			},
+		},
+		// 83. api_audit_log
+		{
+			Name:        "api_audit_log",
+			Description: "Show the log of live Quickbase API calls the qb_* tools have made, newest first: tool, endpoint, realm, dbid, a hash of the request payload, duration and result, including calls the sandbox or the rate limit refused. The log is only ever appended to.",
+			InputSchema: mcp.ToolInputSchema{
+				Type: "object",
+				Properties: map[string]interface{}{
+					"tool": map[string]interface{}{
+						"type":        "string",
+						"description": "Only calls made by this tool, e.g. qb_upsert_records",
+					},
+					"dbid": map[string]interface{}{
+						"type":        "string",
+						"description": "Only calls targeting this app or table ID",
+					},
+					"status": map[string]interface{}{
+						"type":        "string",
+						"description": "Only calls with this result",
+						"enum":        []string{"ok", "error", "timeout", "refused"},
+					},
+					"since_hours": map[string]interface{}{
+						"type":        "integer",
+						"description": "Only calls in the last this many hours",
+					},
+					"limit": map[string]interface{}{
+						"type":        "integer",
+						"description": "Maximum calls listed (default: 50)",
+					},
+				},
+			},
+		},
+		// 84. qb_rate_limit_status
+		{
+			Name:        "qb_rate_limit_status",
+			Description: "Show where the live tools stand against Quickbase's rate limit (100 requests per 10 seconds per user token): requests used and remaining in the current window, when it frees up, how often calls were held back, and the recent 429s the SDK's throttle/retry code reported.",
 			InputSchema: mcp.ToolInputSchema{
 				Type:       "object",
 				Properties: map[string]interface{}{},
 			},
 		},
+		// 85. qb_download_file
+		{
+			Name:        "qb_download_file",
+			Description: "Download a file attachment from a real record through the quickbase-go SDK's file endpoint: the latest version or a given one, saved to a temporary directory (or save_to), with its size and SHA-256. Small text files are shown inline.",
+			InputSchema: mcp.ToolInputSchema{
+				Type: "object",
+				Properties: map[string]interface{}{
+					"table": map[string]interface{}{
+						"type":        "string",
+						"description": "Table ID (default: table_id from config.yaml)",
+					},
+					"record": map[string]interface{}{
+						"type":        "integer",
+						"description": "Record ID",
+					},
+					"field": map[string]interface{}{
+						"type":        "integer",
+						"description": "File attachment field ID",
+					},
+					"version": map[string]interface{}{
+						"type":        "integer",
+						"description": "Version number (default: the latest)",
+					},
+					"save_to": map[string]interface{}{
+						"type":        "string",
+						"description": "Directory to save the file in (default: a temporary directory, cleared after a day)",
+					},
+					"max_bytes": map[string]interface{}{
+						"type":        "integer",
+						"description": "Refuse files bigger than this (default: 10485760, 10 MiB)",
+					},
+					"timeout_seconds": map[string]interface{}{
+						"type":        "number",
+						"description": "Timeout for the call (default: 60)",
+					},
+				},
+				Required: []string{"record", "field"},
+			},
+		},
+		// 86. qb_upload_file
+		{
+			Name:        "qb_upload_file",
+			Description: "Upload a local file to a real record's file attachment field as a new version, through the quickbase-go SDK's Upsert. Dry run by default: checks the file and size limit and shows the record's current versions, without sending anything. Uploading needs both dry_run: false and confirm: true.",
+			InputSchema: mcp.ToolInputSchema{
+				Type: "object",
+				Properties: map[string]interface{}{
+					"table": map[string]interface{}{
+						"type":        "string",
+						"description": "Table ID (default: table_id from config.yaml)",
+					},
+					"record": map[string]interface{}{
+						"type":        "integer",
+						"description": "Record ID",
+					},
+					"field": map[string]interface{}{
+						"type":        "integer",
+						"description": "File attachment field ID",
+					},
+					"path": map[string]interface{}{
+						"type":        "string",
+						"description": "Local file to upload",
+					},
+					"file_name": map[string]interface{}{
+						"type":        "string",
+						"description": "File name to store it under (default: the local file's name)",
+					},
+					"max_bytes": map[string]interface{}{
+						"type":        "integer",
+						"description": "Refuse files bigger than this (default: 10485760, 10 MiB)",
+					},
+					"dry_run": map[string]interface{}{
+						"type":        "boolean",
+						"description": "Only check the file and record (default: true)",
+					},
+					"confirm": map[string]interface{}{
+						"type":        "boolean",
+						"description": "Must be true, with dry_run: false, to upload",
+					},
+					"timeout_seconds": map[string]interface{}{
+						"type":        "number",
+						"description": "Timeout for the call (default: 60)",
+					},
+				},
+				Required: []string{"record", "field", "path"},
+			},
+			Annotations: mcp.ToolAnnotation{
+				Title:           "Upload a Quickbase file attachment",
+				ReadOnlyHint:    mcp.ToBoolPtr(false),
+				DestructiveHint: mcp.ToBoolPtr(false),
+				IdempotentHint:  mcp.ToBoolPtr(false),
+				OpenWorldHint:   mcp.ToBoolPtr(true),
+			},
+		},
+		// 87. qb_get_relationships
+		{
+			Name:        "qb_get_relationships",
+			Description: "Map a real app's shape: its parent/child table relationships with each child's reference field, the lookup fields a child pulls from its parent and the summary fields a parent takes of its children, through the quickbase-go SDK's GetRelationships. Markdown or structured JSON, optionally with a mermaid ER diagram.",
+			InputSchema: mcp.ToolInputSchema{
+				Type: "object",
+				Properties: map[string]interface{}{
+					"app": map[string]interface{}{
+						"type":        "string",
+						"description": "App ID (default: app_id from config.yaml)",
+					},
+					"format": map[string]interface{}{
+						"type":        "string",
+						"description": "Output format: 'markdown' or 'json' (default: 'markdown')",
+						"enum":        []string{"markdown", "json"},
+					},
+					"diagram": map[string]interface{}{
+						"type":        "boolean",
+						"description": "Also emit a mermaid ER diagram of the tables and relationships",
+					},
+					"refresh": map[string]interface{}{
+						"type":        "boolean",
+						"description": "Skip the cache and fetch a fresh copy (cached for 15 minutes otherwise)",
+					},
+					"timeout_seconds": map[string]interface{}{
+						"type":        "number",
+						"description": "Timeout for the call (default: 60)",
+					},
+				},
+			},
+		},
+		// 88. qb_export_schema
+		{
+			Name:        "qb_export_schema",
+			Description: "Export a real app's schema (tables, fields with their properties, relationships and reports) to a normalized YAML or JSON file in schema_dir, sorted by ID with data-dependent values left out, so schema changes can be diffed and committed with git. Reports what changed since the last export.",
+			InputSchema: mcp.ToolInputSchema{
+				Type: "object",
+				Properties: map[string]interface{}{
+					"app": map[string]interface{}{
+						"type":        "string",
+						"description": "App ID (default: app_id from config.yaml)",
+					},
+					"format": map[string]interface{}{
+						"type":        "string",
+						"description": "File format: 'yaml' or 'json' (default: 'yaml')",
+						"enum":        []string{"yaml", "json"},
+					},
+					"timeout_seconds": map[string]interface{}{
+						"type":        "number",
+						"description": "Timeout for reading the whole schema (default: 120)",
+					},
+				},
+			},
+		},
+		// 89. reset_sandbox
+		{
+			Name:        "reset_sandbox",
+			Description: "Restore the sandbox app to its canonical schema (the qb_export_schema file in schema_dir) and seed dataset (<app id>.seed.yaml beside it) in one call: recreate missing fields, delete extra ones, then empty each seeded table and upsert its seed records. Only runs in sandbox mode. Dry run by default, showing the plan; resetting needs both dry_run: false and confirm: true.",
+			InputSchema: mcp.ToolInputSchema{
+				Type: "object",
+				Properties: map[string]interface{}{
+					"dry_run": map[string]interface{}{
+						"type":        "boolean",
+						"description": "Only show what the reset would do (default: true)",
+					},
+					"confirm": map[string]interface{}{
+						"type":        "boolean",
+						"description": "Must be true, with dry_run: false, to reset",
+					},
+					"timeout_seconds": map[string]interface{}{
+						"type":        "number",
+						"description": "Timeout for each call (default: 60)",
+					},
+				},
+			},
+			Annotations: mcp.ToolAnnotation{
+				Title:           "Reset the Quickbase sandbox",
+				ReadOnlyHint:    mcp.ToBoolPtr(false),
+				DestructiveHint: mcp.ToBoolPtr(true),
+				IdempotentHint:  mcp.ToBoolPtr(true),
+				OpenWorldHint:   mcp.ToBoolPtr(true),
+			},
+		},
 	}
 }
 
 // Tool handlers
 func (s *QuickBasePersonalMCPServer) handleSearchCode(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 	var params struct {
-		Query string `json:"query"`
-		Repo  string `json:"repo"`
+		Query          string `json:"query"`
+		Repo           string `json:"repo"`
+		ExpandSynonyms *bool  `json:"expand_synonyms"`
 	}
 	argsData, _ := json.Marshal(request.Params.Arguments)
 	if err := json.Unmarshal(argsData, &params); err != nil {
@@ -163,25 +2500,40 @@ func (s *QuickBasePersonalMCPServer) handleSearchCode(ctx context.Context, reque
 		params.Repo = "all"
 	}
 
+	expand := params.ExpandSynonyms == nil || *params.ExpandSynonyms
+	output, matches := s.searchCode(params.Query, params.Repo, expand)
+
+	entry := searchHistoryEntry{Query: params.Query, Repo: params.Repo, Matches: matches, Timestamp: time.Now()}
+	if err := s.store.appendCapped(searchHistoryBucket, entry, searchHistoryLimit); err != nil {
+		s.logger.Printf("Failed to record search history: %v", err)
+	}
+
+	return mcp.NewToolResultText(output), nil
+}
+
+// searchCode runs ripgrep over the selected repos and returns the
+// formatted results along with the total number of matching lines. With
+// expand set, QuickBase domain synonyms in the query are matched too.
+func (s *QuickBasePersonalMCPServer) searchCode(query, repo string, expand bool) (string, int) {
 	// Determine which repos to search
 	repos := []struct {
 		name string
 		path string
 	}{}
 
-	if params.Repo == "all" || params.Repo == "js" {
+	if repo == "all" || repo == "js" {
 		repos = append(repos, struct {
 			name string
 			path string
 		}{"quickbase-js", quickbaseJSPath})
 	}
-	if params.Repo == "all" || params.Repo == "go" {
+	if repo == "all" || repo == "go" {
 		repos = append(repos, struct {
 			name string
 			path string
 		}{"quickbase-go", quickbaseGoPath})
 	}
-	if params.Repo == "all" || params.Repo == "spec" {
+	if repo == "all" || repo == "spec" {
 		repos = append(repos, struct {
 			name string
 			path string
@@ -189,163 +2541,186 @@ func (s *QuickBasePersonalMCPServer) handleSearchCode(ctx context.Context, reque
 	}
 
 	var results strings.Builder
-	results.WriteString(fmt.Sprintf("Searching for: %s\n\n", params.Query))
+	results.WriteString(fmt.Sprintf("Searching for: %s\n", query))
+
+	pattern := query
+	if expand {
+		var expansions []string
+		pattern, expansions = expandSearchSynonyms(query)
+		for _, e := range expansions {
+			results.WriteString(fmt.Sprintf("Expanded: %s\n", e))
+		}
+	}
+	results.WriteString("\n")
 
+	matches := 0
 	for _, repo := range repos {
 		results.WriteString(fmt.Sprintf("## %s\n\n", repo.name))
 
 		// Use ripgrep for fast searching
-		cmd := exec.Command("rg", "--no-heading", "--line-number", "--color", "never", params.Query, repo.path)
+		cmd := exec.Command("rg", "--no-heading", "--line-number", "--color", "never", pattern, repo.path)
 		output, err := cmd.Output()
 		if err != nil {
 			results.WriteString(fmt.Sprintf("No matches found\n\n"))
 			continue
 		}
 
-		results.WriteString(string(output))
+		matches += strings.Count(string(output), "\n")
+		results.WriteString(rankSearchResults(string(output)))
 		results.WriteString("\n")
 	}
 
-	return mcp.NewToolResultText(results.String()), nil
+	return results.String(), matches
 }
 
 func (s *QuickBasePersonalMCPServer) handleCompareImplementations(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 	var params struct {
-		Feature string `json:"feature"`
+		Feature         string `json:"feature"`
+		JSDir           string `json:"js_dir"`
+		GoDir           string `json:"go_dir"`
+		JSPath          string `json:"js_path"`
+		GoPath          string `json:"go_path"`
+		JSRef           string `json:"js_ref"`
+		GoRef           string `json:"go_ref"`
+		IncludeContents bool   `json:"include_contents"`
+		IncludeTests    bool   `json:"include_tests"`
+		MaxBytes        int    `json:"max_bytes"`
+		MaxTokens       int    `json:"max_tokens"`
+		Output          string `json:"output"`
 	}
 	argsData, _ := json.Marshal(request.Params.Arguments)
 	if err := json.Unmarshal(argsData, &params); err != nil {
 		return mcp.NewToolResultError(fmt.Sprintf("Invalid parameters: %v", err)), nil
 	}
 
-	// Map features to file paths
-	featureMap := map[string]struct {
-		jsPath string
-		goPath string
-	}{
-		"ticket-auth":  {jsPath: "src/auth/ticket.ts", goPath: "auth/ticket.go"},
-		"temp-token":   {jsPath: "src/auth/temp-token.ts", goPath: "auth/temp_token.go"},
-		"user-token":   {jsPath: "src/auth/user-token.ts", goPath: "auth/user_token.go"},
-		"sso":          {jsPath: "src/auth/sso.ts", goPath: "auth/sso_token.go"},
-		"pagination":   {jsPath: "src/client/pagination.ts", goPath: "client/pagination.go"},
-		"retry":        {jsPath: "src/client/retry.ts", goPath: "client/client.go"},
-		"throttle":     {jsPath: "src/client/throttle.ts", goPath: "client/throttle.go"},
+	// Directory mode compares whole subsystems
+	if params.JSDir != "" || params.GoDir != "" {
+		if params.JSDir == "" || params.GoDir == "" {
+			return mcp.NewToolResultError("js_dir and go_dir must be given together"), nil
+		}
+		if params.JSRef != "" || params.GoRef != "" {
+			return mcp.NewToolResultError("js_ref and go_ref are not supported with js_dir/go_dir"), nil
+		}
+		result, err := compareDirectories(params.JSDir, params.GoDir, params.IncludeContents)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		return mcp.NewToolResultText(result), nil
 	}
-
-	paths, ok := featureMap[params.Feature]
-	if !ok {
-		return mcp.NewToolResultError(fmt.Sprintf("Unknown feature: %s", params.Feature)), nil
+	// Refs read files with `git show` instead of the working tree
+	if params.JSRef != "" {
+		if err := verifyRef(quickbaseJSPath, params.JSRef); err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("quickbase-js: %v", err)), nil
+		}
 	}
-
-	var results strings.Builder
-	results.WriteString(fmt.Sprintf("# Comparing: %s\n\n", params.Feature))
-
-	// Read JS implementation
-	jsFullPath := filepath.Join(quickbaseJSPath, paths.jsPath)
-	jsContent, err := os.ReadFile(jsFullPath)
-	if err != nil {
-		results.WriteString(fmt.Sprintf("## JavaScript (%s)\nFile not found\n\n", paths.jsPath))
-	} else {
-		results.WriteString(fmt.Sprintf("## JavaScript (%s)\n\n```typescript\n%s\n```\n\n", paths.jsPath, string(jsContent)))
+	if params.GoRef != "" {
+		if err := verifyRef(quickbaseGoPath, params.GoRef); err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("quickbase-go: %v", err)), nil
+		}
 	}
 
-	// Read Go implementation
-	goFullPath := filepath.Join(quickbaseGoPath, paths.goPath)
-	goContent, err := os.ReadFile(goFullPath)
-	if err != nil {
-		results.WriteString(fmt.Sprintf("## Go (%s)\nFile not found\n\n", paths.goPath))
-	} else {
-		results.WriteString(fmt.Sprintf("## Go (%s)\n\n```go\n%s\n```\n\n", paths.goPath, string(goContent)))
+	var jsFiles, goFiles []string
+	var feature featureEntry
+	label := params.Feature
+	switch {
+	case params.JSPath != "" || params.GoPath != "":
+		// Explicit file pair, validated to stay inside the repos
+		if params.JSPath != "" {
+			if _, err := resolveRepoPath(quickbaseJSPath, params.JSPath); err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("Invalid js_path: %v", err)), nil
+			}
+			jsFiles = []string{filepath.ToSlash(filepath.Clean(params.JSPath))}
+		}
+		if params.GoPath != "" {
+			if _, err := resolveRepoPath(quickbaseGoPath, params.GoPath); err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("Invalid go_path: %v", err)), nil
+			}
+			goFiles = []string{filepath.ToSlash(filepath.Clean(params.GoPath))}
+		}
+		label = strings.Join(append(append([]string{}, jsFiles...), goFiles...), " ↔ ")
+	case params.Feature != "":
+		features, err := loadFeatureMap()
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("Failed to load feature map: %v", err)), nil
+		}
+		var ok bool
+		feature, ok = features[params.Feature]
+		if !ok {
+			return mcp.NewToolResultError(fmt.Sprintf("Unknown feature: %s (available: %s)", params.Feature, strings.Join(featureNames(features), ", "))), nil
+		}
+		jsFiles = resolveFeatureFilesAt(quickbaseJSPath, params.JSRef, feature.JS)
+		goFiles = resolveFeatureFilesAt(quickbaseGoPath, params.GoRef, feature.Go)
+	default:
+		return mcp.NewToolResultError("feature is required (or js_path/go_path for a file pair, js_dir and go_dir for a directory comparison)"), nil
 	}
 
-	return mcp.NewToolResultText(results.String()), nil
-}
+	if params.JSRef != "" || params.GoRef != "" {
+		label += fmt.Sprintf(" (%s ↔ %s)", refLabel("quickbase-js", params.JSRef), refLabel("quickbase-go", params.GoRef))
+	}
 
-func (s *QuickBasePersonalMCPServer) handleGetAuthExample(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	var params struct {
-		AuthType string `json:"auth_type"`
-		Language string `json:"language"`
+	if params.Output == "todos" {
+		return mcp.NewToolResultText(compareTodos(label, jsFiles, goFiles, params.JSRef, params.GoRef)), nil
 	}
-	argsData, _ := json.Marshal(request.Params.Arguments)
-	if err := json.Unmarshal(argsData, &params); err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("Invalid parameters: %v", err)), nil
+	if params.Output == "symbols" {
+		return mcp.NewToolResultText(compareSymbols(label, jsFiles, goFiles, params.JSRef, params.GoRef)), nil
 	}
-	if params.Language == "" {
-		params.Language = "both"
+	if params.Output == "diff" {
+		result, err := diffImplementations(label, jsFiles, goFiles, params.JSRef, params.GoRef)
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("Failed to diff implementations: %v", err)), nil
+		}
+		return mcp.NewToolResultText(result), nil
 	}
 
-	// TODO: Implement auth examples
-	result := fmt.Sprintf("Getting %s auth examples for: %s\n\nTODO: Implement auth examples", params.AuthType, params.Language)
-
-	return mcp.NewToolResultText(result), nil
-}
+	var results strings.Builder
+	results.WriteString(fmt.Sprintf("# Comparing: %s\n\n", label))
 
-func (s *QuickBasePersonalMCPServer) handleListFeatures(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	var params struct {
-		Category string `json:"category"`
+	// Read both implementations; over budget, large files become outlines
+	type implFile struct {
+		lang, fence, rel, name, content string
+		found                           bool
 	}
-	argsData, _ := json.Marshal(request.Params.Arguments)
-	if err := json.Unmarshal(argsData, &params); err != nil {
-		params.Category = "all"
+	var impls []implFile
+	total := 0
+	for _, side := range []struct {
+		lang, fence, root, ref string
+		files                  []string
+	}{
+		{"JavaScript", "typescript", quickbaseJSPath, params.JSRef, jsFiles},
+		{"Go", "go", quickbaseGoPath, params.GoRef, goFiles},
+	} {
+		for _, rel := range side.files {
+			f := implFile{lang: side.lang, fence: side.fence, rel: rel, name: refLabel(rel, side.ref)}
+			if data, err := readRepoFile(side.root, side.ref, rel); err == nil {
+				f.content, f.found = string(data), true
+				total += len(data)
+			}
+			impls = append(impls, f)
+		}
+	}
+	budget := params.MaxBytes
+	if params.MaxTokens > 0 {
+		budget = params.MaxTokens * bytesPerToken
+	}
+	share := 0
+	if budget > 0 && total > budget && len(impls) > 0 {
+		share = budget / len(impls)
+		results.WriteString(fmt.Sprintf("Files total %d bytes, over the %d byte budget; files larger than %d bytes are shown as outlines (imports, exported signatures, doc comments).\n\n", total, budget, share))
 	}
 
-	result := `# QuickBase SDK Features
-
-## Authentication Methods
-- ✅ User Token (both JS & Go)
-- ✅ Temporary Token (both JS & Go)
-- ✅ SSO Token (both JS & Go)
-- ✅ Ticket Auth - API_Authenticate (both JS & Go)
-
-## Client Features
-- ✅ Retry with exponential backoff (both JS & Go)
-- ✅ Rate limiting / throttling (both JS & Go)
-- ✅ Automatic date parsing (both JS & Go)
-- ✅ Custom error types (both JS & Go)
-
-## Pagination
-- ✅ Fluent pagination API (both JS & Go)
-- ✅ Auto-pagination (both JS & Go)
-- ✅ Manual page iteration (both JS & Go)
-
-## Code Generation
-- ✅ TypeScript types from OpenAPI spec (JS)
-- ✅ Go types from OpenAPI spec (Go)
-- ✅ Shared OpenAPI spec (both)
-`
+	for _, f := range impls {
+		switch {
+		case !f.found:
+			results.WriteString(fmt.Sprintf("## %s (%s)\nFile not found\n\n", f.lang, f.name))
+		case share > 0 && len(f.content) > share:
+			results.WriteString(fmt.Sprintf("## %s (%s) - outline of %d bytes\n\n```%s\n%s```\n\n", f.lang, f.name, len(f.content), f.fence, outlineFile(f.rel, f.content, share)))
+		default:
+			results.WriteString(fmt.Sprintf("## %s (%s)\n\n```%s\n%s\n```\n\n", f.lang, f.name, f.fence, f.content))
+		}
+	}
 
-	return mcp.NewToolResultText(result), nil
-}
+	writeTestComparison(&results, jsFiles, goFiles, params.JSRef, params.GoRef, params.IncludeTests)
+	writeSpecCoverage(&results, params.Feature, feature, jsFiles, goFiles, params.JSRef, params.GoRef)
 
-func (s *QuickBasePersonalMCPServer) handleCheckParity(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	result := `# Feature Parity Check
-
-## ✅ Complete Parity
-- User Token Auth
-- Temporary Token Auth
-- SSO Token Auth
-- Ticket Auth (API_Authenticate)
-- Retry logic
-- Rate limiting
-- Date parsing
-- Error handling
-- Pagination (fluent API)
-
-## 🔄 Differences
-- **Browser support**: JS has browser bundles, Go is server-only
-- **Testing**: JS uses Vitest, Go uses native testing
-- **Generated code**: Different generators (openapi-generator-typescript vs oapi-codegen)
-
-## 📝 Implementation Notes
-- Both SDKs share the same OpenAPI spec via git submodule
-- Both follow the same architectural patterns
-- Both have identical test fixtures (JSON-based)
-- Code structure mirrors between languages
-
-## Version Info
-- quickbase-js: v2.1.0
-- quickbase-go: v1.2.0
-`
-
-	return mcp.NewToolResultText(result), nil
+	return mcp.NewToolResultText(results.String()), nil
 }
@@ -0,0 +1,194 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/printer"
+	"go/token"
+	"strings"
+)
+
+// bytesPerToken approximates the tokenizer so max_tokens can be turned
+// into a byte budget.
+const bytesPerToken = 4
+
+// goOutline reduces Go source to its package clause, imports and exported
+// declarations with their doc comments. Function bodies are dropped; type
+// declarations are kept whole since their fields are the interface.
+func goOutline(src []byte) (string, error) {
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, "", src, parser.ParseComments|parser.SkipObjectResolution)
+	if err != nil {
+		return "", err
+	}
+
+	var out bytes.Buffer
+	writeDoc := func(doc *ast.CommentGroup) {
+		if doc == nil {
+			return
+		}
+		for _, line := range strings.Split(strings.TrimRight(doc.Text(), "\n"), "\n") {
+			out.WriteString("// " + line + "\n")
+		}
+	}
+	printDecl := func(node interface{}) {
+		printer.Fprint(&out, fset, node)
+		out.WriteString("\n\n")
+	}
+
+	out.WriteString("package " + file.Name.Name + "\n\n")
+	if len(file.Imports) > 0 {
+		out.WriteString("import (\n")
+		for _, imp := range file.Imports {
+			out.WriteString("\t" + imp.Path.Value + "\n")
+		}
+		out.WriteString(")\n\n")
+	}
+
+	for _, decl := range file.Decls {
+		switch d := decl.(type) {
+		case *ast.FuncDecl:
+			if !d.Name.IsExported() {
+				continue
+			}
+			if d.Recv != nil && len(d.Recv.List) > 0 && !ast.IsExported(receiverName(d.Recv.List[0].Type)) {
+				continue
+			}
+			writeDoc(d.Doc)
+			printDecl(&ast.FuncDecl{Recv: d.Recv, Name: d.Name, Type: d.Type})
+		case *ast.GenDecl:
+			if d.Tok == token.IMPORT {
+				continue
+			}
+			var specs []ast.Spec
+			for _, spec := range d.Specs {
+				switch sp := spec.(type) {
+				case *ast.TypeSpec:
+					if sp.Name.IsExported() {
+						specs = append(specs, sp)
+					}
+				case *ast.ValueSpec:
+					for _, n := range sp.Names {
+						if n.IsExported() {
+							specs = append(specs, sp)
+							break
+						}
+					}
+				}
+			}
+			if len(specs) == 0 {
+				continue
+			}
+			writeDoc(d.Doc)
+			printDecl(&ast.GenDecl{Tok: d.Tok, Lparen: d.Lparen, Specs: specs, Rparen: d.Rparen})
+		}
+	}
+	return strings.TrimRight(out.String(), "\n") + "\n", nil
+}
+
+// jsOutline reduces TS/JS source to its imports, exported declarations,
+// public class members and the JSDoc comments attached to them. Function
+// bodies are dropped; interfaces and object types are kept whole.
+func jsOutline(src string) string {
+	var out strings.Builder
+	var doc []string
+	inDoc, inImport := false, false
+	depth := 0
+	keepUntil := -1 // keep every line while depth > keepUntil (shapes)
+	inClass := -1   // depth of the class body being outlined
+
+	for _, line := range strings.Split(src, "\n") {
+		trimmed := strings.TrimSpace(line)
+		docLine := inDoc || strings.HasPrefix(trimmed, "/**")
+		opens, closes := strings.Count(line, "{"), strings.Count(line, "}")
+		next := depth + opens - closes
+
+		switch {
+		case inDoc:
+			doc = append(doc, line)
+			if strings.Contains(trimmed, "*/") {
+				inDoc = false
+			}
+		case inImport:
+			out.WriteString(line + "\n")
+			if strings.Contains(line, " from ") || strings.HasSuffix(trimmed, ";") {
+				inImport = false
+			}
+		case keepUntil >= 0:
+			out.WriteString(line + "\n")
+			if next <= keepUntil {
+				keepUntil = -1
+			}
+		case strings.HasPrefix(trimmed, "/**") && (depth == 0 || depth == inClass):
+			doc = append(doc[:0], line)
+			inDoc = !strings.Contains(trimmed, "*/")
+		case depth == 0 && strings.HasPrefix(trimmed, "import "):
+			out.WriteString(line + "\n")
+			inImport = !strings.Contains(line, " from ") && !strings.HasSuffix(trimmed, ";")
+		case depth == 0 && strings.HasPrefix(trimmed, "export "):
+			for _, d := range doc {
+				out.WriteString(d + "\n")
+			}
+			switch {
+			case jsShapeStart.MatchString(line) && next > depth:
+				out.WriteString(line + "\n")
+				keepUntil = depth
+			case jsClassStart.MatchString(line):
+				out.WriteString(line + "\n")
+				inClass = depth + 1
+			default:
+				out.WriteString(signatureLine(line, next > depth))
+			}
+		case depth == inClass && trimmed == "}":
+			out.WriteString(line + "\n")
+			inClass = -1
+		case depth == inClass && jsMemberMethod.MatchString(line):
+			m := jsMemberMethod.FindStringSubmatch(line)
+			if m[1] != "private" && m[1] != "protected" && !strings.HasPrefix(m[2], "#") && !jsMethodKeywords[m[2]] || m[2] == "constructor" {
+				for _, d := range doc {
+					out.WriteString(d + "\n")
+				}
+				out.WriteString(signatureLine(line, next > depth))
+			}
+		}
+		if !docLine && trimmed != "" {
+			doc = doc[:0]
+		}
+		depth = next
+	}
+	return out.String()
+}
+
+// signatureLine cuts a declaration line at its opening body brace.
+func signatureLine(line string, opensBody bool) string {
+	if !opensBody {
+		return line + "\n"
+	}
+	if idx := strings.LastIndex(line, "{"); idx >= 0 {
+		line = strings.TrimRight(line[:idx], " ")
+	}
+	return line + " { … }\n"
+}
+
+// outlineFile returns an outline of a JS or Go file, falling back to the
+// first bytes of the file when it can't be parsed.
+func outlineFile(rel, content string, limit int) string {
+	var outline string
+	if strings.HasSuffix(rel, ".go") {
+		o, err := goOutline([]byte(content))
+		if err == nil {
+			outline = o
+		}
+	} else {
+		outline = jsOutline(content)
+	}
+	if strings.TrimSpace(outline) == "" {
+		outline = content
+	}
+	if len(outline) > limit {
+		outline = outline[:limit] + fmt.Sprintf("\n… truncated (%d of %d bytes shown)\n", limit, len(outline))
+	}
+	return outline
+}
@@ -0,0 +1,219 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+func (s *QuickBasePersonalMCPServer) handleCheckParity(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	var params struct {
+		Tag         string `json:"tag"`
+		MissingOnly bool   `json:"missing_only"`
+		GapsOnly    bool   `json:"gaps_only"`
+		Format      string `json:"format"`
+	}
+	argsData, _ := json.Marshal(request.Params.Arguments)
+	if err := json.Unmarshal(argsData, &params); err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Invalid parameters: %v", err)), nil
+	}
+	format, err := validReportFormat(params.Format)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	report, err := takeParitySnapshot()
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	// Every run becomes a data point for parity_drift
+	if err := s.saveParityReport(&report); err != nil {
+		s.logger.Printf("failed to save parity report: %v", err)
+	}
+
+	var rows []parityOperation
+	for _, op := range report.Operations {
+		if params.Tag == "" || containsFold(op.Tags, params.Tag) {
+			rows = append(rows, op)
+		}
+	}
+	if len(rows) == 0 {
+		return mcp.NewToolResultText(fmt.Sprintf("No operations tagged %q in the spec", params.Tag)), nil
+	}
+
+	var jsCount, goCount, bothCount int
+	var missingJS, missingGo []parityOperation
+	for _, r := range rows {
+		if r.JS != "" {
+			jsCount++
+		} else {
+			missingJS = append(missingJS, r)
+		}
+		if r.Go != "" {
+			goCount++
+		} else {
+			missingGo = append(missingGo, r)
+		}
+		if r.JS != "" && r.Go != "" {
+			bothCount++
+		}
+	}
+
+	scope := "all operations"
+	if params.Tag != "" {
+		scope = "operations tagged " + params.Tag
+	}
+	root, err := loadSpec()
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to load spec: %v", err)), nil
+	}
+	gaps := classifyParityGaps(root, rows, params.Tag == "")
+
+	if format != "markdown" {
+		out, err := renderParity(format, report, rows, gaps, scope, params.MissingOnly, params.GapsOnly, [3]int{jsCount, goCount, bothCount})
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("Failed to render %s: %v", format, err)), nil
+		}
+		return mcp.NewToolResultText(out), nil
+	}
+
+	var results strings.Builder
+	results.WriteString("# Feature Parity Check\n\n")
+	results.WriteString(fmt.Sprintf("Endpoint coverage for %s in the spec with an operationId, matched to client methods by operationId (generated variants such as `Raw` and `WithResponse` count).\n\n", scope))
+	total := len(rows)
+	pct := func(n int) string {
+		if total == 0 {
+			return "-"
+		}
+		return fmt.Sprintf("%d%%", n*100/total)
+	}
+	results.WriteString("| | Operations | Coverage |\n|---|---|---|\n")
+	results.WriteString(fmt.Sprintf("| quickbase-js | %d/%d | %s |\n", jsCount, total, pct(jsCount)))
+	results.WriteString(fmt.Sprintf("| quickbase-go | %d/%d | %s |\n", goCount, total, pct(goCount)))
+	results.WriteString(fmt.Sprintf("| Both | %d/%d | %s |\n\n", bothCount, total, pct(bothCount)))
+	if params.GapsOnly {
+		writeGapQueue(&results, gaps)
+		return mcp.NewToolResultText(results.String()), nil
+	}
+
+	cell := func(method, file string) string {
+		if method == "" {
+			return "❌"
+		}
+		return fmt.Sprintf("✅ `%s` (%s)", method, file)
+	}
+	results.WriteString("## Matrix\n\n| operationId | Endpoint | JS | Go |\n|---|---|---|---|\n")
+	shown := 0
+	for _, r := range rows {
+		if params.MissingOnly && r.JS != "" && r.Go != "" {
+			continue
+		}
+		name := r.OperationID
+		if r.Deprecated {
+			name += " (deprecated)"
+		}
+		results.WriteString(fmt.Sprintf("| %s | %s %s | %s | %s |\n", name, r.Method, r.Path, cell(r.JS, r.JSFile), cell(r.Go, r.GoFile)))
+		shown++
+	}
+	if shown == 0 {
+		results.WriteString("| (every operation is in both SDKs) | | | |\n")
+	}
+	results.WriteString("\n")
+
+	for _, missing := range []struct {
+		label string
+		rows  []parityOperation
+	}{{"quickbase-js", missingJS}, {"quickbase-go", missingGo}} {
+		results.WriteString(fmt.Sprintf("## Missing from %s (%d)\n\n", missing.label, len(missing.rows)))
+		if len(missing.rows) == 0 {
+			results.WriteString("None\n\n")
+			continue
+		}
+		for _, r := range missing.rows {
+			results.WriteString(fmt.Sprintf("- %s (%s %s)\n", r.OperationID, r.Method, r.Path))
+		}
+		results.WriteString("\n")
+	}
+	writeGapQueue(&results, gaps)
+	// Link differences to the decisions that explain them
+	if decisions, err := s.decisions(); err == nil {
+		var linked strings.Builder
+		for _, d := range decisions {
+			if d.SupersededBy == 0 {
+				linked.WriteString(fmt.Sprintf("- #%d %s: %s\n", d.ID, d.Title, d.Decision))
+			}
+		}
+		if linked.Len() > 0 {
+			results.WriteString("## Recorded Decisions\n" + linked.String())
+		}
+	}
+
+	return mcp.NewToolResultText(results.String()), nil
+}
+
+// renderParity writes the endpoint matrix as JSON (the stored report's
+// operations with counts and gaps), CSV (the matrix, or the gaps with
+// gapsOnly) or an HTML page.
+func renderParity(format string, report parityReport, rows []parityOperation, gaps []parityGap, scope string, missingOnly, gapsOnly bool, counts [3]int) (string, error) {
+	var shown []parityOperation
+	for _, r := range rows {
+		if !missingOnly || r.JS == "" || r.Go == "" {
+			shown = append(shown, r)
+		}
+	}
+	if format == "json" {
+		if gapsOnly {
+			shown = nil
+		}
+		return renderJSON(struct {
+			ReportID   uint64            `json:"report_id"`
+			CreatedAt  time.Time         `json:"created_at"`
+			Scope      string            `json:"scope"`
+			Total      int               `json:"total"`
+			JS         int               `json:"js"`
+			Go         int               `json:"go"`
+			Both       int               `json:"both"`
+			Operations []parityOperation `json:"operations,omitempty"`
+			Gaps       []parityGap       `json:"gaps"`
+		}{report.ID, report.CreatedAt, scope, len(rows), counts[0], counts[1], counts[2], shown, gaps})
+	}
+
+	matrix := reportTable{Title: "Matrix", Columns: []string{"operation_id", "method", "path", "deprecated", "js", "js_file", "go", "go_file"}}
+	for _, r := range shown {
+		matrix.Rows = append(matrix.Rows, []string{r.OperationID, r.Method, r.Path, fmt.Sprint(r.Deprecated), r.JS, r.JSFile, r.Go, r.GoFile})
+	}
+	if format == "csv" {
+		if gapsOnly {
+			return renderCSV(gapsTable(gaps))
+		}
+		return renderCSV(matrix)
+	}
+	coverage := reportTable{Title: "Coverage", Columns: []string{"SDK", "Operations", "Total"}, Rows: [][]string{
+		{"quickbase-js", fmt.Sprint(counts[0]), fmt.Sprint(len(rows))},
+		{"quickbase-go", fmt.Sprint(counts[1]), fmt.Sprint(len(rows))},
+		{"Both", fmt.Sprint(counts[2]), fmt.Sprint(len(rows))},
+	}}
+	intro := []string{
+		fmt.Sprintf("Endpoint coverage for %s in the spec, matched to client methods by operationId.", scope),
+		fmt.Sprintf("Report #%d, generated %s.", report.ID, report.CreatedAt.Format("2006-01-02 15:04")),
+	}
+	tables := []reportTable{coverage, gapsTable(gaps)}
+	if !gapsOnly {
+		tables = append(tables, matrix)
+	}
+	return renderHTML("Feature Parity Check", intro, tables)
+}
+
+// containsFold is containsString ignoring case.
+func containsFold(list []string, s string) bool {
+	for _, v := range list {
+		if strings.EqualFold(v, s) {
+			return true
+		}
+	}
+	return false
+}
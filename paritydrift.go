@@ -0,0 +1,310 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+const parityReportBucket = "parity_reports"
+
+// maxParityReports is how many parity runs are kept for drift checks.
+const maxParityReports = 200
+
+// parityReport is a stored snapshot of parity at one point in time: the
+// endpoint matrix, which features each SDK has and the one-sided public
+// API symbols.
+type parityReport struct {
+	ID           uint64            `json:"id"`
+	Label        string            `json:"label,omitempty"`
+	CreatedAt    time.Time         `json:"created_at"`
+	Operations   []parityOperation `json:"operations"`
+	Features     []parityFeature   `json:"features"`
+	OnlyJS       []string          `json:"only_js"`
+	OnlyGo       []string          `json:"only_go"`
+	SurfaceError string            `json:"surface_error,omitempty"`
+}
+
+// parityOperation is one spec operation and the client method (if any)
+// each SDK exposes for it.
+type parityOperation struct {
+	OperationID string   `json:"operation_id"`
+	Method      string   `json:"method"`
+	Path        string   `json:"path"`
+	Tags        []string `json:"tags,omitempty"`
+	Deprecated  bool     `json:"deprecated,omitempty"`
+	JS          string   `json:"js,omitempty"`
+	JSFile      string   `json:"js_file,omitempty"`
+	Go          string   `json:"go,omitempty"`
+	GoFile      string   `json:"go_file,omitempty"`
+}
+
+// parityFeature is whether each SDK has files for a feature.
+type parityFeature struct {
+	Name     string `json:"name"`
+	Category string `json:"category"`
+	JS       bool   `json:"js"`
+	Go       bool   `json:"go"`
+}
+
+// takeParitySnapshot computes a full parity report. Operations without an
+// operationId are skipped since they can't be matched to a method.
+func takeParitySnapshot() (parityReport, error) {
+	report := parityReport{CreatedAt: time.Now()}
+	root, err := loadSpec()
+	if err != nil {
+		return report, fmt.Errorf("failed to load spec: %w", err)
+	}
+	jsIndex := sdkMethodIndex(quickbaseJSPath, isJSSource, false)
+	goIndex := sdkMethodIndex(quickbaseGoPath, isGoSource, true)
+	for _, op := range specOperations(root) {
+		if op.OperationID == "" {
+			continue
+		}
+		js, g := lookupOperation(jsIndex, op.OperationID), lookupOperation(goIndex, op.OperationID)
+		report.Operations = append(report.Operations, parityOperation{
+			OperationID: op.OperationID, Method: op.Method, Path: op.Path, Tags: op.Tags, Deprecated: op.Deprecated,
+			JS: js.name, JSFile: js.file, Go: g.name, GoFile: g.file,
+		})
+	}
+
+	if features, err := loadFeatureMap(); err == nil {
+		items := buildInventory(features, inventorySides())
+		for _, key := range sortedKeys(items) {
+			it := items[key]
+			report.Features = append(report.Features, parityFeature{Name: it.name, Category: it.category, JS: len(it.jsFiles) > 0, Go: len(it.goFiles) > 0})
+		}
+	}
+
+	if d, err := diffSurfaces(); err != nil {
+		report.SurfaceError = err.Error()
+	} else {
+		report.OnlyJS, report.OnlyGo = d.onlyJS, d.onlyGo
+	}
+	return report, nil
+}
+
+// saveParityReport stores a report, assigning its ID.
+func (s *QuickBasePersonalMCPServer) saveParityReport(report *parityReport) error {
+	_, err := s.store.insert(parityReportBucket, func(id uint64) interface{} {
+		report.ID = id
+		return report
+	})
+	if err != nil {
+		return err
+	}
+	return s.store.trim(parityReportBucket, maxParityReports)
+}
+
+// parityReports returns the stored reports, oldest first.
+func (s *QuickBasePersonalMCPServer) parityReports() ([]parityReport, error) {
+	var reports []parityReport
+	err := s.store.each(parityReportBucket, func(key string, data []byte) error {
+		var r parityReport
+		if err := json.Unmarshal(data, &r); err == nil {
+			reports = append(reports, r)
+		}
+		return nil
+	})
+	return reports, err
+}
+
+// findParityReport picks a report by ID, label, "previous" (the one before
+// latest) or "first".
+func findParityReport(reports []parityReport, latest parityReport, ref string) (parityReport, bool) {
+	switch ref {
+	case "previous":
+		for i := len(reports) - 1; i >= 0; i-- {
+			if reports[i].ID < latest.ID {
+				return reports[i], true
+			}
+		}
+		return parityReport{}, false
+	case "first":
+		if len(reports) > 0 && reports[0].ID != latest.ID {
+			return reports[0], true
+		}
+		return parityReport{}, false
+	}
+	id, err := strconv.ParseUint(strings.TrimPrefix(ref, "#"), 10, 64)
+	for i := len(reports) - 1; i >= 0; i-- {
+		if (err == nil && reports[i].ID == id) || strings.EqualFold(reports[i].Label, ref) {
+			return reports[i], true
+		}
+	}
+	return parityReport{}, false
+}
+
+// reportName describes a report for headings.
+func reportName(r parityReport) string {
+	name := fmt.Sprintf("#%d", r.ID)
+	if r.Label != "" {
+		name += " " + r.Label
+	}
+	return name + " (" + r.CreatedAt.Format("2006-01-02 15:04") + ")"
+}
+
+// gaps sorts the report into items only JS has and items only Go has:
+// operations, features and public API symbols.
+func (r parityReport) gaps() (jsOnly, goOnly map[string]string) {
+	jsOnly, goOnly = map[string]string{}, map[string]string{}
+	for _, op := range r.Operations {
+		switch {
+		case op.JS != "" && op.Go == "":
+			jsOnly["operation "+op.OperationID] = fmt.Sprintf("%s %s (JS: %s in %s)", op.Method, op.Path, op.JS, op.JSFile)
+		case op.Go != "" && op.JS == "":
+			goOnly["operation "+op.OperationID] = fmt.Sprintf("%s %s (Go: %s in %s)", op.Method, op.Path, op.Go, op.GoFile)
+		}
+	}
+	for _, f := range r.Features {
+		switch {
+		case f.JS && !f.Go:
+			jsOnly["feature "+f.Name] = f.Category
+		case f.Go && !f.JS:
+			goOnly["feature "+f.Name] = f.Category
+		}
+	}
+	for _, sym := range r.OnlyJS {
+		jsOnly["symbol "+sym] = ""
+	}
+	for _, sym := range r.OnlyGo {
+		goOnly["symbol "+sym] = ""
+	}
+	return jsOnly, goOnly
+}
+
+// missing lists the operations neither SDK implements, which gaps can't
+// show.
+func (r parityReport) missing() map[string]string {
+	m := map[string]string{}
+	for _, op := range r.Operations {
+		if op.JS == "" && op.Go == "" {
+			m["operation "+op.OperationID] = op.Method + " " + op.Path
+		}
+	}
+	return m
+}
+
+// writeDriftSection lists keys in now but not in before.
+func writeDriftSection(results *strings.Builder, title string, now, before map[string]string) int {
+	var added []string
+	for _, k := range sortedKeys(now) {
+		if _, ok := before[k]; !ok {
+			added = append(added, k)
+		}
+	}
+	if len(added) == 0 {
+		return 0
+	}
+	results.WriteString(fmt.Sprintf("### %s (%d)\n\n", title, len(added)))
+	for _, k := range added {
+		if detail := now[k]; detail != "" {
+			results.WriteString(fmt.Sprintf("- %s — %s\n", k, detail))
+		} else {
+			results.WriteString(fmt.Sprintf("- %s\n", k))
+		}
+	}
+	results.WriteString("\n")
+	return len(added)
+}
+
+func (s *QuickBasePersonalMCPServer) handleParityDrift(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	var params struct {
+		Action   string `json:"action"`
+		Baseline string `json:"baseline"`
+		Label    string `json:"label"`
+		Refresh  *bool  `json:"refresh"`
+	}
+	argsData, _ := json.Marshal(request.Params.Arguments)
+	if err := json.Unmarshal(argsData, &params); err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Invalid parameters: %v", err)), nil
+	}
+	if params.Action == "" {
+		params.Action = "diff"
+	}
+	if params.Baseline == "" {
+		params.Baseline = "previous"
+	}
+
+	reports, err := s.parityReports()
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to read parity reports: %v", err)), nil
+	}
+
+	if params.Action == "list" {
+		var results strings.Builder
+		results.WriteString(fmt.Sprintf("# Parity Reports (%d)\n\n", len(reports)))
+		if len(reports) == 0 {
+			results.WriteString("None yet. Run check_parity or parity_drift to record one.\n")
+		}
+		for i := len(reports) - 1; i >= 0; i-- {
+			r := reports[i]
+			jsOnly, goOnly := r.gaps()
+			results.WriteString(fmt.Sprintf("- %s: %d operations, %d features, %d JS-only and %d Go-only items\n", reportName(r), len(r.Operations), len(r.Features), len(jsOnly), len(goOnly)))
+		}
+		return mcp.NewToolResultText(results.String()), nil
+	}
+	if params.Action != "diff" {
+		return mcp.NewToolResultError(fmt.Sprintf("Unknown action: %s (use diff or list)", params.Action)), nil
+	}
+
+	var latest parityReport
+	if params.Refresh == nil || *params.Refresh {
+		latest, err = takeParitySnapshot()
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		latest.Label = params.Label
+		if err := s.saveParityReport(&latest); err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("Failed to save parity report: %v", err)), nil
+		}
+		reports = append(reports, latest)
+	} else {
+		if len(reports) == 0 {
+			return mcp.NewToolResultError("No parity reports stored yet; run with refresh to take one"), nil
+		}
+		latest = reports[len(reports)-1]
+	}
+
+	baseline, ok := findParityReport(reports, latest, params.Baseline)
+	if !ok {
+		return mcp.NewToolResultText(fmt.Sprintf("# Parity Drift\n\nRecorded %s. No baseline %q to compare with yet; this run is the starting point.\n", reportName(latest), params.Baseline)), nil
+	}
+
+	nowJS, nowGo := latest.gaps()
+	wasJS, wasGo := baseline.gaps()
+
+	var results strings.Builder
+	results.WriteString("# Parity Drift\n\n")
+	results.WriteString(fmt.Sprintf("Baseline %s → latest %s\n\n", reportName(baseline), reportName(latest)))
+	results.WriteString("| | Baseline | Latest |\n|---|---|---|\n")
+	results.WriteString(fmt.Sprintf("| JS-only items | %d | %d |\n", len(wasJS), len(nowJS)))
+	results.WriteString(fmt.Sprintf("| Go-only items | %d | %d |\n", len(wasGo), len(nowGo)))
+	results.WriteString(fmt.Sprintf("| Operations in neither SDK | %d | %d |\n\n", len(baseline.missing()), len(latest.missing())))
+
+	results.WriteString("## ⚠️ New gaps\n\n")
+	added := writeDriftSection(&results, "Now JS only", nowJS, wasJS)
+	added += writeDriftSection(&results, "Now Go only", nowGo, wasGo)
+	added += writeDriftSection(&results, "New operations in neither SDK", latest.missing(), baseline.missing())
+	if added == 0 {
+		results.WriteString("None\n\n")
+	}
+
+	results.WriteString("## ✅ Closed gaps\n\n")
+	closed := writeDriftSection(&results, "No longer JS only", wasJS, nowJS)
+	closed += writeDriftSection(&results, "No longer Go only", wasGo, nowGo)
+	if closed == 0 {
+		results.WriteString("None\n\n")
+	}
+
+	if latest.SurfaceError != "" {
+		results.WriteString(fmt.Sprintf("Note: the API surface could not be compared in the latest run (%s), so symbol gaps may show as closed.\n", latest.SurfaceError))
+	}
+
+	return mcp.NewToolResultText(results.String()), nil
+}
@@ -0,0 +1,202 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// parityGap is one difference between the SDKs, ranked so the parity
+// report can be worked through top to bottom.
+type parityGap struct {
+	Severity string `json:"severity"` // high, medium or low
+	Kind     string `json:"kind"`     // endpoint, option, error_type or test
+	Missing  string `json:"missing"`  // the SDK missing it: js, go or both
+	Subject  string `json:"subject"`
+	Detail   string `json:"detail"`
+	Action   string `json:"action"`
+}
+
+// gapSeverityRank orders severities, most urgent first.
+var gapSeverityRank = map[string]int{"high": 0, "medium": 1, "low": 2}
+
+// gapKindRank orders kinds within a severity.
+var gapKindRank = map[string]int{"endpoint": 0, "error_type": 1, "option": 2, "test": 3}
+
+// sdkIdentifiers is every identifier in an SDK's source, normalized, so an
+// option can be looked up wherever the SDK declares it (the method, an
+// options type or a generated model).
+func sdkIdentifiers(repoPath string, keep func(string) bool) map[string]bool {
+	ids := map[string]bool{}
+	for _, rel := range listSourceFiles(repoPath, keep) {
+		data, err := os.ReadFile(filepath.Join(repoPath, rel))
+		if err != nil {
+			continue
+		}
+		for _, id := range identifierPattern.FindAll(data, -1) {
+			ids[normalizeTerm(string(id))] = true
+		}
+	}
+	return ids
+}
+
+var identifierPattern = regexp.MustCompile(`[A-Za-z_$][\w$]*`)
+
+// sdkTestSources reads an SDK's test files for method mentions.
+func sdkTestSources(repoPath string, isGo bool) []string {
+	var sources []string
+	walkRepo(repoPath, func(rel string) error {
+		isTest := strings.HasSuffix(rel, "_test.go")
+		if !isGo {
+			isTest = isTestPath(rel) && isJSTestFile(rel) && !strings.Contains("/"+rel, "/testdata/")
+		}
+		if !isTest {
+			return nil
+		}
+		if data, err := os.ReadFile(filepath.Join(repoPath, rel)); err == nil {
+			sources = append(sources, string(data))
+		}
+		return nil
+	})
+	return sources
+}
+
+// mentioned reports whether any source refers to name as a whole word.
+func mentioned(sources []string, name string) bool {
+	re := regexp.MustCompile(`\b` + regexp.QuoteMeta(name) + `\b`)
+	for _, src := range sources {
+		if re.MatchString(src) {
+			return true
+		}
+	}
+	return false
+}
+
+// classifyParityGaps ranks what one SDK has and the other lacks:
+//   - endpoints: high when one SDK has it, medium when neither does, low
+//     when the operation is deprecated
+//   - error types: medium
+//   - options: a parameter or body property of an operation both SDKs
+//     implement that only one SDK's source names; medium
+//   - tests: an operation only one SDK's tests call; low
+//
+// Error types aren't tied to operations, so they are left out when
+// withErrors is false (a tag-filtered report).
+func classifyParityGaps(root *yaml.Node, rows []parityOperation, withErrors bool) []parityGap {
+	var gaps []parityGap
+	specOps := specOperations(root)
+	jsIDs, goIDs := sdkIdentifiers(quickbaseJSPath, isJSSource), sdkIdentifiers(quickbaseGoPath, isGoSource)
+	jsTests, goTests := sdkTestSources(quickbaseJSPath, false), sdkTestSources(quickbaseGoPath, true)
+
+	for _, r := range rows {
+		jsName, goName := lowerFirst(r.OperationID), goFieldName(r.OperationID)
+		endpoint := r.Method + " " + r.Path
+		severity := "high"
+		if r.Deprecated {
+			severity = "low"
+			endpoint += " (deprecated)"
+		}
+		switch {
+		case r.JS == "" && r.Go == "":
+			if !r.Deprecated {
+				severity = "medium"
+			}
+			gaps = append(gaps, parityGap{severity, "endpoint", "both", r.OperationID, endpoint + " is in neither SDK",
+				fmt.Sprintf("Scaffold %s (JS) and %s (Go), or record_decision why it's unsupported", jsName, goName)})
+			continue
+		case r.JS == "":
+			gaps = append(gaps, parityGap{severity, "endpoint", "js", r.OperationID, fmt.Sprintf("%s; Go has %s in %s", endpoint, r.Go, r.GoFile),
+				fmt.Sprintf("Scaffold JS method %s, porting Go's %s", jsName, r.Go)})
+			continue
+		case r.Go == "":
+			gaps = append(gaps, parityGap{severity, "endpoint", "go", r.OperationID, fmt.Sprintf("%s; JS has %s in %s", endpoint, r.JS, r.JSFile),
+				fmt.Sprintf("Scaffold Go method %s, porting JS's %s", goName, r.JS)})
+			continue
+		}
+
+		if op, ok := findOperation(specOps, r.OperationID); ok {
+			params, body := operationInputs(root, op)
+			options := params
+			if schema, _ := resolveRef(root, body); schema != nil {
+				options = append(options, schemaFields(schema)...)
+			}
+			for _, o := range options {
+				key := normalizeTerm(o.name)
+				switch inJS, inGo := jsIDs[key], goIDs[key]; {
+				case inJS && !inGo:
+					gaps = append(gaps, parityGap{"medium", "option", "go", r.OperationID + "." + o.name, fmt.Sprintf("quickbase-js handles %s for %s", o.name, r.OperationID),
+						fmt.Sprintf("Add %s to Go %s", goFieldName(o.name), r.Go)})
+				case inGo && !inJS:
+					gaps = append(gaps, parityGap{"medium", "option", "js", r.OperationID + "." + o.name, fmt.Sprintf("quickbase-go handles %s for %s", o.name, r.OperationID),
+						fmt.Sprintf("Add %s to JS %s", lowerFirst(o.name), r.JS)})
+				}
+			}
+		}
+
+		switch inJS, inGo := mentioned(jsTests, r.JS), mentioned(goTests, r.Go); {
+		case inJS && !inGo:
+			gaps = append(gaps, parityGap{"low", "test", "go", r.OperationID, "only the JS tests call " + r.JS, fmt.Sprintf("Add a Go test for %s", r.Go)})
+		case inGo && !inJS:
+			gaps = append(gaps, parityGap{"low", "test", "js", r.OperationID, "only the Go tests call " + r.Go, fmt.Sprintf("Add a JS test for %s", r.JS)})
+		case !inJS && !inGo:
+			gaps = append(gaps, parityGap{"low", "test", "both", r.OperationID, "neither SDK's tests call it", fmt.Sprintf("Add tests for %s (JS) and %s (Go)", r.JS, r.Go)})
+		}
+	}
+
+	if withErrors {
+		_, onlyJS, onlyGo, _, _ := pairErrorTypes()
+		describe := func(e *errorType) string {
+			if e.status != 0 {
+				return fmt.Sprintf("%s in %s (HTTP %d)", e.kind, e.file, e.status)
+			}
+			return fmt.Sprintf("%s in %s", e.kind, e.file)
+		}
+		for _, e := range onlyJS {
+			gaps = append(gaps, parityGap{"medium", "error_type", "go", e.name, describe(e), fmt.Sprintf("Port %s to Go as an error type", e.name)})
+		}
+		for _, e := range onlyGo {
+			gaps = append(gaps, parityGap{"medium", "error_type", "js", e.name, describe(e), fmt.Sprintf("Port %s to JS as an Error subclass", e.name)})
+		}
+	}
+
+	sort.SliceStable(gaps, func(a, b int) bool {
+		ga, gb := gaps[a], gaps[b]
+		if ga.Severity != gb.Severity {
+			return gapSeverityRank[ga.Severity] < gapSeverityRank[gb.Severity]
+		}
+		if ga.Kind != gb.Kind {
+			return gapKindRank[ga.Kind] < gapKindRank[gb.Kind]
+		}
+		return ga.Subject < gb.Subject
+	})
+	return gaps
+}
+
+// gapsTable lays the work queue out for CSV and HTML.
+func gapsTable(gaps []parityGap) reportTable {
+	t := reportTable{Title: "Work Queue", Columns: []string{"severity", "kind", "missing", "subject", "detail", "action"}}
+	for _, g := range gaps {
+		t.Rows = append(t.Rows, []string{g.Severity, g.Kind, g.Missing, g.Subject, g.Detail, g.Action})
+	}
+	return t
+}
+
+// writeGapQueue renders the gaps as a markdown table.
+func writeGapQueue(results *strings.Builder, gaps []parityGap) {
+	results.WriteString(fmt.Sprintf("## Work Queue (%d)\n\n", len(gaps)))
+	if len(gaps) == 0 {
+		results.WriteString("Nothing to do\n\n")
+		return
+	}
+	icons := map[string]string{"high": "🔴 high", "medium": "🟠 medium", "low": "⚪ low"}
+	results.WriteString("| Severity | Gap | Missing in | Detail | Suggested action |\n|---|---|---|---|---|\n")
+	for _, g := range gaps {
+		results.WriteString(fmt.Sprintf("| %s | %s: %s | %s | %s | %s |\n", icons[g.Severity], strings.ReplaceAll(g.Kind, "_", " "), g.Subject, g.Missing, g.Detail, g.Action))
+	}
+	results.WriteString("\n")
+}
@@ -0,0 +1,122 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"go/format"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"gopkg.in/yaml.v3"
+)
+
+// goSnippet gofmts a single Go declaration, falling back to the input
+// when it doesn't parse on its own.
+func goSnippet(decl string) string {
+	const header = "package p\n\n"
+	formatted, err := format.Source([]byte(header + decl))
+	if err != nil {
+		return decl
+	}
+	return strings.TrimPrefix(string(formatted), header)
+}
+
+// requiredNames splits an object schema's properties into required and
+// optional for the payload summary.
+func requiredNames(fields []exampleField) (required, optional []string) {
+	for _, f := range fields {
+		if f.required {
+			required = append(required, f.name)
+		} else {
+			optional = append(optional, f.name)
+		}
+	}
+	return required, optional
+}
+
+func (s *QuickBasePersonalMCPServer) handleGeneratePayload(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	var params struct {
+		Operation       string `json:"operation"`
+		Schema          string `json:"schema"`
+		IncludeOptional bool   `json:"include_optional"`
+	}
+	argsData, _ := json.Marshal(request.Params.Arguments)
+	if err := json.Unmarshal(argsData, &params); err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Invalid parameters: %v", err)), nil
+	}
+	if (params.Operation == "") == (params.Schema == "") {
+		return mcp.NewToolResultError("Give either operation (an operationId or 'METHOD /path') or schema (a component schema name)"), nil
+	}
+
+	root, err := loadSpec()
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to load spec: %v", err)), nil
+	}
+	cfg, err := loadConfig()
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to load config: %v", err)), nil
+	}
+	e := exampleValue{root: root, cfg: cfg}
+
+	var title, typeName string
+	var schema *yaml.Node
+	if params.Schema != "" {
+		schemas := mappingValue(mappingValue(root, "components"), "schemas")
+		for _, name := range mappingKeys(schemas) {
+			if normalizeTerm(name) == normalizeTerm(params.Schema) {
+				title, typeName, schema = name, name, mappingValue(schemas, name)
+			}
+		}
+		if schema == nil {
+			return mcp.NewToolResultError(fmt.Sprintf("Unknown schema: %q (available: %s)", params.Schema, strings.Join(mappingKeys(schemas), ", "))), nil
+		}
+	} else {
+		op, ok := findOperation(specOperations(root), strings.TrimSpace(params.Operation))
+		if !ok {
+			return mcp.NewToolResultError(fmt.Sprintf("Unknown operation: %q; give an operationId or 'METHOD /path', or use spec_search", params.Operation)), nil
+		}
+		title = fmt.Sprintf("%s %s", op.Method, op.Path)
+		if op.OperationID != "" {
+			title = fmt.Sprintf("%s (%s)", op.OperationID, title)
+		}
+		_, schema = operationInputs(root, op)
+		if schema == nil {
+			return mcp.NewToolResultText(fmt.Sprintf("%s has no JSON request body; its inputs are parameters (see get_endpoint or get_endpoint_example).\n", title)), nil
+		}
+		typeName = goFieldName(op.OperationID) + "Request"
+		if _, ref := e.flatten(schema); ref != "" {
+			typeName = ref
+		}
+	}
+
+	flat, _ := e.flatten(schema)
+	required, optional := requiredNames(schemaFields(flat))
+
+	var results strings.Builder
+	results.WriteString(fmt.Sprintf("# Payload: %s\n\n", title))
+	if len(required) > 0 {
+		results.WriteString(fmt.Sprintf("Required: %s\n\n", strings.Join(required, ", ")))
+	}
+	if len(optional) > 0 {
+		if params.IncludeOptional {
+			results.WriteString(fmt.Sprintf("Optional (included): %s\n\n", strings.Join(optional, ", ")))
+		} else {
+			results.WriteString(fmt.Sprintf("Optional (left out; pass include_optional to add them): %s\n\n", strings.Join(optional, ", ")))
+		}
+	}
+
+	results.WriteString(fmt.Sprintf("## JSON\n\n```json\n%s\n```\n\n", e.jsonValue("", schema, "", params.IncludeOptional, 0)))
+
+	goExpr := e.goValue("", schema, typeName, "", params.IncludeOptional, 0)
+	results.WriteString(fmt.Sprintf("## Go\n\n```go\n%s```\n\n", goSnippet("var payload = "+goExpr+"\n")))
+
+	tsType := ""
+	if schemaType(flat) == "object" && mappingValue(flat, "properties") != nil {
+		tsType = ": " + typeName
+	}
+	results.WriteString(fmt.Sprintf("## TypeScript\n\n```typescript\nconst payload%s = %s;\n```\n\n", tsType, e.jsValue("", schema, "", params.IncludeOptional, 0)))
+
+	results.WriteString("Values come from the spec's example, default or enum first, then the format, then your configured app and table IDs for properties that name them; anything else is a `<name>` placeholder. Go and TS type names follow the spec's $ref names.\n")
+	return mcp.NewToolResultText(results.String()), nil
+}
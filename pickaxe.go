@@ -0,0 +1,192 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// pickaxeLineLimit is how many matching lines are quoted per commit.
+const pickaxeLineLimit = 3
+
+// pickaxeChange counts the changed lines of one commit that match the
+// query, keeping the first few to quote.
+type pickaxeChange struct {
+	added, removed int
+	files          []string
+	lines          []string
+}
+
+// pickaxeMatches reads a -U0 patch and counts the added and removed lines
+// that match.
+func pickaxeMatches(patch string, match func(string) bool) pickaxeChange {
+	var change pickaxeChange
+	for _, line := range strings.Split(patch, "\n") {
+		switch {
+		case strings.HasPrefix(line, "diff --git "):
+			// "diff --git a/path b/path"; the new side names renames
+			if i := strings.LastIndex(line, " b/"); i >= 0 {
+				change.files = append(change.files, line[i+3:])
+			}
+			continue
+		case strings.HasPrefix(line, "+++ ") || strings.HasPrefix(line, "--- "):
+			continue
+		case strings.HasPrefix(line, "+") && match(line[1:]):
+			change.added++
+		case strings.HasPrefix(line, "-") && match(line[1:]):
+			change.removed++
+		default:
+			continue
+		}
+		if len(change.lines) < pickaxeLineLimit {
+			change.lines = append(change.lines, line)
+		}
+	}
+	return change
+}
+
+func (s *QuickBasePersonalMCPServer) handleGitPickaxe(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	var params struct {
+		Query      string `json:"query"`
+		Regex      bool   `json:"regex"`
+		IgnoreCase bool   `json:"ignore_case"`
+		Repo       string `json:"repo"`
+		Path       string `json:"path"`
+		Ref        string `json:"ref"`
+		Limit      int    `json:"limit"`
+	}
+	argsData, _ := json.Marshal(request.Params.Arguments)
+	if err := json.Unmarshal(argsData, &params); err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Invalid parameters: %v", err)), nil
+	}
+	if params.Query == "" {
+		return mcp.NewToolResultError("query is required"), nil
+	}
+	if params.Limit <= 0 {
+		params.Limit = 20
+	}
+	pattern := params.Query
+	if !params.Regex {
+		pattern = regexp.QuoteMeta(pattern)
+	}
+	if params.IgnoreCase {
+		pattern = "(?i)" + pattern
+	}
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Invalid regex: %v", err)), nil
+	}
+	params.Repo, params.Path = splitRepoPath(params.Repo, strings.Trim(params.Path, "/"))
+	repos, err := gitRepos(params.Repo)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	// -S finds commits that change how many times the string occurs, so
+	// moves within a file don't count; -G finds any changed line matching
+	pickaxe := []string{"-S" + params.Query}
+	grepArgs := []string{"grep", "-c", "-F"}
+	if params.Regex {
+		pickaxe = []string{"-G" + params.Query}
+		grepArgs = []string{"grep", "-c", "-E"}
+	}
+	if params.IgnoreCase {
+		pickaxe = append(pickaxe, "--regexp-ignore-case")
+		grepArgs = append(grepArgs, "-i")
+	}
+	var pathArgs []string
+	if params.Path != "" {
+		pathArgs = []string{"--", params.Path}
+	}
+
+	kind := "string"
+	if params.Regex {
+		kind = "regex"
+	}
+	var results strings.Builder
+	results.WriteString(fmt.Sprintf("# Pickaxe: %s `%s`\n\n", kind, params.Query))
+	if params.Path != "" {
+		results.WriteString(fmt.Sprintf("Path: %s\n\n", params.Path))
+	}
+	total := 0
+	for _, repo := range repos {
+		ref := "HEAD"
+		if params.Ref != "" {
+			if err := verifyRef(repo.path, params.Ref); err != nil {
+				results.WriteString(fmt.Sprintf("## %s\n\n%v\n\n", repo.name, err))
+				continue
+			}
+			ref = params.Ref
+		}
+		commits, err := gitLog(repo.path, false, append(append([]string{"-n", fmt.Sprint(params.Limit)}, pickaxe...), append([]string{ref}, pathArgs...)...)...)
+		if err != nil {
+			results.WriteString(fmt.Sprintf("## %s\n\n⚠️ %v\n\n", repo.name, err))
+			continue
+		}
+		total += len(commits)
+		if len(commits) == 0 && len(repos) > 1 {
+			continue
+		}
+		results.WriteString(fmt.Sprintf("## %s (%d)\n\n", repo.name, len(commits)))
+
+		// Where it stands now: git grep counts matches per file at ref
+		present := 0
+		if out, err := runGit(repo.path, append(append(append([]string{}, grepArgs...), "-e", params.Query, ref), pathArgs...)...); err == nil && out != "" {
+			present = len(strings.Split(out, "\n"))
+		}
+		if present > 0 {
+			results.WriteString(fmt.Sprintf("Present at %s in %d file(s)\n\n", ref, present))
+		} else {
+			results.WriteString(fmt.Sprintf("Not present at %s\n\n", ref))
+		}
+		if len(commits) == 0 {
+			results.WriteString("No commits added or removed it\n\n")
+			continue
+		}
+
+		var introduced, removed *gitCommit
+		for i, c := range commits {
+			patch, _ := runGit(repo.path, append(append([]string{"show", "--format=", "-U0", "--no-color"}, pickaxe...), append([]string{c.hash}, pathArgs...)...)...)
+			change := pickaxeMatches(patch, re.MatchString)
+			verb := "changed"
+			switch {
+			case change.added > 0 && change.removed == 0:
+				verb = "added"
+				introduced = &commits[i]
+			case change.removed > 0 && change.added == 0:
+				verb = "removed"
+				if removed == nil {
+					removed = &commits[i]
+				}
+			}
+			results.WriteString(fmt.Sprintf("- %s %s %s — %s\n", c.hash, c.date, c.author, c.subject))
+			detail := fmt.Sprintf("%s (+%d/-%d)", verb, change.added, change.removed)
+			if len(change.files) > 0 {
+				detail += " in " + strings.Join(change.files, ", ")
+			}
+			results.WriteString(fmt.Sprintf("  - %s\n", detail))
+			if len(change.lines) > 0 {
+				results.WriteString(fmt.Sprintf("    ```diff\n    %s\n    ```\n", strings.Join(change.lines, "\n    ")))
+			}
+		}
+		results.WriteString("\n")
+		if len(commits) == params.Limit {
+			results.WriteString(fmt.Sprintf("Showing the latest %d; raise limit to reach further back.\n\n", params.Limit))
+		} else if introduced != nil {
+			// Newest first, so the last commit that only added it is the oldest
+			results.WriteString(fmt.Sprintf("First added in %s %s — %s\n", introduced.hash, introduced.date, introduced.subject))
+		}
+		if removed != nil && present == 0 {
+			results.WriteString(fmt.Sprintf("Last removed in %s %s — %s\n", removed.hash, removed.date, removed.subject))
+		}
+		results.WriteString("\n")
+	}
+	if total == 0 && len(repos) > 1 {
+		results.WriteString("No commits added or removed it in any repo\n")
+	}
+	return mcp.NewToolResultText(strings.TrimRight(results.String(), "\n") + "\n"), nil
+}
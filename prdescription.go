@@ -0,0 +1,314 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// workingChanges lists what a repo changes relative to since (a commit):
+// committed and uncommitted changes, plus untracked files as added.
+func workingChanges(repoPath, since string) ([]changedFile, error) {
+	files, err := changedFiles(repoPath, since)
+	if err != nil {
+		return nil, err
+	}
+	st, err := readRepoStatus(repoPath)
+	if err != nil {
+		return nil, err
+	}
+	for _, f := range st.untracked {
+		_, n := untrackedDiff(repoPath, f)
+		files = append(files, changedFile{status: "A", path: f, added: fmt.Sprint(n), removed: "0"})
+	}
+	return files, nil
+}
+
+// mentionedOperations finds spec operationIds named in a diff's changed
+// lines, case-insensitively so runQuery matches RunQuery.
+func mentionedOperations(diff string, ops []specOperation) []string {
+	var changed strings.Builder
+	for _, line := range strings.Split(diff, "\n") {
+		if (strings.HasPrefix(line, "+") || strings.HasPrefix(line, "-")) && !strings.HasPrefix(line, "+++") && !strings.HasPrefix(line, "---") {
+			changed.WriteString(line[1:] + "\n")
+		}
+	}
+	text := changed.String()
+	var found []string
+	for _, op := range ops {
+		if op.OperationID == "" {
+			continue
+		}
+		if re, err := regexp.Compile(`(?i)\b` + regexp.QuoteMeta(op.OperationID) + `\b`); err == nil && re.MatchString(text) {
+			found = append(found, op.OperationID)
+		}
+	}
+	return found
+}
+
+func (s *QuickBasePersonalMCPServer) handleDraftPRDescription(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	var params struct {
+		Repo string `json:"repo"`
+		Base string `json:"base"`
+	}
+	argsData, _ := json.Marshal(request.Params.Arguments)
+	if err := json.Unmarshal(argsData, &params); err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Invalid parameters: %v", err)), nil
+	}
+	if params.Repo == "" || params.Repo == "all" {
+		return mcp.NewToolResultError("repo is required: a PR is for one repo"), nil
+	}
+	repos, err := gitRepos(params.Repo)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	repo := repos[0]
+
+	// Without base, just the uncommitted changes; with it, everything the
+	// branch adds since it left base too
+	since := "HEAD"
+	var commits []gitCommit
+	if params.Base != "" {
+		if err := verifyRef(repo.path, params.Base); err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("%s: %v", repo.name, err)), nil
+		}
+		mergeBase, err := runGit(repo.path, "merge-base", params.Base, "HEAD")
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("%s and HEAD share no history", params.Base)), nil
+		}
+		since = mergeBase
+		commits, _ = gitLog(repo.path, false, "--no-merges", mergeBase+"..HEAD")
+	}
+	files, err := workingChanges(repo.path, since)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to read changes in %s: %v", repo.name, err)), nil
+	}
+	if len(files) == 0 {
+		return mcp.NewToolResultText(fmt.Sprintf("No changes in %s to describe\n", repo.name)), nil
+	}
+	diff, _ := runGit(repo.path, "diff", "-U0", since)
+	st, _ := readRepoStatus(repo.path)
+	for _, f := range st.untracked {
+		text, _ := untrackedDiff(repo.path, f)
+		diff += "\n" + text
+	}
+
+	var source, tests, docs, other []changedFile
+	for _, f := range files {
+		switch {
+		case isTestPath(f.path):
+			tests = append(tests, f)
+		case strings.HasSuffix(f.path, ".md") || strings.HasPrefix(f.path, "docs/"):
+			docs = append(docs, f)
+		case isJSSource(f.path) || isGoSource(f.path):
+			source = append(source, f)
+		default:
+			other = append(other, f)
+		}
+	}
+
+	features, err := loadFeatureMap()
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to load feature map: %v", err)), nil
+	}
+	var touched []string
+	var otherSDK gitRepo
+	switch repo.path {
+	case quickbaseJSPath, quickbaseGoPath:
+		otherSDK = gitRepo{"quickbase-go", quickbaseGoPath}
+		if repo.path == quickbaseGoPath {
+			otherSDK = gitRepo{"quickbase-js", quickbaseJSPath}
+		}
+		for _, name := range featureNames(features) {
+			patterns := features[name].JS
+			if repo.path == quickbaseGoPath {
+				patterns = features[name].Go
+			}
+			match := featureMatcher(patterns)
+			for _, f := range files {
+				if match(f.path) {
+					touched = append(touched, name)
+					break
+				}
+			}
+		}
+	}
+	var operations []string
+	var specChanges []specChange
+	if root, err := loadSpec(); err == nil {
+		operations = mentionedOperations(diff, specOperations(root))
+	}
+	if repo.path == quickbaseSpecPath {
+		if oldRoot, err := loadSpecAt(since); err == nil {
+			if newRoot, err := loadSpecAt(""); err == nil {
+				specChanges = diffSpecs(oldRoot, newRoot)
+			}
+		}
+	}
+
+	var body strings.Builder
+	title := ""
+	switch {
+	case len(commits) == 1:
+		title = commits[0].subject
+	case len(touched) > 0:
+		title = strings.Join(touched, ", ") + ": "
+	}
+
+	body.WriteString("## What\n\n")
+	for _, c := range commits {
+		body.WriteString(fmt.Sprintf("- %s (%s)\n", c.subject, c.hash))
+	}
+	if len(commits) > 0 {
+		body.WriteString("\n")
+	}
+	if len(touched) > 0 {
+		body.WriteString(fmt.Sprintf("Features: %s\n\n", strings.Join(touched, ", ")))
+	}
+	for _, group := range []struct {
+		title string
+		files []changedFile
+	}{{"Source", source}, {"Tests", tests}, {"Docs", docs}, {"Other", other}} {
+		if len(group.files) == 0 {
+			continue
+		}
+		body.WriteString(fmt.Sprintf("%s:\n", group.title))
+		for _, f := range group.files {
+			body.WriteString(fmt.Sprintf("- `%s` (%s, +%s/-%s)\n", f.path, fileStatusNames[f.status], f.added, f.removed))
+		}
+		body.WriteString("\n")
+	}
+	if len(specChanges) > 0 {
+		body.WriteString("Spec changes:\n")
+		for _, c := range specChanges {
+			line := fmt.Sprintf("- %s %s %s", c.Area, c.Subject, c.Kind)
+			if c.Impact == impactBreaking {
+				line += " (breaking)"
+			}
+			body.WriteString(line + "\n")
+		}
+		body.WriteString("\n")
+	}
+	if len(operations) > 0 {
+		body.WriteString(fmt.Sprintf("Spec operations touched: %s\n\n", strings.Join(operations, ", ")))
+	}
+
+	body.WriteString("## Why\n\n")
+	why := false
+	for _, c := range commits {
+		if msg, err := runGit(repo.path, "show", "-s", "--format=%b", c.hash); err == nil && strings.TrimSpace(msg) != "" {
+			body.WriteString(strings.TrimSpace(msg) + "\n\n")
+			why = true
+		}
+	}
+	if !why {
+		body.WriteString("_TODO: the problem this solves and why this approach._\n\n")
+	}
+
+	body.WriteString("## Parity impact\n\n")
+	switch {
+	case repo.path == quickbaseSpecPath:
+		if len(specChanges) > 0 {
+			body.WriteString("Both SDKs need the spec pin bumped and regenerating (bump_spec_pin).\n\n")
+		} else {
+			body.WriteString("No operation or schema changes, so the SDKs are unaffected.\n\n")
+		}
+	case otherSDK.path == "":
+		body.WriteString("Not an SDK.\n\n")
+	case len(touched) == 0:
+		body.WriteString("Touches no mapped feature.\n\n")
+	default:
+		// The other SDK's matching work is uncommitted or on a branch of the
+		// same name
+		var otherFiles []string
+		if changes, err := workingChanges(otherSDK.path, "HEAD"); err == nil {
+			for _, f := range changes {
+				otherFiles = append(otherFiles, f.path)
+			}
+		}
+		if st.branch != "" && st.branch != defaultBranch(repo.path) && verifyRef(otherSDK.path, st.branch) == nil {
+			if changes, err := changedFiles(otherSDK.path, defaultBranch(otherSDK.path)+"..."+st.branch); err == nil {
+				for _, f := range changes {
+					otherFiles = append(otherFiles, f.path)
+				}
+			}
+		}
+		for _, name := range touched {
+			patterns := features[name].Go
+			if otherSDK.path == quickbaseJSPath {
+				patterns = features[name].JS
+			}
+			match := featureMatcher(patterns)
+			ported := false
+			for _, f := range otherFiles {
+				if match(f) {
+					ported = true
+					break
+				}
+			}
+			if ported {
+				body.WriteString(fmt.Sprintf("- %s: %s changes it too\n", name, otherSDK.name))
+			} else {
+				body.WriteString(fmt.Sprintf("- %s: ⚠️ no matching change in %s yet; port it or say why it isn't needed\n", name, otherSDK.name))
+			}
+		}
+		body.WriteString("\n")
+	}
+
+	body.WriteString("## Test coverage\n\n")
+	changedStems := map[string]bool{}
+	for _, f := range tests {
+		changedStems[testStem(f.path)] = true
+	}
+	if len(tests) > 0 {
+		body.WriteString(fmt.Sprintf("%d test file(s) changed.\n", len(tests)))
+	}
+	var untested []string
+	for _, f := range source {
+		if f.status != "D" && !changedStems[fileStem(f.path)] {
+			untested = append(untested, f.path)
+		}
+	}
+	if len(untested) > 0 {
+		existing := findTestFiles(repo.path, untested)
+		body.WriteString("Source changes without test changes:\n")
+		for _, rel := range untested {
+			var have []string
+			for _, t := range existing {
+				if testStem(t) == fileStem(rel) {
+					have = append(have, t)
+				}
+			}
+			if len(have) > 0 {
+				body.WriteString(fmt.Sprintf("- `%s` (existing tests: %s)\n", rel, strings.Join(have, ", ")))
+			} else {
+				body.WriteString(fmt.Sprintf("- `%s` (no tests found)\n", rel))
+			}
+		}
+	} else if len(source) > 0 {
+		body.WriteString("Every changed source file has a matching test change.\n")
+	} else if len(tests) == 0 {
+		body.WriteString("No source or test changes.\n")
+	}
+
+	var results strings.Builder
+	results.WriteString(fmt.Sprintf("# PR draft: %s", repo.name))
+	if st.branch != "" {
+		results.WriteString(fmt.Sprintf(" (%s)", st.branch))
+	}
+	results.WriteString("\n\n")
+	if params.Base != "" {
+		results.WriteString(fmt.Sprintf("Changes since %s: %d commit(s) and the working tree.\n\n", params.Base, len(commits)))
+	} else {
+		results.WriteString("Uncommitted changes only; give base to include the branch's commits.\n\n")
+	}
+	if title != "" {
+		results.WriteString(fmt.Sprintf("Title: %s\n\n", title))
+	}
+	results.WriteString(fmt.Sprintf("````markdown\n%s````\n", strings.TrimRight(body.String(), "\n")+"\n"))
+	return mcp.NewToolResultText(results.String()), nil
+}
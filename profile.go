@@ -0,0 +1,185 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+var (
+	// benchmarkFunc is a benchmark's declaration in a _test.go file
+	benchmarkFunc = regexp.MustCompile(`(?m)^func (Benchmark\w*)\(\w+ \*testing\.B\)`)
+	// benchmarkResult is "BenchmarkPaginate-8   1203   981234 ns/op   ..."
+	benchmarkResult = regexp.MustCompile(`^Benchmark\S+\s+\d+\s+.*\bns/op\b`)
+)
+
+// benchmarkPackages finds the Go SDK's packages with a benchmark matching
+// pattern, as ./dir for go test, since profiling takes one package at a time.
+func benchmarkPackages(repoPath string, pattern *regexp.Regexp) ([]string, error) {
+	seen := map[string]bool{}
+	var packages []string
+	err := walkRepo(repoPath, func(rel string) error {
+		if !strings.HasSuffix(rel, "_test.go") {
+			return nil
+		}
+		data, err := os.ReadFile(filepath.Join(repoPath, rel))
+		if err != nil {
+			return nil
+		}
+		for _, m := range benchmarkFunc.FindAllStringSubmatch(string(data), -1) {
+			dir := "./" + path.Dir(rel)
+			if dir == "./." {
+				dir = "."
+			}
+			if pattern.MatchString(m[1]) && !seen[dir] {
+				seen[dir] = true
+				packages = append(packages, dir)
+			}
+		}
+		return nil
+	})
+	return packages, err
+}
+
+func (s *QuickBasePersonalMCPServer) handleProfileBenchmark(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	var params struct {
+		Benchmark      string `json:"benchmark"`
+		Path           string `json:"path"`
+		Profile        string `json:"profile"`
+		Benchtime      string `json:"benchtime"`
+		Focus          string `json:"focus"`
+		Top            int    `json:"top"`
+		TimeoutSeconds int    `json:"timeout_seconds"`
+	}
+	argsData, _ := json.Marshal(request.Params.Arguments)
+	if err := json.Unmarshal(argsData, &params); err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Invalid parameters: %v", err)), nil
+	}
+	if params.Benchmark == "" {
+		return mcp.NewToolResultError("benchmark is required, e.g. 'BenchmarkPaginate' or a -bench pattern"), nil
+	}
+	pattern, err := regexp.Compile(params.Benchmark)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Invalid benchmark pattern: %v", err)), nil
+	}
+	if params.Profile == "" {
+		params.Profile = "both"
+	}
+	if params.Profile != "cpu" && params.Profile != "mem" && params.Profile != "both" {
+		return mcp.NewToolResultError("profile must be 'cpu', 'mem' or 'both'"), nil
+	}
+	if params.Top <= 0 {
+		params.Top = 20
+	}
+	timeout := defaultTestTimeout
+	if params.TimeoutSeconds > 0 {
+		timeout = time.Duration(params.TimeoutSeconds) * time.Second
+	}
+
+	pkg := params.Path
+	if pkg == "" {
+		packages, err := benchmarkPackages(quickbaseGoPath, pattern)
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("Failed to scan for benchmarks: %v", err)), nil
+		}
+		switch len(packages) {
+		case 0:
+			return mcp.NewToolResultError(fmt.Sprintf("No benchmark in quickbase-go matches %q", params.Benchmark)), nil
+		case 1:
+			pkg = packages[0]
+		default:
+			return mcp.NewToolResultError(fmt.Sprintf("Benchmarks matching %q are in %d packages (%s); profiling takes one, so set path", params.Benchmark, len(packages), strings.Join(packages, ", "))), nil
+		}
+	}
+
+	dir, err := os.MkdirTemp("", "qb-profile-")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	defer os.RemoveAll(dir)
+	// -o keeps the test binary, which go test would otherwise leave in the
+	// repo when profiling, out of the working tree
+	flags := []string{"-run '^$'", "-bench " + shellQuote(params.Benchmark), "-benchmem", "-o " + shellQuote(filepath.Join(dir, "bench.test"))}
+	if params.Benchtime != "" {
+		flags = append(flags, "-benchtime "+shellQuote(params.Benchtime))
+	}
+	var profiles []string
+	if params.Profile != "mem" {
+		flags = append(flags, "-cpuprofile "+shellQuote(filepath.Join(dir, "cpu.out")))
+		profiles = append(profiles, "cpu")
+	}
+	if params.Profile != "cpu" {
+		flags = append(flags, "-memprofile "+shellQuote(filepath.Join(dir, "mem.out")))
+		profiles = append(profiles, "mem")
+	}
+	command := "go test " + strings.Join(flags, " ") + " " + shellQuote(pkg)
+	result, err := runShell(ctx, quickbaseGoPath, timeout, command)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	// Shown without the temp paths, as it would be run by hand
+	shown := strings.ReplaceAll(command, shellQuote(dir)+"/", "")
+	shown = strings.ReplaceAll(shown, dir+"/", "")
+
+	var results strings.Builder
+	results.WriteString(fmt.Sprintf("# Profile: %s\n\n", params.Benchmark))
+	results.WriteString(fmt.Sprintf("`%s` in quickbase-go (%s)\n\n", shown, result.duration.Round(100*time.Millisecond)))
+	if result.timedOut {
+		results.WriteString(fmt.Sprintf("⏱️ Timed out after %s; lower benchtime or raise timeout_seconds.\n", timeout))
+		return mcp.NewToolResultText(results.String()), nil
+	}
+	var benchLines []string
+	for _, line := range strings.Split(result.output, "\n") {
+		if benchmarkResult.MatchString(line) {
+			benchLines = append(benchLines, strings.Join(strings.Fields(line), " "))
+		}
+	}
+	if result.exitCode != 0 || len(benchLines) == 0 {
+		results.WriteString(fmt.Sprintf("❌ No benchmark results (exit %d):\n\n```\n%s\n```\n", result.exitCode, tailLines(result.output, 30)))
+		return mcp.NewToolResultText(results.String()), nil
+	}
+	results.WriteString(fmt.Sprintf("## Results\n\n```\n%s\n```\n\n", strings.Join(benchLines, "\n")))
+
+	for _, profile := range profiles {
+		// A benchmark's allocations are what it did, not what it still
+		// holds at the end, so memory is ranked by alloc_space
+		options := fmt.Sprintf("-top -nodecount=%d", params.Top)
+		title := "CPU"
+		if profile == "mem" {
+			options += " -sample_index=alloc_space"
+			title = "Memory (allocated bytes)"
+		}
+		if params.Focus != "" {
+			options += " -focus=" + shellQuote(params.Focus)
+		}
+		pprof, err := runShell(ctx, quickbaseGoPath, time.Minute, fmt.Sprintf("go tool pprof %s %s %s", options, shellQuote(filepath.Join(dir, "bench.test")), shellQuote(filepath.Join(dir, profile+".out"))))
+		results.WriteString(fmt.Sprintf("## %s\n\n", title))
+		if err != nil {
+			results.WriteString(fmt.Sprintf("⚠️ %v\n\n", err))
+			continue
+		}
+		if pprof.exitCode != 0 {
+			results.WriteString(fmt.Sprintf("⚠️ go tool pprof failed:\n\n```\n%s\n```\n\n", tailLines(pprof.output, 10)))
+			continue
+		}
+		// pprof's header names the temp binary; the table is what matters
+		var lines []string
+		for _, line := range strings.Split(strings.TrimRight(pprof.output, "\n"), "\n") {
+			if strings.HasPrefix(line, "File: ") || strings.HasPrefix(line, "Build ID: ") {
+				continue
+			}
+			lines = append(lines, line)
+		}
+		results.WriteString(fmt.Sprintf("```\n%s\n```\n\n", strings.Join(lines, "\n")))
+	}
+	results.WriteString("flat is time or bytes in the function itself, cum includes what it calls. Narrow with focus (a function regex) to see one area, e.g. `throttle` or `paginate`.\n")
+	return mcp.NewToolResultText(results.String()), nil
+}
@@ -0,0 +1,398 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// qbOperators are the query language's comparison operators.
+var qbOperators = map[string]string{
+	"CT": "contains", "XCT": "does not contain",
+	"EX": "is", "XEX": "is not",
+	"SW": "starts with", "XSW": "does not start with",
+	"TV": "true value is", "XTV": "true value is not",
+	"HAS": "has", "XHAS": "does not have",
+	"LT": "less than", "LTE": "less than or equal", "GT": "greater than", "GTE": "greater than or equal",
+	"BF": "before", "OBF": "on or before", "AF": "after", "OAF": "on or after",
+	"IR": "in range", "XIR": "not in range",
+}
+
+// qbOperatorAliases let filters use familiar spellings.
+var qbOperatorAliases = map[string]string{
+	"=": "EX", "==": "EX", "!=": "XEX", "<>": "XEX",
+	"<": "LT", "<=": "LTE", ">": "GT", ">=": "GTE",
+	"CONTAINS": "CT", "STARTS_WITH": "SW", "BEFORE": "BF", "AFTER": "AF",
+	"ON_OR_BEFORE": "OBF", "ON_OR_AFTER": "OAF", "IN_RANGE": "IR",
+}
+
+// qbFieldOps are the operators that make sense for each kind of field;
+// qbFieldOpsOdd are accepted by Quickbase but compare the value as text.
+var (
+	qbFieldOps = map[string][]string{
+		"text":     {"CT", "XCT", "EX", "XEX", "SW", "XSW", "TV", "XTV", "LT", "LTE", "GT", "GTE"},
+		"number":   {"EX", "XEX", "LT", "LTE", "GT", "GTE", "TV", "XTV"},
+		"date":     {"EX", "XEX", "LT", "LTE", "GT", "GTE", "BF", "OBF", "AF", "OAF", "IR", "XIR", "TV", "XTV"},
+		"checkbox": {"EX", "XEX", "TV", "XTV"},
+		"user":     {"EX", "XEX", "TV", "XTV", "CT", "XCT", "SW", "XSW"},
+		"list":     {"HAS", "XHAS", "EX", "XEX", "CT", "XCT", "TV", "XTV"},
+	}
+	qbFieldOpsOdd = map[string][]string{
+		"number": {"CT", "XCT", "SW", "XSW"},
+		"date":   {"CT", "XCT"},
+	}
+)
+
+var (
+	// qbClause is one {fid.OP.'value'} comparison; the field ID may be
+	// quoted and the value left bare
+	qbClause = regexp.MustCompile(`^\{\s*'?(\w+)'?\s*\.\s*([A-Za-z]+)\s*\.\s*(?:'(.*?)'|([^'}]*))\s*\}`)
+	// qbConnector joins comparisons
+	qbConnector = regexp.MustCompile(`(?i)^(AND|OR)\b`)
+	// qbDateValue is a date the query language reads: ISO, US order,
+	// epoch milliseconds, or a relative day
+	qbDateValue = regexp.MustCompile(`(?i)^(\d{4}-\d{2}-\d{2}|\d{1,2}[-/]\d{1,2}[-/]\d{4}|\d{10,13}|today|yesterday|tomorrow)$`)
+	// qbRangeValue is a period IR and XIR accept besides a date
+	qbRangeValue = regexp.MustCompile(`(?i)^(this|last|next)\s+(\d+\s+)?(day|week|month|quarter|year|fiscal quarter|fiscal year)s?$`)
+)
+
+// fieldKind groups Quickbase field types by how they compare.
+func fieldKind(fieldType string) string {
+	switch fieldType {
+	case "numeric", "currency", "percent", "rating", "duration", "recordid":
+		return "number"
+	case "date", "timestamp", "timeofday":
+		return "date"
+	case "checkbox":
+		return "checkbox"
+	case "user":
+		return "user"
+	case "multitext", "multiuser":
+		return "list"
+	}
+	return "text"
+}
+
+// queryClause is a comparison read from a query string.
+type queryClause struct {
+	text, field, op, value string
+	quoted                 bool
+}
+
+// parseQBQuery splits a query string into its comparisons, checking the
+// structure around them: connectors between comparisons and balanced
+// parentheses.
+func parseQBQuery(query string) ([]queryClause, []string) {
+	var clauses []queryClause
+	var problems []string
+	depth, wantOperand := 0, true
+	rest := strings.TrimSpace(query)
+	for rest != "" {
+		at := len(query) - len(rest) + 1
+		switch {
+		case rest[0] == '(':
+			if !wantOperand {
+				problems = append(problems, fmt.Sprintf("missing AND/OR before ( at %d", at))
+			}
+			depth++
+			rest = rest[1:]
+		case rest[0] == ')':
+			if wantOperand {
+				problems = append(problems, fmt.Sprintf(") at %d closes a group with nothing after its last AND/OR", at))
+			}
+			if depth == 0 {
+				problems = append(problems, fmt.Sprintf("unmatched ) at %d", at))
+			} else {
+				depth--
+			}
+			rest = rest[1:]
+		case rest[0] == '{':
+			m := qbClause.FindStringSubmatch(rest)
+			if m == nil {
+				end := strings.IndexByte(rest, '}')
+				if end < 0 {
+					end = len(rest) - 1
+				}
+				problems = append(problems, fmt.Sprintf("%s at %d isn't {fid.OP.'value'}", rest[:end+1], at))
+				rest = rest[end+1:]
+				wantOperand = false
+				break
+			}
+			if !wantOperand {
+				problems = append(problems, fmt.Sprintf("missing AND/OR before %s", m[0]))
+			}
+			clause := queryClause{text: m[0], field: m[1], op: strings.ToUpper(m[2]), value: m[3], quoted: m[4] == ""}
+			if !clause.quoted {
+				clause.value = strings.TrimSpace(m[4])
+			}
+			clauses = append(clauses, clause)
+			rest = rest[len(m[0]):]
+			wantOperand = false
+		default:
+			m := qbConnector.FindString(rest)
+			if m == "" {
+				word := strings.Fields(rest)[0]
+				problems = append(problems, fmt.Sprintf("unexpected %q at %d; comparisons are joined with AND or OR", word, at))
+				rest = rest[len(word):]
+				break
+			}
+			if wantOperand {
+				problems = append(problems, fmt.Sprintf("%s at %d has no comparison before it", strings.ToUpper(m), at))
+			}
+			rest = rest[len(m):]
+			wantOperand = true
+		}
+		rest = strings.TrimSpace(rest)
+	}
+	if depth > 0 {
+		problems = append(problems, fmt.Sprintf("%d unclosed (", depth))
+	}
+	if wantOperand && len(clauses) > 0 {
+		problems = append(problems, "the query ends with AND/OR")
+	}
+	return clauses, problems
+}
+
+// checkClause checks one comparison's operator and value, and with the
+// field's schema, that the field exists and the operator and value suit
+// its type.
+func checkClause(c queryClause, fields map[int]liveField) (errs, warns []string) {
+	if _, ok := qbOperators[c.op]; !ok {
+		errs = append(errs, fmt.Sprintf("%s isn't an operator", c.op))
+	}
+	fid, err := strconv.Atoi(c.field)
+	if err != nil || fid <= 0 {
+		errs = append(errs, fmt.Sprintf("%q isn't a field ID; queries use IDs, not labels", c.field))
+	}
+	if !c.quoted {
+		warns = append(warns, "quote the value: '"+c.value+"'")
+	}
+	if strings.Contains(c.value, "'") {
+		warns = append(warns, "a ' in the value can end it early")
+	}
+	if len(errs) > 0 || fields == nil {
+		return errs, warns
+	}
+
+	field, ok := fields[fid]
+	if !ok {
+		return append(errs, fmt.Sprintf("field %d isn't in the table", fid)), warns
+	}
+	kind := fieldKind(field.FieldType)
+	switch {
+	case containsString(qbFieldOps[kind], c.op):
+	case containsString(qbFieldOpsOdd[kind], c.op):
+		warns = append(warns, fmt.Sprintf("%s on a %s field compares it as text", c.op, field.FieldType))
+	default:
+		errs = append(errs, fmt.Sprintf("%s doesn't apply to %s fields (use %s)", c.op, field.FieldType, strings.Join(qbFieldOps[kind], ", ")))
+		return errs, warns
+	}
+	if c.value == "" || c.op == "TV" || c.op == "XTV" {
+		return errs, warns
+	}
+	switch kind {
+	case "number":
+		if _, err := strconv.ParseFloat(strings.ReplaceAll(c.value, ",", ""), 64); err != nil && !containsString(qbFieldOpsOdd[kind], c.op) {
+			errs = append(errs, fmt.Sprintf("'%s' isn't a number", c.value))
+		}
+	case "date":
+		switch {
+		case field.FieldType == "timeofday":
+		case (c.op == "IR" || c.op == "XIR") && qbRangeValue.MatchString(c.value):
+		case qbRangeValue.MatchString(c.value):
+			errs = append(errs, fmt.Sprintf("'%s' is a period; only IR and XIR take one", c.value))
+		case !qbDateValue.MatchString(c.value) && !containsString(qbFieldOpsOdd[kind], c.op):
+			warns = append(warns, fmt.Sprintf("'%s' may not be read as a date (use YYYY-MM-DD)", c.value))
+		}
+	case "checkbox":
+		switch strings.ToLower(c.value) {
+		case "true", "false", "yes", "no", "1", "0":
+		default:
+			errs = append(errs, fmt.Sprintf("'%s' isn't a checkbox value (true or false)", c.value))
+		}
+	}
+	return errs, warns
+}
+
+// qbFilter is one structured comparison to build a query from.
+type qbFilter struct {
+	Field any    `json:"field"`
+	Op    string `json:"op"`
+	Value any    `json:"value"`
+}
+
+// buildQBQuery turns filters into a query string, resolving field labels
+// with the schema when there is one.
+func buildQBQuery(filters []qbFilter, join string, fields map[int]liveField) (string, error) {
+	byLabel := map[string]int{}
+	for id, f := range fields {
+		byLabel[strings.ToLower(f.Label)] = id
+	}
+	parts := make([]string, 0, len(filters))
+	for i, f := range filters {
+		var fid string
+		switch v := f.Field.(type) {
+		case float64:
+			fid = strconv.Itoa(int(v))
+		case string:
+			if _, err := strconv.Atoi(v); err == nil {
+				fid = v
+			} else if id, ok := byLabel[strings.ToLower(v)]; ok {
+				fid = strconv.Itoa(id)
+			} else if fields == nil {
+				return "", fmt.Errorf("filter %d: field %q needs the live schema to resolve; give its ID", i+1, v)
+			} else {
+				return "", fmt.Errorf("filter %d: no field labeled %q", i+1, v)
+			}
+		default:
+			return "", fmt.Errorf("filter %d: field is required", i+1)
+		}
+		op := strings.ToUpper(strings.TrimSpace(f.Op))
+		if alias, ok := qbOperatorAliases[op]; ok {
+			op = alias
+		}
+		var value string
+		switch v := f.Value.(type) {
+		case nil:
+		case string:
+			value = v
+		case float64:
+			value = strconv.FormatFloat(v, 'f', -1, 64)
+		case bool:
+			value = strconv.FormatBool(v)
+		default:
+			return "", fmt.Errorf("filter %d: value must be a string, number or boolean", i+1)
+		}
+		parts = append(parts, fmt.Sprintf("{%s.%s.'%s'}", fid, op, value))
+	}
+	return strings.Join(parts, " "+join+" "), nil
+}
+
+func (s *QuickBasePersonalMCPServer) handleQBBuildQuery(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	var params struct {
+		Query          string     `json:"query"`
+		Filters        []qbFilter `json:"filters"`
+		Join           string     `json:"join"`
+		Table          string     `json:"table"`
+		Offline        bool       `json:"offline"`
+		Refresh        bool       `json:"refresh"`
+		TimeoutSeconds int        `json:"timeout_seconds"`
+	}
+	argsData, _ := json.Marshal(request.Params.Arguments)
+	if err := json.Unmarshal(argsData, &params); err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Invalid parameters: %v", err)), nil
+	}
+	if (params.Query == "") == (len(params.Filters) == 0) {
+		return mcp.NewToolResultError("Provide exactly one of query (to check) or filters (to build from)"), nil
+	}
+	params.Join = strings.ToUpper(params.Join)
+	if params.Join == "" {
+		params.Join = "AND"
+	}
+	if params.Join != "AND" && params.Join != "OR" {
+		return mcp.NewToolResultError("join must be AND or OR"), nil
+	}
+	timeout := defaultLiveTimeout
+	if params.TimeoutSeconds > 0 {
+		timeout = time.Duration(params.TimeoutSeconds) * time.Second
+	}
+
+	// The live schema, when it can be had: without it only the syntax is
+	// checked
+	var fields map[int]liveField
+	schema := "not used (offline), so field IDs and types aren't checked"
+	if !params.Offline {
+		cfg, err := loadLiveConfig()
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("Failed to load config: %v", err)), nil
+		}
+		if params.Table == "" && cfg.TableID != defaultConfig.TableID {
+			params.Table = cfg.TableID
+		}
+		if params.Table == "" {
+			schema = "no table (or table_id in config.yaml): field IDs and types not checked"
+		} else if client, err := s.newLiveClient(ctx, cfg, request.Params.Name); err != nil {
+			schema = fmt.Sprintf("field IDs and types not checked: %v", err)
+		} else {
+			var reply struct {
+				Fields []liveField `json:"fields"`
+			}
+			if cached, err := client.callCached(ctx, timeout, map[string]any{"op": "get_fields", "table": params.Table}, &reply, params.Refresh); err != nil {
+				schema = fmt.Sprintf("field IDs and types not checked: %v", err)
+			} else {
+				fields = map[int]liveField{}
+				for _, f := range reply.Fields {
+					fields[f.ID] = f
+				}
+				schema = fmt.Sprintf("checked against the %d fields of %s on %s", len(fields), params.Table, client.realm)
+				if !cached.IsZero() {
+					schema += fmt.Sprintf(" (schema cached %s ago; refresh: true to refetch)", time.Since(cached).Round(time.Second))
+				}
+			}
+		}
+	}
+
+	query := params.Query
+	if len(params.Filters) > 0 {
+		built, err := buildQBQuery(params.Filters, params.Join, fields)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		query = built
+	}
+	clauses, problems := parseQBQuery(query)
+
+	var results strings.Builder
+	results.WriteString(fmt.Sprintf("# qb_build_query\n\n```\n%s\n```\n\n", query))
+	marker := "⚠️"
+	if fields != nil {
+		marker = "✅"
+	}
+	results.WriteString(fmt.Sprintf("%s Schema: %s\n\n", marker, schema))
+	warnings := 0
+	if len(clauses) > 0 {
+		results.WriteString("| Comparison | Field | Meaning | Check |\n|---|---|---|---|\n")
+	}
+	for _, c := range clauses {
+		errs, warns := checkClause(c, fields)
+		problems = append(problems, errs...)
+		warnings += len(warns)
+		field := c.field
+		if fid, err := strconv.Atoi(c.field); err == nil {
+			if f, ok := fields[fid]; ok {
+				field = fmt.Sprintf("%s (%d, %s)", f.Label, fid, f.FieldType)
+			}
+		}
+		check := "✅"
+		if notes := append(errs, warns...); len(notes) > 0 {
+			check = "⚠️ " + strings.Join(notes, "; ")
+			if len(errs) > 0 {
+				check = "❌ " + strings.Join(notes, "; ")
+			}
+		}
+		results.WriteString(fmt.Sprintf("| `%s` | %s | %s '%s' | %s |\n", strings.ReplaceAll(c.text, "|", `\|`), strings.ReplaceAll(field, "|", `\|`), dash(qbOperators[c.op]), strings.ReplaceAll(c.value, "|", `\|`), strings.ReplaceAll(check, "|", `\|`)))
+	}
+	if len(clauses) > 0 {
+		results.WriteString("\n")
+	}
+	switch {
+	case len(clauses) == 0:
+		results.WriteString("❌ No {fid.OP.'value'} comparisons found\n")
+	case len(problems) > 0:
+		results.WriteString(fmt.Sprintf("❌ %d problem(s):\n\n", len(problems)))
+		for _, p := range problems {
+			results.WriteString("- " + p + "\n")
+		}
+	case warnings > 0:
+		results.WriteString(fmt.Sprintf("⚠️ Valid, with %d warning(s)\n", warnings))
+	default:
+		results.WriteString("✅ Valid\n")
+	}
+	return mcp.NewToolResultText(results.String()), nil
+}
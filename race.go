@@ -0,0 +1,280 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"path"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+var (
+	// raceAccess is "Write at 0x00c0000b4010 by goroutine 8:" or
+	// "Previous read at 0x00c0000b4010 by goroutine 7:"
+	raceAccess = regexp.MustCompile(`^((?:Previous )?(?:[Ww]rite|[Rr]ead|atomic write|atomic read)) at 0x[0-9a-f]+ by (goroutine \d+|main goroutine):$`)
+	// raceCreated is "Goroutine 8 (running) created at:"
+	raceCreated = regexp.MustCompile(`^Goroutine (\d+) \((?:running|finished)\) created at:$`)
+	// raceFrame is a stack frame's source line, "\t/abs/path/file.go:42 +0x64"
+	raceFrame = regexp.MustCompile(`^\s+(\S+\.go):(\d+)(?: \+0x[0-9a-f]+)?$`)
+)
+
+// dataRace is one race the detector reported: the two conflicting accesses
+// and where their goroutines were started, each as "what at file:line in
+// function".
+type dataRace struct {
+	accesses, created []string
+	tests             []string
+	seen              int
+}
+
+// parseDataRace reads one WARNING: DATA RACE report, with file paths made
+// relative to the repo where they're inside it.
+func parseDataRace(lines []string, repoPath string) dataRace {
+	var race dataRace
+	heading, function := "", ""
+	for _, line := range lines {
+		if m := raceAccess.FindStringSubmatch(line); m != nil {
+			heading, function = fmt.Sprintf("%s by %s", m[1], m[2]), ""
+			continue
+		}
+		if m := raceCreated.FindStringSubmatch(line); m != nil {
+			heading, function = fmt.Sprintf("goroutine %s created", m[1]), ""
+			continue
+		}
+		if heading == "" {
+			continue
+		}
+		if m := raceFrame.FindStringSubmatch(line); m != nil {
+			// The first frame under a heading is the access (or go
+			// statement) itself
+			file := strings.TrimPrefix(m[1], repoPath+"/")
+			entry := fmt.Sprintf("%s at `%s:%s`", heading, file, m[2])
+			if function != "" {
+				entry += fmt.Sprintf(" in `%s`", function)
+			}
+			if strings.HasPrefix(heading, "goroutine") {
+				race.created = append(race.created, entry)
+			} else {
+				race.accesses = append(race.accesses, entry)
+			}
+			heading = ""
+			continue
+		}
+		if trimmed := strings.TrimSpace(line); trimmed != "" && function == "" {
+			function = trimmed
+		}
+	}
+	return race
+}
+
+// testOutcome counts one test's results over -count runs.
+type testOutcome struct {
+	passed, failed int
+}
+
+// scanRaceRun reads go test -json events from a -race run: the data races
+// in each test's output, deduplicated by their accesses, and each test's
+// pass/fail counts.
+func scanRaceRun(output, repoPath string) ([]*dataRace, map[string]*testOutcome) {
+	var races []*dataRace
+	bySignature := map[string]*dataRace{}
+	outcomes := map[string]*testOutcome{}
+	// Reports are collected per test, since parallel tests' output
+	// interleaves
+	open := map[string][]string{}
+	for _, line := range strings.Split(output, "\n") {
+		var ev goTestEvent
+		if !strings.HasPrefix(line, "{") || json.Unmarshal([]byte(line), &ev) != nil {
+			continue
+		}
+		name := ev.Test
+		if name == "" {
+			name = ev.Package
+		}
+		switch ev.Action {
+		case "output":
+			text := strings.TrimRight(ev.Output, "\n")
+			lines, inRace := open[name]
+			switch {
+			case strings.TrimSpace(text) == "WARNING: DATA RACE":
+				open[name] = []string{}
+			case inRace && strings.HasPrefix(text, "=================="):
+				race := parseDataRace(lines, repoPath)
+				delete(open, name)
+				signature := strings.Join(race.accesses, "\n")
+				existing := bySignature[signature]
+				if existing == nil {
+					existing = &race
+					bySignature[signature] = existing
+					races = append(races, existing)
+				}
+				existing.seen++
+				if ev.Test != "" && !containsString(existing.tests, ev.Test) {
+					existing.tests = append(existing.tests, ev.Test)
+				}
+			case inRace:
+				open[name] = append(lines, text)
+			}
+		case "pass", "fail":
+			if ev.Test == "" {
+				continue
+			}
+			key := ev.Package + " " + ev.Test
+			if outcomes[key] == nil {
+				outcomes[key] = &testOutcome{}
+			}
+			if ev.Action == "pass" {
+				outcomes[key].passed++
+			} else {
+				outcomes[key].failed++
+			}
+		}
+	}
+	return races, outcomes
+}
+
+func (s *QuickBasePersonalMCPServer) handleRunRaceTests(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	var params struct {
+		Path           string `json:"path"`
+		TestName       string `json:"test_name"`
+		Count          int    `json:"count"`
+		TimeoutSeconds int    `json:"timeout_seconds"`
+	}
+	argsData, _ := json.Marshal(request.Params.Arguments)
+	if err := json.Unmarshal(argsData, &params); err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Invalid parameters: %v", err)), nil
+	}
+	if params.Count <= 0 {
+		params.Count = 1
+	}
+	// The race detector slows tests down several times over, and each
+	// -count run repeats them
+	timeout := defaultTestTimeout * time.Duration(params.Count)
+	if params.TimeoutSeconds > 0 {
+		timeout = time.Duration(params.TimeoutSeconds) * time.Second
+	}
+	cfg, err := loadConfig()
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to load config: %v", err)), nil
+	}
+	repo := gitRepo{"quickbase-go", quickbaseGoPath}
+	command, err := testCommand(cfg, repo)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	if testRunner(repo.path, command) != "go" {
+		return mcp.NewToolResultError(fmt.Sprintf("test_commands.go is %q; the race detector needs go test", command)), nil
+	}
+	// -count also keeps go test from replaying cached results, which
+	// would skip the detector
+	rest, _ := strings.CutPrefix(withJSONReporter(command, "go"), "go test")
+	command = fmt.Sprintf("go test -race -count=%d%s", params.Count, rest)
+	command = withTestFilter(withTestName(command, params.TestName), params.Path)
+
+	result, err := runShell(ctx, repo.path, timeout, command)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	output := ansiEscape.ReplaceAllString(result.output, "")
+	report, text := parseGoTestJSON(output)
+	races, outcomes := scanRaceRun(output, repo.path)
+
+	var results strings.Builder
+	results.WriteString("# Race detector\n\n")
+	status := "✅ passed"
+	switch {
+	case result.timedOut:
+		status = fmt.Sprintf("⏱️ timed out after %s", timeout)
+	case result.exitCode != 0:
+		status = fmt.Sprintf("❌ failed (exit %d)", result.exitCode)
+	}
+	results.WriteString(fmt.Sprintf("`%s` in %s: %s in %s\n\n", command, repo.name, status, result.duration.Round(100*time.Millisecond)))
+	if report.counted {
+		results.WriteString(fmt.Sprintf("Test runs: %d passed, %d failed, %d skipped\n", report.passed, report.failed, report.skipped))
+	}
+	if report.packagesOK+report.packagesFailed > 0 {
+		results.WriteString(fmt.Sprintf("Packages: %d ok, %d failed\n", report.packagesOK, report.packagesFailed))
+	}
+	results.WriteString("\n")
+
+	if len(races) == 0 {
+		if report.counted {
+			results.WriteString(fmt.Sprintf("✅ No data races detected over %d run(s).\n\n", params.Count))
+		}
+	} else {
+		results.WriteString(fmt.Sprintf("## Data races (%d)\n\n", len(races)))
+		for i, race := range races {
+			results.WriteString(fmt.Sprintf("### Race %d\n\n", i+1))
+			seen := fmt.Sprintf("Reported %d time(s)", race.seen)
+			if len(race.tests) > 0 {
+				seen += " during " + strings.Join(race.tests, ", ")
+			}
+			results.WriteString(seen + "\n\n")
+			for _, entry := range append(race.accesses, race.created...) {
+				results.WriteString(fmt.Sprintf("- %s\n", entry))
+			}
+			results.WriteString("\n")
+		}
+	}
+
+	if params.Count > 1 {
+		// The detector reports a race once per test binary, so a racy test
+		// passes its later runs; that's the race, not a flake
+		racy := map[string]bool{}
+		for _, race := range races {
+			for _, name := range race.tests {
+				racy[name] = true
+			}
+		}
+		var flaky []string
+		for _, key := range sortedKeys(outcomes) {
+			pkg, name, _ := strings.Cut(key, " ")
+			if o := outcomes[key]; o.passed > 0 && o.failed > 0 && !racy[name] {
+				flaky = append(flaky, fmt.Sprintf("- %s (%s): failed %d of %d runs", name, goPackageDir(repo.path, pkg), o.failed, o.passed+o.failed))
+			}
+		}
+		if len(flaky) > 0 {
+			results.WriteString(fmt.Sprintf("## Flaky tests (%d)\n\nThese passed in some runs and failed in others.\n\n%s\n\n", len(flaky), strings.Join(flaky, "\n")))
+		}
+	}
+
+	// Failures other than the race reports themselves, once per test
+	var others []testFailure
+	listed := map[string]bool{}
+	for _, f := range report.failures {
+		key := f.pkg + " " + f.name
+		if listed[key] || strings.Contains(f.message, "race detected during execution of test") {
+			continue
+		}
+		listed[key] = true
+		if f.pkg != "" && f.file != "" && !strings.Contains(f.file, "/") {
+			f.file = path.Join(goPackageDir(repo.path, f.pkg), f.file)
+		}
+		others = append(others, f)
+	}
+	sort.SliceStable(others, func(i, j int) bool { return others[i].pkg < others[j].pkg })
+	if len(others) > 0 {
+		results.WriteString(fmt.Sprintf("## Other failures (%d)\n\n", len(others)))
+		for _, f := range others {
+			location := ""
+			if f.file != "" {
+				location = fmt.Sprintf(" at `%s:%d`", f.file, f.line)
+			}
+			results.WriteString(fmt.Sprintf("### %s%s\n\n", f.name, location))
+			if f.message != "" {
+				message, _ := truncateLines(f.message, 10)
+				results.WriteString(fmt.Sprintf("```\n%s\n```\n\n", message))
+			}
+		}
+		results.WriteString("run_tests with test_name shows a test's full output.\n\n")
+	} else if len(races) == 0 && (result.timedOut || result.exitCode != 0) {
+		// -race needs cgo; a failure before any test ran shows up here
+		results.WriteString(fmt.Sprintf("```\n%s\n```\n\n", tailLines(text, 30)))
+	}
+	return mcp.NewToolResultText(strings.TrimRight(results.String(), "\n") + "\n"), nil
+}
@@ -0,0 +1,387 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// readinessCheck is one line of the release checklist. A skipped check
+// couldn't apply to the repo and doesn't count against it.
+type readinessCheck struct {
+	name, detail string
+	passed       bool
+	skipped      bool
+}
+
+// compareVersions orders two semver versions, with or without a v, by
+// major, minor and patch. ok is false when either isn't a version.
+func compareVersions(a, b string) (int, bool) {
+	ma, mb := semverTag.FindStringSubmatch(a), semverTag.FindStringSubmatch(b)
+	if ma == nil || mb == nil {
+		return 0, false
+	}
+	for i := 2; i <= 4; i++ {
+		x, _ := strconv.Atoi(ma[i])
+		y, _ := strconv.Atoi(mb[i])
+		if x != y {
+			if x < y {
+				return -1, true
+			}
+			return 1, true
+		}
+	}
+	return 0, true
+}
+
+// findChangelog is the repo's changelog file, if it has one.
+func findChangelog(repoPath string) string {
+	for _, name := range []string{"CHANGELOG.md", "Changelog.md", "changelog.md", "CHANGES.md"} {
+		if fileExists(filepath.Join(repoPath, name)) {
+			return name
+		}
+	}
+	return ""
+}
+
+// checkCleanTree fails on uncommitted work or an unfinished merge or rebase.
+func checkCleanTree(repo gitRepo) readinessCheck {
+	check := readinessCheck{name: "Clean working tree"}
+	st, err := readRepoStatus(repo.path)
+	if err != nil {
+		check.detail = err.Error()
+		return check
+	}
+	if op := inProgress(repo.path); op != "" {
+		check.detail = fmt.Sprintf("a %s is in progress", op)
+		return check
+	}
+	if st.dirty() {
+		var parts []string
+		for _, group := range []struct {
+			label string
+			files []string
+		}{{"staged", st.staged}, {"modified", st.modified}, {"untracked", st.untracked}, {"conflicted", st.conflicts}} {
+			if len(group.files) > 0 {
+				parts = append(parts, fmt.Sprintf("%d %s", len(group.files), group.label))
+			}
+		}
+		check.detail = strings.Join(parts, ", ") + "; commit or stash them first"
+		return check
+	}
+	check.passed = true
+	check.detail = "nothing uncommitted on " + st.branch
+	if st.behind != "" && st.behind != "0" {
+		check.detail += fmt.Sprintf(" (%s commit(s) behind %s)", st.behind, st.upstream)
+	}
+	return check
+}
+
+// checkTestsPass runs the repo's test command the way run_tests does.
+func checkTestsPass(ctx context.Context, cfg serverConfig, repo gitRepo, timeout time.Duration) readinessCheck {
+	check := readinessCheck{name: "Tests passing"}
+	command, err := testCommand(cfg, repo)
+	if err != nil {
+		check.detail = err.Error()
+		return check
+	}
+	runner := testRunner(repo.path, command)
+	command = withJSONReporter(command, runner)
+	run := runTestOnce(ctx, repo.path, command, runner, "", timeout)
+	switch run.status {
+	case "passed":
+		check.passed = true
+		check.detail = fmt.Sprintf("`%s` passed (%s)", command, run.duration.Round(100*time.Millisecond))
+	case "timed out":
+		check.detail = fmt.Sprintf("`%s` timed out after %s", command, timeout)
+	case "error":
+		check.detail = run.output
+	default:
+		var names []string
+		for _, f := range run.failures {
+			names = append(names, f.name)
+		}
+		check.detail = fmt.Sprintf("`%s` failed", command)
+		if len(names) > 0 {
+			more := ""
+			if len(names) > 5 {
+				names, more = names[:5], fmt.Sprintf(" and %d more", len(names)-5)
+			}
+			check.detail += ": " + strings.Join(names, ", ") + more
+		}
+	}
+	return check
+}
+
+// checkCodegenFresh regenerates HEAD from the spec it pins; repos without
+// a generator skip it.
+func checkCodegenFresh(ctx context.Context, cfg serverConfig, repo gitRepo, timeout time.Duration) readinessCheck {
+	check := readinessCheck{name: "Generated code fresh"}
+	if _, err := codegenCommand(cfg, repo); err != nil {
+		check.skipped, check.detail = true, "no generator configured"
+		return check
+	}
+	result, err := checkCodegenAtHead(ctx, cfg, repo, "pin", timeout)
+	switch {
+	case err != nil:
+		check.detail = err.Error()
+	case result.result.timedOut:
+		check.detail = fmt.Sprintf("`%s` timed out after %s", result.command, timeout)
+	case result.result.exitCode != 0:
+		check.detail = fmt.Sprintf("`%s` failed (exit %d)", result.command, result.result.exitCode)
+	case len(result.changes) > 0:
+		var paths []string
+		for _, f := range result.changes {
+			paths = append(paths, "`"+f.path+"`")
+		}
+		check.detail = fmt.Sprintf("regenerating from %s changes %s; see check_codegen_stale", result.label, strings.Join(paths, ", "))
+	default:
+		check.passed = true
+		check.detail = fmt.Sprintf("regenerating from %s changes nothing", result.label)
+	}
+	return check
+}
+
+// checkChangelog wants an entry for the version being released, or at
+// least changes to the changelog since the last tag when the version
+// isn't known.
+func checkChangelog(repo gitRepo, tag, version string) readinessCheck {
+	check := readinessCheck{name: "Changelog updated"}
+	file := findChangelog(repo.path)
+	if file == "" {
+		check.detail = "no CHANGELOG.md"
+		return check
+	}
+	data, err := os.ReadFile(filepath.Join(repo.path, file))
+	if err != nil {
+		check.detail = err.Error()
+		return check
+	}
+	if version != "" {
+		heading := regexp.MustCompile(`(?m)^#+.*\bv?` + regexp.QuoteMeta(strings.TrimPrefix(version, "v")) + `\b`)
+		if heading.Match(data) {
+			check.passed = true
+			check.detail = fmt.Sprintf("%s has a heading for %s", file, version)
+		} else {
+			check.detail = fmt.Sprintf("%s has no heading for %s", file, version)
+		}
+		return check
+	}
+	if tag == "" {
+		check.passed = true
+		check.detail = fmt.Sprintf("%s exists; no earlier tag to compare with", file)
+		return check
+	}
+	if changed, _ := runGit(repo.path, "log", "--format=%h", tag+"..HEAD", "--", file); changed != "" {
+		check.passed = true
+		check.detail = fmt.Sprintf("%s changed since %s", file, tag)
+	} else {
+		check.detail = fmt.Sprintf("%s unchanged since %s", file, tag)
+	}
+	return check
+}
+
+// checkSpecPin wants the checked-out spec to be the pinned one, and both
+// SDKs to pin the same spec commit so they release in lockstep.
+func checkSpecPin(repo, other gitRepo) readinessCheck {
+	check := readinessCheck{name: "Spec pin consistent"}
+	sub, ok := specSubmodule(repo.path)
+	if !ok {
+		check.skipped, check.detail = true, "no spec submodule"
+		return check
+	}
+	pin := specPin(repo.path)
+	if sub.state != "checked out" {
+		check.detail = fmt.Sprintf("%s is %s, not the pinned %s", sub.path, sub.state, shortSHA(pin))
+		return check
+	}
+	if otherPin := specPin(other.path); otherPin != "" && otherPin != pin {
+		check.detail = fmt.Sprintf("pins %s but %s pins %s", shortSHA(pin), other.name, shortSHA(otherPin))
+		return check
+	}
+	check.passed = true
+	check.detail = fmt.Sprintf("pins %s", shortSHA(pin))
+	if specPin(other.path) != "" {
+		check.detail += ", same as " + other.name
+	}
+	if head, err := runGit(quickbaseSpecPath, "rev-parse", "HEAD"); err == nil && head != pin {
+		if behind, err := runGit(quickbaseSpecPath, "rev-list", "--count", pin+"..HEAD"); err == nil {
+			check.detail += fmt.Sprintf(" (%s commit(s) behind quickbase-spec HEAD)", behind)
+		}
+	}
+	return check
+}
+
+// checkVersionBumped compares the version being released with the last
+// tag. quickbase-js's is package.json's; quickbase-go's is the tag it's
+// about to get, so only a given version can be checked, along with the
+// module path's major suffix.
+func checkVersionBumped(repo gitRepo, tag, version string) readinessCheck {
+	check := readinessCheck{name: "Version bumped"}
+	if version == "" {
+		check.skipped = true
+		if tag != "" {
+			if commits, err := runGit(repo.path, "rev-list", "--count", tag+"..HEAD"); err == nil {
+				check.detail = fmt.Sprintf("%s commit(s) since %s; pass version to check it (draft_changelog suggests one)", commits, tag)
+				return check
+			}
+		}
+		check.detail = "pass version to check it"
+		return check
+	}
+	if _, err := runGit(repo.path, "rev-parse", "--verify", "--quiet", "refs/tags/"+version); err == nil {
+		check.detail = fmt.Sprintf("%s is already tagged", version)
+		return check
+	}
+	if tag != "" {
+		cmp, ok := compareVersions(version, tag)
+		if !ok {
+			check.detail = fmt.Sprintf("can't compare %s with %s", version, tag)
+			return check
+		}
+		if cmp <= 0 {
+			check.detail = fmt.Sprintf("%s isn't after the latest tag %s", version, tag)
+			return check
+		}
+	}
+	if repo.name == "quickbase-go" {
+		data, err := os.ReadFile(filepath.Join(repo.path, "go.mod"))
+		if err != nil {
+			check.detail = err.Error()
+			return check
+		}
+		module := ""
+		if m := goModuleLine.FindSubmatch(data); m != nil {
+			module = string(m[1])
+		}
+		suffix := ""
+		if m := goMajorSuffix.FindStringSubmatch(module); m != nil {
+			suffix = m[1]
+		}
+		if major := majorVersion(version); major >= 2 && suffix != strconv.Itoa(major) {
+			check.detail = fmt.Sprintf("%s needs the module path %s to end in /v%d", version, module, major)
+			return check
+		} else if major < 2 && suffix != "" {
+			check.detail = fmt.Sprintf("module path %s ends in /v%s but the version is %s", module, suffix, version)
+			return check
+		}
+	}
+	check.passed = true
+	if tag == "" {
+		check.detail = fmt.Sprintf("%s, the first tag", version)
+	} else {
+		check.detail = fmt.Sprintf("%s after %s", version, tag)
+	}
+	return check
+}
+
+func (s *QuickBasePersonalMCPServer) handleReleaseReadiness(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	var params struct {
+		SDK            string `json:"sdk"`
+		Version        string `json:"version"`
+		SkipTests      bool   `json:"skip_tests"`
+		SkipCodegen    bool   `json:"skip_codegen"`
+		TimeoutSeconds int    `json:"timeout_seconds"`
+	}
+	argsData, _ := json.Marshal(request.Params.Arguments)
+	if err := json.Unmarshal(argsData, &params); err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Invalid parameters: %v", err)), nil
+	}
+	var repo, other gitRepo
+	switch params.SDK {
+	case "js", "quickbase-js":
+		repo, other = gitRepo{"quickbase-js", quickbaseJSPath}, gitRepo{"quickbase-go", quickbaseGoPath}
+	case "go", "quickbase-go":
+		repo, other = gitRepo{"quickbase-go", quickbaseGoPath}, gitRepo{"quickbase-js", quickbaseJSPath}
+	default:
+		return mcp.NewToolResultError("sdk must be 'js' or 'go'"), nil
+	}
+	timeout := defaultTestTimeout
+	if params.TimeoutSeconds > 0 {
+		timeout = time.Duration(params.TimeoutSeconds) * time.Second
+	}
+	cfg, err := loadConfig()
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to load config: %v", err)), nil
+	}
+	gv := readGitVersion(repo.path)
+	if gv.err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("%s: %v", repo.name, gv.err)), nil
+	}
+
+	// package.json already says what quickbase-js will publish as
+	version := params.Version
+	var mismatch *readinessCheck
+	if repo.name == "quickbase-js" {
+		var pkg struct {
+			Version string `json:"version"`
+		}
+		data, err := os.ReadFile(filepath.Join(repo.path, "package.json"))
+		if err == nil {
+			err = json.Unmarshal(data, &pkg)
+		}
+		switch {
+		case err != nil:
+			mismatch = &readinessCheck{name: "Version bumped", detail: fmt.Sprintf("package.json: %v", err)}
+		case version != "" && strings.TrimPrefix(version, "v") != pkg.Version:
+			mismatch = &readinessCheck{name: "Version bumped", detail: fmt.Sprintf("package.json says %s, not %s", pkg.Version, version)}
+		case version == "":
+			version = pkg.Version
+			if strings.HasPrefix(gv.latestTag, "v") {
+				version = "v" + version
+			}
+		}
+	}
+
+	checks := []readinessCheck{checkCleanTree(repo)}
+	if params.SkipTests {
+		checks = append(checks, readinessCheck{name: "Tests passing", skipped: true, detail: "skipped"})
+	} else {
+		checks = append(checks, checkTestsPass(ctx, cfg, repo, timeout))
+	}
+	if params.SkipCodegen {
+		checks = append(checks, readinessCheck{name: "Generated code fresh", skipped: true, detail: "skipped"})
+	} else {
+		checks = append(checks, checkCodegenFresh(ctx, cfg, repo, timeout))
+	}
+	checks = append(checks, checkChangelog(repo, gv.latestTag, version), checkSpecPin(repo, other))
+	if mismatch != nil {
+		checks = append(checks, *mismatch)
+	} else {
+		checks = append(checks, checkVersionBumped(repo, gv.latestTag, version))
+	}
+
+	var results strings.Builder
+	title := repo.name
+	if version != "" {
+		title += " " + version
+	}
+	results.WriteString(fmt.Sprintf("# Release readiness: %s\n\n", title))
+	results.WriteString(fmt.Sprintf("HEAD %s on %s, latest tag %s\n\n", commitLine(repo.path, "HEAD"), gv.branch, orNone(gv.latestTag)))
+	failed := 0
+	for _, check := range checks {
+		mark := "✅"
+		switch {
+		case check.skipped:
+			mark = "➖"
+		case !check.passed:
+			mark = "❌"
+			failed++
+		}
+		results.WriteString(fmt.Sprintf("- %s **%s**: %s\n", mark, check.name, check.detail))
+	}
+	if failed == 0 {
+		results.WriteString(fmt.Sprintf("\n✅ Ready to release %s.\n", title))
+	} else {
+		results.WriteString(fmt.Sprintf("\n❌ Not ready: %d of %d check(s) failed.\n", failed, len(checks)))
+	}
+	return mcp.NewToolResultText(results.String()), nil
+}
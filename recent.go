@@ -0,0 +1,240 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// featureMatcher reports whether a path belongs to a feature's patterns.
+// Unlike resolveFeatureFiles it doesn't need the file to exist, so deleted
+// and renamed files still count.
+func featureMatcher(patterns []string) func(string) bool {
+	var include, exclude []func(string) bool
+	for _, pattern := range patterns {
+		negate := strings.HasPrefix(pattern, "!")
+		pattern = strings.TrimPrefix(pattern, "!")
+		var match func(string) bool
+		if strings.ContainsAny(pattern, "*?") {
+			re, err := globRegexp(pattern)
+			if err != nil {
+				continue
+			}
+			match = re.MatchString
+		} else {
+			p := strings.TrimSuffix(pattern, "/")
+			match = func(rel string) bool { return rel == p || strings.HasPrefix(rel, p+"/") }
+		}
+		if negate {
+			exclude = append(exclude, match)
+		} else {
+			include = append(include, match)
+		}
+	}
+	return func(rel string) bool {
+		for _, m := range exclude {
+			if m(rel) {
+				return false
+			}
+		}
+		for _, m := range include {
+			if m(rel) {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// commitPaths are the paths a commit touched, new paths for renames.
+func commitPaths(c gitCommit) []string {
+	var paths []string
+	for _, f := range c.files {
+		_, p, _ := strings.Cut(f, " ")
+		if _, to, ok := strings.Cut(p, " → "); ok {
+			p = to
+		}
+		paths = append(paths, p)
+	}
+	return paths
+}
+
+// areaCommit is a commit listed under a feature area, with the files that
+// put it there.
+type areaCommit struct {
+	repo  string
+	c     gitCommit
+	files []string
+}
+
+func (s *QuickBasePersonalMCPServer) handleRecentChanges(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	var params struct {
+		Days  int    `json:"days"`
+		Repo  string `json:"repo"`
+		Limit int    `json:"limit"`
+	}
+	argsData, _ := json.Marshal(request.Params.Arguments)
+	if err := json.Unmarshal(argsData, &params); err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Invalid parameters: %v", err)), nil
+	}
+	if params.Days <= 0 {
+		params.Days = 7
+	}
+	if params.Limit <= 0 {
+		params.Limit = 10
+	}
+	repos, err := gitRepos(params.Repo)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	features, err := loadFeatureMap()
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to load feature map: %v", err)), nil
+	}
+	names := featureNames(features)
+	matchers := map[string]map[string]func(string) bool{quickbaseJSPath: {}, quickbaseGoPath: {}}
+	for _, name := range names {
+		matchers[quickbaseJSPath][name] = featureMatcher(features[name].JS)
+		matchers[quickbaseGoPath][name] = featureMatcher(features[name].Go)
+	}
+
+	// Parity is only worth flagging when both SDKs were looked at
+	sdks := 0
+	for _, repo := range repos {
+		if repo.path == quickbaseJSPath || repo.path == quickbaseGoPath {
+			sdks++
+		}
+	}
+
+	const specArea, otherArea = "API spec", "Other"
+	areas := map[string][]areaCommit{}
+	sides := map[string]map[string]bool{} // feature → repos that touched it
+	var summary strings.Builder
+	summary.WriteString("| Repo | Commits | Authors | Files touched |\n|---|---|---|---|\n")
+	total := 0
+	for _, repo := range repos {
+		commits, err := gitLog(repo.path, true, "--no-merges", fmt.Sprintf("--since=%d days ago", params.Days))
+		if err != nil {
+			summary.WriteString(fmt.Sprintf("| %s | ⚠️ %v | | |\n", repo.name, err))
+			continue
+		}
+		total += len(commits)
+		authors, touched := map[string]bool{}, map[string]bool{}
+		for _, c := range commits {
+			authors[c.author] = true
+			byArea := map[string][]string{}
+			for _, p := range commitPaths(c) {
+				touched[p] = true
+				area := otherArea
+				switch repo.path {
+				case quickbaseSpecPath:
+					area = specArea
+				case quickbaseJSPath, quickbaseGoPath:
+					for _, name := range names {
+						if matchers[repo.path][name](p) {
+							area = name
+							byArea[name] = append(byArea[name], p)
+						}
+					}
+					if area != otherArea {
+						continue
+					}
+				}
+				byArea[area] = append(byArea[area], p)
+			}
+			for area, files := range byArea {
+				areas[area] = append(areas[area], areaCommit{repo.name, c, files})
+				if sides[area] == nil {
+					sides[area] = map[string]bool{}
+				}
+				sides[area][repo.name] = true
+			}
+		}
+		summary.WriteString(fmt.Sprintf("| %s | %d | %s | %d |\n", repo.name, len(commits), strings.Join(sortedKeys(authors), ", "), len(touched)))
+	}
+
+	var results strings.Builder
+	results.WriteString(fmt.Sprintf("# Recent changes (last %d days)\n\n", params.Days))
+	results.WriteString(summary.String())
+	results.WriteString("\n")
+	if total == 0 {
+		results.WriteString(fmt.Sprintf("No commits in the last %d days\n", params.Days))
+		return mcp.NewToolResultText(results.String()), nil
+	}
+
+	// Feature areas first, busiest first, then the spec and everything else
+	var order []string
+	for _, name := range names {
+		if len(areas[name]) > 0 {
+			order = append(order, name)
+		}
+	}
+	for i := 1; i < len(order); i++ {
+		for j := i; j > 0 && len(areas[order[j]]) > len(areas[order[j-1]]); j-- {
+			order[j], order[j-1] = order[j-1], order[j]
+		}
+	}
+	for _, area := range []string{specArea, otherArea} {
+		if len(areas[area]) > 0 {
+			order = append(order, area)
+		}
+	}
+
+	var oneSided []string
+	for _, area := range order {
+		list := areas[area]
+		counts := map[string]int{}
+		for _, ac := range list {
+			counts[ac.repo]++
+		}
+		var parts []string
+		for _, repo := range sortedKeys(counts) {
+			parts = append(parts, fmt.Sprintf("%s %d", repo, counts[repo]))
+		}
+		results.WriteString(fmt.Sprintf("## %s (%s)\n\n", area, strings.Join(parts, ", ")))
+		_, isFeature := features[area]
+		if isFeature && sdks == 2 && len(sides[area]) == 1 {
+			for repo := range sides[area] {
+				oneSided = append(oneSided, fmt.Sprintf("%s: changed in %s only", area, repo))
+			}
+		}
+		for i, ac := range list {
+			if i == params.Limit {
+				results.WriteString(fmt.Sprintf("- … %d more\n", len(list)-params.Limit))
+				break
+			}
+			files := ac.files
+			if area == otherArea {
+				// Unmapped files are summarized by directory
+				dirs := map[string]bool{}
+				for _, f := range files {
+					dir := path.Dir(f) + "/"
+					if dir == "./" {
+						dir = "(root)"
+					}
+					dirs[dir] = true
+				}
+				files = sortedKeys(dirs)
+			}
+			if len(files) > 4 {
+				files = append(files[:4:4], fmt.Sprintf("+%d", len(files)-4))
+			}
+			results.WriteString(fmt.Sprintf("- %s %s %s %s — %s (%s)\n", ac.repo, ac.c.hash, ac.c.date, ac.c.author, ac.c.subject, strings.Join(files, ", ")))
+		}
+		results.WriteString("\n")
+	}
+
+	if len(oneSided) > 0 {
+		results.WriteString("## Parity watch\n\n")
+		for _, line := range oneSided {
+			results.WriteString("- " + line + "; check whether the other SDK needs the same change\n")
+		}
+		results.WriteString("\n")
+	}
+	results.WriteString("Areas come from the feature map (list_comparable_features); files outside it are under Other, by directory.\n")
+	return mcp.NewToolResultText(results.String()), nil
+}
@@ -0,0 +1,295 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// conventionalPrefix matches a conventional commit type and scope, as in
+// "feat(auth)!: ".
+var conventionalPrefix = regexp.MustCompile(`^(\w+)(\(([^)]*)\))?(!)?:\s*`)
+
+// messageNoise are words too common in commit messages to relate two.
+var messageNoise = map[string]bool{
+	"the": true, "a": true, "an": true, "to": true, "for": true, "in": true, "of": true,
+	"and": true, "on": true, "with": true, "from": true, "sdk": true, "port": true, "ported": true,
+}
+
+// messageTokens are the words of a commit subject, without its
+// conventional commit prefix.
+func messageTokens(subject string) []string {
+	subject = conventionalPrefix.ReplaceAllString(subject, "")
+	subject = strings.Map(func(r rune) rune {
+		if r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' {
+			return r
+		}
+		return ' '
+	}, subject)
+	var tokens []string
+	for _, t := range pathTokens(subject) {
+		if !messageNoise[t] {
+			tokens = append(tokens, t)
+		}
+	}
+	return tokens
+}
+
+// relatedCommit is a commit in one SDK scored as the counterpart of a
+// commit in the other.
+type relatedCommit struct {
+	c          gitCommit
+	score      float64
+	shared     []string // features both touched
+	similarity float64  // of the messages
+	days       int      // apart, or -1 beyond the window
+	reasons    []string
+}
+
+// sdkFeatures lists the features whose paths a commit touched.
+func sdkFeatures(c gitCommit, names []string, matchers map[string]func(string) bool) []string {
+	var touched []string
+	for _, name := range names {
+		for _, p := range commitPaths(c) {
+			if matchers[name](p) {
+				touched = append(touched, name)
+				break
+			}
+		}
+	}
+	return touched
+}
+
+// relatedScore rates how likely b is a's counterpart: shared features
+// weigh 0.5, message similarity 0.3 and closeness in time 0.2. Commits
+// further than window days apart score 0 for time.
+func relatedScore(a gitCommit, aFeatures []string, b gitCommit, bFeatures []string, window int) relatedCommit {
+	r := relatedCommit{c: b, days: -1}
+	for _, f := range aFeatures {
+		if containsString(bFeatures, f) {
+			r.shared = append(r.shared, f)
+		}
+	}
+	if len(r.shared) > 0 {
+		r.score += 0.5
+		r.reasons = append(r.reasons, "both touch "+strings.Join(r.shared, ", "))
+	}
+	r.similarity = jaccard(messageTokens(a.subject), messageTokens(b.subject))
+	r.score += 0.3 * r.similarity
+	if r.similarity >= 0.2 {
+		r.reasons = append(r.reasons, fmt.Sprintf("messages %.0f%% alike", r.similarity*100))
+	}
+	da, errA := time.Parse("2006-01-02", a.date)
+	db, errB := time.Parse("2006-01-02", b.date)
+	if errA == nil && errB == nil {
+		days := int(db.Sub(da).Hours() / 24)
+		if days < 0 {
+			days = -days
+		}
+		if days <= window {
+			r.days = days
+			r.score += 0.2 * (1 - float64(days)/float64(window+1))
+			if days == 0 {
+				r.reasons = append(r.reasons, "same day")
+			} else {
+				r.reasons = append(r.reasons, fmt.Sprintf("%d day(s) apart", days))
+			}
+		}
+	}
+	return r
+}
+
+func (s *QuickBasePersonalMCPServer) handleFindRelatedCommits(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	var params struct {
+		Commit     string `json:"commit"`
+		Repo       string `json:"repo"`
+		Feature    string `json:"feature"`
+		Since      string `json:"since"`
+		WindowDays int    `json:"window_days"`
+		Limit      int    `json:"limit"`
+	}
+	argsData, _ := json.Marshal(request.Params.Arguments)
+	if err := json.Unmarshal(argsData, &params); err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Invalid parameters: %v", err)), nil
+	}
+	if (params.Commit == "") == (params.Feature == "") {
+		return mcp.NewToolResultError("give either commit or feature"), nil
+	}
+	if params.WindowDays <= 0 {
+		params.WindowDays = 14
+	}
+	if params.Limit <= 0 {
+		params.Limit = 5
+	}
+	features, err := loadFeatureMap()
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to load feature map: %v", err)), nil
+	}
+	names := featureNames(features)
+	sdks := []gitRepo{{"quickbase-js", quickbaseJSPath}, {"quickbase-go", quickbaseGoPath}}
+	matchers := map[string]map[string]func(string) bool{quickbaseJSPath: {}, quickbaseGoPath: {}}
+	for _, name := range names {
+		matchers[quickbaseJSPath][name] = featureMatcher(features[name].JS)
+		matchers[quickbaseGoPath][name] = featureMatcher(features[name].Go)
+	}
+
+	var results strings.Builder
+	if params.Commit != "" {
+		// Find which SDK has the commit
+		var source gitRepo
+		switch params.Repo {
+		case "js":
+			source = sdks[0]
+		case "go":
+			source = sdks[1]
+		case "", "all":
+			for _, sdk := range sdks {
+				if verifyRef(sdk.path, params.Commit) == nil {
+					if source.path != "" {
+						return mcp.NewToolResultError(fmt.Sprintf("%s is a commit in both SDKs; give repo", params.Commit)), nil
+					}
+					source = sdk
+				}
+			}
+			if source.path == "" {
+				return mcp.NewToolResultError(fmt.Sprintf("%s is not a commit in either SDK", params.Commit)), nil
+			}
+		default:
+			return mcp.NewToolResultError(fmt.Sprintf("Unknown repo: %s (related commits are found between the SDKs: use js or go)", params.Repo)), nil
+		}
+		if err := verifyRef(source.path, params.Commit); err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("%s: %v", source.name, err)), nil
+		}
+		target := sdks[0]
+		if source == sdks[0] {
+			target = sdks[1]
+		}
+		commits, err := gitLog(source.path, true, "-1", params.Commit)
+		if err != nil || len(commits) == 0 {
+			return mcp.NewToolResultError(fmt.Sprintf("Failed to read %s: %v", params.Commit, err)), nil
+		}
+		c := commits[0]
+		cFeatures := sdkFeatures(c, names, matchers[source.path])
+		when, err := time.Parse("2006-01-02", c.date)
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("Failed to read the date of %s: %v", c.hash, err)), nil
+		}
+		candidates, err := gitLog(target.path, true, "--no-merges",
+			"--since="+when.AddDate(0, 0, -params.WindowDays).Format("2006-01-02"),
+			"--until="+when.AddDate(0, 0, params.WindowDays+1).Format("2006-01-02"))
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("Failed to read %s: %v", target.name, err)), nil
+		}
+		var ranked []relatedCommit
+		for _, candidate := range candidates {
+			r := relatedScore(c, cFeatures, candidate, sdkFeatures(candidate, names, matchers[target.path]), params.WindowDays)
+			// Time alone relates everything in the window
+			if len(r.shared) > 0 || r.similarity >= 0.2 {
+				ranked = append(ranked, r)
+			}
+		}
+		sort.SliceStable(ranked, func(i, j int) bool { return ranked[i].score > ranked[j].score })
+
+		results.WriteString(fmt.Sprintf("# Related commits: %s %s\n\n", source.name, c.hash))
+		results.WriteString(fmt.Sprintf("- %s %s — %s\n", c.date, c.author, c.subject))
+		if len(cFeatures) > 0 {
+			results.WriteString(fmt.Sprintf("- Features: %s\n", strings.Join(cFeatures, ", ")))
+		} else {
+			results.WriteString("- Touches no mapped feature, so only the message and timing relate it\n")
+		}
+		results.WriteString(fmt.Sprintf("- Searched %s commits within %d days (%d)\n\n", target.name, params.WindowDays, len(candidates)))
+		if len(ranked) == 0 {
+			results.WriteString(fmt.Sprintf("⚠️ No %s commit shares a feature or message with it; it may not be ported yet.\n", target.name))
+			return mcp.NewToolResultText(results.String()), nil
+		}
+		results.WriteString(fmt.Sprintf("## Likely counterparts in %s\n\n| Commit | Date | Subject | Score | Why |\n|---|---|---|---|---|\n", target.name))
+		for i, r := range ranked {
+			if i == params.Limit {
+				break
+			}
+			results.WriteString(fmt.Sprintf("| %s | %s | %s | %.2f | %s |\n", r.c.hash, r.c.date, docCell(r.c.subject), r.score, strings.Join(r.reasons, "; ")))
+		}
+		results.WriteString("\n")
+		if ranked[0].score >= 0.6 {
+			results.WriteString(fmt.Sprintf("✅ Most likely ported in %s %s\n", ranked[0].c.hash, ranked[0].c.subject))
+		} else {
+			results.WriteString("⚠️ No strong match; check whether the change was ported\n")
+		}
+		return mcp.NewToolResultText(results.String()), nil
+	}
+
+	// Feature mode: pair each SDK's commits to the feature with the other's
+	entry, ok := features[params.Feature]
+	if !ok {
+		return mcp.NewToolResultError(fmt.Sprintf("Unknown feature: %s (see list_comparable_features)", params.Feature)), nil
+	}
+	if params.Since == "" {
+		params.Since = "90 days ago"
+	}
+	sides := map[string][]gitCommit{}
+	for _, sdk := range sdks {
+		patterns := entry.JS
+		if sdk.path == quickbaseGoPath {
+			patterns = entry.Go
+		}
+		match := featureMatcher(patterns)
+		commits, err := gitLog(sdk.path, true, "--no-merges", "--since="+params.Since)
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("Failed to read %s: %v", sdk.name, err)), nil
+		}
+		for _, c := range commits {
+			for _, p := range commitPaths(c) {
+				if match(p) {
+					sides[sdk.name] = append(sides[sdk.name], c)
+					break
+				}
+			}
+		}
+	}
+
+	results.WriteString(fmt.Sprintf("# Related commits: %s (since %s)\n\n", params.Feature, params.Since))
+	results.WriteString(fmt.Sprintf("quickbase-js: %d commit(s), quickbase-go: %d commit(s). Each is paired with the other SDK's closest commit to the feature within %d days.\n\n", len(sides["quickbase-js"]), len(sides["quickbase-go"]), params.WindowDays))
+	feature := []string{params.Feature}
+	for i, sdk := range sdks {
+		other := sdks[1-i]
+		results.WriteString(fmt.Sprintf("## %s → %s\n\n", sdk.name, other.name))
+		if len(sides[sdk.name]) == 0 {
+			results.WriteString("No commits to this feature\n\n")
+			continue
+		}
+		results.WriteString(fmt.Sprintf("| Commit | Subject | Counterpart in %s | Score | Why |\n|---|---|---|---|---|\n", other.name))
+		unmatched := 0
+		for n, c := range sides[sdk.name] {
+			if n == params.Limit*4 {
+				results.WriteString(fmt.Sprintf("| … %d more | | | | |\n", len(sides[sdk.name])-n))
+				break
+			}
+			var best relatedCommit
+			for _, o := range sides[other.name] {
+				r := relatedScore(c, feature, o, feature, params.WindowDays)
+				// Same feature but far apart in time isn't a port
+				if r.days >= 0 && r.score > best.score {
+					best = r
+				}
+			}
+			counterpart, why := "⚠️ none", fmt.Sprintf("no %s commit to %s within %d days", other.name, params.Feature, params.WindowDays)
+			if best.score > 0 {
+				counterpart, why = fmt.Sprintf("%s %s", best.c.hash, docCell(best.c.subject)), strings.Join(best.reasons[1:], "; ")
+			} else {
+				unmatched++
+			}
+			results.WriteString(fmt.Sprintf("| %s %s | %s | %s | %.2f | %s |\n", c.hash, c.date, docCell(c.subject), counterpart, best.score, why))
+		}
+		results.WriteString("\n")
+		if unmatched > 0 {
+			results.WriteString(fmt.Sprintf("%d commit(s) have no counterpart; look at them with find_related_commits commit, or check_parity %s.\n\n", unmatched, params.Feature))
+		}
+	}
+	return mcp.NewToolResultText(strings.TrimRight(results.String(), "\n") + "\n"), nil
+}
@@ -0,0 +1,143 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// release is one tag of a repo.
+type release struct {
+	tag, date, commit, subject string
+	annotated                  bool
+}
+
+// listTags reads a repo's tags, newest first. Lightweight tags have no
+// message of their own, so the tagged commit's subject stands in.
+func listTags(repoPath string) ([]release, error) {
+	out, err := runGit(repoPath, "for-each-ref", "refs/tags", "--sort=-creatordate",
+		"--format=%(refname:short)%1f%(creatordate:short)%1f%(objecttype)%1f%(if)%(*objectname)%(then)%(*objectname:short)%(else)%(objectname:short)%(end)%1f%(contents:subject)")
+	if err != nil || out == "" {
+		return nil, err
+	}
+	var tags []release
+	for _, line := range strings.Split(out, "\n") {
+		fields := strings.Split(line, "\x1f")
+		if len(fields) != 5 {
+			continue
+		}
+		// Annotated tags are peeled to the commit they tag
+		tags = append(tags, release{tag: fields[0], date: fields[1], annotated: fields[2] == "tag", commit: fields[3], subject: fields[4]})
+	}
+	return tags, nil
+}
+
+func (s *QuickBasePersonalMCPServer) handleListReleases(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	var params struct {
+		Repo      string `json:"repo"`
+		Operation string `json:"operation"`
+		Limit     int    `json:"limit"`
+	}
+	argsData, _ := json.Marshal(request.Params.Arguments)
+	if err := json.Unmarshal(argsData, &params); err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Invalid parameters: %v", err)), nil
+	}
+	if params.Limit <= 0 {
+		params.Limit = 10
+	}
+	if params.Repo == "" {
+		params.Repo = "all"
+	}
+	repos, err := gitRepos(params.Repo)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	if params.Repo == "all" {
+		// Releases are the SDKs'; the spec's tags show up as their pins
+		repos = repos[:2]
+	}
+
+	var results strings.Builder
+	results.WriteString("# Releases\n\n")
+	if params.Operation != "" {
+		results.WriteString(fmt.Sprintf("Operation: %s, checked in the spec each release pins\n\n", params.Operation))
+	}
+	for _, repo := range repos {
+		results.WriteString(fmt.Sprintf("## %s\n\n", repo.name))
+		tags, err := listTags(repo.path)
+		if err != nil {
+			results.WriteString(fmt.Sprintf("⚠️ %v\n\n", err))
+			continue
+		}
+		if len(tags) == 0 {
+			results.WriteString("No tags\n\n")
+			continue
+		}
+		sub, hasSpec := specSubmodule(repo.path)
+		if !hasSpec && params.Operation != "" {
+			results.WriteString(fmt.Sprintf("No spec submodule, so releases can't be checked for %s.\n\n", params.Operation))
+		}
+		header := "| Tag | Date | Commit |"
+		if hasSpec {
+			header += " Spec pin |"
+			if params.Operation != "" {
+				header += " " + params.Operation + " |"
+			}
+		}
+		header += " Message |\n"
+		results.WriteString(header + strings.Repeat("|---", strings.Count(header, "|")-1) + "|\n")
+		for i, r := range tags {
+			if i == params.Limit {
+				break
+			}
+			row := fmt.Sprintf("| %s | %s | %s |", r.tag, r.date, r.commit)
+			if hasSpec {
+				pin, err := runGit(repo.path, "rev-parse", r.tag+":"+sub.path)
+				switch {
+				case err != nil:
+					row += " no submodule |"
+					if params.Operation != "" {
+						row += " ? |"
+					}
+				case verifyRef(quickbaseSpecPath, pin) != nil:
+					row += fmt.Sprintf(" %s (not in local spec) |", shortSHA(pin))
+					if params.Operation != "" {
+						row += " ? |"
+					}
+				default:
+					label := shortSHA(pin)
+					if tag, err := runGit(quickbaseSpecPath, "describe", "--tags", pin); err == nil {
+						label += " (" + tag + ")"
+					}
+					row += " " + label + " |"
+					if params.Operation != "" {
+						cell := "?"
+						if root, err := loadSpecAt(pin); err == nil {
+							cell = "no"
+							if op, ok := findOperation(specOperations(root), params.Operation); ok {
+								cell = "yes"
+								if op.Deprecated {
+									cell = "deprecated"
+								}
+							}
+						}
+						row += " " + cell + " |"
+					}
+				}
+			}
+			message := r.subject
+			if !r.annotated {
+				message = "(lightweight) " + message
+			}
+			results.WriteString(fmt.Sprintf("%s %s |\n", row, docCell(message)))
+		}
+		if len(tags) > params.Limit {
+			results.WriteString(fmt.Sprintf("\n… %d older tag(s); raise limit to see them.\n", len(tags)-params.Limit))
+		}
+		results.WriteString("\n")
+	}
+	return mcp.NewToolResultText(strings.TrimRight(results.String(), "\n") + "\n"), nil
+}
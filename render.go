@@ -0,0 +1,109 @@
+package main
+
+import (
+	"context"
+	"embed"
+	"encoding/json"
+	"fmt"
+	"path"
+	"strings"
+	"text/template"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+//go:embed templates/examples/*.tmpl
+var exampleTemplates embed.FS
+
+// exampleLanguages maps template file extensions to languages.
+var exampleLanguages = []struct {
+	lang, ext, label, fence string
+}{
+	{"js", ".ts", "JavaScript", "typescript"},
+	{"go", ".go", "Go", "go"},
+}
+
+// exampleTemplateNames lists the embedded templates by name, without the
+// language extension.
+func exampleTemplateNames() []string {
+	entries, _ := exampleTemplates.ReadDir("templates/examples")
+	names := map[string]bool{}
+	for _, e := range entries {
+		name := strings.TrimSuffix(e.Name(), ".tmpl")
+		names[strings.TrimSuffix(name, path.Ext(name))] = true
+	}
+	return sortedKeys(names)
+}
+
+// renderExample fills in one embedded template with cfg.
+func renderExample(name, ext string, cfg serverConfig) (string, bool, error) {
+	data, err := exampleTemplates.ReadFile("templates/examples/" + name + ext + ".tmpl")
+	if err != nil {
+		return "", false, nil
+	}
+	tmpl, err := template.New(name).Option("missingkey=error").Parse(string(data))
+	if err != nil {
+		return "", true, err
+	}
+	var out strings.Builder
+	if err := tmpl.Execute(&out, cfg); err != nil {
+		return "", true, err
+	}
+	return out.String(), true, nil
+}
+
+func (s *QuickBasePersonalMCPServer) handleRenderExample(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	var params struct {
+		Name     string `json:"name"`
+		Language string `json:"language"`
+		Realm    string `json:"realm"`
+		AppID    string `json:"app_id"`
+		TableID  string `json:"table_id"`
+	}
+	argsData, _ := json.Marshal(request.Params.Arguments)
+	if err := json.Unmarshal(argsData, &params); err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Invalid parameters: %v", err)), nil
+	}
+	if params.Language == "" {
+		params.Language = "both"
+	}
+
+	names := exampleTemplateNames()
+	if !containsString(names, params.Name) {
+		return mcp.NewToolResultError(fmt.Sprintf("Unknown example: %q (available: %s)", params.Name, strings.Join(names, ", "))), nil
+	}
+
+	cfg, err := loadConfig()
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to load config: %v", err)), nil
+	}
+	if params.Realm != "" {
+		cfg.Realm = strings.TrimSuffix(params.Realm, ".quickbase.com")
+	}
+	if params.AppID != "" {
+		cfg.AppID = params.AppID
+	}
+	if params.TableID != "" {
+		cfg.TableID = params.TableID
+	}
+
+	var results strings.Builder
+	results.WriteString(fmt.Sprintf("# %s example\n\n", params.Name))
+	results.WriteString(fmt.Sprintf("Realm %s, app %s, table %s; tokens read from $%s and $%s (settings from %s).\n\n", cfg.Hostname(), cfg.AppID, cfg.TableID, cfg.UserTokenEnv, cfg.AppTokenEnv, cfg.source))
+	for _, l := range exampleLanguages {
+		if params.Language != "both" && params.Language != l.lang {
+			continue
+		}
+		code, found, err := renderExample(params.Name, l.ext, cfg)
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("Failed to render %s%s: %v", params.Name, l.ext, err)), nil
+		}
+		if !found {
+			results.WriteString(fmt.Sprintf("## %s\n\nNo %s template for this example\n\n", l.label, l.label))
+			continue
+		}
+		results.WriteString(fmt.Sprintf("## %s\n\n```%s\n%s```\n\n", l.label, l.fence, code))
+	}
+
+	return mcp.NewToolResultText(results.String()), nil
+}
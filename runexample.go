@@ -0,0 +1,241 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// envFileName holds sandbox credentials for run_example, in configDir.
+const envFileName = ".env"
+
+// defaultExampleTimeout allows for a first-run go mod download or npx
+// install of tsx.
+const defaultExampleTimeout = 120 * time.Second
+
+// loadEnvFile parses KEY=VALUE lines, skipping blanks and # comments and
+// accepting an "export " prefix and quoted values.
+func loadEnvFile(path string) (map[string]string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	env := map[string]string{}
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		line = strings.TrimPrefix(line, "export ")
+		key, value, ok := strings.Cut(line, "=")
+		if !ok {
+			continue
+		}
+		value = strings.TrimSpace(value)
+		if len(value) >= 2 && (value[0] == '"' || value[0] == '\'') && value[len(value)-1] == value[0] {
+			value = value[1 : len(value)-1]
+		}
+		env[strings.TrimSpace(key)] = value
+	}
+	return env, scanner.Err()
+}
+
+// tokenPatterns catch credentials that were not in the .env file, such as
+// a temp token fetched during the run.
+var tokenPatterns = []*regexp.Regexp{
+	regexp.MustCompile(`(?i)(QB-(?:USER|TEMP)-TOKEN\s+)[^\s"',]+`),
+	regexp.MustCompile(`(?i)(Authorization["']?\s*[:=]\s*["']?)[^"'\n]+`),
+	regexp.MustCompile(`(?i)((?:user|temp|app)[_-]?token["']?\s*[:=]\s*["']?)[^\s"',]+`),
+	regexp.MustCompile(`()\bb[a-z0-9]{5}_[a-z0-9]{3,}_[a-z0-9_]+\b`),
+}
+
+// redactOutput replaces every .env value, then anything token-shaped.
+// Longer values go first so one value containing another is fully hidden.
+func redactOutput(output string, env map[string]string) string {
+	keys := make([]string, 0, len(env))
+	for k, v := range env {
+		// Short values such as flags or IDs would redact ordinary words
+		if len(v) >= 6 {
+			keys = append(keys, k)
+		}
+	}
+	sort.Slice(keys, func(i, j int) bool { return len(env[keys[i]]) > len(env[keys[j]]) })
+	for _, k := range keys {
+		output = strings.ReplaceAll(output, env[k], "[REDACTED:"+k+"]")
+	}
+	for _, p := range tokenPatterns {
+		output = p.ReplaceAllString(output, "${1}[REDACTED]")
+	}
+	return output
+}
+
+// exampleRun is a prepared example: where to run it and how.
+type exampleRun struct {
+	dir, command, label string
+	cleanup             func()
+}
+
+// prepareTemplateRun writes a rendered template into a scratch project
+// wired to the local SDK checkout, so it runs against the working tree
+// rather than a published release.
+func prepareTemplateRun(lang, code string) (exampleRun, error) {
+	switch lang {
+	case "go":
+		dir, err := os.MkdirTemp("", "qb-run-example-")
+		if err != nil {
+			return exampleRun{}, err
+		}
+		gomod := fmt.Sprintf("module runexample\n\ngo 1.21\n\nrequire github.com/DrewBradfordXYZ/quickbase-go v0.0.0\n\nreplace github.com/DrewBradfordXYZ/quickbase-go => %s\n", quickbaseGoPath)
+		if err := os.WriteFile(filepath.Join(dir, "go.mod"), []byte(gomod), 0644); err != nil {
+			os.RemoveAll(dir)
+			return exampleRun{}, err
+		}
+		if err := os.WriteFile(filepath.Join(dir, "main.go"), []byte(code), 0644); err != nil {
+			os.RemoveAll(dir)
+			return exampleRun{}, err
+		}
+		return exampleRun{dir: dir, command: "go mod tidy >/dev/null && go run .", cleanup: func() { os.RemoveAll(dir) }}, nil
+	default:
+		// Inside the SDK checkout so its node_modules resolve
+		dir, err := os.MkdirTemp(quickbaseJSPath, ".run-example-")
+		if err != nil {
+			return exampleRun{}, err
+		}
+		code = strings.NewReplacer(`from 'quickbase-js'`, `from '../src'`, `from "quickbase-js"`, `from "../src"`).Replace(code)
+		if err := os.WriteFile(filepath.Join(dir, "example.ts"), []byte(code), 0644); err != nil {
+			os.RemoveAll(dir)
+			return exampleRun{}, err
+		}
+		return exampleRun{dir: dir, command: "npx --yes tsx example.ts", cleanup: func() { os.RemoveAll(dir) }}, nil
+	}
+}
+
+// prepareFileRun runs an example that already exists in an SDK's examples
+// directory, in place.
+func prepareFileRun(lang, rel string) (exampleRun, error) {
+	root := quickbaseJSPath
+	if lang == "go" {
+		root = quickbaseGoPath
+	}
+	rel = filepath.ToSlash(filepath.Clean(rel))
+	if !containsString(exampleFiles(root), rel) {
+		return exampleRun{}, fmt.Errorf("%s is not an example file in %s", rel, root)
+	}
+	run := exampleRun{dir: root, cleanup: func() {}}
+	if lang == "go" {
+		run.command = "go run ./" + filepath.ToSlash(filepath.Dir(rel))
+	} else {
+		run.command = "npx --yes tsx " + rel
+	}
+	return run, nil
+}
+
+func (s *QuickBasePersonalMCPServer) handleRunExample(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	var params struct {
+		Name           string `json:"name"`
+		Path           string `json:"path"`
+		Language       string `json:"language"`
+		TimeoutSeconds int    `json:"timeout_seconds"`
+	}
+	argsData, _ := json.Marshal(request.Params.Arguments)
+	if err := json.Unmarshal(argsData, &params); err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Invalid parameters: %v", err)), nil
+	}
+	if params.Language != "js" && params.Language != "go" {
+		return mcp.NewToolResultError("language must be js or go"), nil
+	}
+	if (params.Name == "") == (params.Path == "") {
+		return mcp.NewToolResultError("Provide exactly one of name (an embedded template) or path (an SDK example file)"), nil
+	}
+	timeout := defaultExampleTimeout
+	if params.TimeoutSeconds > 0 {
+		timeout = time.Duration(params.TimeoutSeconds) * time.Second
+	}
+
+	cfg, err := loadConfig()
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to load config: %v", err)), nil
+	}
+	cfg, ok := cfg.sandbox()
+	if !ok {
+		return mcp.NewToolResultError(fmt.Sprintf("No sandbox_realm in %s; run_example only runs against a sandbox realm", filepath.Join(configDir, configFileNames[0]))), nil
+	}
+	envPath := filepath.Join(configDir, envFileName)
+	env, err := loadEnvFile(envPath)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to read credentials from %s: %v", envPath, err)), nil
+	}
+	if env[cfg.UserTokenEnv] == "" && env[cfg.AppTokenEnv] == "" {
+		return mcp.NewToolResultError(fmt.Sprintf("%s sets neither %s nor %s", envPath, cfg.UserTokenEnv, cfg.AppTokenEnv)), nil
+	}
+
+	var run exampleRun
+	if params.Name != "" {
+		ext := ".ts"
+		if params.Language == "go" {
+			ext = ".go"
+		}
+		code, found, err := renderExample(params.Name, ext, cfg)
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("Failed to render %s%s: %v", params.Name, ext, err)), nil
+		}
+		if !found {
+			return mcp.NewToolResultError(fmt.Sprintf("No %s template for %q (available: %s)", params.Language, params.Name, strings.Join(exampleTemplateNames(), ", "))), nil
+		}
+		run, err = prepareTemplateRun(params.Language, code)
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("Failed to prepare example: %v", err)), nil
+		}
+		run.label = "template " + params.Name + ext
+	} else {
+		run, err = prepareFileRun(params.Language, params.Path)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		run.label = params.Path
+	}
+	defer run.cleanup()
+
+	// The sandbox settings are exported too, for examples that read them
+	// from the environment rather than having them templated in
+	vars := []string{"QB_REALM=" + cfg.Hostname(), "QB_APP_ID=" + cfg.AppID, "QB_TABLE_ID=" + cfg.TableID}
+	for _, k := range sortedKeys(env) {
+		vars = append(vars, k+"="+env[k])
+	}
+
+	result, err := runShellEnv(ctx, run.dir, timeout, vars, run.command)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to run example: %v", err)), nil
+	}
+
+	var results strings.Builder
+	results.WriteString(fmt.Sprintf("# run_example: %s (%s)\n\n", run.label, params.Language))
+	results.WriteString(fmt.Sprintf("Sandbox realm %s, app %s, table %s; credentials from %s (%d variables, values redacted).\n\n", cfg.Hostname(), cfg.AppID, cfg.TableID, envPath, len(env)))
+	switch {
+	case result.timedOut:
+		results.WriteString(fmt.Sprintf("**Timed out** after %s\n\n", timeout))
+	case result.exitCode == 0:
+		results.WriteString(fmt.Sprintf("**Passed** in %s\n\n", result.duration.Round(time.Millisecond)))
+	default:
+		results.WriteString(fmt.Sprintf("**Failed** with exit code %d in %s\n\n", result.exitCode, result.duration.Round(time.Millisecond)))
+	}
+	output := strings.TrimRight(redactOutput(result.output, env), "\n")
+	if output == "" {
+		output = "(no output)"
+	}
+	results.WriteString(fmt.Sprintf("```\n%s\n```\n", output))
+
+	return mcp.NewToolResultText(results.String()), nil
+}
@@ -0,0 +1,194 @@
+package main
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// searchHit is one line of ripgrep output.
+type searchHit struct {
+	path string
+	line string
+	text string
+}
+
+// generatedMarkers identify generated files when found near the top of a file.
+var generatedMarkers = [][]byte{
+	[]byte("Code generated"),
+	[]byte("DO NOT EDIT"),
+	[]byte("@generated"),
+	[]byte("auto-generated"),
+}
+
+// isGenerated reports whether a file's header marks it as generated.
+func isGenerated(data []byte) bool {
+	head := data
+	if len(head) > 1024 {
+		head = head[:1024]
+	}
+	for _, marker := range generatedMarkers {
+		if bytes.Contains(head, marker) {
+			return true
+		}
+	}
+	return false
+}
+
+// searchFileInfo caches per-file facts needed for ranking and dedup.
+type searchFileInfo struct {
+	hash      string
+	generated bool
+}
+
+// rankSearchResults parses ripgrep --no-heading output, orders it so
+// hand-written source comes before tests, generated code and build output,
+// and collapses hits from files with identical content (symlinks, vendored
+// or copied sources).
+func rankSearchResults(output string) string {
+	var hits []searchHit
+	for _, raw := range strings.Split(strings.TrimRight(output, "\n"), "\n") {
+		parts := strings.SplitN(raw, ":", 3)
+		if len(parts) < 3 {
+			continue
+		}
+		hits = append(hits, searchHit{path: parts[0], line: parts[1], text: parts[2]})
+	}
+
+	infos := map[string]searchFileInfo{}
+	fileInfo := func(path string) searchFileInfo {
+		if info, ok := infos[path]; ok {
+			return info
+		}
+		var info searchFileInfo
+		if data, err := os.ReadFile(path); err == nil {
+			sum := sha256.Sum256(data)
+			info.hash = fmt.Sprintf("%x", sum[:8])
+			info.generated = isGenerated(data)
+		}
+		infos[path] = info
+		return info
+	}
+
+	sort.SliceStable(hits, func(i, j int) bool {
+		return searchPathRank(hits[i].path, fileInfo(hits[i].path).generated) < searchPathRank(hits[j].path, fileInfo(hits[j].path).generated)
+	})
+
+	// Collapse files with identical content onto the best-ranked path
+	canonical := map[string]string{}
+	duplicates := map[string][]string{}
+	var results strings.Builder
+	for _, hit := range hits {
+		if hash := fileInfo(hit.path).hash; hash != "" {
+			if first, ok := canonical[hash]; !ok {
+				canonical[hash] = hit.path
+			} else if first != hit.path {
+				if dups := duplicates[first]; len(dups) == 0 || dups[len(dups)-1] != hit.path {
+					duplicates[first] = append(dups, hit.path)
+				}
+				continue
+			}
+		}
+		results.WriteString(fmt.Sprintf("%s:%s:%s\n", hit.path, hit.line, hit.text))
+	}
+
+	if len(duplicates) > 0 {
+		results.WriteString("\nCollapsed identical copies:\n")
+		for _, hash := range sortedKeys(canonical) {
+			first := canonical[hash]
+			if dups := duplicates[first]; len(dups) > 0 {
+				results.WriteString(fmt.Sprintf("- %s (same as %s)\n", strings.Join(dups, ", "), first))
+			}
+		}
+	}
+	return results.String()
+}
+
+// searchPathRank scores a path for result ordering; lower ranks first.
+func searchPathRank(path string, generated bool) int {
+	p := "/" + filepath.ToSlash(strings.ToLower(path))
+	rank := 0
+	for _, dir := range []string{"/dist/", "/build/", "/out/", "/lib/esm/", "/lib/cjs/", "/.next/"} {
+		if strings.Contains(p, dir) {
+			rank += 8
+			break
+		}
+	}
+	if strings.HasSuffix(p, ".min.js") || strings.HasSuffix(p, ".map") || strings.HasSuffix(p, ".d.ts") {
+		rank += 4
+	}
+	if generated || strings.Contains(p, "generated") || strings.Contains(p, "/gen/") || strings.HasSuffix(p, ".gen.go") || strings.HasSuffix(p, "_gen.go") {
+		rank += 4
+	}
+	if isTestPath(p) {
+		rank += 2
+	}
+	if strings.Contains(p, "/src/") {
+		rank--
+	}
+	return rank
+}
+
+// isTestPath reports whether path looks like a test file or test directory.
+func isTestPath(path string) bool {
+	p := "/" + filepath.ToSlash(strings.ToLower(path))
+	return strings.HasSuffix(p, "_test.go") ||
+		strings.Contains(p, ".test.") ||
+		strings.Contains(p, ".spec.") ||
+		strings.Contains(p, "/test/") ||
+		strings.Contains(p, "/tests/") ||
+		strings.Contains(p, "/__tests__/") ||
+		strings.Contains(p, "/testdata/")
+}
+
+// searchSynonyms groups QuickBase domain terms that the SDKs and the spec
+// spell differently. A query term matching any member expands to the group.
+var searchSynonyms = [][]string{
+	{"dbid", "tableId", "table_id"},
+	{"appId", "app_id", "appDbid"},
+	{"fid", "fieldId", "field_id"},
+	{"rid", "recordId", "record_id"},
+	{"upsert", "insert"},
+	{"realm", "hostname", "realmHostname", "QB-Realm-Hostname"},
+	{"usertoken", "userToken", "user_token", "QB-USER-TOKEN"},
+	{"temptoken", "tempToken", "temp_token", "QB-TEMP-TOKEN"},
+}
+
+var searchTermPattern = regexp.MustCompile(`[A-Za-z][A-Za-z0-9_-]*`)
+
+// normalizeTerm folds case and separators so tableId, table_id and TABLEID compare equal.
+func normalizeTerm(term string) string {
+	return strings.NewReplacer("_", "", "-", "").Replace(strings.ToLower(term))
+}
+
+// expandSearchSynonyms rewrites domain terms in a ripgrep pattern into
+// case-insensitive alternations of their synonyms. It returns the expanded
+// pattern and a description of each expansion made.
+func expandSearchSynonyms(query string) (string, []string) {
+	lookup := map[string][]string{}
+	for _, group := range searchSynonyms {
+		for _, term := range group {
+			lookup[normalizeTerm(term)] = group
+		}
+	}
+
+	var expansions []string
+	expanded := searchTermPattern.ReplaceAllStringFunc(query, func(term string) string {
+		group, ok := lookup[normalizeTerm(term)]
+		if !ok {
+			return term
+		}
+		alts := make([]string, 0, len(group))
+		for _, syn := range group {
+			alts = append(alts, regexp.QuoteMeta(syn))
+		}
+		expansions = append(expansions, fmt.Sprintf("%s → %s", term, strings.Join(group, " | ")))
+		return "(?i:" + strings.Join(alts, "|") + ")"
+	})
+	return expanded, expansions
+}
@@ -0,0 +1,210 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+const (
+	searchHistoryBucket = "search_history"
+	savedSearchBucket   = "saved_searches"
+	searchHistoryLimit  = 200
+)
+
+type searchHistoryEntry struct {
+	Query     string    `json:"query"`
+	Repo      string    `json:"repo"`
+	Matches   int       `json:"matches"`
+	Timestamp time.Time `json:"timestamp"`
+}
+
+type savedSearch struct {
+	Name        string    `json:"name"`
+	Query       string    `json:"query"`
+	Repo        string    `json:"repo"`
+	Description string    `json:"description,omitempty"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+func (s *QuickBasePersonalMCPServer) handleSearchHistory(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	var params struct {
+		Limit  int    `json:"limit"`
+		Filter string `json:"filter"`
+	}
+	argsData, _ := json.Marshal(request.Params.Arguments)
+	if err := json.Unmarshal(argsData, &params); err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Invalid parameters: %v", err)), nil
+	}
+	if params.Limit <= 0 {
+		params.Limit = 20
+	}
+
+	var history []searchHistoryEntry
+	err := s.store.each(searchHistoryBucket, func(key string, data []byte) error {
+		var entry searchHistoryEntry
+		if err := json.Unmarshal(data, &entry); err != nil {
+			return nil
+		}
+		if params.Filter != "" && !strings.Contains(strings.ToLower(entry.Query), strings.ToLower(params.Filter)) {
+			return nil
+		}
+		history = append(history, entry)
+		return nil
+	})
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to read search history: %v", err)), nil
+	}
+
+	saved, err := s.savedSearches()
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to read saved searches: %v", err)), nil
+	}
+
+	var results strings.Builder
+	results.WriteString("# Search History\n\n")
+
+	results.WriteString("## Saved Searches\n\n")
+	if len(saved) == 0 {
+		results.WriteString("None yet. Use save_search to store a query.\n\n")
+	} else {
+		for _, ss := range saved {
+			results.WriteString(fmt.Sprintf("- **%s**: `%s` (repo: %s)", ss.Name, ss.Query, ss.Repo))
+			if ss.Description != "" {
+				results.WriteString(" - " + ss.Description)
+			}
+			results.WriteString("\n")
+		}
+		results.WriteString("\n")
+	}
+
+	results.WriteString("## Recent Searches\n\n")
+	if len(history) == 0 {
+		results.WriteString("No searches recorded\n")
+		return mcp.NewToolResultText(results.String()), nil
+	}
+
+	// Newest first, collapsing repeats of the same query/repo
+	seen := map[string]int{}
+	var recent []searchHistoryEntry
+	for i := len(history) - 1; i >= 0; i-- {
+		key := history[i].Repo + "\x00" + history[i].Query
+		seen[key]++
+		if seen[key] > 1 {
+			continue
+		}
+		recent = append(recent, history[i])
+	}
+	if len(recent) > params.Limit {
+		recent = recent[:params.Limit]
+	}
+	for _, entry := range recent {
+		key := entry.Repo + "\x00" + entry.Query
+		results.WriteString(fmt.Sprintf("- %s `%s` (repo: %s, %d matches", entry.Timestamp.Local().Format("2006-01-02 15:04"), entry.Query, entry.Repo, entry.Matches))
+		if seen[key] > 1 {
+			results.WriteString(fmt.Sprintf(", run %d times", seen[key]))
+		}
+		results.WriteString(")\n")
+	}
+
+	return mcp.NewToolResultText(results.String()), nil
+}
+
+func (s *QuickBasePersonalMCPServer) handleSaveSearch(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	var params struct {
+		Name        string `json:"name"`
+		Query       string `json:"query"`
+		Repo        string `json:"repo"`
+		Description string `json:"description"`
+		Delete      bool   `json:"delete"`
+	}
+	argsData, _ := json.Marshal(request.Params.Arguments)
+	if err := json.Unmarshal(argsData, &params); err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Invalid parameters: %v", err)), nil
+	}
+	if params.Name == "" {
+		return mcp.NewToolResultError("name is required"), nil
+	}
+
+	if params.Delete {
+		found, err := s.store.delete(savedSearchBucket, params.Name)
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("Failed to delete saved search: %v", err)), nil
+		}
+		if !found {
+			return mcp.NewToolResultError(fmt.Sprintf("Unknown saved search: %s", params.Name)), nil
+		}
+		return mcp.NewToolResultText(fmt.Sprintf("Deleted saved search: %s", params.Name)), nil
+	}
+
+	if params.Query == "" {
+		return mcp.NewToolResultError("query is required"), nil
+	}
+	if params.Repo == "" {
+		params.Repo = "all"
+	}
+
+	ss := savedSearch{
+		Name:        params.Name,
+		Query:       params.Query,
+		Repo:        params.Repo,
+		Description: params.Description,
+		CreatedAt:   time.Now(),
+	}
+	if err := s.store.put(savedSearchBucket, ss.Name, ss); err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to save search: %v", err)), nil
+	}
+
+	return mcp.NewToolResultText(fmt.Sprintf("Saved search %s: `%s` (repo: %s)", ss.Name, ss.Query, ss.Repo)), nil
+}
+
+func (s *QuickBasePersonalMCPServer) handleRunSavedSearch(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	var params struct {
+		Name string `json:"name"`
+	}
+	argsData, _ := json.Marshal(request.Params.Arguments)
+	if err := json.Unmarshal(argsData, &params); err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Invalid parameters: %v", err)), nil
+	}
+
+	var ss savedSearch
+	found, err := s.store.get(savedSearchBucket, params.Name, &ss)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to read saved search: %v", err)), nil
+	}
+	if !found {
+		saved, _ := s.savedSearches()
+		names := make([]string, 0, len(saved))
+		for _, other := range saved {
+			names = append(names, other.Name)
+		}
+		return mcp.NewToolResultError(fmt.Sprintf("Unknown saved search: %s (saved: %s)", params.Name, strings.Join(names, ", "))), nil
+	}
+
+	output, matches := s.searchCode(ss.Query, ss.Repo, true)
+
+	entry := searchHistoryEntry{Query: ss.Query, Repo: ss.Repo, Matches: matches, Timestamp: time.Now()}
+	if err := s.store.appendCapped(searchHistoryBucket, entry, searchHistoryLimit); err != nil {
+		s.logger.Printf("Failed to record search history: %v", err)
+	}
+
+	return mcp.NewToolResultText(fmt.Sprintf("# Saved search: %s\n\n%s", ss.Name, output)), nil
+}
+
+// savedSearches returns all saved searches in name order.
+func (s *QuickBasePersonalMCPServer) savedSearches() ([]savedSearch, error) {
+	var saved []savedSearch
+	err := s.store.each(savedSearchBucket, func(key string, data []byte) error {
+		var ss savedSearch
+		if err := json.Unmarshal(data, &ss); err != nil {
+			return nil
+		}
+		saved = append(saved, ss)
+		return nil
+	})
+	return saved, err
+}
@@ -0,0 +1,122 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// fenceLangs maps file extensions to markdown code fence languages.
+var fenceLangs = map[string]string{
+	".go": "go", ".ts": "typescript", ".js": "javascript", ".mjs": "javascript",
+	".json": "json", ".yaml": "yaml", ".yml": "yaml", ".md": "markdown", ".sh": "bash",
+}
+
+func (s *QuickBasePersonalMCPServer) handleShowFileAtRef(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	var params struct {
+		Repo      string `json:"repo"`
+		Ref       string `json:"ref"`
+		Path      string `json:"path"`
+		StartLine int    `json:"start_line"`
+		EndLine   int    `json:"end_line"`
+		MaxLines  int    `json:"max_lines"`
+	}
+	argsData, _ := json.Marshal(request.Params.Arguments)
+	if err := json.Unmarshal(argsData, &params); err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Invalid parameters: %v", err)), nil
+	}
+	if params.Ref == "" || params.Path == "" {
+		return mcp.NewToolResultError("ref and path are required"), nil
+	}
+	if params.StartLine < 0 || params.EndLine < 0 || (params.EndLine > 0 && params.EndLine < params.StartLine) {
+		return mcp.NewToolResultError(fmt.Sprintf("Invalid line range: %d-%d", params.StartLine, params.EndLine)), nil
+	}
+	// end_line alone is the file up to that line
+	if params.StartLine == 0 && params.EndLine > 0 {
+		params.StartLine = 1
+	}
+	if params.MaxLines <= 0 {
+		params.MaxLines = 500
+	}
+	repo, rel, err := blameTarget(params.Repo, params.Path, params.Ref)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	// The same checks as reading the working tree, so history can't be used
+	// to name paths outside the repo
+	full, err := resolveRepoPath(repo.path, rel)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	rel, _ = filepath.Rel(repo.path, full)
+	rel = filepath.ToSlash(rel)
+	if err := verifyRef(repo.path, params.Ref); err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("%s: %v", repo.name, err)), nil
+	}
+
+	object := params.Ref + ":" + rel
+	if rel == "." {
+		object = params.Ref + ":"
+	}
+	kind, err := runGit(repo.path, "cat-file", "-t", object)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("%s does not exist in %s at %s", rel, repo.name, params.Ref)), nil
+	}
+	var results strings.Builder
+	results.WriteString(fmt.Sprintf("# %s/%s at %s\n\n", repo.name, rel, params.Ref))
+	if last, err := runGit(repo.path, "log", "-1", "--format=%h", params.Ref, "--", rel); err == nil && last != "" {
+		results.WriteString(fmt.Sprintf("Last changed in %s\n\n", commitLine(repo.path, last)))
+	}
+
+	if kind == "tree" {
+		out, err := runGit(repo.path, "ls-tree", "--name-only", object)
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("Failed to list %s: %v", rel, err)), nil
+		}
+		entries := strings.Split(out, "\n")
+		results.WriteString(fmt.Sprintf("Directory with %d entries:\n\n", len(entries)))
+		for _, e := range entries {
+			results.WriteString("- " + e + "\n")
+		}
+		return mcp.NewToolResultText(results.String()), nil
+	}
+
+	data, err := readRepoFile(repo.path, params.Ref, rel)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to read %s: %v", rel, err)), nil
+	}
+	if strings.ContainsRune(string(data), 0) {
+		results.WriteString(fmt.Sprintf("Binary file, %d bytes\n", len(data)))
+		return mcp.NewToolResultText(results.String()), nil
+	}
+	lines := strings.Split(strings.TrimSuffix(string(data), "\n"), "\n")
+	first, last := 1, len(lines)
+	if params.StartLine > 0 {
+		first = params.StartLine
+		last = params.EndLine
+		if last == 0 || last > len(lines) {
+			last = len(lines)
+		}
+		if first > len(lines) {
+			return mcp.NewToolResultError(fmt.Sprintf("%s has %d lines at %s", rel, len(lines), params.Ref)), nil
+		}
+	}
+	text, cut := truncateLines(strings.Join(lines[first-1:last], "\n"), params.MaxLines)
+	if first > 1 || last < len(lines) || cut > 0 {
+		results.WriteString(fmt.Sprintf("Lines %d-%d of %d\n\n", first, last-cut, len(lines)))
+	}
+	fence := "```"
+	if strings.Contains(text, "```") {
+		fence = "````"
+	}
+	results.WriteString(fmt.Sprintf("%s%s\n%s\n%s\n", fence, fenceLangs[path.Ext(rel)], text, fence))
+	if cut > 0 {
+		results.WriteString(fmt.Sprintf("\n… %d more line(s); give start_line and end_line or raise max_lines.\n", cut))
+	}
+	return mcp.NewToolResultText(results.String()), nil
+}
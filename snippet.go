@@ -0,0 +1,268 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+var (
+	goPackageClause = regexp.MustCompile(`(?m)^package \w+`)
+	goTopLevelDecl  = regexp.MustCompile(`(?m)^(func|type|var|const|import)\b`)
+	goMainFunc      = regexp.MustCompile(`(?m)^func main\(\)`)
+	// goQualifier is pkg.Name, for guessing a statement snippet's imports
+	goQualifier = regexp.MustCompile(`\b([a-z]\w*)\.[A-Za-z_]`)
+	jsImport    = regexp.MustCompile(`(?m)^\s*(import|export)\b`)
+)
+
+// snippetStdlib are the standard packages a statement snippet gets
+// imported for it when it uses them.
+var snippetStdlib = map[string]string{
+	"context": "context", "errors": "errors", "fmt": "fmt", "http": "net/http",
+	"io": "io", "json": "encoding/json", "log": "log", "os": "os",
+	"strconv": "strconv", "strings": "strings", "sync": "sync", "time": "time",
+}
+
+// goSDKPackages maps the Go SDK's package names to their import paths.
+func goSDKPackages() map[string]string {
+	packages := map[string]string{}
+	data, err := os.ReadFile(filepath.Join(quickbaseGoPath, "go.mod"))
+	if err != nil {
+		return packages
+	}
+	m := goModuleLine.FindSubmatch(data)
+	if m == nil {
+		return packages
+	}
+	module := string(m[1])
+	walkRepo(quickbaseGoPath, func(rel string) error {
+		if !strings.HasSuffix(rel, ".go") || strings.HasSuffix(rel, "_test.go") {
+			return nil
+		}
+		src, err := os.ReadFile(filepath.Join(quickbaseGoPath, rel))
+		if err != nil {
+			return nil
+		}
+		name := strings.TrimPrefix(goPackageClause.FindString(string(src)), "package ")
+		if name == "" || name == "main" {
+			return nil
+		}
+		importPath := module
+		if dir := path.Dir(rel); dir != "." {
+			importPath += "/" + dir
+		}
+		packages[name] = importPath
+		return nil
+	})
+	return packages
+}
+
+// wrapGoSnippet turns a snippet into a main package file: a whole file is
+// used as is, declarations get a package clause (and an empty main), and
+// statements go in main with imports added for the SDK and standard
+// packages they name. It returns the source and how many lines and
+// columns the snippet was shifted by.
+func wrapGoSnippet(snippet string) (string, int, int) {
+	if goPackageClause.MatchString(snippet) {
+		return snippet, 0, 0
+	}
+	if goTopLevelDecl.MatchString(snippet) {
+		src := "package main\n\n" + snippet + "\n"
+		if !goMainFunc.MatchString(snippet) {
+			src += "\nfunc main() {}\n"
+		}
+		return src, 2, 0
+	}
+
+	known := goSDKPackages()
+	for name, importPath := range snippetStdlib {
+		if _, ok := known[name]; !ok {
+			known[name] = importPath
+		}
+	}
+	seen := map[string]bool{}
+	var imports []string
+	for _, m := range goQualifier.FindAllStringSubmatch(snippet, -1) {
+		name := m[1]
+		importPath, ok := known[name]
+		// A variable of the same name isn't the package
+		declared := regexp.MustCompile(`\b` + name + `\s*(,[\w\s,]*)?:=|\bvar ` + name + `\b`)
+		if !ok || seen[name] || declared.MatchString(snippet) {
+			continue
+		}
+		seen[name] = true
+		imports = append(imports, fmt.Sprintf("\t%q", importPath))
+	}
+	sort.Strings(imports)
+	var src strings.Builder
+	src.WriteString("package main\n\n")
+	offset := 2
+	if len(imports) > 0 {
+		src.WriteString("import (\n" + strings.Join(imports, "\n") + "\n)\n\n")
+		offset += len(imports) + 3
+	}
+	src.WriteString("func main() {\n")
+	offset++
+	for _, line := range strings.Split(strings.TrimRight(snippet, "\n"), "\n") {
+		src.WriteString("\t" + line + "\n")
+	}
+	src.WriteString("}\n")
+	return src.String(), offset, 1
+}
+
+// snippetDiagnostics moves compiler errors in the snippet file back onto the
+// snippet's own lines; errors elsewhere (the SDK itself) keep their file.
+func snippetDiagnostics(issues []lintIssue, file string, lineOffset, colOffset int) []string {
+	var out []string
+	for _, issue := range issues {
+		if filepath.Base(issue.file) != filepath.Base(file) {
+			out = append(out, fmt.Sprintf("`%s:%d`: %s", issue.file, issue.line, issue.message))
+			continue
+		}
+		line, col := issue.line-lineOffset, issue.column
+		if col > colOffset {
+			col -= colOffset
+		}
+		at := fmt.Sprintf("line %d", line)
+		if line < 1 {
+			at = "wrapper"
+		} else if col > 0 {
+			at += fmt.Sprintf(":%d", col)
+		}
+		if issue.rule != "" {
+			at += " " + issue.rule
+		}
+		out = append(out, fmt.Sprintf("%s: %s", at, issue.message))
+	}
+	return out
+}
+
+func (s *QuickBasePersonalMCPServer) handleCompileSnippet(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	var params struct {
+		Language       string `json:"language"`
+		Code           string `json:"code"`
+		TimeoutSeconds int    `json:"timeout_seconds"`
+	}
+	argsData, _ := json.Marshal(request.Params.Arguments)
+	if err := json.Unmarshal(argsData, &params); err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Invalid parameters: %v", err)), nil
+	}
+	if strings.TrimSpace(params.Code) == "" {
+		return mcp.NewToolResultError("code is required"), nil
+	}
+	switch params.Language {
+	case "go", "golang":
+		params.Language = "go"
+	case "ts", "typescript", "js":
+		params.Language = "ts"
+	default:
+		return mcp.NewToolResultError("language must be 'go' or 'ts'"), nil
+	}
+	timeout := 2 * time.Minute
+	if params.TimeoutSeconds > 0 {
+		timeout = time.Duration(params.TimeoutSeconds) * time.Second
+	}
+	dir, err := os.MkdirTemp("", "qb-snippet-")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	defer os.RemoveAll(dir)
+
+	var src, file, command, repoPath string
+	lineOffset, colOffset := 0, 0
+	if params.Language == "go" {
+		// An overlay puts the file in a package inside the SDK's module,
+		// so it builds against the local SDK without touching the tree
+		repoPath = quickbaseGoPath
+		src, lineOffset, colOffset = wrapGoSnippet(params.Code)
+		file = filepath.Join(dir, "main.go")
+		overlay, _ := json.Marshal(map[string]map[string]string{
+			"Replace": {filepath.Join(quickbaseGoPath, "qbsnippet", "main.go"): file},
+		})
+		if err := os.WriteFile(filepath.Join(dir, "overlay.json"), overlay, 0o644); err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		command = fmt.Sprintf("go build -overlay %s -o /dev/null ./qbsnippet", shellQuote(filepath.Join(dir, "overlay.json")))
+	} else {
+		// 'quickbase-js' resolves to the local source, with the SDK's own
+		// compiler options when it has a tsconfig.json
+		repoPath = quickbaseJSPath
+		src = params.Code
+		if !jsImport.MatchString(src) {
+			src += "\nexport {};\n"
+		}
+		file = filepath.Join(dir, "snippet.ts")
+		tsconfig := map[string]interface{}{
+			"compilerOptions": map[string]interface{}{
+				"noEmit":       true,
+				"skipLibCheck": true,
+				"composite":    false,
+				"rootDir":      "/",
+				"paths": map[string][]string{
+					"quickbase-js":   {filepath.Join(quickbaseJSPath, "src", "index.ts")},
+					"quickbase-js/*": {filepath.Join(quickbaseJSPath, "src", "*")},
+				},
+				"typeRoots": []string{filepath.Join(quickbaseJSPath, "node_modules", "@types")},
+			},
+			"files":   []string{file},
+			"include": []string{},
+		}
+		if _, err := os.Stat(filepath.Join(quickbaseJSPath, "tsconfig.json")); err == nil {
+			tsconfig["extends"] = filepath.Join(quickbaseJSPath, "tsconfig.json")
+		} else {
+			options := tsconfig["compilerOptions"].(map[string]interface{})
+			options["strict"], options["target"], options["module"], options["moduleResolution"] = true, "es2022", "esnext", "bundler"
+		}
+		data, _ := json.MarshalIndent(tsconfig, "", "  ")
+		if err := os.WriteFile(filepath.Join(dir, "tsconfig.json"), data, 0o644); err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		command = fmt.Sprintf("npx --no-install tsc -p %s --pretty false", shellQuote(dir))
+	}
+	if err := os.WriteFile(file, []byte(src), 0o644); err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	result, err := runShell(ctx, repoPath, timeout, command)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	output := ansiEscape.ReplaceAllString(result.output, "")
+
+	var results strings.Builder
+	sdk := "quickbase-go"
+	if params.Language == "ts" {
+		sdk = "quickbase-js"
+	}
+	results.WriteString(fmt.Sprintf("# Compile snippet (%s)\n\n", params.Language))
+	if result.timedOut {
+		results.WriteString(fmt.Sprintf("⏱️ Timed out after %s\n", timeout))
+		return mcp.NewToolResultText(results.String()), nil
+	}
+	if result.exitCode == 0 {
+		results.WriteString(fmt.Sprintf("✅ Compiles against the local %s (%s)\n", sdk, result.duration.Round(100*time.Millisecond)))
+		return mcp.NewToolResultText(results.String()), nil
+	}
+	diagnostics := snippetDiagnostics(parseCompileErrors(output, params.Language == "ts"), file, lineOffset, colOffset)
+	if len(diagnostics) == 0 {
+		results.WriteString(fmt.Sprintf("❌ Compiler failed (exit %d):\n\n```\n%s\n```\n", result.exitCode, strings.ReplaceAll(tailLines(output, 30), dir+"/", "")))
+		return mcp.NewToolResultText(results.String()), nil
+	}
+	results.WriteString(fmt.Sprintf("❌ %d error(s) against the local %s:\n\n", len(diagnostics), sdk))
+	for _, d := range diagnostics {
+		results.WriteString("- " + d + "\n")
+	}
+	if src != params.Code {
+		results.WriteString(fmt.Sprintf("\nLines are the snippet's own. It was compiled as:\n\n```%s\n%s\n```\n", params.Language, strings.TrimRight(src, "\n")))
+	}
+	return mcp.NewToolResultText(results.String()), nil
+}
@@ -0,0 +1,486 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"gopkg.in/yaml.v3"
+)
+
+// specFileCandidates are checked (relative to quickbaseSpecPath) before
+// falling back to scanning the repo for an OpenAPI document.
+var specFileCandidates = []string{
+	"openapi.yaml",
+	"openapi.yml",
+	"openapi.json",
+	"spec/openapi.yaml",
+	"spec/openapi.yml",
+	"spec/openapi.json",
+	"quickbase.yaml",
+	"quickbase.json",
+}
+
+// specCache holds the parsed spec so repeated queries don't re-parse a
+// multi-megabyte document. It is invalidated when the file changes.
+var specCache struct {
+	sync.Mutex
+	path    string
+	modTime int64
+	root    *yaml.Node
+}
+
+// findSpecFile locates the OpenAPI document inside the spec repo.
+func findSpecFile() (string, error) {
+	for _, candidate := range specFileCandidates {
+		full := filepath.Join(quickbaseSpecPath, candidate)
+		if info, err := os.Stat(full); err == nil && !info.IsDir() {
+			return full, nil
+		}
+	}
+
+	// Fall back to the largest YAML/JSON file that declares an openapi version
+	var best string
+	var bestSize int64
+	filepath.WalkDir(quickbaseSpecPath, func(path string, d os.DirEntry, err error) error {
+		if err != nil {
+			return nil
+		}
+		if d.IsDir() {
+			if name := d.Name(); name == ".git" || name == "node_modules" {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		switch filepath.Ext(path) {
+		case ".yaml", ".yml", ".json":
+		default:
+			return nil
+		}
+		f, err := os.Open(path)
+		if err != nil {
+			return nil
+		}
+		head := make([]byte, 512)
+		n, _ := f.Read(head)
+		f.Close()
+		if !bytes.Contains(head[:n], []byte("openapi")) {
+			return nil
+		}
+		if info, err := d.Info(); err == nil && info.Size() > bestSize {
+			best, bestSize = path, info.Size()
+		}
+		return nil
+	})
+	if best == "" {
+		return "", fmt.Errorf("no OpenAPI document found in %s", quickbaseSpecPath)
+	}
+	return best, nil
+}
+
+// loadSpec returns the root mapping node of the OpenAPI document.
+func loadSpec() (*yaml.Node, error) {
+	path, err := findSpecFile()
+	if err != nil {
+		return nil, err
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+
+	specCache.Lock()
+	defer specCache.Unlock()
+	if specCache.root != nil && specCache.path == path && specCache.modTime == info.ModTime().UnixNano() {
+		return specCache.root, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	if doc.Kind != yaml.DocumentNode || len(doc.Content) == 0 {
+		return nil, fmt.Errorf("%s is empty", path)
+	}
+
+	specCache.path = path
+	specCache.modTime = info.ModTime().UnixNano()
+	specCache.root = doc.Content[0]
+	return specCache.root, nil
+}
+
+// loadSpecAt parses the OpenAPI document as of a git ref of the spec repo,
+// at the path it has in the working tree. An empty ref is the working
+// tree itself.
+func loadSpecAt(ref string) (*yaml.Node, error) {
+	if ref == "" {
+		return loadSpec()
+	}
+	if err := verifyRef(quickbaseSpecPath, ref); err != nil {
+		return nil, err
+	}
+	path, err := findSpecFile()
+	if err != nil {
+		return nil, err
+	}
+	rel, err := filepath.Rel(quickbaseSpecPath, path)
+	if err != nil {
+		return nil, err
+	}
+	data, err := readRepoFile(quickbaseSpecPath, ref, filepath.ToSlash(rel))
+	if err != nil {
+		return nil, err
+	}
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("failed to parse %s at %s: %w", rel, ref, err)
+	}
+	if doc.Kind != yaml.DocumentNode || len(doc.Content) == 0 {
+		return nil, fmt.Errorf("%s is empty at %s", rel, ref)
+	}
+	return doc.Content[0], nil
+}
+
+// pathSegment is one step of a spec_query expression. Exactly one of
+// key, index or iterate is meaningful.
+type pathSegment struct {
+	key     string
+	index   int
+	isIndex bool
+	iterate bool
+}
+
+// parseQueryPath parses a jq/yq-style path such as
+// `.paths."/records/query".post.parameters[0]` or `.tags[].name`.
+func parseQueryPath(expr string) ([]pathSegment, error) {
+	expr = strings.TrimSpace(expr)
+	if expr == "" || expr == "." {
+		return nil, nil
+	}
+	if expr[0] != '.' && expr[0] != '[' {
+		return nil, fmt.Errorf("path must start with '.' (e.g. .paths)")
+	}
+
+	var segments []pathSegment
+	i := 0
+	for i < len(expr) {
+		switch expr[i] {
+		case '.':
+			i++
+			if i >= len(expr) {
+				return nil, fmt.Errorf("trailing '.' in path")
+			}
+			if expr[i] == '[' {
+				continue
+			}
+			if expr[i] == '"' {
+				end := strings.IndexByte(expr[i+1:], '"')
+				if end < 0 {
+					return nil, fmt.Errorf("unterminated quoted key at offset %d", i)
+				}
+				segments = append(segments, pathSegment{key: expr[i+1 : i+1+end]})
+				i += end + 2
+				continue
+			}
+			start := i
+			for i < len(expr) && expr[i] != '.' && expr[i] != '[' {
+				i++
+			}
+			segments = append(segments, pathSegment{key: expr[start:i]})
+		case '[':
+			end := strings.IndexByte(expr[i:], ']')
+			if end < 0 {
+				return nil, fmt.Errorf("unterminated '[' at offset %d", i)
+			}
+			inner := strings.TrimSpace(expr[i+1 : i+end])
+			i += end + 1
+			switch {
+			case inner == "":
+				segments = append(segments, pathSegment{iterate: true})
+			case strings.HasPrefix(inner, `"`) && strings.HasSuffix(inner, `"`) && len(inner) >= 2:
+				segments = append(segments, pathSegment{key: inner[1 : len(inner)-1]})
+			default:
+				n, err := strconv.Atoi(inner)
+				if err != nil {
+					return nil, fmt.Errorf("invalid index [%s]", inner)
+				}
+				segments = append(segments, pathSegment{index: n, isIndex: true})
+			}
+		default:
+			return nil, fmt.Errorf("unexpected %q at offset %d", expr[i], i)
+		}
+	}
+	return segments, nil
+}
+
+// evalQueryPath applies the segments to node, fanning out on [] iteration.
+func evalQueryPath(node *yaml.Node, segments []pathSegment) ([]*yaml.Node, error) {
+	current := []*yaml.Node{node}
+	for _, seg := range segments {
+		var next []*yaml.Node
+		for _, n := range current {
+			if n.Kind == yaml.AliasNode {
+				n = n.Alias
+			}
+			switch {
+			case seg.iterate:
+				switch n.Kind {
+				case yaml.SequenceNode:
+					next = append(next, n.Content...)
+				case yaml.MappingNode:
+					for j := 1; j < len(n.Content); j += 2 {
+						next = append(next, n.Content[j])
+					}
+				default:
+					return nil, fmt.Errorf("cannot iterate over a scalar")
+				}
+			case seg.isIndex:
+				if n.Kind != yaml.SequenceNode {
+					return nil, fmt.Errorf("cannot index [%d] into a non-array", seg.index)
+				}
+				idx := seg.index
+				if idx < 0 {
+					idx += len(n.Content)
+				}
+				if idx >= 0 && idx < len(n.Content) {
+					next = append(next, n.Content[idx])
+				}
+			default:
+				if v := mappingValue(n, seg.key); v != nil {
+					next = append(next, v)
+				}
+			}
+		}
+		current = next
+	}
+	return current, nil
+}
+
+// mappingValue returns the value for key in a mapping node, or nil.
+func mappingValue(n *yaml.Node, key string) *yaml.Node {
+	if n == nil || n.Kind != yaml.MappingNode {
+		return nil
+	}
+	for j := 0; j+1 < len(n.Content); j += 2 {
+		if n.Content[j].Value == key {
+			return n.Content[j+1]
+		}
+	}
+	return nil
+}
+
+// resolveRef follows a local $ref ("#/components/schemas/X") to its
+// target, returning the node unchanged when it isn't a reference. The ref
+// name (X) is returned too since SDK types are usually named after it.
+func resolveRef(root, n *yaml.Node) (*yaml.Node, string) {
+	name := ""
+	for i := 0; i < 8 && n != nil; i++ {
+		ref := mappingValue(n, "$ref")
+		if ref == nil || !strings.HasPrefix(ref.Value, "#/") {
+			return n, name
+		}
+		target := root
+		for _, part := range strings.Split(strings.TrimPrefix(ref.Value, "#/"), "/") {
+			part = strings.NewReplacer("~1", "/", "~0", "~").Replace(part)
+			target = mappingValue(target, part)
+		}
+		if target == nil {
+			return n, name
+		}
+		name = ref.Value[strings.LastIndex(ref.Value, "/")+1:]
+		n = target
+	}
+	return n, name
+}
+
+// expandRefs returns a copy of n with every local $ref replaced by its
+// target, recursively. An expanded schema gets an x-ref key naming what it
+// came from; a reference back into a schema being expanded is left as a
+// $ref marked circular.
+func expandRefs(root, n *yaml.Node, stack []string) *yaml.Node {
+	if n == nil {
+		return nil
+	}
+	if ref := mappingValue(n, "$ref"); ref != nil && strings.HasPrefix(ref.Value, "#/") {
+		if containsString(stack, ref.Value) {
+			return &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map", Content: []*yaml.Node{
+				{Kind: yaml.ScalarNode, Tag: "!!str", Value: "$ref"}, {Kind: yaml.ScalarNode, Tag: "!!str", Value: ref.Value},
+				{Kind: yaml.ScalarNode, Tag: "!!str", Value: "x-circular"}, {Kind: yaml.ScalarNode, Tag: "!!bool", Value: "true"},
+			}}
+		}
+		target, name := resolveRef(root, n)
+		if target == n {
+			return n
+		}
+		out := expandRefs(root, target, append(stack, ref.Value))
+		if out.Kind == yaml.MappingNode && name != "" {
+			out.Content = append([]*yaml.Node{{Kind: yaml.ScalarNode, Tag: "!!str", Value: "x-ref"}, {Kind: yaml.ScalarNode, Tag: "!!str", Value: name}}, out.Content...)
+		}
+		return out
+	}
+	out := *n
+	// Flow style from the source reads badly once refs are inlined
+	out.Style &^= yaml.FlowStyle
+	out.Content = make([]*yaml.Node, len(n.Content))
+	for i, c := range n.Content {
+		out.Content[i] = expandRefs(root, c, stack)
+	}
+	return &out
+}
+
+// mappingKeys lists the keys of a mapping node in document order.
+func mappingKeys(n *yaml.Node) []string {
+	if n == nil || n.Kind != yaml.MappingNode {
+		return nil
+	}
+	keys := make([]string, 0, len(n.Content)/2)
+	for j := 0; j+1 < len(n.Content); j += 2 {
+		keys = append(keys, n.Content[j].Value)
+	}
+	return keys
+}
+
+// renderNode serializes a node as YAML or indented JSON.
+func renderNode(n *yaml.Node, format string) (string, error) {
+	if format == "json" {
+		var v interface{}
+		if err := n.Decode(&v); err != nil {
+			return "", err
+		}
+		out, err := json.MarshalIndent(v, "", "  ")
+		if err != nil {
+			return "", err
+		}
+		return string(out), nil
+	}
+	var buf bytes.Buffer
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+	if err := enc.Encode(n); err != nil {
+		return "", err
+	}
+	enc.Close()
+	return strings.TrimRight(buf.String(), "\n"), nil
+}
+
+func (s *QuickBasePersonalMCPServer) handleSpecQuery(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	var params struct {
+		Path     string `json:"path"`
+		Format   string `json:"format"`
+		KeysOnly bool   `json:"keys_only"`
+	}
+	argsData, _ := json.Marshal(request.Params.Arguments)
+	if err := json.Unmarshal(argsData, &params); err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Invalid parameters: %v", err)), nil
+	}
+	if params.Format == "" {
+		params.Format = "yaml"
+	}
+
+	segments, err := parseQueryPath(params.Path)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Invalid path %q: %v", params.Path, err)), nil
+	}
+	root, err := loadSpec()
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to load spec: %v", err)), nil
+	}
+	matches, err := evalQueryPath(root, segments)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to evaluate %q: %v", params.Path, err)), nil
+	}
+	if len(matches) == 0 {
+		return mcp.NewToolResultText(fmt.Sprintf("No match for: %s", params.Path)), nil
+	}
+
+	var results strings.Builder
+	results.WriteString(fmt.Sprintf("# %s (%d match", params.Path, len(matches)))
+	if len(matches) != 1 {
+		results.WriteString("es")
+	}
+	results.WriteString(")\n\n")
+
+	for _, m := range matches {
+		if params.KeysOnly {
+			switch m.Kind {
+			case yaml.MappingNode:
+				for _, k := range mappingKeys(m) {
+					results.WriteString(fmt.Sprintf("- %s\n", k))
+				}
+			case yaml.SequenceNode:
+				results.WriteString(fmt.Sprintf("- [0..%d]\n", len(m.Content)-1))
+			default:
+				results.WriteString(fmt.Sprintf("- (scalar) %s\n", m.Value))
+			}
+			results.WriteString("\n")
+			continue
+		}
+		out, err := renderNode(m, params.Format)
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("Failed to render result: %v", err)), nil
+		}
+		results.WriteString(fmt.Sprintf("```%s\n%s\n```\n\n", params.Format, out))
+	}
+
+	return mcp.NewToolResultText(results.String()), nil
+}
+
+// httpMethods are the operation keys of an OpenAPI path item.
+var httpMethods = []string{"get", "put", "post", "delete", "options", "head", "patch", "trace"}
+
+// specOperation is one method+path entry in the spec.
+type specOperation struct {
+	Method      string
+	Path        string
+	OperationID string
+	Summary     string
+	Tags        []string
+	Deprecated  bool
+	Node        *yaml.Node
+}
+
+// specOperations lists every operation in document order.
+func specOperations(root *yaml.Node) []specOperation {
+	var ops []specOperation
+	paths := mappingValue(root, "paths")
+	if paths == nil {
+		return nil
+	}
+	for j := 0; j+1 < len(paths.Content); j += 2 {
+		p, item := paths.Content[j].Value, paths.Content[j+1]
+		for _, method := range httpMethods {
+			node := mappingValue(item, method)
+			if node == nil {
+				continue
+			}
+			op := specOperation{Method: strings.ToUpper(method), Path: p, Node: node}
+			if v := mappingValue(node, "operationId"); v != nil {
+				op.OperationID = v.Value
+			}
+			if v := mappingValue(node, "summary"); v != nil {
+				op.Summary = v.Value
+			}
+			if v := mappingValue(node, "deprecated"); v != nil {
+				op.Deprecated = v.Value == "true"
+			}
+			if v := mappingValue(node, "tags"); v != nil {
+				for _, t := range v.Content {
+					op.Tags = append(op.Tags, t.Value)
+				}
+			}
+			ops = append(ops, op)
+		}
+	}
+	return ops
+}
@@ -0,0 +1,604 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"gopkg.in/yaml.v3"
+)
+
+// specChange is one difference between two versions of the spec.
+type specChange struct {
+	Area    string       `json:"area"` // operation or schema
+	Kind    string       `json:"kind"` // added, removed or changed
+	Subject string       `json:"subject"`
+	Impact  string       `json:"impact"`
+	Details []specDetail `json:"details,omitempty"`
+}
+
+// specDetail is one itemized change and its impact on SDK callers.
+type specDetail struct {
+	Text   string `json:"text"`
+	Impact string `json:"impact"`
+}
+
+// Impacts, most severe first. Breaking changes need SDK work before the
+// pin can move; review ones can't be classified from the spec alone.
+const (
+	impactBreaking = "breaking"
+	impactReview   = "review"
+	impactAdditive = "additive"
+	impactNeutral  = "neutral"
+)
+
+var impactRank = map[string]int{impactBreaking: 0, impactReview: 1, impactAdditive: 2, impactNeutral: 3}
+
+var impactIcons = map[string]string{impactBreaking: "🔴", impactReview: "🟡", impactAdditive: "🟢", impactNeutral: "⚪"}
+
+// worstImpact is the most severe impact among details.
+func worstImpact(details []specDetail) string {
+	worst := impactNeutral
+	for _, d := range details {
+		if impactRank[d.Impact] < impactRank[worst] {
+			worst = d.Impact
+		}
+	}
+	return worst
+}
+
+// Where a schema is used decides what a change means to callers: a new
+// required property breaks requests but only adds a guarantee to
+// responses, and a new enum value is safe to send but may surprise code
+// reading it.
+const (
+	inRequest  = "request"
+	inResponse = "response"
+	inEither   = "" // a component schema used in both, or not at all
+)
+
+// pick returns the impact for a schema used in a request, a response or
+// (unknown) either; either takes the more severe.
+func pick(direction, request, response string) string {
+	switch direction {
+	case inRequest:
+		return request
+	case inResponse:
+		return response
+	}
+	if impactRank[request] < impactRank[response] {
+		return request
+	}
+	return response
+}
+
+// schemaDirections records whether each component schema is used in
+// requests, responses or both, following refs through other schemas.
+// Unused schemas are left out and treated as either.
+func schemaDirections(root *yaml.Node) map[string]string {
+	seen := map[string]map[string]bool{}
+	var collect func(n *yaml.Node, direction string)
+	collect = func(n *yaml.Node, direction string) {
+		if n == nil {
+			return
+		}
+		if name := scalarAt(n, "x-ref"); name != "" {
+			if seen[name] == nil {
+				seen[name] = map[string]bool{}
+			}
+			seen[name][direction] = true
+		}
+		for _, c := range n.Content {
+			collect(c, direction)
+		}
+	}
+	for _, op := range specOperations(root) {
+		for _, p := range operationParameters(root, op) {
+			collect(p, inRequest)
+		}
+		collect(expandRefs(root, mappingValue(op.Node, "requestBody"), nil), inRequest)
+		collect(expandRefs(root, mappingValue(op.Node, "responses"), nil), inResponse)
+	}
+	directions := map[string]string{}
+	for name, used := range seen {
+		switch {
+		case used[inRequest] && used[inResponse]:
+			directions[name] = inEither
+		case used[inRequest]:
+			directions[name] = inRequest
+		default:
+			directions[name] = inResponse
+		}
+	}
+	return directions
+}
+
+// canonical renders a node as sorted JSON so formatting and key order
+// don't count as changes.
+func canonical(n *yaml.Node) string {
+	if n == nil {
+		return ""
+	}
+	var v interface{}
+	if err := n.Decode(&v); err != nil {
+		return ""
+	}
+	data, _ := json.Marshal(v)
+	return string(data)
+}
+
+// stringList reads a sequence of scalars.
+func stringList(n *yaml.Node) []string {
+	var out []string
+	if n != nil {
+		for _, c := range n.Content {
+			out = append(out, c.Value)
+		}
+	}
+	return out
+}
+
+// diffEnum reports values removed from (narrowed) or added to (widened)
+// a schema's enum.
+func diffEnum(old, new *yaml.Node, what, direction string) []specDetail {
+	oldEnum, newEnum := mappingValue(old, "enum"), mappingValue(new, "enum")
+	if oldEnum == nil && newEnum == nil {
+		return nil
+	}
+	var details []specDetail
+	if oldEnum == nil {
+		return append(details, specDetail{fmt.Sprintf("%s restricted to enum [%s]", what, strings.Join(stringList(newEnum), ", ")), pick(direction, impactBreaking, impactAdditive)})
+	}
+	if newEnum == nil {
+		return append(details, specDetail{fmt.Sprintf("%s no longer restricted to an enum", what), pick(direction, impactAdditive, impactBreaking)})
+	}
+	oldValues, newValues := stringList(oldEnum), stringList(newEnum)
+	var removed, added []string
+	for _, v := range oldValues {
+		if !containsString(newValues, v) {
+			removed = append(removed, v)
+		}
+	}
+	for _, v := range newValues {
+		if !containsString(oldValues, v) {
+			added = append(added, v)
+		}
+	}
+	if len(removed) > 0 {
+		details = append(details, specDetail{fmt.Sprintf("%s enum narrowed, removed: %s", what, strings.Join(removed, ", ")), pick(direction, impactBreaking, impactAdditive)})
+	}
+	if len(added) > 0 {
+		details = append(details, specDetail{fmt.Sprintf("%s enum widened, added: %s", what, strings.Join(added, ", ")), pick(direction, impactAdditive, impactBreaking)})
+	}
+	return details
+}
+
+// diffSchema describes how a schema changed and what each change means
+// for callers: properties added, removed or retyped, required-ness and
+// enum values, down through nested objects. Refs are expected to be
+// expanded already.
+func diffSchema(old, new *yaml.Node, prefix, direction string) []specDetail {
+	return diffSchemaAt(old, new, prefix, "", direction)
+}
+
+// diffSchemaAt is diffSchema for the object at path ("options." for a
+// nested property).
+func diffSchemaAt(old, new *yaml.Node, prefix, path, direction string) []specDetail {
+	var details []specDetail
+	if ot, nt := schemaType(old), schemaType(new); ot != nt && path == "" {
+		details = append(details, specDetail{fmt.Sprintf("%stype %s → %s", prefix, orNone(ot), orNone(nt)), impactBreaking})
+	}
+	if path == "" {
+		details = append(details, diffEnum(old, new, strings.TrimSuffix(prefix, ": ")+" value", direction)...)
+	}
+	oldProps, newProps := mappingValue(old, "properties"), mappingValue(new, "properties")
+	newReq := stringList(mappingValue(new, "required"))
+	for _, name := range mappingKeys(newProps) {
+		o := mappingValue(oldProps, name)
+		n := mappingValue(newProps, name)
+		prop := fmt.Sprintf("%sproperty %s%s", prefix, path, name)
+		switch {
+		case o == nil:
+			// A new required request property is reported as newly required below
+			details = append(details, specDetail{fmt.Sprintf("%s added (%s)", prop, orNone(schemaType(n))), impactAdditive})
+		case canonical(o) != canonical(n):
+			ot, nt := schemaType(o), schemaType(n)
+			switch {
+			case ot != nt:
+				details = append(details, specDetail{fmt.Sprintf("%s: type %s → %s", prop, orNone(ot), orNone(nt)), impactBreaking})
+			case mappingValue(o, "properties") != nil || mappingValue(n, "properties") != nil:
+				details = append(details, diffSchemaAt(o, n, prefix, path+name+".", direction)...)
+			default:
+				enum := diffEnum(o, n, prop, direction)
+				details = append(details, enum...)
+				if len(enum) == 0 || canonical(mappingValue(o, "enum")) == canonical(mappingValue(n, "enum")) {
+					details = append(details, specDetail{prop + " changed (format, constraints or description)", impactReview})
+				}
+			}
+		}
+	}
+	for _, name := range mappingKeys(oldProps) {
+		if mappingValue(newProps, name) == nil {
+			details = append(details, specDetail{fmt.Sprintf("%sproperty %s%s removed", prefix, path, name), impactBreaking})
+		}
+	}
+	oldReq := stringList(mappingValue(old, "required"))
+	for _, name := range newReq {
+		if !containsString(oldReq, name) {
+			details = append(details, specDetail{fmt.Sprintf("%sproperty %s%s now required", prefix, path, name), pick(direction, impactBreaking, impactAdditive)})
+		}
+	}
+	for _, name := range oldReq {
+		if !containsString(newReq, name) {
+			details = append(details, specDetail{fmt.Sprintf("%sproperty %s%s no longer required", prefix, path, name), pick(direction, impactAdditive, impactBreaking)})
+		}
+	}
+	if len(details) == 0 && canonical(old) != canonical(new) {
+		what := "schema"
+		if path != "" {
+			what = "property " + strings.TrimSuffix(path, ".")
+		}
+		details = append(details, specDetail{fmt.Sprintf("%s%s changed (constraints, composition or description)", prefix, what), impactReview})
+	}
+	return details
+}
+
+func orNone(s string) string {
+	if s == "" {
+		return "(none)"
+	}
+	return s
+}
+
+// jsonSchema is an operation's expanded application/json schema in a
+// request body or response, or nil.
+func jsonSchema(root, n *yaml.Node) *yaml.Node {
+	n, _ = resolveRef(root, n)
+	content := mappingValue(n, "content")
+	if content == nil {
+		return nil
+	}
+	media := mappingValue(content, "application/json")
+	if media == nil && len(content.Content) >= 2 {
+		media = content.Content[1]
+	}
+	return expandRefs(root, mappingValue(media, "schema"), nil)
+}
+
+// diffOperation lists what changed in one operation.
+func diffOperation(oldRoot, newRoot *yaml.Node, o, n specOperation) []specDetail {
+	var details []specDetail
+	add := func(impact, format string, args ...interface{}) {
+		details = append(details, specDetail{fmt.Sprintf(format, args...), impact})
+	}
+	if o.OperationID != n.OperationID {
+		// Generated SDK methods are named after it
+		add(impactBreaking, "operationId %s → %s", orNone(o.OperationID), orNone(n.OperationID))
+	}
+	if o.Deprecated != n.Deprecated {
+		if n.Deprecated {
+			add(impactNeutral, "now deprecated")
+		} else {
+			add(impactNeutral, "no longer deprecated")
+		}
+	}
+	if strings.Join(o.Tags, ",") != strings.Join(n.Tags, ",") {
+		add(impactNeutral, "tags [%s] → [%s]", strings.Join(o.Tags, ", "), strings.Join(n.Tags, ", "))
+	}
+
+	paramKey := func(p *yaml.Node) string { return scalarAt(p, "in") + " " + scalarAt(p, "name") }
+	oldParams := map[string]*yaml.Node{}
+	for _, p := range operationParameters(oldRoot, o) {
+		oldParams[paramKey(p)] = p
+	}
+	newParams := map[string]*yaml.Node{}
+	for _, p := range operationParameters(newRoot, n) {
+		k := paramKey(p)
+		newParams[k] = p
+		old, ok := oldParams[k]
+		name := scalarAt(p, "name")
+		required := scalarAt(p, "required") == "true" || scalarAt(p, "in") == "path"
+		switch {
+		case !ok && required:
+			add(impactBreaking, "required %s parameter %s added", scalarAt(p, "in"), name)
+		case !ok:
+			add(impactAdditive, "optional %s parameter %s added", scalarAt(p, "in"), name)
+		default:
+			wasRequired := scalarAt(old, "required") == "true" || scalarAt(old, "in") == "path"
+			if required && !wasRequired {
+				add(impactBreaking, "parameter %s now required", name)
+			} else if !required && wasRequired {
+				add(impactAdditive, "parameter %s no longer required", name)
+			}
+			oldSchema, newSchema := mappingValue(old, "schema"), mappingValue(p, "schema")
+			if ot, nt := schemaType(oldSchema), schemaType(newSchema); ot != nt {
+				add(impactBreaking, "parameter %s: type %s → %s", name, orNone(ot), orNone(nt))
+			} else if canonical(oldSchema) != canonical(newSchema) {
+				enum := diffEnum(oldSchema, newSchema, "parameter "+name, inRequest)
+				details = append(details, enum...)
+				if len(enum) == 0 {
+					add(impactReview, "parameter %s: schema changed", name)
+				}
+			}
+		}
+	}
+	for _, k := range sortedKeys(oldParams) {
+		if _, ok := newParams[k]; !ok {
+			add(impactBreaking, "%s parameter %s removed", scalarAt(oldParams[k], "in"), scalarAt(oldParams[k], "name"))
+		}
+	}
+
+	oldBody, newBody := jsonSchema(oldRoot, mappingValue(o.Node, "requestBody")), jsonSchema(newRoot, mappingValue(n.Node, "requestBody"))
+	switch {
+	case oldBody == nil && newBody != nil:
+		rb, _ := resolveRef(newRoot, mappingValue(n.Node, "requestBody"))
+		if scalarAt(rb, "required") == "true" {
+			add(impactBreaking, "required request body added")
+		} else {
+			add(impactAdditive, "optional request body added")
+		}
+	case oldBody != nil && newBody == nil:
+		add(impactBreaking, "request body removed")
+	case oldBody != nil && canonical(oldBody) != canonical(newBody):
+		details = append(details, diffSchema(oldBody, newBody, "request body: ", inRequest)...)
+	}
+
+	oldResp, newResp := mappingValue(o.Node, "responses"), mappingValue(n.Node, "responses")
+	for _, status := range mappingKeys(newResp) {
+		if mappingValue(oldResp, status) == nil {
+			// A new error status is one more case callers may not handle
+			impact := impactReview
+			if strings.HasPrefix(status, "2") {
+				impact = impactAdditive
+			}
+			add(impact, "response %s added", status)
+			continue
+		}
+		oldSchema, newSchema := jsonSchema(oldRoot, mappingValue(oldResp, status)), jsonSchema(newRoot, mappingValue(newResp, status))
+		switch {
+		case oldSchema == nil && newSchema != nil:
+			add(impactAdditive, "response %s: body added", status)
+		case oldSchema != nil && newSchema == nil:
+			add(impactBreaking, "response %s: body removed", status)
+		case canonical(oldSchema) != canonical(newSchema):
+			details = append(details, diffSchema(oldSchema, newSchema, fmt.Sprintf("response %s: ", status), inResponse)...)
+		}
+	}
+	for _, status := range mappingKeys(oldResp) {
+		if mappingValue(newResp, status) == nil {
+			impact := impactNeutral
+			if strings.HasPrefix(status, "2") {
+				impact = impactBreaking
+			}
+			add(impact, "response %s removed", status)
+		}
+	}
+	return details
+}
+
+// operationLabel is how a change names an operation: method, path and
+// operationId when it has one.
+func operationLabel(op specOperation) string {
+	if op.OperationID != "" {
+		return fmt.Sprintf("%s %s (%s)", op.Method, op.Path, op.OperationID)
+	}
+	return op.Method + " " + op.Path
+}
+
+// diffSpecs compares two versions of the spec: operations by method and
+// path, and component schemas by name. Removals are breaking, additions
+// additive, and a changed entry takes the impact of its worst detail.
+func diffSpecs(oldRoot, newRoot *yaml.Node) []specChange {
+	var changes []specChange
+	label := operationLabel
+	oldOps := map[string]specOperation{}
+	for _, op := range specOperations(oldRoot) {
+		oldOps[op.Method+" "+op.Path] = op
+	}
+	newOps := map[string]bool{}
+	for _, op := range specOperations(newRoot) {
+		key := op.Method + " " + op.Path
+		newOps[key] = true
+		old, ok := oldOps[key]
+		if !ok {
+			changes = append(changes, specChange{Area: "operation", Kind: "added", Subject: label(op), Impact: impactAdditive})
+			continue
+		}
+		if details := diffOperation(oldRoot, newRoot, old, op); len(details) > 0 {
+			changes = append(changes, specChange{Area: "operation", Kind: "changed", Subject: label(op), Impact: worstImpact(details), Details: details})
+		}
+	}
+	for _, key := range sortedKeys(oldOps) {
+		if !newOps[key] {
+			changes = append(changes, specChange{Area: "operation", Kind: "removed", Subject: label(oldOps[key]), Impact: impactBreaking})
+		}
+	}
+
+	oldSchemas := mappingValue(mappingValue(oldRoot, "components"), "schemas")
+	newSchemas := mappingValue(mappingValue(newRoot, "components"), "schemas")
+	directions := schemaDirections(newRoot)
+	for _, name := range mappingKeys(newSchemas) {
+		o := mappingValue(oldSchemas, name)
+		if o == nil {
+			changes = append(changes, specChange{Area: "schema", Kind: "added", Subject: name, Impact: impactAdditive})
+			continue
+		}
+		n := mappingValue(newSchemas, name)
+		if canonical(o) != canonical(n) {
+			details := diffSchema(o, n, "", directions[name])
+			changes = append(changes, specChange{Area: "schema", Kind: "changed", Subject: name, Impact: worstImpact(details), Details: details})
+		}
+	}
+	for _, name := range mappingKeys(oldSchemas) {
+		if mappingValue(newSchemas, name) == nil {
+			changes = append(changes, specChange{Area: "schema", Kind: "removed", Subject: name, Impact: impactBreaking})
+		}
+	}
+	return changes
+}
+
+// specSubmodule is an SDK's submodule holding the spec, if it has one.
+func specSubmodule(repoPath string) (submodule, bool) {
+	for _, sub := range listSubmodules(repoPath) {
+		if strings.Contains(strings.ToLower(sub.path), "spec") {
+			return sub, true
+		}
+	}
+	return submodule{}, false
+}
+
+// specPin is the spec commit an SDK pins as a submodule, if it has one:
+// what its HEAD records, which can differ from what's checked out.
+func specPin(repoPath string) string {
+	sub, ok := specSubmodule(repoPath)
+	if !ok {
+		return ""
+	}
+	if pin, err := runGit(repoPath, "rev-parse", "HEAD:"+sub.path); err == nil {
+		return pin
+	}
+	return sub.commit
+}
+
+// resolveSpecRef turns a spec_diff ref into a git ref of the spec repo:
+// js-pin and go-pin are the SDKs' submodule commits, "working" the
+// working tree ("").
+func resolveSpecRef(ref string) (string, string, error) {
+	switch ref {
+	case "working":
+		return "", "working tree", nil
+	case "js-pin", "go-pin":
+		repo := map[string]string{"js-pin": quickbaseJSPath, "go-pin": quickbaseGoPath}[ref]
+		pin := specPin(repo)
+		if pin == "" {
+			return "", "", fmt.Errorf("%s has no spec submodule", repo)
+		}
+		return pin, fmt.Sprintf("%s (%s)", ref, shortSHA(pin)), nil
+	}
+	sha, err := runGit(quickbaseSpecPath, "rev-parse", "--short", ref+"^{commit}")
+	if err != nil || strings.HasPrefix(ref, "-") {
+		return "", "", fmt.Errorf("unknown ref: %s", ref)
+	}
+	return ref, fmt.Sprintf("%s (%s)", ref, sha), nil
+}
+
+func (s *QuickBasePersonalMCPServer) handleSpecDiff(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	var params struct {
+		Base         string `json:"base"`
+		Head         string `json:"head"`
+		BreakingOnly bool   `json:"breaking_only"`
+	}
+	argsData, _ := json.Marshal(request.Params.Arguments)
+	if err := json.Unmarshal(argsData, &params); err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Invalid parameters: %v", err)), nil
+	}
+	if params.Base == "" {
+		// What an SDK is built against is the natural starting point
+		switch {
+		case specPin(quickbaseJSPath) != "":
+			params.Base = "js-pin"
+		case specPin(quickbaseGoPath) != "":
+			params.Base = "go-pin"
+		default:
+			return mcp.NewToolResultError("base is required (neither SDK pins the spec as a submodule)"), nil
+		}
+	}
+	if params.Head == "" {
+		params.Head = "HEAD"
+	}
+
+	baseRef, baseName, err := resolveSpecRef(params.Base)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	headRef, headName, err := resolveSpecRef(params.Head)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	oldRoot, err := loadSpecAt(baseRef)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to load spec at %s: %v", baseName, err)), nil
+	}
+	newRoot, err := loadSpecAt(headRef)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to load spec at %s: %v", headName, err)), nil
+	}
+
+	changes := diffSpecs(oldRoot, newRoot)
+	var results strings.Builder
+	results.WriteString(fmt.Sprintf("# Spec diff: %s → %s\n\n", baseName, headName))
+	if len(changes) == 0 {
+		results.WriteString("No changes to operations or schemas.\n")
+		return mcp.NewToolResultText(results.String()), nil
+	}
+
+	counts := map[string]int{}
+	var breaking []string
+	for _, c := range changes {
+		counts[c.Area+" "+c.Kind]++
+		counts[c.Impact]++
+		if c.Impact != impactBreaking {
+			continue
+		}
+		// One entry per change, as counted above, with its breaking
+		// details under it
+		if c.Kind == "removed" {
+			breaking = append(breaking, fmt.Sprintf("- %s: %s removed", c.Subject, c.Area))
+			continue
+		}
+		entry := "- " + c.Subject
+		for _, d := range c.Details {
+			if d.Impact == impactBreaking {
+				entry += "\n  - " + d.Text
+			}
+		}
+		breaking = append(breaking, entry)
+	}
+	switch {
+	case counts[impactBreaking] > 0:
+		results.WriteString(fmt.Sprintf("🔴 **%d breaking** change(s): the SDKs need updating before the pin moves.\n\n", counts[impactBreaking]))
+	case counts[impactReview] > 0:
+		results.WriteString(fmt.Sprintf("🟡 Nothing breaking found, but %d change(s) need a look.\n\n", counts[impactReview]))
+	default:
+		results.WriteString("🟢 Nothing breaking: safe to move the pin.\n\n")
+	}
+	results.WriteString(fmt.Sprintf("| Impact | Changes |\n|---|---|\n| 🔴 Breaking | %d |\n| 🟡 Review | %d |\n| 🟢 Additive | %d |\n| ⚪ Neutral | %d |\n\n",
+		counts[impactBreaking], counts[impactReview], counts[impactAdditive], counts[impactNeutral]))
+
+	if len(breaking) > 0 {
+		results.WriteString(fmt.Sprintf("## Breaking (%d)\n\n%s\n\n", len(breaking), strings.Join(breaking, "\n")))
+	}
+	if params.BreakingOnly {
+		return mcp.NewToolResultText(strings.TrimRight(results.String(), "\n") + "\n"), nil
+	}
+
+	titles := map[string]string{"operation": "Operations", "schema": "Schemas", "added": "Added", "removed": "Removed", "changed": "Changed"}
+	results.WriteString("| | Added | Removed | Changed |\n|---|---|---|---|\n")
+	for _, area := range []string{"operation", "schema"} {
+		results.WriteString(fmt.Sprintf("| %s | %d | %d | %d |\n", titles[area], counts[area+" added"], counts[area+" removed"], counts[area+" changed"]))
+	}
+	results.WriteString("\n")
+
+	for _, area := range []string{"operation", "schema"} {
+		for _, kind := range []string{"added", "removed", "changed"} {
+			if counts[area+" "+kind] == 0 {
+				continue
+			}
+			results.WriteString(fmt.Sprintf("## %s %s (%d)\n\n", titles[kind], strings.ToLower(titles[area]), counts[area+" "+kind]))
+			for _, c := range changes {
+				if c.Area != area || c.Kind != kind {
+					continue
+				}
+				results.WriteString(fmt.Sprintf("- %s %s\n", impactIcons[c.Impact], c.Subject))
+				for _, d := range c.Details {
+					results.WriteString(fmt.Sprintf("  - %s %s\n", impactIcons[d.Impact], d.Text))
+				}
+			}
+			results.WriteString("\n")
+		}
+	}
+
+	return mcp.NewToolResultText(results.String()), nil
+}
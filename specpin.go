@@ -0,0 +1,190 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// pinChangeSummary counts the operation and schema changes between two
+// spec commits and lists the breaking ones.
+func pinChangeSummary(from, to string) (string, []string, error) {
+	fromRoot, err := loadSpecAt(from)
+	if err != nil {
+		return "", nil, err
+	}
+	toRoot, err := loadSpecAt(to)
+	if err != nil {
+		return "", nil, err
+	}
+	counts := map[string]map[string]int{"operation": {}, "schema": {}}
+	var breaking []string
+	for _, c := range diffSpecs(fromRoot, toRoot) {
+		counts[c.Area][c.Kind]++
+		if c.Impact == impactBreaking {
+			breaking = append(breaking, fmt.Sprintf("%s %s %s", c.Area, c.Subject, c.Kind))
+		}
+	}
+	var parts []string
+	for _, area := range []string{"operation", "schema"} {
+		if c := counts[area]; len(c) > 0 {
+			parts = append(parts, fmt.Sprintf("%ss %d added, %d removed, %d changed", area, c["added"], c["removed"], c["changed"]))
+		}
+	}
+	if len(parts) == 0 {
+		return "no operation or schema changes", nil, nil
+	}
+	return strings.Join(parts, "; "), breaking, nil
+}
+
+func (s *QuickBasePersonalMCPServer) handleBumpSpecPin(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	var params struct {
+		SDK     string `json:"sdk"`
+		To      string `json:"to"`
+		Fetch   bool   `json:"fetch"`
+		Confirm bool   `json:"confirm"`
+		Commit  bool   `json:"commit"`
+	}
+	argsData, _ := json.Marshal(request.Params.Arguments)
+	if err := json.Unmarshal(argsData, &params); err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Invalid parameters: %v", err)), nil
+	}
+	sdks := []struct{ name, path string }{{"quickbase-js", quickbaseJSPath}, {"quickbase-go", quickbaseGoPath}}
+	switch params.SDK {
+	case "", "both":
+	case "js":
+		sdks = sdks[:1]
+	case "go":
+		sdks = sdks[1:]
+	default:
+		return mcp.NewToolResultError(fmt.Sprintf("Unknown sdk: %s (use js, go or both)", params.SDK)), nil
+	}
+
+	fetchNote := ""
+	if params.Fetch {
+		if _, err := runGit(quickbaseSpecPath, "fetch", "--quiet"); err != nil {
+			fetchNote = fmt.Sprintf("⚠️ Fetch failed, using local refs: %v\n\n", err)
+		}
+	}
+	target, err := specTarget(params.To)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	targetSHA, err := runGit(quickbaseSpecPath, "rev-parse", target+"^{commit}")
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to read quickbase-spec: %v", err)), nil
+	}
+	title := "Preview"
+	if params.Confirm {
+		title = "Applied"
+	}
+	var results strings.Builder
+	results.WriteString(fmt.Sprintf("# Bump spec pin (%s)\n\n%sTarget: %s (%s)\n\n", title, fetchNote, commitLine(quickbaseSpecPath, targetSHA), target))
+	if params.To == "" && target != "HEAD" {
+		if ahead, err := runGit(quickbaseSpecPath, "rev-list", "--count", target+"..HEAD"); err == nil && ahead != "0" {
+			results.WriteString(fmt.Sprintf("The local quickbase-spec has %s commit(s) not yet pushed to %s; they aren't included. Give to: 'HEAD' to pin them anyway.\n\n", ahead, target))
+		}
+	}
+
+	pending := 0
+	for _, sdk := range sdks {
+		results.WriteString(fmt.Sprintf("## %s\n\n", sdk.name))
+		sub, ok := specSubmodule(sdk.path)
+		if !ok {
+			results.WriteString("No spec submodule\n\n")
+			continue
+		}
+		pin := specPin(sdk.path)
+		if pin == targetSHA {
+			results.WriteString(fmt.Sprintf("✅ Already pinned to %s\n\n", shortSHA(pin)))
+			continue
+		}
+		results.WriteString(fmt.Sprintf("- Submodule: %s (%s)\n", sub.path, sub.state))
+		results.WriteString(fmt.Sprintf("- Pinned: %s\n", commitLine(quickbaseSpecPath, pin)))
+		if verifyRef(quickbaseSpecPath, pin) == nil {
+			if behind, err := runGit(quickbaseSpecPath, "rev-list", "--count", pin+".."+targetSHA); err == nil {
+				results.WriteString(fmt.Sprintf("- Moves forward %s commit(s)", behind))
+				if back, _ := runGit(quickbaseSpecPath, "rev-list", "--count", targetSHA+".."+pin); back != "" && back != "0" {
+					results.WriteString(fmt.Sprintf(" and drops %s the pin has that the target doesn't", back))
+				}
+				results.WriteString("\n")
+			}
+			if summary, breaking, err := pinChangeSummary(pin, targetSHA); err != nil {
+				results.WriteString(fmt.Sprintf("- ⚠️ Couldn't diff the spec: %v\n", err))
+			} else {
+				results.WriteString(fmt.Sprintf("- Spec changes: %s\n", summary))
+				for _, b := range breaking {
+					results.WriteString(fmt.Sprintf("  - 🔴 %s\n", b))
+				}
+			}
+		} else {
+			results.WriteString(fmt.Sprintf("- ⚠️ The pin %s isn't in the local quickbase-spec, so the changes can't be summarized\n", shortSHA(pin)))
+		}
+
+		subPath := filepath.Join(sdk.path, sub.path)
+		problem := ""
+		switch {
+		case sub.state == "not initialized":
+			problem = fmt.Sprintf("the submodule isn't initialized; run `git -C %s submodule update --init %s` first", sdk.path, sub.path)
+		case sub.state == "merge conflict":
+			problem = "the submodule has a merge conflict"
+		default:
+			if dirty, err := runGit(subPath, "status", "--porcelain"); err != nil || dirty != "" {
+				problem = "the submodule has uncommitted changes"
+			} else if staged, _ := runGit(sdk.path, "diff", "--cached", "--name-only"); params.Commit && staged != "" && staged != sub.path {
+				problem = fmt.Sprintf("%s has other staged changes, so the bump can't be committed on its own", sdk.name)
+			}
+		}
+		if problem != "" {
+			results.WriteString(fmt.Sprintf("- ❌ Can't bump: %s\n\n", problem))
+			continue
+		}
+		if !params.Confirm {
+			pending++
+			results.WriteString("\n")
+			continue
+		}
+
+		// The submodule clone may not have the commit yet: try its remote,
+		// then the local quickbase-spec
+		if _, err := runGit(subPath, "cat-file", "-e", targetSHA+"^{commit}"); err != nil {
+			runGit(subPath, "fetch", "--quiet")
+		}
+		if _, err := runGit(subPath, "cat-file", "-e", targetSHA+"^{commit}"); err != nil {
+			if _, err := runGit(subPath, "fetch", "--quiet", quickbaseSpecPath, targetSHA); err != nil {
+				results.WriteString(fmt.Sprintf("- ❌ The submodule can't get %s: %v\n\n", shortSHA(targetSHA), err))
+				continue
+			}
+			results.WriteString("- ⚠️ Fetched the target from the local quickbase-spec; push it before pushing this bump\n")
+		}
+		if _, err := runGit(subPath, "checkout", "--quiet", "--detach", targetSHA); err != nil {
+			results.WriteString(fmt.Sprintf("- ❌ Checkout failed: %v\n\n", err))
+			continue
+		}
+		if _, err := runGit(sdk.path, "add", "--", sub.path); err != nil {
+			results.WriteString(fmt.Sprintf("- ❌ Staging failed: %v\n\n", err))
+			continue
+		}
+		if !params.Commit {
+			results.WriteString(fmt.Sprintf("- ✅ Checked out %s and staged %s; commit it after regenerating\n\n", shortSHA(targetSHA), sub.path))
+			continue
+		}
+		message := fmt.Sprintf("chore: bump quickbase-spec to %s", shortSHA(targetSHA))
+		if _, err := runGit(sdk.path, "commit", "--quiet", "-m", message, "--", sub.path); err != nil {
+			results.WriteString(fmt.Sprintf("- ❌ Staged, but the commit failed: %v\n\n", err))
+			continue
+		}
+		results.WriteString(fmt.Sprintf("- ✅ Committed %s\n\n", commitLine(sdk.path, "HEAD")))
+	}
+
+	if pending > 0 {
+		results.WriteString("Nothing was changed. Review the changes (spec_diff, spec_changelog), then call again with confirm: true to check out the target in each submodule and stage it (commit: true also commits it).\n")
+	} else if params.Confirm {
+		results.WriteString("Regenerate each SDK from the new spec and run its tests before pushing.\n")
+	}
+	return mcp.NewToolResultText(strings.TrimRight(results.String(), "\n") + "\n"), nil
+}
@@ -0,0 +1,188 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// opReference matches an operation in source code, by operationId (in
+// any naming convention) or by its path as a string literal with the
+// parameters filled in.
+type opReference struct {
+	key  string // normalized operationId
+	path *regexp.Regexp
+}
+
+var pathParam = regexp.MustCompile(`\{[^}]+\}`)
+
+func newOpReference(op specOperation) opReference {
+	ref := opReference{key: normalizeTerm(op.OperationID)}
+	segments := pathParam.Split(op.Path, -1)
+	for i, seg := range segments {
+		segments[i] = regexp.QuoteMeta(seg)
+	}
+	// Parameters may be interpolated (${id}, %s, + id +) so match loosely
+	expr := `['"` + "`" + `]` + strings.Join(segments, `[^'"`+"`"+`\s]*`)
+	if !strings.HasSuffix(op.Path, "}") {
+		expr += `(?:['"` + "`" + `?]|$)`
+	}
+	ref.path = regexp.MustCompile(expr)
+	return ref
+}
+
+// in reports whether src references the operation.
+func (r opReference) in(src string) bool {
+	if r.path.MatchString(src) {
+		return true
+	}
+	if r.key == "" {
+		return false
+	}
+	for _, ident := range identPattern.FindAllString(src, -1) {
+		if normalizeTerm(ident) == r.key {
+			return true
+		}
+	}
+	return false
+}
+
+// opCoverage is one spec operation a feature touches and where each SDK
+// references it.
+type opCoverage struct {
+	op      specOperation
+	reason  string
+	jsFiles []string // files referencing the operation
+	goFiles []string
+	jsElse  string // a file outside the feature referencing it, if any
+	goElse  string
+}
+
+// featureOperations finds the spec operations a feature touches: those
+// listed in its operations, those whose operationId or summary matches its
+// keywords, and those its files already reference.
+func featureOperations(ops []specOperation, name string, feature featureEntry, jsSrc, goSrc map[string]string) []opCoverage {
+	mapped := map[string]bool{}
+	for _, o := range feature.Operations {
+		mapped[normalizeTerm(o)] = true
+		mapped[strings.ToUpper(o)] = true
+	}
+	keywords := keywordPattern(append([]string{name}, feature.Keywords...))
+
+	var touched []opCoverage
+	for _, op := range ops {
+		cov := opCoverage{op: op}
+		ref := newOpReference(op)
+		for _, rel := range sortedKeys(jsSrc) {
+			if ref.in(jsSrc[rel]) {
+				cov.jsFiles = append(cov.jsFiles, rel)
+			}
+		}
+		for _, rel := range sortedKeys(goSrc) {
+			if ref.in(goSrc[rel]) {
+				cov.goFiles = append(cov.goFiles, rel)
+			}
+		}
+		switch {
+		case mapped[normalizeTerm(op.OperationID)] || mapped[op.Method+" "+op.Path]:
+			cov.reason = "mapped"
+		case name != "" && (keywords.MatchString(op.OperationID) || keywords.MatchString(op.Summary)):
+			cov.reason = "keyword"
+		case len(cov.jsFiles) > 0 || len(cov.goFiles) > 0:
+			cov.reason = "referenced"
+		default:
+			continue
+		}
+		touched = append(touched, cov)
+	}
+	return touched
+}
+
+// findElsewhere records, for operations a side doesn't reference in the
+// feature's files, the first other source file that does.
+func findElsewhere(touched []opCoverage, repoPath string, keep func(string) bool, featureFiles map[string]string, isGo bool) {
+	var missing []int
+	for i, cov := range touched {
+		if (isGo && len(cov.goFiles) == 0) || (!isGo && len(cov.jsFiles) == 0) {
+			missing = append(missing, i)
+		}
+	}
+	if len(missing) == 0 {
+		return
+	}
+	for _, rel := range listSourceFiles(repoPath, keep) {
+		if _, ok := featureFiles[rel]; ok {
+			continue
+		}
+		data, err := os.ReadFile(filepath.Join(repoPath, rel))
+		if err != nil {
+			continue
+		}
+		src := string(data)
+		for _, i := range missing {
+			cov := &touched[i]
+			if (isGo && cov.goElse != "") || (!isGo && cov.jsElse != "") {
+				continue
+			}
+			if newOpReference(cov.op).in(src) {
+				if isGo {
+					cov.goElse = rel
+				} else {
+					cov.jsElse = rel
+				}
+			}
+		}
+	}
+}
+
+// writeSpecCoverage appends the spec operations a feature touches and
+// whether each SDK references them.
+func writeSpecCoverage(results *strings.Builder, name string, feature featureEntry, jsFiles, goFiles []string, jsRef, goRef string) {
+	results.WriteString("## Spec operations\n\n")
+	root, err := loadSpec()
+	if err != nil {
+		results.WriteString(fmt.Sprintf("Spec not available: %v\n\n", err))
+		return
+	}
+
+	read := func(repoPath, ref string, files []string) map[string]string {
+		src := map[string]string{}
+		for _, rel := range files {
+			if data, err := readRepoFile(repoPath, ref, rel); err == nil {
+				src[rel] = stripComments(string(data))
+			}
+		}
+		return src
+	}
+	jsSrc, goSrc := read(quickbaseJSPath, jsRef, jsFiles), read(quickbaseGoPath, goRef, goFiles)
+
+	touched := featureOperations(specOperations(root), name, feature, jsSrc, goSrc)
+	if len(touched) == 0 {
+		results.WriteString("No spec operations matched. List them under `operations` in the feature map to track coverage.\n\n")
+		return
+	}
+	// Only the working tree is searched outside the feature's files
+	if jsRef == "" {
+		findElsewhere(touched, quickbaseJSPath, isJSSource, jsSrc, false)
+	}
+	if goRef == "" {
+		findElsewhere(touched, quickbaseGoPath, isGoSource, goSrc, true)
+	}
+
+	cell := func(files []string, elsewhere string) string {
+		switch {
+		case len(files) > 0:
+			return "✅ " + strings.Join(files, ", ")
+		case elsewhere != "":
+			return "☑️ elsewhere: " + elsewhere
+		}
+		return "❌"
+	}
+	results.WriteString("| Operation | Endpoint | Matched by | JS | Go |\n|---|---|---|---|---|\n")
+	for _, cov := range touched {
+		results.WriteString(fmt.Sprintf("| %s | %s %s | %s | %s | %s |\n", cov.op.OperationID, cov.op.Method, cov.op.Path, cov.reason, cell(cov.jsFiles, cov.jsElse), cell(cov.goFiles, cov.goElse)))
+	}
+	results.WriteString("\n")
+}
@@ -0,0 +1,203 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"gopkg.in/yaml.v3"
+)
+
+// specSearchFields are what spec_search can be narrowed to.
+var specSearchFields = []string{"any", "operation_id", "path", "tag", "schema"}
+
+// specHit is one search result and how well it matched.
+type specHit struct {
+	score   int
+	matched string
+	line    string
+}
+
+// matchScore rates how well value matches the query: exact beats prefix
+// beats substring. Case, dashes and underscores are ignored, so
+// "delete_records" finds deleteRecords.
+func matchScore(value, query string) int {
+	v, q := normalizeTerm(value), normalizeTerm(query)
+	switch {
+	case v == "" || q == "":
+		return 0
+	case v == q:
+		return 100
+	case strings.HasPrefix(v, q):
+		return 60
+	case strings.Contains(v, q):
+		return 30
+	}
+	return 0
+}
+
+// pathScore matches paths on their raw text, so "/records" and "{appId}"
+// work, and ranks a query equal to a whole segment above a partial one.
+func pathScore(path, query string) int {
+	p, q := strings.ToLower(path), strings.ToLower(query)
+	switch {
+	case p == q:
+		return 100
+	case strings.Contains(p+"/", q+"/") && strings.HasPrefix(q, "/"):
+		return 60
+	case strings.Contains(p, q):
+		return 30
+	}
+	return matchScore(path, query) / 2
+}
+
+// schemaSummary describes a component schema in one line: its type, its
+// properties and the start of its description.
+func schemaSummary(root, schema *yaml.Node) string {
+	schema, _ = resolveRef(root, schema)
+	var parts []string
+	if t := schemaType(schema); t != "" {
+		parts = append(parts, t)
+	}
+	if props := mappingValue(schema, "properties"); props != nil {
+		names := mappingKeys(props)
+		list := strings.Join(names, ", ")
+		if len(names) > 6 {
+			list = strings.Join(names[:6], ", ") + ", …"
+		}
+		parts = append(parts, fmt.Sprintf("%d properties (%s)", len(names), list))
+	}
+	for _, combo := range []string{"allOf", "oneOf", "anyOf"} {
+		if v := mappingValue(schema, combo); v != nil {
+			parts = append(parts, fmt.Sprintf("%s of %d", combo, len(v.Content)))
+		}
+	}
+	line := strings.Join(parts, ", ")
+	if d := mappingValue(schema, "description"); d != nil && d.Value != "" {
+		desc, _, _ := strings.Cut(strings.TrimSpace(d.Value), "\n")
+		line += " — " + desc
+	}
+	return line
+}
+
+func (s *QuickBasePersonalMCPServer) handleSpecSearch(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	var params struct {
+		Query string `json:"query"`
+		In    string `json:"in"`
+		Limit int    `json:"limit"`
+	}
+	argsData, _ := json.Marshal(request.Params.Arguments)
+	if err := json.Unmarshal(argsData, &params); err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Invalid parameters: %v", err)), nil
+	}
+	params.Query = strings.TrimSpace(params.Query)
+	if params.Query == "" {
+		return mcp.NewToolResultError("query is required"), nil
+	}
+	if params.In == "" {
+		params.In = "any"
+	}
+	if !containsString(specSearchFields, params.In) {
+		return mcp.NewToolResultError(fmt.Sprintf("Unknown field: %s (use %s)", params.In, strings.Join(specSearchFields, ", "))), nil
+	}
+	if params.Limit <= 0 {
+		params.Limit = 20
+	}
+
+	root, err := loadSpec()
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to load spec: %v", err)), nil
+	}
+	want := func(field string) bool { return params.In == "any" || params.In == field }
+
+	var ops []specHit
+	if params.In != "schema" {
+		for _, op := range specOperations(root) {
+			best := specHit{}
+			consider := func(score int, matched string) {
+				if score > best.score {
+					best.score, best.matched = score, matched
+				}
+			}
+			if want("operation_id") {
+				consider(matchScore(op.OperationID, params.Query), "operationId")
+			}
+			if want("path") {
+				consider(pathScore(op.Path, params.Query), "path")
+			}
+			if want("tag") {
+				for _, tag := range op.Tags {
+					consider(matchScore(tag, params.Query)*9/10, "tag "+tag)
+				}
+			}
+			// Summaries only break ties and catch words nothing else has
+			if params.In == "any" && strings.Contains(strings.ToLower(op.Summary), strings.ToLower(params.Query)) {
+				consider(10, "summary")
+			}
+			if best.score == 0 {
+				continue
+			}
+			name := op.OperationID
+			if name == "" {
+				name = "(no operationId)"
+			}
+			line := fmt.Sprintf("- **%s** `%s %s`", name, op.Method, op.Path)
+			if len(op.Tags) > 0 {
+				line += " [" + strings.Join(op.Tags, ", ") + "]"
+			}
+			if op.Deprecated {
+				line += " (deprecated)"
+			}
+			if op.Summary != "" {
+				line += " — " + op.Summary
+			}
+			best.line = line + fmt.Sprintf("\n  matched %s · `spec_query` `.paths.%q.%s`", best.matched, op.Path, strings.ToLower(op.Method))
+			ops = append(ops, best)
+		}
+	}
+
+	var schemas []specHit
+	if want("schema") {
+		if all := mappingValue(mappingValue(root, "components"), "schemas"); all != nil {
+			for j := 0; j+1 < len(all.Content); j += 2 {
+				name := all.Content[j].Value
+				score := matchScore(name, params.Query)
+				if score == 0 {
+					continue
+				}
+				line := fmt.Sprintf("- **%s** %s\n  `spec_query` `.components.schemas.%s`", name, schemaSummary(root, all.Content[j+1]), name)
+				schemas = append(schemas, specHit{score: score, matched: "name", line: line})
+			}
+		}
+	}
+
+	if len(ops)+len(schemas) == 0 {
+		return mcp.NewToolResultText(fmt.Sprintf("No operations or schemas match %q (searched %s)", params.Query, params.In)), nil
+	}
+
+	var results strings.Builder
+	results.WriteString(fmt.Sprintf("# Spec search: %s\n\n", params.Query))
+	for _, section := range []struct {
+		title string
+		hits  []specHit
+	}{{"Operations", ops}, {"Schemas", schemas}} {
+		if len(section.hits) == 0 {
+			continue
+		}
+		sort.SliceStable(section.hits, func(a, b int) bool { return section.hits[a].score > section.hits[b].score })
+		results.WriteString(fmt.Sprintf("## %s (%d)\n\n", section.title, len(section.hits)))
+		for i, h := range section.hits {
+			if i == params.Limit {
+				results.WriteString(fmt.Sprintf("- … %d more (raise limit or narrow with in)\n", len(section.hits)-params.Limit))
+				break
+			}
+			results.WriteString(h.line + "\n")
+		}
+		results.WriteString("\n")
+	}
+
+	return mcp.NewToolResultText(results.String()), nil
+}
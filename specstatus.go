@@ -0,0 +1,142 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// specTarget picks what the pins are measured against: the given ref, else
+// the spec branch's upstream (what's been pushed), else its HEAD.
+func specTarget(ref string) (string, error) {
+	if ref != "" {
+		return ref, verifyRef(quickbaseSpecPath, ref)
+	}
+	if upstream, err := runGit(quickbaseSpecPath, "rev-parse", "--abbrev-ref", "@{upstream}"); err == nil && upstream != "" {
+		return upstream, nil
+	}
+	return "HEAD", nil
+}
+
+// commitLine is a commit's short SHA, date and subject.
+func commitLine(repoPath, ref string) string {
+	out, err := runGit(repoPath, "log", "-1", "--format=%h %ad %s", "--date=short", ref)
+	if err != nil {
+		return shortSHA(ref)
+	}
+	return out
+}
+
+func (s *QuickBasePersonalMCPServer) handleSpecStatus(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	var params struct {
+		Target string `json:"target"`
+		Fetch  bool   `json:"fetch"`
+	}
+	argsData, _ := json.Marshal(request.Params.Arguments)
+	if err := json.Unmarshal(argsData, &params); err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Invalid parameters: %v", err)), nil
+	}
+
+	var results strings.Builder
+	results.WriteString("# Spec Status\n\n")
+	if params.Fetch {
+		if _, err := runGit(quickbaseSpecPath, "fetch", "--quiet"); err != nil {
+			results.WriteString(fmt.Sprintf("⚠️ Fetch failed, using local refs: %v\n\n", err))
+		}
+	}
+	target, err := specTarget(params.Target)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	targetSHA, err := runGit(quickbaseSpecPath, "rev-parse", target+"^{commit}")
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to read quickbase-spec: %v", err)), nil
+	}
+	results.WriteString(fmt.Sprintf("Latest quickbase-spec (%s): %s\n\n", target, commitLine(quickbaseSpecPath, targetSHA)))
+	if local, _ := runGit(quickbaseSpecPath, "rev-parse", "HEAD"); params.Target == "" && local != targetSHA {
+		if ahead, err := runGit(quickbaseSpecPath, "rev-list", "--count", target+"..HEAD"); err == nil && ahead != "0" {
+			results.WriteString(fmt.Sprintf("The local checkout has %s commit(s) not yet pushed to %s; pins are measured against %s.\n\n", ahead, target, target))
+		}
+	}
+	targetRoot, err := loadSpecAt(targetSHA)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to load spec at %s: %v", target, err)), nil
+	}
+
+	var pins []string
+	var actions []string
+	results.WriteString("| SDK | Pinned | Commits behind | Operations behind |\n|---|---|---|---|\n")
+	var details strings.Builder
+	for _, repo := range []struct{ name, path, pinRef string }{{"quickbase-js", quickbaseJSPath, "js-pin"}, {"quickbase-go", quickbaseGoPath, "go-pin"}} {
+		sub, ok := specSubmodule(repo.path)
+		if !ok {
+			results.WriteString(fmt.Sprintf("| %s | no spec submodule | — | — |\n", repo.name))
+			continue
+		}
+		pin := specPin(repo.path)
+		pins = append(pins, pin)
+		details.WriteString(fmt.Sprintf("## %s\n\n- Submodule: %s (%s)\n", repo.name, sub.path, sub.state))
+		if sub.commit != pin {
+			details.WriteString(fmt.Sprintf("- Checked out at %s, but HEAD records %s\n", shortSHA(sub.commit), shortSHA(pin)))
+		}
+
+		if verifyRef(quickbaseSpecPath, pin) != nil {
+			results.WriteString(fmt.Sprintf("| %s | %s | ? | ? |\n", repo.name, shortSHA(pin)))
+			details.WriteString(fmt.Sprintf("- Pin %s is not in the local quickbase-spec history; fetch it (or pass fetch) to measure drift\n\n", shortSHA(pin)))
+			continue
+		}
+		details.WriteString(fmt.Sprintf("- Pinned: %s\n", commitLine(quickbaseSpecPath, pin)))
+		behind, _ := runGit(quickbaseSpecPath, "rev-list", "--count", pin+".."+targetSHA)
+		ahead, _ := runGit(quickbaseSpecPath, "rev-list", "--count", targetSHA+".."+pin)
+		if ahead != "" && ahead != "0" {
+			details.WriteString(fmt.Sprintf("- ⚠️ The pin has %s commit(s) that aren't in %s (pinned to an unmerged branch?)\n", ahead, target))
+		}
+
+		opsCell := "0"
+		if behind != "0" {
+			if pinRoot, err := loadSpecAt(pin); err != nil {
+				opsCell = "?"
+				details.WriteString(fmt.Sprintf("- Failed to load the spec at the pin: %v\n", err))
+			} else {
+				counts := map[string]int{}
+				for _, c := range diffSpecs(pinRoot, targetRoot) {
+					if c.Area == "operation" {
+						counts[c.Kind]++
+						counts[c.Impact]++
+					}
+				}
+				opsCell = fmt.Sprintf("%d added, %d removed, %d changed", counts["added"], counts["removed"], counts["changed"])
+				if counts[impactBreaking] > 0 {
+					opsCell += fmt.Sprintf(" (🔴 %d breaking)", counts[impactBreaking])
+				}
+			}
+			if missing, err := runGit(quickbaseSpecPath, "log", "--format=  - %h %ad %s", "--date=short", "-n", "10", pin+".."+targetSHA); err == nil && missing != "" {
+				details.WriteString(fmt.Sprintf("- Missing commits:\n%s\n", missing))
+				if n := len(strings.Split(missing, "\n")); behind != fmt.Sprint(n) {
+					details.WriteString(fmt.Sprintf("  - … and more (%s in all)\n", behind))
+				}
+			}
+			actions = append(actions, fmt.Sprintf("Review with spec_diff base %s, then bump_spec_pin sdk %s and regenerate", repo.pinRef, strings.TrimPrefix(repo.name, "quickbase-")))
+		} else {
+			details.WriteString("- ✅ Up to date\n")
+		}
+		details.WriteString("\n")
+		results.WriteString(fmt.Sprintf("| %s | %s | %s | %s |\n", repo.name, shortSHA(pin), behind, opsCell))
+	}
+	results.WriteString("\n")
+	if len(pins) == 2 && pins[0] != pins[1] {
+		results.WriteString(fmt.Sprintf("⚠️ The SDKs pin different spec commits (%s vs %s), so they're generated from different APIs.\n\n", shortSHA(pins[0]), shortSHA(pins[1])))
+	}
+	results.WriteString(details.String())
+
+	if len(actions) > 0 {
+		results.WriteString("## Next steps\n\n")
+		for _, a := range actions {
+			results.WriteString("- " + a + "\n")
+		}
+	}
+	return mcp.NewToolResultText(results.String()), nil
+}
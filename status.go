@@ -0,0 +1,199 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+const (
+	probeHistoryBucket = "probe_history"
+	probeHistoryLimit  = 50
+)
+
+// Status endpoints - override with QB_STATUS_URL / QB_PROBE_URL
+var (
+	quickbaseStatusURL = envOr("QB_STATUS_URL", "https://status.quickbase.com/api/v2/summary.json")
+	quickbaseProbeURL  = envOr("QB_PROBE_URL", "https://api.quickbase.com/v1/apps")
+)
+
+func envOr(key, fallback string) string {
+	if v := os.Getenv(key); v != "" {
+		return v
+	}
+	return fallback
+}
+
+// statusSummary is the subset of the Statuspage summary.json we use.
+type statusSummary struct {
+	Status struct {
+		Indicator   string `json:"indicator"`
+		Description string `json:"description"`
+	} `json:"status"`
+	Components []struct {
+		Name   string `json:"name"`
+		Status string `json:"status"`
+	} `json:"components"`
+	Incidents []struct {
+		Name      string `json:"name"`
+		Status    string `json:"status"`
+		Impact    string `json:"impact"`
+		UpdatedAt string `json:"updated_at"`
+	} `json:"incidents"`
+}
+
+// probeResult is one reachability check against the API.
+type probeResult struct {
+	Timestamp  time.Time `json:"timestamp"`
+	StatusCode int       `json:"status_code"`
+	LatencyMs  int64     `json:"latency_ms"`
+	Error      string    `json:"error,omitempty"`
+}
+
+// healthy reports whether the API answered at all. An unauthenticated
+// probe is expected to get a 401, so any non-5xx response counts.
+func (p probeResult) healthy() bool {
+	return p.Error == "" && p.StatusCode > 0 && p.StatusCode < 500
+}
+
+var statusHTTPClient = &http.Client{Timeout: 10 * time.Second}
+
+// fetchStatusSummary reads the Quickbase status page feed.
+func fetchStatusSummary(ctx context.Context) (*statusSummary, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, quickbaseStatusURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := statusHTTPClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("status feed returned %s", resp.Status)
+	}
+	var summary statusSummary
+	if err := json.NewDecoder(resp.Body).Decode(&summary); err != nil {
+		return nil, fmt.Errorf("failed to decode status feed: %w", err)
+	}
+	return &summary, nil
+}
+
+// probeAPI makes an unauthenticated request to the API and records it.
+func (s *QuickBasePersonalMCPServer) probeAPI(ctx context.Context) probeResult {
+	result := probeResult{Timestamp: time.Now()}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, quickbaseProbeURL, nil)
+	if err != nil {
+		result.Error = err.Error()
+		return result
+	}
+	start := time.Now()
+	resp, err := statusHTTPClient.Do(req)
+	result.LatencyMs = time.Since(start).Milliseconds()
+	if err != nil {
+		result.Error = err.Error()
+	} else {
+		result.StatusCode = resp.StatusCode
+		resp.Body.Close()
+	}
+
+	if err := s.store.appendCapped(probeHistoryBucket, result, probeHistoryLimit); err != nil {
+		s.logger.Printf("Failed to record probe result: %v", err)
+	}
+	return result
+}
+
+// recentProbes returns up to n probe results, newest first.
+func (s *QuickBasePersonalMCPServer) recentProbes(n int) []probeResult {
+	var probes []probeResult
+	s.store.each(probeHistoryBucket, func(key string, data []byte) error {
+		var p probeResult
+		if json.Unmarshal(data, &p) == nil {
+			probes = append(probes, p)
+		}
+		return nil
+	})
+	var recent []probeResult
+	for i := len(probes) - 1; i >= 0 && len(recent) < n; i-- {
+		recent = append(recent, probes[i])
+	}
+	return recent
+}
+
+func (s *QuickBasePersonalMCPServer) handleQBStatus(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	var results strings.Builder
+	results.WriteString("# Quickbase Platform Status\n\n")
+
+	degraded := false
+
+	results.WriteString("## Status page\n\n")
+	summary, err := fetchStatusSummary(ctx)
+	if err != nil {
+		results.WriteString(fmt.Sprintf("⚠️ Could not read %s: %v\n\n", quickbaseStatusURL, err))
+	} else {
+		indicator := summary.Status.Indicator
+		mark := "✅"
+		if indicator != "" && indicator != "none" {
+			mark = "❌"
+			degraded = true
+		}
+		results.WriteString(fmt.Sprintf("%s %s (indicator: %s)\n\n", mark, summary.Status.Description, indicator))
+		for _, c := range summary.Components {
+			if c.Status != "operational" {
+				results.WriteString(fmt.Sprintf("- %s: %s\n", c.Name, c.Status))
+			}
+		}
+		for _, inc := range summary.Incidents {
+			results.WriteString(fmt.Sprintf("- Incident: %s (%s, impact %s, updated %s)\n", inc.Name, inc.Status, inc.Impact, inc.UpdatedAt))
+		}
+		results.WriteString("\n")
+	}
+
+	results.WriteString("## API probe\n\n")
+	probe := s.probeAPI(ctx)
+	if probe.healthy() {
+		results.WriteString(fmt.Sprintf("✅ %s answered HTTP %d in %dms\n\n", quickbaseProbeURL, probe.StatusCode, probe.LatencyMs))
+	} else {
+		degraded = true
+		if probe.Error != "" {
+			results.WriteString(fmt.Sprintf("❌ %s failed after %dms: %s\n\n", quickbaseProbeURL, probe.LatencyMs, probe.Error))
+		} else {
+			results.WriteString(fmt.Sprintf("❌ %s answered HTTP %d in %dms\n\n", quickbaseProbeURL, probe.StatusCode, probe.LatencyMs))
+		}
+	}
+
+	results.WriteString("## Recent probes\n\n")
+	recent := s.recentProbes(10)
+	failures := 0
+	for _, p := range recent {
+		mark := "✅"
+		if !p.healthy() {
+			mark = "❌"
+			failures++
+		}
+		detail := fmt.Sprintf("HTTP %d", p.StatusCode)
+		if p.Error != "" {
+			detail = p.Error
+		}
+		results.WriteString(fmt.Sprintf("- %s %s %s, %dms\n", mark, p.Timestamp.Local().Format("2006-01-02 15:04:05"), detail, p.LatencyMs))
+	}
+	results.WriteString("\n")
+
+	if err != nil && probe.Error != "" {
+		results.WriteString("**Verdict: UNREACHABLE** - neither the status page nor the API answered; check your own network before blaming the platform or the SDKs.\n")
+	} else if degraded {
+		results.WriteString("**Verdict: DEGRADED** - skip contract tests and seed jobs; failures now are likely the platform, not the SDKs.\n")
+	} else if failures > len(recent)/2 {
+		results.WriteString("**Verdict: UNSTABLE** - the platform is up now but most recent probes failed.\n")
+	} else {
+		results.WriteString("**Verdict: OK** - safe to run live tests.\n")
+	}
+
+	return mcp.NewToolResultText(results.String()), nil
+}
@@ -0,0 +1,195 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	bolt "go.etcd.io/bbolt"
+)
+
+// stateStore is a small bbolt-backed key/value store for server state
+// (search history, saved searches, ...). The database is opened per
+// operation so several server instances can share it without holding
+// the file lock for their whole lifetime.
+type stateStore struct {
+	path string
+}
+
+func newStateStore(path string) *stateStore {
+	return &stateStore{path: path}
+}
+
+func (st *stateStore) open() (*bolt.DB, error) {
+	if err := os.MkdirAll(filepath.Dir(st.path), 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create state directory: %w", err)
+	}
+	db, err := bolt.Open(st.path, 0o600, &bolt.Options{Timeout: 2 * time.Second})
+	if err != nil {
+		return nil, fmt.Errorf("failed to open state store %s: %w", st.path, err)
+	}
+	return db, nil
+}
+
+// update runs fn in a read-write transaction.
+func (st *stateStore) update(fn func(tx *bolt.Tx) error) error {
+	db, err := st.open()
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+	return db.Update(fn)
+}
+
+// view runs fn in a read-only transaction.
+func (st *stateStore) view(fn func(tx *bolt.Tx) error) error {
+	db, err := st.open()
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+	return db.View(fn)
+}
+
+// put stores v as JSON under bucket/key.
+func (st *stateStore) put(bucket, key string, v interface{}) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	return st.update(func(tx *bolt.Tx) error {
+		b, err := tx.CreateBucketIfNotExists([]byte(bucket))
+		if err != nil {
+			return err
+		}
+		return b.Put([]byte(key), data)
+	})
+}
+
+// get decodes bucket/key into v. It reports false if the key is missing.
+func (st *stateStore) get(bucket, key string, v interface{}) (bool, error) {
+	found := false
+	err := st.view(func(tx *bolt.Tx) error {
+		b := tx.Bucket([]byte(bucket))
+		if b == nil {
+			return nil
+		}
+		data := b.Get([]byte(key))
+		if data == nil {
+			return nil
+		}
+		found = true
+		return json.Unmarshal(data, v)
+	})
+	return found, err
+}
+
+// delete removes bucket/key. It reports false if the key was missing.
+func (st *stateStore) delete(bucket, key string) (bool, error) {
+	found := false
+	err := st.update(func(tx *bolt.Tx) error {
+		b := tx.Bucket([]byte(bucket))
+		if b == nil || b.Get([]byte(key)) == nil {
+			return nil
+		}
+		found = true
+		return b.Delete([]byte(key))
+	})
+	return found, err
+}
+
+// each calls fn for every entry in bucket in key order.
+func (st *stateStore) each(bucket string, fn func(key string, data []byte) error) error {
+	return st.view(func(tx *bolt.Tx) error {
+		b := tx.Bucket([]byte(bucket))
+		if b == nil {
+			return nil
+		}
+		return b.ForEach(func(k, v []byte) error {
+			return fn(string(k), v)
+		})
+	})
+}
+
+// appendCapped adds v to an append-only bucket keyed by insertion order and
+// drops the oldest entries once the bucket holds more than max.
+func (st *stateStore) appendCapped(bucket string, v interface{}, max int) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	return st.update(func(tx *bolt.Tx) error {
+		b, err := tx.CreateBucketIfNotExists([]byte(bucket))
+		if err != nil {
+			return err
+		}
+		seq, err := b.NextSequence()
+		if err != nil {
+			return err
+		}
+		if err := b.Put([]byte(sequenceKey(seq)), data); err != nil {
+			return err
+		}
+		var keys [][]byte
+		c := b.Cursor()
+		for k, _ := c.First(); k != nil; k, _ = c.Next() {
+			keys = append(keys, append([]byte(nil), k...))
+		}
+		for i := 0; i < len(keys)-max; i++ {
+			if err := b.Delete(keys[i]); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// insert stores the value built for the bucket's next sequence number and
+// returns that number. Keys are zero-padded so they sort numerically.
+func (st *stateStore) insert(bucket string, build func(id uint64) interface{}) (uint64, error) {
+	var id uint64
+	err := st.update(func(tx *bolt.Tx) error {
+		b, err := tx.CreateBucketIfNotExists([]byte(bucket))
+		if err != nil {
+			return err
+		}
+		if id, err = b.NextSequence(); err != nil {
+			return err
+		}
+		data, err := json.Marshal(build(id))
+		if err != nil {
+			return err
+		}
+		return b.Put([]byte(sequenceKey(id)), data)
+	})
+	return id, err
+}
+
+// trim drops the oldest entries of a bucket keyed by insertion order once
+// it holds more than max.
+func (st *stateStore) trim(bucket string, max int) error {
+	return st.update(func(tx *bolt.Tx) error {
+		b := tx.Bucket([]byte(bucket))
+		if b == nil {
+			return nil
+		}
+		var keys [][]byte
+		c := b.Cursor()
+		for k, _ := c.First(); k != nil; k, _ = c.Next() {
+			keys = append(keys, append([]byte(nil), k...))
+		}
+		for i := 0; i < len(keys)-max; i++ {
+			if err := b.Delete(keys[i]); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// sequenceKey formats a sequence number as a sortable key.
+func sequenceKey(id uint64) string {
+	return fmt.Sprintf("%020d", id)
+}
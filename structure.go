@@ -0,0 +1,456 @@
+package main
+
+import (
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// apiSymbol is an exported function, method or type with the parts of its
+// shape that should match across SDKs.
+type apiSymbol struct {
+	name      string // as declared, methods as Type.method
+	kind      string // "func", "method" or "type"
+	file      string
+	signature string
+	params    []string // parameter names, context.Context dropped
+	fields    []string // fields and methods of option/interface types
+	errors    bool     // Go: returns error; JS: throws or returns an error type
+}
+
+// symbolKey normalizes a symbol name so getToken and GetToken match. JS
+// constructors are keyed like Go's NewType constructor functions.
+func symbolKey(name string) string {
+	if typ, ok := strings.CutSuffix(name, ".constructor"); ok {
+		return normalizeTerm("New" + typ)
+	}
+	parts := strings.Split(name, ".")
+	for i, p := range parts {
+		parts[i] = normalizeTerm(p)
+	}
+	return strings.Join(parts, ".")
+}
+
+var (
+	jsFuncStart      = regexp.MustCompile(`(?m)^[ \t]*export\s+(?:default\s+)?(?:async\s+)?function\*?\s+([A-Za-z_$][\w$]*)\s*(?:<[^>]*>)?\s*\(`)
+	jsArrowStart     = regexp.MustCompile(`(?m)^[ \t]*export\s+const\s+([A-Za-z_$][\w$]*)\s*(?::[^=\n]+)?=\s*(?:async\s+)?\(`)
+	jsClassStart     = regexp.MustCompile(`(?m)^[ \t]*export\s+(?:default\s+)?(?:abstract\s+)?class\s+([A-Za-z_$][\w$]*)[^{]*\{`)
+	jsShapeStart     = regexp.MustCompile(`(?m)^[ \t]*export\s+(?:interface\s+([A-Za-z_$][\w$]*)[^{=]*|type\s+([A-Za-z_$][\w$]*)\s*(?:<[^>]*>)?\s*=\s*)\{`)
+	jsMemberMethod   = regexp.MustCompile(`^\s*(?:(public|private|protected)\s+)?(?:static\s+)?(?:readonly\s+)?(?:async\s+)?(#?[A-Za-z_$][\w$]*)\??\s*(?:<[^>\n]*>)?\s*\(`)
+	jsMemberField    = regexp.MustCompile(`^\s*(?:readonly\s+)?([A-Za-z_$][\w$]*)\??\s*:`)
+	jsParamModifiers = regexp.MustCompile(`^(?:(?:public|private|protected|readonly)\s+)+`)
+)
+
+// matchClose returns the index of the bracket closing the one at open,
+// skipping string literals, or -1.
+func matchClose(src string, open int) int {
+	pairs := map[byte]byte{'(': ')', '{': '}', '[': ']', '<': '>'}
+	openCh := src[open]
+	closeCh := pairs[openCh]
+	depth := 0
+	for i := open; i < len(src); i++ {
+		switch c := src[i]; c {
+		case '"', '\'', '`':
+			for i++; i < len(src) && src[i] != c; i++ {
+				if src[i] == '\\' {
+					i++
+				}
+			}
+		case openCh:
+			depth++
+		case closeCh:
+			depth--
+			if depth == 0 {
+				return i
+			}
+		}
+	}
+	return -1
+}
+
+// splitTopLevel splits s on commas that aren't nested in brackets.
+func splitTopLevel(s string) []string {
+	var parts []string
+	depth, start := 0, 0
+	for i := 0; i < len(s); i++ {
+		switch s[i] {
+		case '(', '{', '[', '<':
+			depth++
+		case ')', '}', ']', '>':
+			if i > 0 && s[i] == '>' && s[i-1] == '=' {
+				continue // arrow in a function type
+			}
+			depth--
+		case ',':
+			if depth == 0 {
+				parts = append(parts, s[start:i])
+				start = i + 1
+			}
+		}
+	}
+	parts = append(parts, s[start:])
+	var trimmed []string
+	for _, p := range parts {
+		if p = strings.TrimSpace(p); p != "" {
+			trimmed = append(trimmed, p)
+		}
+	}
+	return trimmed
+}
+
+// jsParamNames extracts parameter names from a TS parameter list.
+// Destructured parameters are named after their type.
+func jsParamNames(list string) []string {
+	var names []string
+	for _, p := range splitTopLevel(list) {
+		p = jsParamModifiers.ReplaceAllString(p, "")
+		p = strings.TrimPrefix(p, "...")
+		if strings.HasPrefix(p, "{") || strings.HasPrefix(p, "[") {
+			if idx := strings.LastIndex(p, ":"); idx >= 0 {
+				names = append(names, strings.TrimSpace(p[idx+1:]))
+			} else {
+				names = append(names, "options")
+			}
+			continue
+		}
+		end := strings.IndexAny(p, "?:=")
+		if end < 0 {
+			end = len(p)
+		}
+		names = append(names, strings.TrimSpace(p[:end]))
+	}
+	return names
+}
+
+// jsCallable parses the parameters, return type and body of a function
+// whose parameter list opens at src[open].
+func jsCallable(src string, open int) (params, ret, body string, ok bool) {
+	closeParen := matchClose(src, open)
+	if closeParen < 0 {
+		return "", "", "", false
+	}
+	params = src[open+1 : closeParen]
+	rest := src[closeParen+1:]
+	brace := strings.IndexAny(rest, "{;")
+	if brace < 0 {
+		return params, "", "", true
+	}
+	ret = strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(rest[:brace]), ":")), "=>"))
+	ret = strings.TrimSpace(strings.TrimSuffix(ret, "=>"))
+	if rest[brace] == '{' {
+		if end := matchClose(rest, brace); end >= 0 {
+			body = rest[brace : end+1]
+		}
+	}
+	return params, ret, body, true
+}
+
+// jsSignalsError reports whether a JS function surfaces errors: it throws,
+// rejects or declares an error-ish return type.
+func jsSignalsError(ret, body string) bool {
+	return strings.Contains(body, "throw ") || strings.Contains(body, "reject(") || strings.Contains(ret, "Error") || strings.Contains(ret, "Result")
+}
+
+// jsMembers returns the depth-0 lines of a class, interface or object type
+// body, so nested method bodies are skipped.
+func jsMembers(body string) []string {
+	var members []string
+	depth := 0
+	for _, line := range strings.Split(body, "\n") {
+		if depth == 0 {
+			members = append(members, line)
+		}
+		depth += strings.Count(line, "{") - strings.Count(line, "}")
+		depth += strings.Count(line, "(") - strings.Count(line, ")")
+	}
+	return members
+}
+
+// jsSymbols extracts exported functions, classes (with public methods) and
+// object-shaped types from TS source. It is regex-based, so unusual
+// formatting can hide a symbol.
+func jsSymbols(file, src string) []apiSymbol {
+	src = stripComments(src)
+	var symbols []apiSymbol
+	addFunc := func(name, kind string, open int, text string) {
+		params, ret, body, ok := jsCallable(text, open)
+		if !ok {
+			return
+		}
+		sig := fmt.Sprintf("%s(%s)", name[strings.LastIndex(name, ".")+1:], strings.Join(strings.Fields(params), " "))
+		if ret != "" {
+			sig += ": " + ret
+		}
+		symbols = append(symbols, apiSymbol{name: name, kind: kind, file: file, signature: sig, params: jsParamNames(params), errors: jsSignalsError(ret, body)})
+	}
+
+	for _, re := range []*regexp.Regexp{jsFuncStart, jsArrowStart} {
+		for _, m := range re.FindAllStringSubmatchIndex(src, -1) {
+			addFunc(src[m[2]:m[3]], "func", m[1]-1, src)
+		}
+	}
+
+	for _, m := range jsClassStart.FindAllStringSubmatchIndex(src, -1) {
+		class := src[m[2]:m[3]]
+		end := matchClose(src, m[1]-1)
+		if end < 0 {
+			continue
+		}
+		body := src[m[1]:end]
+		symbols = append(symbols, apiSymbol{name: class, kind: "type", file: file, signature: "class " + class})
+		offset := 0
+		for _, line := range strings.Split(body, "\n") {
+			lineStart := offset
+			offset += len(line) + 1
+			mm := jsMemberMethod.FindStringSubmatchIndex(line)
+			if mm == nil || !isDepthZero(body, lineStart) {
+				continue
+			}
+			visibility, method := "", line[mm[4]:mm[5]]
+			if mm[2] >= 0 {
+				visibility = line[mm[2]:mm[3]]
+			}
+			if visibility == "private" || visibility == "protected" || strings.HasPrefix(method, "#") || jsMethodKeywords[method] && method != "constructor" {
+				continue
+			}
+			addFunc(class+"."+method, "method", lineStart+mm[1]-1, body)
+		}
+	}
+
+	for _, m := range jsShapeStart.FindAllStringSubmatchIndex(src, -1) {
+		name := ""
+		if m[2] >= 0 {
+			name = src[m[2]:m[3]]
+		} else {
+			name = src[m[4]:m[5]]
+		}
+		end := matchClose(src, m[1]-1)
+		if end < 0 {
+			continue
+		}
+		sym := apiSymbol{name: name, kind: "type", file: file, signature: "type " + name}
+		for _, line := range jsMembers(src[m[1]:end]) {
+			if fm := jsMemberMethod.FindStringSubmatch(line); fm != nil {
+				sym.fields = append(sym.fields, fm[2])
+			} else if fm := jsMemberField.FindStringSubmatch(line); fm != nil {
+				sym.fields = append(sym.fields, fm[1])
+			}
+		}
+		symbols = append(symbols, sym)
+	}
+	return symbols
+}
+
+// isDepthZero reports whether pos is directly inside body, not nested in
+// braces or parentheses.
+func isDepthZero(body string, pos int) bool {
+	depth := 0
+	for i := 0; i < pos; i++ {
+		switch body[i] {
+		case '{', '(':
+			depth++
+		case '}', ')':
+			depth--
+		}
+	}
+	return depth == 0
+}
+
+// goSymbols extracts exported functions, methods and types from Go source.
+func goSymbols(file string, src []byte) []apiSymbol {
+	fset := token.NewFileSet()
+	f, err := parser.ParseFile(fset, file, src, parser.SkipObjectResolution)
+	if err != nil {
+		return nil
+	}
+	var symbols []apiSymbol
+	for _, decl := range f.Decls {
+		switch d := decl.(type) {
+		case *ast.FuncDecl:
+			if !d.Name.IsExported() {
+				continue
+			}
+			sym := apiSymbol{name: d.Name.Name, kind: "func", file: file}
+			if d.Recv != nil && len(d.Recv.List) > 0 {
+				recv := receiverName(d.Recv.List[0].Type)
+				if !ast.IsExported(recv) {
+					continue
+				}
+				sym.name, sym.kind = recv+"."+d.Name.Name, "method"
+			}
+			sym.signature = d.Name.Name + strings.TrimPrefix(types.ExprString(d.Type), "func")
+			for _, field := range d.Type.Params.List {
+				if types.ExprString(field.Type) == "context.Context" {
+					continue
+				}
+				if len(field.Names) == 0 {
+					sym.params = append(sym.params, types.ExprString(field.Type))
+				}
+				for _, n := range field.Names {
+					sym.params = append(sym.params, n.Name)
+				}
+			}
+			if res := d.Type.Results; res != nil && len(res.List) > 0 {
+				sym.errors = types.ExprString(res.List[len(res.List)-1].Type) == "error"
+			}
+			symbols = append(symbols, sym)
+		case *ast.GenDecl:
+			for _, spec := range d.Specs {
+				ts, ok := spec.(*ast.TypeSpec)
+				if !ok || !ts.Name.IsExported() {
+					continue
+				}
+				sym := apiSymbol{name: ts.Name.Name, kind: "type", file: file, signature: "type " + ts.Name.Name}
+				var fields *ast.FieldList
+				switch t := ts.Type.(type) {
+				case *ast.StructType:
+					fields = t.Fields
+				case *ast.InterfaceType:
+					fields = t.Methods
+				}
+				if fields != nil {
+					for _, field := range fields.List {
+						if len(field.Names) == 0 {
+							sym.fields = append(sym.fields, receiverName(field.Type))
+						}
+						for _, n := range field.Names {
+							if n.IsExported() {
+								sym.fields = append(sym.fields, n.Name)
+							}
+						}
+					}
+				}
+				symbols = append(symbols, sym)
+			}
+		}
+	}
+	return symbols
+}
+
+// diffNames returns the names in a whose normalized form is not in b.
+func diffNames(a, b []string) []string {
+	have := map[string]bool{}
+	for _, n := range b {
+		have[normalizeTerm(n)] = true
+	}
+	var missing []string
+	for _, n := range a {
+		if !have[normalizeTerm(n)] {
+			missing = append(missing, n)
+		}
+	}
+	return missing
+}
+
+// compareSymbols reports exported-symbol mismatches between the JS and Go
+// files of a feature: missing symbols, differing parameters, option fields
+// and error handling. Empty refs read the working trees.
+func compareSymbols(label string, jsFiles, goFiles []string, jsRef, goRef string) string {
+	var jsSyms, goSyms []apiSymbol
+	var missing []string
+	for _, rel := range jsFiles {
+		data, err := readRepoFile(quickbaseJSPath, jsRef, rel)
+		if err != nil {
+			missing = append(missing, refLabel("js/"+rel, jsRef))
+			continue
+		}
+		jsSyms = append(jsSyms, jsSymbols(rel, string(data))...)
+	}
+	for _, rel := range goFiles {
+		data, err := readRepoFile(quickbaseGoPath, goRef, rel)
+		if err != nil {
+			missing = append(missing, refLabel("go/"+rel, goRef))
+			continue
+		}
+		goSyms = append(goSyms, goSymbols(rel, data)...)
+	}
+
+	byKey := func(syms []apiSymbol) map[string]apiSymbol {
+		m := map[string]apiSymbol{}
+		for _, s := range syms {
+			m[symbolKey(s.name)] = s
+		}
+		return m
+	}
+	jsByKey, goByKey := byKey(jsSyms), byKey(goSyms)
+	keys := map[string]bool{}
+	for k := range jsByKey {
+		keys[k] = true
+	}
+	for k := range goByKey {
+		keys[k] = true
+	}
+
+	var results strings.Builder
+	results.WriteString(fmt.Sprintf("# Symbol comparison: %s\n\n", label))
+	for _, rel := range missing {
+		results.WriteString(fmt.Sprintf("⚠️ File not found: %s\n", rel))
+	}
+	if len(missing) > 0 {
+		results.WriteString("\n")
+	}
+
+	var mismatches []string
+	results.WriteString("| Symbol | JS | Go | Status |\n|---|---|---|---|\n")
+	for _, key := range sortedKeys(keys) {
+		js, inJS := jsByKey[key]
+		g, inGo := goByKey[key]
+		jsSig, goSig := "—", "—"
+		if inJS {
+			jsSig = "`" + js.signature + "`"
+		}
+		if inGo {
+			goSig = "`" + g.signature + "`"
+		}
+		name := js.name
+		if !inJS {
+			name = g.name
+		}
+
+		var problems []string
+		switch {
+		case !inGo:
+			problems = append(problems, "only in JS")
+		case !inJS:
+			problems = append(problems, "only in Go")
+		default:
+			if js.kind != "type" && g.kind != "type" {
+				if only := diffNames(js.params, g.params); len(only) > 0 {
+					problems = append(problems, "params only in JS: "+strings.Join(only, ", "))
+				}
+				if only := diffNames(g.params, js.params); len(only) > 0 {
+					problems = append(problems, "params only in Go: "+strings.Join(only, ", "))
+				}
+				if g.errors && !js.errors {
+					problems = append(problems, "Go returns error, JS never throws")
+				}
+				if js.errors && !g.errors {
+					problems = append(problems, "JS throws, Go returns no error")
+				}
+			}
+			if only := diffNames(js.fields, g.fields); len(only) > 0 {
+				problems = append(problems, "fields only in JS: "+strings.Join(only, ", "))
+			}
+			if only := diffNames(g.fields, js.fields); len(only) > 0 {
+				problems = append(problems, "fields only in Go: "+strings.Join(only, ", "))
+			}
+		}
+
+		status := "✅"
+		if len(problems) > 0 {
+			status = "❌ " + strings.Join(problems, "; ")
+			mismatches = append(mismatches, name)
+		}
+		results.WriteString(fmt.Sprintf("| %s | %s | %s | %s |\n", name, jsSig, goSig, status))
+	}
+
+	sort.Strings(mismatches)
+	results.WriteString(fmt.Sprintf("\n%d symbols, %d with mismatches", len(keys), len(mismatches)))
+	results.WriteString(". Names are compared case- and separator-insensitively; JS constructors pair with Go NewX functions and context.Context parameters are ignored.\n")
+	return results.String()
+}
@@ -0,0 +1,290 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// jsDeclKeyword marks the declarations whose body is their interface, so
+// get_docs shows them whole rather than cut at the brace.
+var jsDeclKeyword = regexp.MustCompile(`\b(interface|type|enum)\s+[A-Za-z_$]`)
+
+// jsDeclaration finds name's declaration in TS/JS source: a top-level
+// export, or for Owner.member a member of that class or interface. It
+// returns where the declaration starts, or -1.
+func jsDeclaration(src, name string) int {
+	owner, member, isMember := strings.Cut(name, ".")
+	if !isMember {
+		owner = name
+	}
+	decl := regexp.MustCompile(`(?m)^[ \t]*(?:export\s+)?(?:declare\s+)?(?:default\s+)?(?:abstract\s+)?(?:async\s+)?(?:function\*?|const|let|class|interface|type|enum)\s+` + regexp.QuoteMeta(owner) + `\b`)
+	loc := decl.FindStringIndex(src)
+	if loc == nil || !isMember {
+		if loc == nil {
+			return -1
+		}
+		return loc[0] + len(src[loc[0]:loc[1]]) - len(strings.TrimLeft(src[loc[0]:loc[1]], " \t"))
+	}
+	open := strings.Index(src[loc[1]:], "{")
+	if open < 0 {
+		return -1
+	}
+	open += loc[1]
+	end := matchClose(src, open)
+	if end < 0 {
+		return -1
+	}
+	body := src[open+1 : end]
+	memberLine := regexp.MustCompile(`(?m)^[ \t]*(?:(?:public|static|readonly|async|declare|get|set)\s+)*` + regexp.QuoteMeta(member) + `\??\s*[(<:]`)
+	for _, m := range memberLine.FindAllStringIndex(body, -1) {
+		if isDepthZero(body, m[0]) {
+			return open + 1 + m[0] + len(body[m[0]:m[1]]) - len(strings.TrimLeft(body[m[0]:m[1]], " \t"))
+		}
+	}
+	return -1
+}
+
+// jsDocComment returns the /** */ comment right above pos, with its
+// markers stripped, or "".
+func jsDocComment(src string, pos int) string {
+	before := strings.TrimRight(src[:pos], " \t\n")
+	if !strings.HasSuffix(before, "*/") {
+		return ""
+	}
+	start := strings.LastIndex(before, "/**")
+	if start < 0 {
+		return ""
+	}
+	var lines []string
+	for _, line := range strings.Split(before[start+3:len(before)-2], "\n") {
+		line = strings.TrimSpace(line)
+		line = strings.TrimPrefix(strings.TrimPrefix(line, "*"), " ")
+		lines = append(lines, line)
+	}
+	return strings.Trim(strings.Join(lines, "\n"), "\n")
+}
+
+// jsSignature is the declaration at pos without its body: shapes are kept
+// whole (up to 60 lines), everything else stops at its body or semicolon.
+func jsSignature(src string, pos int) string {
+	line := src[pos:]
+	if nl := strings.Index(line, "\n"); nl >= 0 {
+		line = line[:nl]
+	}
+	if jsDeclKeyword.MatchString(line) {
+		if open := strings.Index(src[pos:], "{"); open >= 0 && !strings.Contains(src[pos:pos+open], ";") {
+			if end := matchClose(src, pos+open); end >= 0 {
+				text, more := truncateLines(src[pos:end+1], 60)
+				if more > 0 {
+					text += "\n…"
+				}
+				return text
+			}
+		}
+	}
+	depth, sawParams := 0, false
+	for i := pos; i < len(src); i++ {
+		switch src[i] {
+		case '(', '[':
+			depth++
+			sawParams = true
+		case ')', ']':
+			depth--
+		case ';':
+			if depth == 0 {
+				return src[pos : i+1]
+			}
+		case '{':
+			// A class body, a function body, or an object type in the
+			// signature, which only a body can follow at depth zero
+			if depth == 0 && (sawParams || strings.Contains(src[pos:i], "class ")) {
+				return strings.TrimRight(src[pos:i], " ") + " { … }"
+			}
+		case '\n':
+			if depth == 0 && !strings.HasSuffix(strings.TrimSpace(src[pos:i]), ",") && strings.Count(src[pos:i], "{") == strings.Count(src[pos:i], "}") && sawParams {
+				return src[pos:i]
+			}
+		}
+	}
+	return line
+}
+
+// docMatch is a symbol get_docs found: where it's declared and how to show it.
+type docMatch struct {
+	name, file string
+	doc, sig   string
+	exported   bool
+}
+
+// jsDocs finds name in quickbase-js: the package's public surface first
+// (its type declarations when it ships them), then any source file.
+func jsDocs(name string) []docMatch {
+	repoPath := quickbaseJSPath
+	entry, _ := packageEntry(repoPath)
+	key := symbolKey(name)
+	var matches []docMatch
+	seen := map[string]bool{}
+	add := func(symName, file string, exported bool) {
+		if seen[symName+" "+file] {
+			return
+		}
+		data, err := os.ReadFile(filepath.Join(repoPath, file))
+		if err != nil {
+			return
+		}
+		src := string(data)
+		pos := jsDeclaration(src, symName)
+		if pos < 0 {
+			return
+		}
+		seen[symName+" "+file] = true
+		matches = append(matches, docMatch{name: symName, file: file, doc: jsDocComment(src, pos), sig: jsSignature(src, pos), exported: exported})
+	}
+	if entry != "" {
+		surface := jsSurface(repoPath, entry)
+		for _, symName := range sortedKeys(surface) {
+			if symbolKey(symName) == key || (!strings.Contains(name, ".") && strings.HasSuffix(symbolKey(symName), "."+key)) {
+				add(symName, surface[symName].file, true)
+			}
+		}
+	}
+	if len(matches) > 0 {
+		return matches
+	}
+	if first, rest, ok := strings.Cut(name, "."); ok && first != "" && first[0] >= 'a' && first[0] <= 'z' {
+		// A Go package qualifier, as in client.Paginate
+		return jsDocs(rest)
+	}
+	// Not public, or only reachable in ways jsSurface doesn't follow
+	keep := isJSSource
+	if strings.HasSuffix(entry, ".d.ts") {
+		dir := path.Dir(entry)
+		keep = func(rel string) bool { return strings.HasSuffix(rel, ".d.ts") && strings.HasPrefix(rel, dir+"/") }
+	}
+	for _, file := range listSourceFiles(repoPath, keep) {
+		data, err := os.ReadFile(filepath.Join(repoPath, file))
+		if err != nil {
+			continue
+		}
+		for _, sym := range jsSymbols(file, strings.ReplaceAll(string(data), "export declare ", "export ")) {
+			if symbolKey(sym.name) == key || (!strings.Contains(name, ".") && strings.HasSuffix(symbolKey(sym.name), "."+key)) {
+				add(sym.name, file, false)
+			}
+		}
+	}
+	return matches
+}
+
+// goDocs finds name in quickbase-go's public packages and asks go doc for
+// each match. A package qualifier (client.Paginate) narrows the search.
+func goDocs(ctx context.Context, name string) []docMatch {
+	repoPath := quickbaseGoPath
+	pkgFilter := ""
+	if first, rest, ok := strings.Cut(name, "."); ok && first != "" && first[0] >= 'a' && first[0] <= 'z' {
+		pkgFilter, name = first, rest
+	}
+	key := symbolKey(name)
+	surface, _ := goSurface(repoPath)
+	var names []string
+	for _, symName := range sortedKeys(surface) {
+		dir := path.Dir(surface[symName].file)
+		if pkgFilter != "" && path.Base(dir) != pkgFilter && !(dir == "." && pkgFilter == "quickbase") {
+			continue
+		}
+		if symbolKey(symName) == key || (!strings.Contains(name, ".") && strings.HasSuffix(symbolKey(symName), "."+key)) {
+			names = append(names, symName)
+		}
+	}
+	var matches []docMatch
+	for _, symName := range names {
+		sym := surface[symName]
+		dir := "./" + path.Dir(sym.file)
+		if dir == "./." {
+			dir = "."
+		}
+		result, err := runShell(ctx, repoPath, 30*time.Second, fmt.Sprintf("go doc %s %s", shellQuote(dir), shellQuote(symName)))
+		m := docMatch{name: symName, file: sym.file, exported: true}
+		if err == nil && result.exitCode == 0 {
+			// The package line repeats what the heading says
+			output := result.output
+			if strings.HasPrefix(output, "package ") {
+				_, output, _ = strings.Cut(output, "\n")
+			}
+			m.sig = strings.Trim(output, "\n")
+		} else {
+			m.sig = sym.signature
+		}
+		matches = append(matches, m)
+	}
+	return matches
+}
+
+func (s *QuickBasePersonalMCPServer) handleGetDocs(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	var params struct {
+		Symbol string `json:"symbol"`
+		Repo   string `json:"repo"`
+	}
+	argsData, _ := json.Marshal(request.Params.Arguments)
+	if err := json.Unmarshal(argsData, &params); err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Invalid parameters: %v", err)), nil
+	}
+	if params.Symbol == "" {
+		return mcp.NewToolResultError("symbol is required, e.g. 'Paginate' or 'Client.QueryRecords'"), nil
+	}
+	if params.Repo == "" {
+		params.Repo = "all"
+	}
+	if params.Repo != "all" && params.Repo != "js" && params.Repo != "go" {
+		return mcp.NewToolResultError("repo must be 'js', 'go' or 'all'"), nil
+	}
+
+	var results strings.Builder
+	results.WriteString(fmt.Sprintf("# Docs: %s\n\n", params.Symbol))
+	found := 0
+	if params.Repo != "js" {
+		results.WriteString("## quickbase-go\n\n")
+		matches := goDocs(ctx, params.Symbol)
+		if len(matches) == 0 {
+			results.WriteString("No exported symbol by that name.\n\n")
+		}
+		for _, m := range matches {
+			results.WriteString(fmt.Sprintf("### %s (`%s`)\n\n```go\n%s\n```\n\n", m.name, m.file, m.sig))
+		}
+		found += len(matches)
+	}
+	if params.Repo != "go" {
+		results.WriteString("## quickbase-js\n\n")
+		matches := jsDocs(params.Symbol)
+		sort.SliceStable(matches, func(i, j int) bool { return matches[i].exported && !matches[j].exported })
+		if len(matches) == 0 {
+			results.WriteString("No declaration by that name.\n\n")
+		}
+		for _, m := range matches {
+			results.WriteString(fmt.Sprintf("### %s (`%s`)\n\n", m.name, m.file))
+			if !m.exported {
+				results.WriteString("Not exported from the package entry point.\n\n")
+			}
+			results.WriteString(fmt.Sprintf("```ts\n%s\n```\n\n", strings.TrimRight(m.sig, "\n")))
+			if m.doc != "" {
+				results.WriteString(m.doc + "\n\n")
+			} else {
+				results.WriteString("No TSDoc comment.\n\n")
+			}
+		}
+		found += len(matches)
+	}
+	if found == 0 {
+		results.WriteString("Try search_code for partial names.\n")
+	}
+	return mcp.NewToolResultText(strings.TrimRight(results.String(), "\n") + "\n"), nil
+}
@@ -0,0 +1,98 @@
+package main
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+var (
+	jsExportDecl = regexp.MustCompile(`(?m)^\s*export\s+(?:default\s+)?(?:declare\s+)?(?:async\s+)?(?:abstract\s+)?(?:function\*?|class|interface|type|const|let|var|enum|namespace)\s+([A-Za-z_$][\w$]*)`)
+	jsExportList = regexp.MustCompile(`(?m)^\s*export\s+(?:type\s+)?\{([^}]*)\}`)
+)
+
+// jsExports returns the names exported by a TS/JS module, found with
+// regexes over declarations and export lists (re-exports included).
+func jsExports(src string) []string {
+	seen := map[string]bool{}
+	for _, m := range jsExportDecl.FindAllStringSubmatch(src, -1) {
+		seen[m[1]] = true
+	}
+	for _, m := range jsExportList.FindAllStringSubmatch(src, -1) {
+		for _, item := range strings.Split(m[1], ",") {
+			item = strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(item), "type "))
+			if item == "" {
+				continue
+			}
+			// "a as b" exports b
+			if idx := strings.LastIndex(item, " as "); idx >= 0 {
+				item = strings.TrimSpace(item[idx+4:])
+			}
+			seen[item] = true
+		}
+	}
+	return sortedKeys(seen)
+}
+
+// goExports returns the exported top-level identifiers of a Go file.
+// Methods are reported as Receiver.Method.
+func goExports(src []byte) []string {
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, "", src, parser.SkipObjectResolution)
+	if err != nil {
+		return nil
+	}
+	var names []string
+	for _, decl := range file.Decls {
+		switch d := decl.(type) {
+		case *ast.FuncDecl:
+			if !d.Name.IsExported() {
+				continue
+			}
+			if d.Recv != nil && len(d.Recv.List) > 0 {
+				recv := receiverName(d.Recv.List[0].Type)
+				if !ast.IsExported(recv) {
+					continue
+				}
+				names = append(names, recv+"."+d.Name.Name)
+				continue
+			}
+			names = append(names, d.Name.Name)
+		case *ast.GenDecl:
+			for _, spec := range d.Specs {
+				switch sp := spec.(type) {
+				case *ast.TypeSpec:
+					if sp.Name.IsExported() {
+						names = append(names, sp.Name.Name)
+					}
+				case *ast.ValueSpec:
+					for _, n := range sp.Names {
+						if n.IsExported() {
+							names = append(names, n.Name)
+						}
+					}
+				}
+			}
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// receiverName unwraps *T, T[P] and similar receiver expressions to T.
+func receiverName(expr ast.Expr) string {
+	switch t := expr.(type) {
+	case *ast.StarExpr:
+		return receiverName(t.X)
+	case *ast.IndexExpr:
+		return receiverName(t.X)
+	case *ast.IndexListExpr:
+		return receiverName(t.X)
+	case *ast.Ident:
+		return t.Name
+	}
+	return ""
+}
@@ -0,0 +1,234 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"gopkg.in/yaml.v3"
+)
+
+const tagMappingBucket = "tag_mappings"
+
+// tagMapping records which SDK modules implement a spec tag. Paths are
+// directories or files relative to each repo root.
+type tagMapping struct {
+	Tag       string    `json:"tag"`
+	JS        []string  `json:"js"`
+	Go        []string  `json:"go"`
+	Notes     string    `json:"notes,omitempty"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// tagMappings returns the stored mappings keyed by tag.
+func (s *QuickBasePersonalMCPServer) tagMappings() (map[string]tagMapping, error) {
+	mappings := map[string]tagMapping{}
+	err := s.store.each(tagMappingBucket, func(key string, data []byte) error {
+		var m tagMapping
+		if err := json.Unmarshal(data, &m); err != nil {
+			return nil
+		}
+		mappings[key] = m
+		return nil
+	})
+	return mappings, err
+}
+
+// tagModules returns the SDK paths for a spec tag. Mapped tags use the
+// stored mapping; unmapped tags fall back to a lowercase directory guess,
+// reported via mapped=false so callers can say the paths are a guess.
+func (s *QuickBasePersonalMCPServer) tagModules(tag string) (js, goPaths []string, mapped bool) {
+	var m tagMapping
+	if found, err := s.store.get(tagMappingBucket, tag, &m); err == nil && found {
+		return m.JS, m.Go, true
+	}
+	dir := strings.ToLower(strings.ReplaceAll(tag, " ", "-"))
+	return []string{"src/" + dir}, []string{strings.ReplaceAll(dir, "-", "")}, false
+}
+
+// specTags returns the tag names declared at the top level of the spec
+// plus any used by operations, in document order.
+func specTags(root *yaml.Node) []string {
+	var tags []string
+	seen := map[string]bool{}
+	add := func(tag string) {
+		if tag != "" && !seen[tag] {
+			seen[tag] = true
+			tags = append(tags, tag)
+		}
+	}
+	if list := mappingValue(root, "tags"); list != nil && list.Kind == yaml.SequenceNode {
+		for _, t := range list.Content {
+			if name := mappingValue(t, "name"); name != nil {
+				add(name.Value)
+			}
+		}
+	}
+	paths := mappingValue(root, "paths")
+	for j := 1; paths != nil && j < len(paths.Content); j += 2 {
+		item := paths.Content[j]
+		for k := 1; k < len(item.Content); k += 2 {
+			if list := mappingValue(item.Content[k], "tags"); list != nil {
+				for _, t := range list.Content {
+					add(t.Value)
+				}
+			}
+		}
+	}
+	return tags
+}
+
+func (s *QuickBasePersonalMCPServer) handleSetTagMapping(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	var params struct {
+		Tag    string   `json:"tag"`
+		JS     []string `json:"js"`
+		Go     []string `json:"go"`
+		Notes  string   `json:"notes"`
+		Delete bool     `json:"delete"`
+	}
+	argsData, _ := json.Marshal(request.Params.Arguments)
+	if err := json.Unmarshal(argsData, &params); err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Invalid parameters: %v", err)), nil
+	}
+	if params.Tag == "" {
+		return mcp.NewToolResultError("tag is required"), nil
+	}
+
+	if params.Delete {
+		found, err := s.store.delete(tagMappingBucket, params.Tag)
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("Failed to delete tag mapping: %v", err)), nil
+		}
+		if !found {
+			return mcp.NewToolResultError(fmt.Sprintf("No mapping for tag: %s", params.Tag)), nil
+		}
+		return mcp.NewToolResultText(fmt.Sprintf("Deleted mapping for tag: %s", params.Tag)), nil
+	}
+
+	if len(params.JS) == 0 && len(params.Go) == 0 {
+		return mcp.NewToolResultError("at least one of js or go is required"), nil
+	}
+
+	// Merge with the existing mapping so one side can be updated at a time
+	var m tagMapping
+	if _, err := s.store.get(tagMappingBucket, params.Tag, &m); err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to read tag mapping: %v", err)), nil
+	}
+	m.Tag = params.Tag
+	if len(params.JS) > 0 {
+		m.JS = params.JS
+	}
+	if len(params.Go) > 0 {
+		m.Go = params.Go
+	}
+	if params.Notes != "" {
+		m.Notes = params.Notes
+	}
+	m.UpdatedAt = time.Now()
+	if err := s.store.put(tagMappingBucket, m.Tag, m); err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to save tag mapping: %v", err)), nil
+	}
+
+	var results strings.Builder
+	results.WriteString(fmt.Sprintf("Mapped tag %s\n\n", m.Tag))
+	writeTagMappingPaths(&results, m.JS, m.Go)
+
+	// Warn if the tag isn't in the spec, it's most likely a typo
+	if root, err := loadSpec(); err == nil {
+		known := false
+		for _, tag := range specTags(root) {
+			if tag == m.Tag {
+				known = true
+				break
+			}
+		}
+		if !known {
+			results.WriteString(fmt.Sprintf("\n⚠️ Tag %q does not appear in the spec\n", m.Tag))
+		}
+	}
+
+	return mcp.NewToolResultText(results.String()), nil
+}
+
+func (s *QuickBasePersonalMCPServer) handleListTagMappings(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	mappings, err := s.tagMappings()
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to read tag mappings: %v", err)), nil
+	}
+
+	var tags []string
+	if root, err := loadSpec(); err == nil {
+		tags = specTags(root)
+	}
+	// Include mapped tags that are no longer in the spec
+	inSpec := map[string]bool{}
+	for _, tag := range tags {
+		inSpec[tag] = true
+	}
+	var stale []string
+	for tag := range mappings {
+		if !inSpec[tag] {
+			stale = append(stale, tag)
+		}
+	}
+	sort.Strings(stale)
+
+	var results strings.Builder
+	results.WriteString("# Spec Tag → SDK Module Mappings\n\n")
+	var unmapped []string
+	for _, tag := range append(tags, stale...) {
+		m, ok := mappings[tag]
+		if !ok {
+			unmapped = append(unmapped, tag)
+			continue
+		}
+		results.WriteString(fmt.Sprintf("## %s", tag))
+		if !inSpec[tag] {
+			results.WriteString(" (not in spec)")
+		}
+		results.WriteString("\n\n")
+		writeTagMappingPaths(&results, m.JS, m.Go)
+		if m.Notes != "" {
+			results.WriteString(fmt.Sprintf("\nNotes: %s\n", m.Notes))
+		}
+		results.WriteString("\n")
+	}
+
+	if len(unmapped) > 0 {
+		results.WriteString("## Unmapped spec tags\n\n")
+		for _, tag := range unmapped {
+			js, goPaths, _ := s.tagModules(tag)
+			results.WriteString(fmt.Sprintf("- %s (guess: js %s, go %s)\n", tag, strings.Join(js, ", "), strings.Join(goPaths, ", ")))
+		}
+		results.WriteString("\nUse set_tag_mapping to record the real modules.\n")
+	}
+
+	return mcp.NewToolResultText(results.String()), nil
+}
+
+// writeTagMappingPaths lists each side's paths with an existence check.
+func writeTagMappingPaths(results *strings.Builder, js, goPaths []string) {
+	for _, side := range []struct {
+		label string
+		root  string
+		paths []string
+	}{{"JS", quickbaseJSPath, js}, {"Go", quickbaseGoPath, goPaths}} {
+		if len(side.paths) == 0 {
+			results.WriteString(fmt.Sprintf("- %s: (not mapped)\n", side.label))
+			continue
+		}
+		for _, p := range side.paths {
+			mark := "✅"
+			if _, err := os.Stat(filepath.Join(side.root, p)); err != nil {
+				mark = "❌ missing"
+			}
+			results.WriteString(fmt.Sprintf("- %s: %s %s\n", side.label, p, mark))
+		}
+	}
+}
@@ -0,0 +1,143 @@
+package main
+
+import (
+	"fmt"
+	"path"
+	"regexp"
+	"strings"
+)
+
+// testSummary counts the test cases and assertions in one test file.
+type testSummary struct {
+	rel        string
+	cases      int
+	assertions int
+	names      []string
+	content    string
+}
+
+var (
+	jsTestCase    = regexp.MustCompile(`(?m)\b(?:it|test)(?:\.each\([^)]*\))?\(\s*['"` + "`" + `]([^'"` + "`" + `]*)`)
+	jsAssertion   = regexp.MustCompile(`\bexpect\(|\bassert(?:\.\w+)?\(`)
+	goTestCase    = regexp.MustCompile(`(?m)^func (Test\w*)\(|\bt\.Run\(\s*"([^"]*)"`)
+	goAssertion   = regexp.MustCompile(`\bt\.(?:Error|Errorf|Fatal|Fatalf|Fail|FailNow)\(|\b(?:assert|require)\.\w+\(`)
+	testNameTrims = []string{".test", ".spec", "_test"}
+)
+
+// testStem is fileStem for test files: the .test/.spec/_test suffix is
+// dropped so temp-token.test.ts pairs with temp-token.ts.
+func testStem(rel string) string {
+	base := path.Base(rel)
+	base = strings.TrimSuffix(base, path.Ext(base))
+	for _, suffix := range testNameTrims {
+		base = strings.TrimSuffix(base, suffix)
+	}
+	return normalizeTerm(base)
+}
+
+// findTestFiles returns the test files in repoPath whose names match one
+// of the implementation files, wherever they live (same directory,
+// tests/, __tests__/).
+func findTestFiles(repoPath string, implFiles []string) []string {
+	stems := map[string]bool{}
+	for _, rel := range implFiles {
+		stems[fileStem(rel)] = true
+	}
+	var tests []string
+	walkRepo(repoPath, func(rel string) error {
+		if isTestPath(rel) && (isJSTestFile(rel) || strings.HasSuffix(rel, "_test.go")) && stems[testStem(rel)] {
+			tests = append(tests, rel)
+		}
+		return nil
+	})
+	return tests
+}
+
+// isJSTestFile reports whether rel is a TS/JS file (test paths only).
+func isJSTestFile(rel string) bool {
+	switch path.Ext(rel) {
+	case ".ts", ".tsx", ".js", ".mjs", ".cjs":
+		return true
+	}
+	return false
+}
+
+// summarizeTests counts test cases and assertions in each test file.
+func summarizeTests(repoPath, ref string, files []string, isGo bool) []testSummary {
+	var summaries []testSummary
+	for _, rel := range files {
+		data, err := readRepoFile(repoPath, ref, rel)
+		if err != nil {
+			continue
+		}
+		src := stripComments(string(data))
+		sum := testSummary{rel: rel, content: string(data)}
+		if isGo {
+			for _, m := range goTestCase.FindAllStringSubmatch(src, -1) {
+				sum.names = append(sum.names, m[1]+m[2])
+			}
+			sum.assertions = len(goAssertion.FindAllStringIndex(src, -1))
+		} else {
+			for _, m := range jsTestCase.FindAllStringSubmatch(src, -1) {
+				sum.names = append(sum.names, m[1])
+			}
+			sum.assertions = len(jsAssertion.FindAllStringIndex(src, -1))
+		}
+		sum.cases = len(sum.names)
+		summaries = append(summaries, sum)
+	}
+	return summaries
+}
+
+// writeTestComparison appends a summary of both SDKs' tests for a feature,
+// and their contents when includeContents is set.
+func writeTestComparison(results *strings.Builder, jsFiles, goFiles []string, jsRef, goRef string, includeContents bool) {
+	jsTests := summarizeTests(quickbaseJSPath, jsRef, findTestFiles(quickbaseJSPath, jsFiles), false)
+	goTests := summarizeTests(quickbaseGoPath, goRef, findTestFiles(quickbaseGoPath, goFiles), true)
+
+	results.WriteString("## Tests\n\n")
+	if len(jsTests) == 0 && len(goTests) == 0 {
+		results.WriteString("No matching test files in either SDK\n\n")
+		return
+	}
+	results.WriteString("| SDK | Test file | Cases | Assertions |\n|---|---|---|---|\n")
+	totals := [2][2]int{}
+	for i, side := range [][]testSummary{jsTests, goTests} {
+		sdk := "JS"
+		if i == 1 {
+			sdk = "Go"
+		}
+		if len(side) == 0 {
+			results.WriteString(fmt.Sprintf("| %s | — | 0 | 0 |\n", sdk))
+		}
+		for _, t := range side {
+			results.WriteString(fmt.Sprintf("| %s | %s | %d | %d |\n", sdk, t.rel, t.cases, t.assertions))
+			totals[i][0] += t.cases
+			totals[i][1] += t.assertions
+		}
+	}
+	results.WriteString(fmt.Sprintf("\nJS: %d cases, %d assertions. Go: %d cases, %d assertions.\n\n", totals[0][0], totals[0][1], totals[1][0], totals[1][1]))
+
+	for i, side := range [][]testSummary{jsTests, goTests} {
+		for _, t := range side {
+			if len(t.names) == 0 {
+				continue
+			}
+			sdk := "JS"
+			if i == 1 {
+				sdk = "Go"
+			}
+			results.WriteString(fmt.Sprintf("- %s %s: %s\n", sdk, t.rel, strings.Join(t.names, "; ")))
+		}
+	}
+	results.WriteString("\n")
+
+	if includeContents {
+		for _, t := range jsTests {
+			results.WriteString(fmt.Sprintf("### JavaScript test (%s)\n\n```typescript\n%s\n```\n\n", refLabel(t.rel, jsRef), t.content))
+		}
+		for _, t := range goTests {
+			results.WriteString(fmt.Sprintf("### Go test (%s)\n\n```go\n%s\n```\n\n", refLabel(t.rel, goRef), t.content))
+		}
+	}
+}
@@ -0,0 +1,338 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// testRunner says what a test command ends up running, so its output can
+// be asked for as JSON: "go", "vitest", "jest", or "" when unknown. npm
+// commands are looked up in package.json's scripts.
+func testRunner(repoPath, command string) string {
+	if strings.HasPrefix(command, "go test") {
+		return "go"
+	}
+	script := command
+	if fields := strings.Fields(command); len(fields) >= 2 && fields[0] == "npm" {
+		name := fields[1]
+		if name == "run" && len(fields) >= 3 {
+			name = fields[2]
+		}
+		var pkg struct {
+			Scripts map[string]string `json:"scripts"`
+		}
+		if data, err := os.ReadFile(filepath.Join(repoPath, "package.json")); err == nil {
+			json.Unmarshal(data, &pkg)
+		}
+		script = pkg.Scripts[name]
+	}
+	switch {
+	case strings.Contains(script, "vitest"):
+		return "vitest"
+	case strings.Contains(script, "jest"):
+		return "jest"
+	}
+	return ""
+}
+
+// withJSONReporter switches a test command to its runner's JSON output.
+func withJSONReporter(command, runner string) string {
+	switch runner {
+	case "go":
+		if rest, ok := strings.CutPrefix(command, "go test"); ok && !strings.Contains(rest, "-json") {
+			return "go test -json" + rest
+		}
+	case "vitest":
+		return withArgs(command, "--reporter=json")
+	case "jest":
+		return withArgs(command, "--json")
+	}
+	return command
+}
+
+// goTestEvent is one line of go test -json. Build errors arrive as
+// build-output events keyed by ImportPath.
+type goTestEvent struct {
+	Action     string `json:"Action"`
+	Package    string `json:"Package"`
+	Test       string `json:"Test"`
+	Output     string `json:"Output"`
+	ImportPath string `json:"ImportPath"`
+}
+
+// parseGoTestJSON reads go test -json events. Output is attributed by the
+// events' Test field, so parallel tests' logs don't get mixed up. It also
+// returns the plain text the events carry, as go test -v would print it.
+func parseGoTestJSON(output string) (testReport, string) {
+	var report testReport
+	var text strings.Builder
+	logs := map[string][]string{}
+	buildErrors := map[string][]string{}
+	failedTests := map[string]bool{}
+	for _, line := range strings.Split(output, "\n") {
+		var ev goTestEvent
+		if !strings.HasPrefix(line, "{") || json.Unmarshal([]byte(line), &ev) != nil {
+			if line != "" {
+				text.WriteString(line + "\n")
+			}
+			continue
+		}
+		text.WriteString(ev.Output)
+		if ev.Action == "build-output" {
+			// ImportPath is "pkg [pkg.test]" for a test binary
+			pkg, _, _ := strings.Cut(ev.ImportPath, " ")
+			if !strings.HasPrefix(ev.Output, "# ") {
+				buildErrors[pkg] = append(buildErrors[pkg], strings.TrimRight(ev.Output, "\n"))
+			}
+			continue
+		}
+		key := ev.Package + " " + ev.Test
+		switch ev.Action {
+		case "output":
+			switch {
+			case strings.TrimSpace(ev.Output) == "":
+			case ev.Test != "":
+				if !goTestLine.MatchString(ev.Output) {
+					logs[key] = append(logs[key], strings.TrimSpace(ev.Output))
+				}
+			case !goPackageLine.MatchString(ev.Output) && strings.TrimSpace(ev.Output) != "PASS" && strings.TrimSpace(ev.Output) != "FAIL":
+				// Package output outside any test, such as a panic in init
+				logs[key] = append(logs[key], strings.TrimRight(ev.Output, "\n"))
+			}
+		case "pass", "skip", "fail":
+			if ev.Test == "" {
+				switch ev.Action {
+				case "pass":
+					report.packagesOK++
+				case "fail":
+					report.packagesFailed++
+					if !failedTests[ev.Package] {
+						// Failed without a failing test: build error, panic
+						// before any test ran, or TestMain exiting
+						message := strings.Join(buildErrors[ev.Package], "\n")
+						if message == "" {
+							message = strings.Join(logs[key], "\n")
+						}
+						f := testFailure{name: ev.Package + " [build failed]", message: message}
+						if len(buildErrors[ev.Package]) == 0 {
+							f.name = ev.Package
+						}
+						locateFailure(&f, goFileLine)
+						report.failures = append(report.failures, f)
+					}
+				}
+				continue
+			}
+			report.counted = true
+			switch ev.Action {
+			case "pass":
+				report.passed++
+			case "skip":
+				report.skipped++
+			case "fail":
+				report.failed++
+				failedTests[ev.Package] = true
+				f := testFailure{name: ev.Test, pkg: ev.Package, message: strings.Join(logs[key], "\n")}
+				locateFailure(&f, goFileLine)
+				report.failures = append(report.failures, f)
+			}
+		}
+	}
+	report.failures = dropParentFailures(report.failures)
+	return report, text.String()
+}
+
+// jsTestResults is the JSON report vitest's json reporter and jest --json
+// both write.
+type jsTestResults struct {
+	NumPassedTests  int `json:"numPassedTests"`
+	NumFailedTests  int `json:"numFailedTests"`
+	NumPendingTests int `json:"numPendingTests"`
+	NumTodoTests    int `json:"numTodoTests"`
+	TestResults     []struct {
+		Name             string `json:"name"`
+		Status           string `json:"status"`
+		Message          string `json:"message"`
+		AssertionResults []struct {
+			FullName        string   `json:"fullName"`
+			Status          string   `json:"status"`
+			Duration        float64  `json:"duration"`
+			FailureMessages []string `json:"failureMessages"`
+			Location        *struct {
+				Line int `json:"line"`
+			} `json:"location"`
+		} `json:"assertionResults"`
+	} `json:"testResults"`
+}
+
+// parseJSTestJSON reads a vitest or jest JSON report, which may follow npm's
+// banner in the output. ok is false when there's no report to read. Files
+// are made relative to repoPath, and the text returned lists each test's
+// result for when one test's output is wanted.
+func parseJSTestJSON(output, repoPath string) (testReport, string, bool) {
+	var report testReport
+	start := strings.Index(output, "{\"num")
+	if start < 0 {
+		return report, "", false
+	}
+	var results jsTestResults
+	if err := json.NewDecoder(strings.NewReader(output[start:])).Decode(&results); err != nil {
+		return report, "", false
+	}
+	report.counted = true
+	report.passed = results.NumPassedTests
+	report.failed = results.NumFailedTests
+	report.skipped = results.NumPendingTests + results.NumTodoTests
+
+	var text strings.Builder
+	for _, file := range results.TestResults {
+		rel := file.Name
+		if r, err := filepath.Rel(repoPath, file.Name); err == nil && !strings.HasPrefix(r, "..") {
+			rel = filepath.ToSlash(r)
+		}
+		if file.Status == "failed" && len(file.AssertionResults) == 0 {
+			// The file itself failed, such as a syntax or import error
+			f := testFailure{name: rel, file: rel, line: stackLine(file.Message, file.Name), message: stripStackFrames(file.Message)}
+			report.failures = append(report.failures, f)
+			text.WriteString(fmt.Sprintf("× %s\n%s\n", rel, f.message))
+			continue
+		}
+		for _, a := range file.AssertionResults {
+			mark := map[string]string{"passed": "✓", "failed": "×"}[a.Status]
+			if mark == "" {
+				mark = "↓"
+			}
+			text.WriteString(fmt.Sprintf("%s %s > %s (%.0fms)\n", mark, rel, a.FullName, a.Duration))
+			if a.Status != "failed" {
+				continue
+			}
+			message := stripStackFrames(strings.Join(a.FailureMessages, "\n"))
+			text.WriteString(message + "\n")
+			f := testFailure{name: a.FullName, file: rel, message: message}
+			if a.Location != nil {
+				f.line = a.Location.Line
+			} else {
+				f.line = stackLine(strings.Join(a.FailureMessages, "\n"), file.Name)
+			}
+			f.diff = failureDiff(message)
+			report.failures = append(report.failures, f)
+		}
+	}
+	return report, text.String(), true
+}
+
+var (
+	goFileLine      = regexp.MustCompile(`^(\S+\.go):(\d+)`)
+	jsStackFrame    = regexp.MustCompile(`^\s+at |^\s*❯ `)
+	goTestifyDiff   = regexp.MustCompile(`^\s*Diff:\s*$`)
+	expectedLine    = regexp.MustCompile(`^\s*Expected:?\s+(.*)$`)
+	receivedLine    = regexp.MustCompile(`^\s*Received:?\s+(.*)$`)
+	diffMarkerLines = regexp.MustCompile(`^\s*[-+] ?(Expected|Received|expected|actual)\s*$`)
+)
+
+// stripStackFrames drops the "at ..." lines of a JS error; the test's own
+// line is reported separately.
+func stripStackFrames(message string) string {
+	var lines []string
+	for _, line := range strings.Split(message, "\n") {
+		if !jsStackFrame.MatchString(line) {
+			lines = append(lines, line)
+		}
+	}
+	return strings.TrimSpace(strings.Join(lines, "\n"))
+}
+
+// stackLine finds the line of the stack frame in file, the test file a JS
+// error was thrown from, or 0.
+func stackLine(message, file string) int {
+	m := regexp.MustCompile(regexp.QuoteMeta(file) + `:(\d+)`).FindStringSubmatch(message)
+	if m == nil {
+		return 0
+	}
+	line, _ := strconv.Atoi(m[1])
+	return line
+}
+
+// locateFailure fills in a failure's file and line from the last message
+// line that starts with one, such as Go's "client_test.go:42: got 1"; a
+// test's earlier logs lead up to the check that failed.
+func locateFailure(f *testFailure, fileLine *regexp.Regexp) {
+	for _, line := range strings.Split(f.message, "\n") {
+		if m := fileLine.FindStringSubmatch(strings.TrimSpace(line)); m != nil {
+			f.file = m[1]
+			f.line, _ = strconv.Atoi(m[2])
+		}
+	}
+	f.diff = failureDiff(f.message)
+}
+
+// failureDiff pulls the expected/actual difference out of a failure
+// message: testify's Diff: block, -/+ lines (vitest, go-cmp), or jest's
+// Expected:/Received: pair.
+func failureDiff(message string) string {
+	lines := strings.Split(message, "\n")
+	for i, line := range lines {
+		if goTestifyDiff.MatchString(line) {
+			var diff []string
+			for _, l := range lines[i+1:] {
+				if t := strings.TrimSpace(l); t == "" || strings.HasPrefix(t, "Test:") {
+					break
+				}
+				diff = append(diff, strings.TrimSpace(l))
+			}
+			return strings.Join(diff, "\n")
+		}
+	}
+	var diff []string
+	minus, plus := false, false
+	for _, line := range lines {
+		t := strings.TrimSpace(line)
+		switch {
+		case diffMarkerLines.MatchString(line), t == "---" || t == "+++":
+		case strings.HasPrefix(t, "-"):
+			minus = true
+			diff = append(diff, t)
+		case strings.HasPrefix(t, "+"):
+			plus = true
+			diff = append(diff, t)
+		}
+	}
+	if minus && plus {
+		return strings.Join(diff, "\n")
+	}
+	var expected, received string
+	for _, line := range lines {
+		if m := expectedLine.FindStringSubmatch(line); m != nil && expected == "" {
+			expected = m[1]
+		} else if m := receivedLine.FindStringSubmatch(line); m != nil && received == "" {
+			received = m[1]
+		}
+	}
+	if expected != "" && received != "" {
+		return "- " + expected + "\n+ " + received
+	}
+	return ""
+}
+
+// goPackageDir turns an import path in a Go repo into its directory
+// relative to the repo, so failures point at files the other tools open.
+func goPackageDir(repoPath, pkg string) string {
+	data, err := os.ReadFile(filepath.Join(repoPath, "go.mod"))
+	if err != nil {
+		return ""
+	}
+	m := goModuleLine.FindSubmatch(data)
+	if m == nil {
+		return ""
+	}
+	rel, ok := strings.CutPrefix(pkg, string(m[1]))
+	if !ok {
+		return ""
+	}
+	return strings.TrimPrefix(rel, "/")
+}
@@ -0,0 +1,433 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// defaultTestTimeout allows for a cold build cache or first npm install.
+const defaultTestTimeout = 300 * time.Second
+
+// testFailure is one failing test: its name, package (Go) and where it
+// failed, what it printed, and the expected/actual diff when the message
+// has one.
+type testFailure struct {
+	name, pkg, file string
+	line            int
+	message, diff   string
+}
+
+// testReport is what run_tests makes of a test command's output. counted
+// is false when the output only had package-level results, so passed and
+// skipped are unknown.
+type testReport struct {
+	passed, failed, skipped    int
+	counted                    bool
+	packagesOK, packagesFailed int
+	failures                   []testFailure
+}
+
+// testCommand picks the command run_tests runs in a repo: the configured
+// one, else go test or npm test depending on what the repo is.
+func testCommand(cfg serverConfig, repo gitRepo) (string, error) {
+	for _, key := range []string{repo.name, strings.TrimPrefix(repo.name, "quickbase-")} {
+		if command := cfg.TestCommands[key]; command != "" {
+			return command, nil
+		}
+	}
+	if _, err := os.Stat(filepath.Join(repo.path, "go.mod")); err == nil {
+		// -v so passing tests are counted too
+		return "go test -v ./...", nil
+	}
+	if _, err := os.Stat(filepath.Join(repo.path, "package.json")); err == nil {
+		return "npm test", nil
+	}
+	return "", fmt.Errorf("no go.mod or package.json in %s; set test_commands.%s in config.yaml", repo.path, repo.name)
+}
+
+// withTestFilter narrows a test command to a package pattern or path. Go
+// patterns replace ./..., npm scripts get it after --, anything else gets
+// it appended.
+func withTestFilter(command, filter string) string {
+	if filter == "" {
+		return command
+	}
+	if strings.Contains(command, "./...") {
+		return strings.Replace(command, "./...", shellQuote(filter), 1)
+	}
+	return withArgs(command, shellQuote(filter))
+}
+
+// withArgs appends arguments for the test runner, after -- for npm scripts.
+func withArgs(command, args string) string {
+	if strings.HasPrefix(command, "npm ") && !strings.Contains(command, " -- ") {
+		command += " --"
+	}
+	return command + " " + args
+}
+
+// withTestName narrows a test command to one test: go test -run with each
+// level of a subtest name anchored, so TestPaginate doesn't also run
+// TestPaginateAll, and -t for vitest and jest.
+func withTestName(command, name string) string {
+	if name == "" {
+		return command
+	}
+	if rest, ok := strings.CutPrefix(command, "go test"); ok {
+		levels := strings.Split(name, "/")
+		for i, level := range levels {
+			levels[i] = "^" + regexp.QuoteMeta(level) + "$"
+		}
+		return "go test -run " + shellQuote(strings.Join(levels, "/")) + rest
+	}
+	return withArgs(command, "-t "+shellQuote(name))
+}
+
+var (
+	ansiEscape     = regexp.MustCompile(`\x1b\[[0-9;]*[A-Za-z]`)
+	goTestLine     = regexp.MustCompile(`^\s*(---|===) (RUN|PAUSE|CONT|NAME|PASS|FAIL|SKIP):?\s+(\S+)`)
+	goPackageLine  = regexp.MustCompile(`^(ok|FAIL|\?)\s+(\S+)(\s+\[[^\]]+\])?`)
+	goBuildHeader  = regexp.MustCompile(`^# (\S+)`)
+	goTestDuration = regexp.MustCompile(`\((\d+(\.\d+)?s)\)\s*$`)
+	jsSummaryLine  = regexp.MustCompile(`^\s*Tests:?\s+(.*)$`)
+	jsSummaryCount = regexp.MustCompile(`(\d+) (passed|failed|skipped|todo)`)
+	vitestFailLine = regexp.MustCompile(`^\s*FAIL\s+(.+ > .+)$`)
+	vitestCross    = regexp.MustCompile(`^\s*[×✗]\s+(.+?)(\s+\d+m?s)?$`)
+	jestBullet     = regexp.MustCompile(`^\s*●\s+(.+)$`)
+)
+
+// parseGoTestOutput reads go test's text output. Messages are the indented
+// lines logged under a test, which -v prints before its --- FAIL line and
+// plain go test after it.
+func parseGoTestOutput(output string) testReport {
+	var report testReport
+	logs := map[string][]string{}
+	buildErrors := map[string][]string{}
+	current, building := "", ""
+	pending := 0 // failures not yet given a package
+	for _, line := range strings.Split(output, "\n") {
+		if m := goBuildHeader.FindStringSubmatch(line); m != nil {
+			building = m[1]
+			continue
+		}
+		if m := goTestLine.FindStringSubmatch(line); m != nil {
+			current, building = m[3], ""
+			switch m[2] {
+			case "PASS":
+				report.passed++
+				report.counted = true
+			case "SKIP":
+				report.skipped++
+				report.counted = true
+			case "FAIL":
+				report.failed++
+				report.counted = true
+				report.failures = append(report.failures, testFailure{name: m[3]})
+				pending++
+			}
+			continue
+		}
+		if m := goPackageLine.FindStringSubmatch(line); m != nil {
+			switch m[1] {
+			case "ok":
+				report.packagesOK++
+			case "FAIL":
+				report.packagesFailed++
+				if pending == 0 {
+					// Failed without a failing test: build error, panic
+					// before any test ran, or TestMain exiting
+					report.failures = append(report.failures, testFailure{name: strings.TrimSpace(m[2] + m[3]), message: strings.Join(buildErrors[m[2]], "\n")})
+					pending++
+				}
+			default:
+				continue
+			}
+			for i := len(report.failures) - pending; i < len(report.failures); i++ {
+				if report.failures[i].name != m[2]+m[3] {
+					report.failures[i].pkg = m[2]
+				}
+			}
+			pending = 0
+			current, building = "", ""
+			continue
+		}
+		switch {
+		case building != "" && strings.TrimSpace(line) != "":
+			buildErrors[building] = append(buildErrors[building], line)
+		case current != "" && strings.HasPrefix(line, "    "):
+			logs[current] = append(logs[current], strings.TrimSpace(line))
+		}
+	}
+
+	for i := range report.failures {
+		if report.failures[i].message == "" {
+			report.failures[i].message = strings.Join(logs[report.failures[i].name], "\n")
+		}
+		locateFailure(&report.failures[i], goFileLine)
+	}
+	report.failures = dropParentFailures(report.failures)
+	return report
+}
+
+// dropParentFailures leaves out Go tests that failed only because a
+// subtest did; a parent that logged something itself stays.
+func dropParentFailures(failures []testFailure) []testFailure {
+	var kept []testFailure
+	for _, f := range failures {
+		hasSubtest := false
+		for _, other := range failures {
+			if other.pkg == f.pkg && strings.HasPrefix(other.name, f.name+"/") {
+				hasSubtest = true
+				break
+			}
+		}
+		if f.message == "" && hasSubtest {
+			continue
+		}
+		kept = append(kept, f)
+	}
+	return kept
+}
+
+// goTestOutput cuts one test's lines, subtests included, out of go test
+// output, along with the duration from its --- result line.
+func goTestOutput(output, name string) (string, string) {
+	var lines []string
+	duration := ""
+	own := false
+	for _, line := range strings.Split(output, "\n") {
+		if m := goTestLine.FindStringSubmatch(line); m != nil {
+			own = m[3] == name || strings.HasPrefix(m[3], name+"/")
+			if m[3] == name && m[1] == "---" {
+				if d := goTestDuration.FindStringSubmatch(line); d != nil {
+					duration = d[1]
+				}
+			}
+		} else if goPackageLine.MatchString(line) || line == "PASS" || line == "FAIL" {
+			own = false
+		}
+		if own {
+			lines = append(lines, line)
+		}
+	}
+	return strings.Join(lines, "\n"), duration
+}
+
+// parseJSTestOutput reads vitest's or jest's default reporter output: the
+// Tests summary line for counts, and the failure details that follow it
+// (FAIL file > test in vitest, ● test in jest) for messages.
+func parseJSTestOutput(output string) testReport {
+	var report testReport
+	var current *testFailure
+	var crossed []testFailure
+	for _, line := range strings.Split(output, "\n") {
+		if m := jsSummaryLine.FindStringSubmatch(line); m != nil {
+			for _, c := range jsSummaryCount.FindAllStringSubmatch(m[1], -1) {
+				n, _ := strconv.Atoi(c[1])
+				switch c[2] {
+				case "passed":
+					report.passed = n
+				case "failed":
+					report.failed = n
+				default:
+					report.skipped += n
+				}
+				report.counted = true
+			}
+			current = nil
+			continue
+		}
+		name := ""
+		if m := vitestFailLine.FindStringSubmatch(line); m != nil {
+			name = m[1]
+		} else if m := jestBullet.FindStringSubmatch(line); m != nil && !strings.HasPrefix(m[1], "Console") {
+			name = m[1]
+		}
+		if name != "" {
+			report.failures = append(report.failures, testFailure{name: strings.TrimSpace(name)})
+			current = &report.failures[len(report.failures)-1]
+			continue
+		}
+		if m := vitestCross.FindStringSubmatch(line); m != nil {
+			crossed = append(crossed, testFailure{name: m[1]})
+			current = &crossed[len(crossed)-1]
+			continue
+		}
+		if trimmed := strings.TrimSpace(line); strings.HasPrefix(trimmed, "⎯") || strings.HasPrefix(trimmed, "Test Suites:") || strings.HasPrefix(trimmed, "Test Files") {
+			current = nil
+			continue
+		}
+		if current != nil && strings.TrimSpace(line) != "" {
+			current.message += strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(line), "→")) + "\n"
+		}
+	}
+	// The per-file × lines only stand in when there's no failure detail
+	if len(report.failures) == 0 {
+		report.failures = crossed
+	}
+	for i := range report.failures {
+		report.failures[i].message = strings.TrimRight(report.failures[i].message, "\n")
+	}
+	return report
+}
+
+func (s *QuickBasePersonalMCPServer) handleRunTests(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	var params struct {
+		Repo           string `json:"repo"`
+		Path           string `json:"path"`
+		TestName       string `json:"test_name"`
+		TimeoutSeconds int    `json:"timeout_seconds"`
+	}
+	argsData, _ := json.Marshal(request.Params.Arguments)
+	if err := json.Unmarshal(argsData, &params); err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Invalid parameters: %v", err)), nil
+	}
+	timeout := defaultTestTimeout
+	if params.TimeoutSeconds > 0 {
+		timeout = time.Duration(params.TimeoutSeconds) * time.Second
+	}
+	if params.Repo == "" {
+		params.Repo = "all"
+	}
+	repos, err := gitRepos(params.Repo)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	if params.Repo == "all" {
+		repos = repos[:2]
+	}
+	cfg, err := loadConfig()
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to load config: %v", err)), nil
+	}
+
+	var results strings.Builder
+	results.WriteString("# Test results\n\n")
+	for _, repo := range repos {
+		results.WriteString(fmt.Sprintf("## %s\n\n", repo.name))
+		command, err := testCommand(cfg, repo)
+		if err != nil {
+			results.WriteString(fmt.Sprintf("⚠️ %v\n\n", err))
+			continue
+		}
+		runner := testRunner(repo.path, command)
+		command = withTestFilter(withTestName(withJSONReporter(command, runner), params.TestName), params.Path)
+		result, err := runShell(ctx, repo.path, timeout, command)
+		if err != nil {
+			results.WriteString(fmt.Sprintf("⚠️ %v\n\n", err))
+			continue
+		}
+		output := ansiEscape.ReplaceAllString(result.output, "")
+		var report testReport
+		switch {
+		case runner == "go":
+			// From here on output is the -v text the events carry
+			report, output = parseGoTestJSON(output)
+		case runner != "":
+			var text string
+			var ok bool
+			if report, text, ok = parseJSTestJSON(output, repo.path); ok {
+				output = text
+			} else {
+				report = parseJSTestOutput(output)
+			}
+		case strings.HasPrefix(command, "go "):
+			report = parseGoTestOutput(output)
+		default:
+			report = parseJSTestOutput(output)
+		}
+		for i, f := range report.failures {
+			// Go logs name the file within its package
+			if f.pkg != "" && f.file != "" && !strings.Contains(f.file, "/") {
+				report.failures[i].file = path.Join(goPackageDir(repo.path, f.pkg), f.file)
+			}
+		}
+
+		status := "✅ passed"
+		switch {
+		case result.timedOut:
+			status = fmt.Sprintf("⏱️ timed out after %s", timeout)
+		case result.exitCode != 0:
+			status = fmt.Sprintf("❌ failed (exit %d)", result.exitCode)
+		}
+		results.WriteString(fmt.Sprintf("`%s`: %s in %s\n\n", command, status, result.duration.Round(100*time.Millisecond)))
+		var counts []string
+		if report.counted {
+			counts = append(counts, fmt.Sprintf("Tests: %d passed, %d failed, %d skipped", report.passed, report.failed, report.skipped))
+		}
+		if report.packagesOK+report.packagesFailed > 0 {
+			counts = append(counts, fmt.Sprintf("Packages: %d ok, %d failed", report.packagesOK, report.packagesFailed))
+		}
+		if len(counts) > 0 {
+			results.WriteString(strings.Join(counts, "\n") + "\n\n")
+		}
+
+		if params.TestName != "" {
+			// The run is just this test, so its output says more than the
+			// failure summary
+			testOutput, duration := output, ""
+			if strings.HasPrefix(command, "go ") {
+				testOutput, duration = goTestOutput(output, params.TestName)
+			}
+			if strings.TrimSpace(testOutput) == "" || (report.counted && report.passed+report.failed == 0) {
+				results.WriteString(fmt.Sprintf("⚠️ No test matched %q\n\n", params.TestName))
+				if result.exitCode != 0 {
+					results.WriteString(fmt.Sprintf("```\n%s\n```\n\n", tailLines(output, 30)))
+				}
+				continue
+			}
+			if duration != "" {
+				results.WriteString(fmt.Sprintf("%s took %s\n\n", params.TestName, duration))
+			}
+			text, more := truncateLines(testOutput, 200)
+			results.WriteString(fmt.Sprintf("### Output\n\n```\n%s\n```\n", strings.Trim(text, "\n")))
+			if more > 0 {
+				results.WriteString(fmt.Sprintf("… %d more line(s)\n", more))
+			}
+			results.WriteString("\n")
+		} else if len(report.failures) > 0 {
+			results.WriteString("### Failures\n\n")
+			for _, f := range report.failures {
+				title := f.name
+				if f.pkg != "" && f.pkg != f.name {
+					title += " (" + f.pkg + ")"
+				}
+				results.WriteString(fmt.Sprintf("#### %s\n\n", title))
+				if f.file != "" {
+					location := f.file
+					if f.line > 0 {
+						location += fmt.Sprintf(":%d", f.line)
+					}
+					results.WriteString(fmt.Sprintf("At `%s`\n\n", location))
+				}
+				if f.message != "" {
+					message, more := truncateLines(f.message, 20)
+					results.WriteString(fmt.Sprintf("```\n%s\n```\n", message))
+					if more > 0 {
+						results.WriteString(fmt.Sprintf("… %d more line(s)\n", more))
+					}
+					results.WriteString("\n")
+				}
+				if f.diff != "" {
+					diff, _ := truncateLines(f.diff, 40)
+					results.WriteString(fmt.Sprintf("Diff:\n\n```diff\n%s\n```\n\n", diff))
+				}
+			}
+			results.WriteString("Locations are relative to the repo, for show_file_at_ref or search_code.\n\n")
+		} else if result.timedOut || result.exitCode != 0 {
+			// Nothing recognizable, so show where it stopped
+			results.WriteString(fmt.Sprintf("```\n%s\n```\n\n", tailLines(output, 30)))
+		}
+	}
+	return mcp.NewToolResultText(strings.TrimRight(results.String(), "\n") + "\n"), nil
+}
@@ -0,0 +1,105 @@
+package main
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// todoItem is a TODO/FIXME/HACK comment.
+type todoItem struct {
+	file   string
+	line   int
+	marker string
+	text   string
+}
+
+var todoPattern = regexp.MustCompile(`\b(TODO|FIXME|HACK|XXX)\b(?:\([^)]*\))?:?\s*(.*)`)
+
+// todoMatchThreshold is the word overlap above which a JS and a Go item
+// are taken to describe the same debt.
+const todoMatchThreshold = 0.5
+
+// extractTodos collects marker comments from a side's files.
+func extractTodos(repoPath, ref string, files []string) []todoItem {
+	var items []todoItem
+	for _, rel := range files {
+		data, err := readRepoFile(repoPath, ref, rel)
+		if err != nil {
+			continue
+		}
+		for _, seg := range sourceProse(string(data)) {
+			if seg.kind != "comment" {
+				continue
+			}
+			if m := todoPattern.FindStringSubmatch(seg.text); m != nil {
+				items = append(items, todoItem{file: rel, line: seg.line, marker: m[1], text: strings.TrimSpace(m[2])})
+			}
+		}
+	}
+	return items
+}
+
+// compareTodos reports marker comments present in one SDK's
+// implementation of a feature but not the other's. Items are paired by
+// word overlap, so the same note phrased slightly differently still
+// matches.
+func compareTodos(label string, jsFiles, goFiles []string, jsRef, goRef string) string {
+	jsItems := extractTodos(quickbaseJSPath, jsRef, jsFiles)
+	goItems := extractTodos(quickbaseGoPath, goRef, goFiles)
+
+	type match struct{ js, g todoItem }
+	var both []match
+	usedGo := make([]bool, len(goItems))
+	var onlyJS []todoItem
+	for _, j := range jsItems {
+		best, bestScore := -1, 0.0
+		for i, g := range goItems {
+			if usedGo[i] {
+				continue
+			}
+			if score := jaccard(pathTokens(j.text), pathTokens(g.text)); score > bestScore {
+				best, bestScore = i, score
+			}
+		}
+		if best >= 0 && bestScore >= todoMatchThreshold {
+			usedGo[best] = true
+			both = append(both, match{j, goItems[best]})
+		} else {
+			onlyJS = append(onlyJS, j)
+		}
+	}
+	var onlyGo []todoItem
+	for i, g := range goItems {
+		if !usedGo[i] {
+			onlyGo = append(onlyGo, g)
+		}
+	}
+
+	var results strings.Builder
+	results.WriteString(fmt.Sprintf("# TODO divergence: %s\n\n", label))
+	results.WriteString(fmt.Sprintf("%d JS items, %d Go items; %d only in JS, %d only in Go\n\n", len(jsItems), len(goItems), len(onlyJS), len(onlyGo)))
+
+	writeItems := func(title, sdk string, items []todoItem) {
+		results.WriteString(fmt.Sprintf("## %s (%d)\n\n", title, len(items)))
+		if len(items) == 0 {
+			results.WriteString("None\n\n")
+			return
+		}
+		for _, it := range items {
+			results.WriteString(fmt.Sprintf("- **%s** %s (%s %s:%d)\n", it.marker, it.text, sdk, it.file, it.line))
+		}
+		results.WriteString("\n")
+	}
+	writeItems("Only in JS", "js", onlyJS)
+	writeItems("Only in Go", "go", onlyGo)
+
+	results.WriteString(fmt.Sprintf("## In both (%d)\n\n", len(both)))
+	if len(both) == 0 {
+		results.WriteString("None\n")
+	}
+	for _, m := range both {
+		results.WriteString(fmt.Sprintf("- **%s** %s (js %s:%d ↔ go %s:%d)\n", m.js.marker, m.js.text, m.js.file, m.js.line, m.g.file, m.g.line))
+	}
+	return results.String()
+}
@@ -0,0 +1,162 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+var (
+	// tscError is "src/client.ts(12,5): error TS2322: Type ..."
+	tscError = regexp.MustCompile(`^(.+?)\((\d+),(\d+)\): (error|warning) (TS\d+): (.*)$`)
+	// goCompileError is "client/client.go:12:5: message", with a "vet: "
+	// prefix when vet itself couldn't type-check
+	goCompileError = regexp.MustCompile(`^(?:vet: )?(\S+\.go):(\d+)(?::(\d+))?: (.*)$`)
+)
+
+// parseCompileErrors reads compiler or vet output into issues. Lines
+// indented under an error continue its message.
+func parseCompileErrors(output string, tsc bool) []lintIssue {
+	var issues []lintIssue
+	for _, line := range strings.Split(output, "\n") {
+		if tsc {
+			if m := tscError.FindStringSubmatch(line); m != nil {
+				issue := lintIssue{file: m[1], severity: m[4], rule: m[5], message: m[6]}
+				issue.line, _ = strconv.Atoi(m[2])
+				issue.column, _ = strconv.Atoi(m[3])
+				issues = append(issues, issue)
+				continue
+			}
+		} else if m := goCompileError.FindStringSubmatch(line); m != nil {
+			issue := lintIssue{file: strings.TrimPrefix(m[1], "./"), severity: "error", message: m[4]}
+			issue.line, _ = strconv.Atoi(m[2])
+			issue.column, _ = strconv.Atoi(m[3])
+			issues = append(issues, issue)
+			continue
+		}
+		if len(issues) > 0 && (strings.HasPrefix(line, " ") || strings.HasPrefix(line, "\t")) && strings.TrimSpace(line) != "" {
+			issues[len(issues)-1].message += " " + strings.TrimSpace(line)
+		}
+	}
+	return issues
+}
+
+// writeIssuesByFile writes compile errors grouped under their files, in
+// the order the compiler reported them.
+func writeIssuesByFile(results *strings.Builder, issues []lintIssue) {
+	var files []string
+	byFile := map[string][]lintIssue{}
+	for _, issue := range issues {
+		if _, ok := byFile[issue.file]; !ok {
+			files = append(files, issue.file)
+		}
+		byFile[issue.file] = append(byFile[issue.file], issue)
+	}
+	results.WriteString(fmt.Sprintf("❌ %d error(s) in %d file(s)\n\n", len(issues), len(files)))
+	for _, file := range files {
+		results.WriteString(fmt.Sprintf("### %s (%d)\n\n", file, len(byFile[file])))
+		for _, issue := range byFile[file] {
+			at := strconv.Itoa(issue.line)
+			if issue.column > 0 {
+				at += fmt.Sprintf(":%d", issue.column)
+			}
+			if issue.rule != "" {
+				at += " " + issue.rule
+			}
+			results.WriteString(fmt.Sprintf("- %s: %s\n", at, issue.message))
+		}
+		results.WriteString("\n")
+	}
+}
+
+func (s *QuickBasePersonalMCPServer) handleTypeCheck(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	var params struct {
+		Repo           string `json:"repo"`
+		TimeoutSeconds int    `json:"timeout_seconds"`
+	}
+	argsData, _ := json.Marshal(request.Params.Arguments)
+	if err := json.Unmarshal(argsData, &params); err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Invalid parameters: %v", err)), nil
+	}
+	timeout := defaultTestTimeout
+	if params.TimeoutSeconds > 0 {
+		timeout = time.Duration(params.TimeoutSeconds) * time.Second
+	}
+	if params.Repo == "" {
+		params.Repo = "all"
+	}
+	repos, err := gitRepos(params.Repo)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	if params.Repo == "all" {
+		repos = repos[:2]
+	}
+
+	var results strings.Builder
+	results.WriteString("# Type check\n\n")
+	for _, repo := range repos {
+		results.WriteString(fmt.Sprintf("## %s\n\n", repo.name))
+		// vet on code that doesn't build repeats the compile errors, so it
+		// only runs once the build is clean
+		var steps []string
+		tsc := false
+		if _, err := os.Stat(filepath.Join(repo.path, "go.mod")); err == nil {
+			steps = []string{"go build ./...", "go vet ./..."}
+		} else if _, err := os.Stat(filepath.Join(repo.path, "tsconfig.json")); err == nil {
+			steps = []string{"npx --no-install tsc --noEmit --pretty false"}
+			tsc = true
+		} else {
+			results.WriteString(fmt.Sprintf("Nothing to check: no go.mod or tsconfig.json in %s\n\n", repo.path))
+			continue
+		}
+
+		var issues []lintIssue
+		var ran []string
+		failed := ""
+		for _, command := range steps {
+			result, err := runShell(ctx, repo.path, timeout, command)
+			if err != nil {
+				failed = err.Error()
+				break
+			}
+			ran = append(ran, fmt.Sprintf("`%s` (%s)", command, result.duration.Round(100*time.Millisecond)))
+			if result.timedOut {
+				failed = fmt.Sprintf("`%s` timed out after %s", command, timeout)
+				break
+			}
+			if result.exitCode == 0 {
+				continue
+			}
+			output := ansiEscape.ReplaceAllString(result.output, "")
+			found := parseCompileErrors(output, tsc)
+			if len(found) == 0 {
+				// Failed without an error we can place: missing tool,
+				// bad config, module download
+				failed = fmt.Sprintf("`%s` failed (exit %d):\n\n```\n%s\n```", command, result.exitCode, tailLines(output, 30))
+			}
+			issues = append(issues, found...)
+			break
+		}
+		results.WriteString(fmt.Sprintf("Ran %s\n\n", strings.Join(ran, ", then ")))
+		if failed != "" {
+			results.WriteString(fmt.Sprintf("⚠️ %s\n\n", failed))
+			continue
+		}
+		if len(issues) == 0 {
+			results.WriteString("✅ No errors\n\n")
+			continue
+		}
+
+		writeIssuesByFile(&results, issues)
+	}
+	return mcp.NewToolResultText(strings.TrimRight(results.String(), "\n") + "\n"), nil
+}
@@ -0,0 +1,331 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"gopkg.in/yaml.v3"
+)
+
+// namedSchema is a type waiting to be emitted: a component schema, or an
+// inline object named after its parent type and property.
+type namedSchema struct {
+	name   string
+	schema *yaml.Node
+	source string // where it is in the spec
+}
+
+// typeGen turns schemas into Go structs and TS interfaces the way the
+// generators name them: $refs by component name, inline objects as
+// Parent + Property.
+type typeGen struct {
+	e        exampleValue
+	follow   bool // emit referenced component schemas too
+	queue    []namedSchema
+	queued   map[string]bool
+	usesTime bool
+}
+
+func (g *typeGen) enqueue(name string, schema *yaml.Node, source string) {
+	if g.queued[name] {
+		return
+	}
+	g.queued[name] = true
+	g.queue = append(g.queue, namedSchema{name, schema, source})
+}
+
+// ref queues a referenced component schema when following refs.
+func (g *typeGen) ref(name string) {
+	if g.follow {
+		g.enqueue(name, mappingValue(mappingValue(mappingValue(g.e.root, "components"), "schemas"), name), "components.schemas."+name)
+	}
+}
+
+// goType is the Go type of a property schema; inline is the name an
+// inline object gets.
+func (g *typeGen) goType(schema *yaml.Node, inline, source string) string {
+	flat, ref := g.e.flatten(schema)
+	if ref != "" {
+		g.ref(ref)
+		return ref
+	}
+	switch schemaType(flat) {
+	case "array":
+		return "[]" + g.goType(mappingValue(flat, "items"), inline+"Item", source+".items")
+	case "object":
+		if mappingValue(flat, "properties") != nil {
+			g.enqueue(inline, flat, source)
+			return inline
+		}
+		if value := mappingValue(flat, "additionalProperties"); value != nil && value.Kind == yaml.MappingNode {
+			return "map[string]" + g.goType(value, inline+"Value", source+".additionalProperties")
+		}
+		return "map[string]any"
+	case "string":
+		if scalarAt(flat, "format") == "date-time" {
+			g.usesTime = true
+			return "time.Time"
+		}
+		return "string"
+	case "integer":
+		switch scalarAt(flat, "format") {
+		case "int64":
+			return "int64"
+		case "int32":
+			return "int32"
+		}
+		return "int"
+	case "number":
+		if scalarAt(flat, "format") == "float" {
+			return "float32"
+		}
+		return "float64"
+	case "boolean":
+		return "bool"
+	}
+	return "any"
+}
+
+// tsType is the TS type of a property schema.
+func (g *typeGen) tsType(schema *yaml.Node, inline, source string) string {
+	flat, ref := g.e.flatten(schema)
+	if ref != "" {
+		g.ref(ref)
+		return ref
+	}
+	if enum := mappingValue(flat, "enum"); enum != nil && len(enum.Content) > 0 {
+		var values []string
+		for _, v := range enum.Content {
+			if schemaType(flat) == "string" || schemaType(flat) == "" {
+				values = append(values, "'"+strings.ReplaceAll(v.Value, "'", `\'`)+"'")
+			} else {
+				values = append(values, v.Value)
+			}
+		}
+		return strings.Join(values, " | ")
+	}
+	switch schemaType(flat) {
+	case "array":
+		item := g.tsType(mappingValue(flat, "items"), inline+"Item", source+".items")
+		if strings.Contains(item, " ") {
+			item = "(" + item + ")"
+		}
+		return item + "[]"
+	case "object":
+		if mappingValue(flat, "properties") != nil {
+			g.enqueue(inline, flat, source)
+			return inline
+		}
+		if value := mappingValue(flat, "additionalProperties"); value != nil && value.Kind == yaml.MappingNode {
+			return "Record<string, " + g.tsType(value, inline+"Value", source+".additionalProperties") + ">"
+		}
+		return "Record<string, unknown>"
+	case "string":
+		return "string"
+	case "integer", "number":
+		return "number"
+	case "boolean":
+		return "boolean"
+	}
+	return "unknown"
+}
+
+// docLines splits a description into comment lines.
+func docLines(schema *yaml.Node) []string {
+	d := strings.TrimSpace(scalarAt(schema, "description"))
+	if d == "" {
+		return nil
+	}
+	return strings.Split(d, "\n")
+}
+
+// goDecl emits one Go type declaration.
+func (g *typeGen) goDecl(t namedSchema) string {
+	flat, _ := g.e.flatten(t.schema)
+	var b strings.Builder
+	b.WriteString(fmt.Sprintf("// %s mirrors %s.\n", t.name, t.source))
+	for _, line := range docLines(flat) {
+		b.WriteString("// " + line + "\n")
+	}
+	if schemaType(flat) != "object" || mappingValue(flat, "properties") == nil {
+		typ := g.goType(flat, t.name+"Value", t.source)
+		if enum := stringList(mappingValue(flat, "enum")); len(enum) > 0 {
+			b.WriteString(fmt.Sprintf("// One of: %s.\n", strings.Join(enum, ", ")))
+		}
+		b.WriteString(fmt.Sprintf("type %s %s\n", t.name, typ))
+		return b.String()
+	}
+	b.WriteString(fmt.Sprintf("type %s struct {\n", t.name))
+	for _, f := range schemaFields(flat) {
+		fieldFlat, _ := g.e.flatten(f.schema)
+		typ := g.goType(f.schema, t.name+goFieldName(f.name), t.source+".properties."+f.name)
+		tag := f.name
+		// Optional scalars and structs are pointers so zero values can be sent
+		if !f.required || scalarAt(fieldFlat, "nullable") == "true" {
+			if !strings.HasPrefix(typ, "[]") && !strings.HasPrefix(typ, "map[") && typ != "any" {
+				typ = "*" + typ
+			}
+			if !f.required {
+				tag += ",omitempty"
+			}
+		}
+		for _, line := range docLines(fieldFlat) {
+			b.WriteString("\t// " + line + "\n")
+		}
+		if enum := stringList(mappingValue(fieldFlat, "enum")); len(enum) > 0 {
+			b.WriteString(fmt.Sprintf("\t// One of: %s.\n", strings.Join(enum, ", ")))
+		}
+		b.WriteString(fmt.Sprintf("\t%s %s `json:%q`\n", goFieldName(f.name), typ, tag))
+	}
+	b.WriteString("}\n")
+	return b.String()
+}
+
+// tsDecl emits one TS interface (or type alias).
+func (g *typeGen) tsDecl(t namedSchema) string {
+	flat, _ := g.e.flatten(t.schema)
+	var b strings.Builder
+	if lines := docLines(flat); len(lines) > 0 {
+		b.WriteString("/** " + strings.Join(lines, "\n * ") + " */\n")
+	}
+	if schemaType(flat) != "object" || mappingValue(flat, "properties") == nil {
+		b.WriteString(fmt.Sprintf("export type %s = %s;\n", t.name, g.tsType(flat, t.name+"Value", t.source)))
+		return b.String()
+	}
+	b.WriteString(fmt.Sprintf("export interface %s {\n", t.name))
+	for _, f := range schemaFields(flat) {
+		fieldFlat, _ := g.e.flatten(f.schema)
+		if lines := docLines(fieldFlat); len(lines) > 0 {
+			b.WriteString("  /** " + strings.Join(lines, " ") + " */\n")
+		}
+		typ := g.tsType(f.schema, t.name+goFieldName(f.name), t.source+".properties."+f.name)
+		if scalarAt(fieldFlat, "nullable") == "true" {
+			typ += " | null"
+		}
+		optional := ""
+		if !f.required {
+			optional = "?"
+		}
+		key := f.name
+		if !identifierPattern.MatchString(key) || identifierPattern.FindString(key) != key {
+			key = "'" + key + "'"
+		}
+		b.WriteString(fmt.Sprintf("  %s%s: %s;\n", key, optional, typ))
+	}
+	b.WriteString("}\n")
+	return b.String()
+}
+
+// sdkType finds the type an SDK declares under name (any casing).
+func sdkType(repoPath string, keep func(string) bool, isGo bool, name string) (apiSymbol, bool) {
+	key := normalizeTerm(name)
+	for _, rel := range listSourceFiles(repoPath, keep) {
+		data, err := os.ReadFile(filepath.Join(repoPath, rel))
+		if err != nil {
+			continue
+		}
+		var symbols []apiSymbol
+		if isGo {
+			symbols = goSymbols(rel, data)
+		} else {
+			symbols = jsSymbols(rel, string(data))
+		}
+		for _, sym := range symbols {
+			if sym.kind == "type" && normalizeTerm(sym.name) == key {
+				return sym, true
+			}
+		}
+	}
+	return apiSymbol{}, false
+}
+
+func (s *QuickBasePersonalMCPServer) handleGenerateTypes(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	var params struct {
+		Schema string `json:"schema"`
+		Only   bool   `json:"only"`
+	}
+	argsData, _ := json.Marshal(request.Params.Arguments)
+	if err := json.Unmarshal(argsData, &params); err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Invalid parameters: %v", err)), nil
+	}
+	root, err := loadSpec()
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to load spec: %v", err)), nil
+	}
+	schemas := mappingValue(mappingValue(root, "components"), "schemas")
+	name := ""
+	for _, n := range mappingKeys(schemas) {
+		if normalizeTerm(n) == normalizeTerm(params.Schema) {
+			name = n
+		}
+	}
+	if name == "" {
+		return mcp.NewToolResultError(fmt.Sprintf("Unknown schema: %q (available: %s)", params.Schema, strings.Join(mappingKeys(schemas), ", "))), nil
+	}
+
+	// Go and TS each walk the schemas so both queue the same inline types
+	var goDecls, tsDecls []string
+	var emitted []namedSchema
+	g := &typeGen{e: exampleValue{root: root}, follow: !params.Only, queued: map[string]bool{}}
+	g.enqueue(name, mappingValue(schemas, name), "components.schemas."+name)
+	for i := 0; i < len(g.queue); i++ {
+		goDecls = append(goDecls, g.goDecl(g.queue[i]))
+		emitted = append(emitted, g.queue[i])
+	}
+	ts := &typeGen{e: g.e, follow: !params.Only, queued: map[string]bool{}}
+	ts.enqueue(name, mappingValue(schemas, name), "components.schemas."+name)
+	for i := 0; i < len(ts.queue); i++ {
+		tsDecls = append(tsDecls, ts.tsDecl(ts.queue[i]))
+	}
+
+	goSrc := strings.Join(goDecls, "\n")
+	if g.usesTime {
+		goSrc = "import \"time\"\n\n" + goSrc
+	}
+
+	var results strings.Builder
+	results.WriteString(fmt.Sprintf("# Types: %s\n\n", name))
+	if len(emitted) > 1 {
+		var names []string
+		for _, t := range emitted[1:] {
+			names = append(names, t.name)
+		}
+		results.WriteString(fmt.Sprintf("Also emitted (referenced or inline): %s\n\n", strings.Join(names, ", ")))
+	}
+	results.WriteString(fmt.Sprintf("## Go\n\n```go\n%s```\n\n", goSnippet(goSrc)))
+	results.WriteString(fmt.Sprintf("## TypeScript\n\n```typescript\n%s```\n\n", strings.Join(tsDecls, "\n")))
+
+	results.WriteString("## Compared with the SDKs\n\n")
+	results.WriteString("| Type | quickbase-js | quickbase-go |\n|---|---|---|\n")
+	for _, t := range emitted {
+		flat, _ := g.e.flatten(t.schema)
+		var props []string
+		for _, f := range schemaFields(flat) {
+			props = append(props, f.name)
+		}
+		cell := func(repoPath string, keep func(string) bool, isGo bool) string {
+			sym, ok := sdkType(repoPath, keep, isGo, t.name)
+			if !ok {
+				return "— not declared"
+			}
+			notes := []string{"`" + sym.file + "`"}
+			if len(props) > 0 {
+				if missing := diffNames(props, sym.fields); len(missing) > 0 {
+					notes = append(notes, "missing: "+strings.Join(missing, ", "))
+				}
+				if extra := diffNames(sym.fields, props); len(extra) > 0 {
+					notes = append(notes, "not in spec: "+strings.Join(extra, ", "))
+				}
+			}
+			return strings.Join(notes, "; ")
+		}
+		results.WriteString(fmt.Sprintf("| %s | %s | %s |\n", t.name, cell(quickbaseJSPath, isJSSource, false), cell(quickbaseGoPath, isGoSource, true)))
+	}
+
+	return mcp.NewToolResultText(results.String()), nil
+}
@@ -0,0 +1,305 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"gopkg.in/yaml.v3"
+)
+
+// usageConversion spots lines that convert between shapes: JSON
+// (un)marshaling and toX/fromX/mapX/convertX style helpers.
+var usageConversion = regexp.MustCompile(`(?i)json\.(Marshal|Unmarshal|NewDecoder|NewEncoder)|JSON\.(parse|stringify)|\b(to|from|map|convert|parse|encode|decode)[A-Z]\w*\s*\(`)
+
+// usageDeclaration spots lines that declare a type or a typed field.
+var usageDeclaration = regexp.MustCompile("^\\s*(export\\s+)?(type|interface|class)\\s|`json:\"")
+
+// usageHit is one line of an SDK that mentions a schema or field.
+type usageHit struct {
+	file string
+	line int
+	text string
+}
+
+// refersTo reports whether an expanded node contains the named component.
+func refersTo(n *yaml.Node, name string) bool {
+	if n == nil {
+		return false
+	}
+	if scalarAt(n, "x-ref") == name {
+		return true
+	}
+	for _, c := range n.Content {
+		if refersTo(c, name) {
+			return true
+		}
+	}
+	return false
+}
+
+// hasProperty reports whether an expanded schema has a property named
+// field at any depth.
+func hasProperty(n *yaml.Node, field string) bool {
+	if n == nil {
+		return false
+	}
+	if mappingValue(mappingValue(n, "properties"), field) != nil {
+		return true
+	}
+	for _, c := range n.Content {
+		if hasProperty(c, field) {
+			return true
+		}
+	}
+	return false
+}
+
+// schemaUsers lists the operations whose parameters, request body or
+// responses match (directly or through other schemas), with where.
+func schemaUsers(root *yaml.Node, match func(*yaml.Node) bool) []string {
+	var users []string
+	for _, op := range specOperations(root) {
+		var where []string
+		for _, p := range operationParameters(root, op) {
+			if match(p) {
+				where = append(where, "parameter "+scalarAt(p, "name"))
+			}
+		}
+		if match(expandRefs(root, mappingValue(op.Node, "requestBody"), nil)) {
+			where = append(where, "request")
+		}
+		responses := mappingValue(op.Node, "responses")
+		for _, status := range mappingKeys(responses) {
+			if match(expandRefs(root, mappingValue(responses, status), nil)) {
+				where = append(where, "response "+status)
+			}
+		}
+		if len(where) > 0 {
+			name := op.OperationID
+			if name == "" {
+				name = op.Method + " " + op.Path
+			}
+			users = append(users, fmt.Sprintf("%s (%s)", name, strings.Join(where, ", ")))
+		}
+	}
+	return users
+}
+
+// usagePatterns are the regexes that find a schema or field in each SDK.
+// Fields are only matched where they're used as a property (a key, an
+// access or a json tag), so common words like "from" don't match prose.
+func usagePatterns(schema, field string) (js, goPattern *regexp.Regexp) {
+	if field == "" {
+		word := `\b` + regexp.QuoteMeta(schema) + `\b`
+		return regexp.MustCompile(word), regexp.MustCompile(word)
+	}
+	f, gf := regexp.QuoteMeta(field), regexp.QuoteMeta(goFieldName(field))
+	js = regexp.MustCompile(`\.` + f + `\b|\b` + f + `\??\s*:|['"]` + f + `['"]`)
+	goPattern = regexp.MustCompile(`\.` + gf + `\b|\b` + gf + `\s*:|json:"` + f + `[,"]|"` + f + `"`)
+	return js, goPattern
+}
+
+// findUsages scans an SDK (tests included, build output not) for lines
+// matching pattern.
+func findUsages(repoPath string, isGo bool, pattern *regexp.Regexp) []usageHit {
+	var hits []usageHit
+	walkRepo(repoPath, func(rel string) error {
+		if isGo {
+			if !strings.HasSuffix(rel, ".go") {
+				return nil
+			}
+		} else {
+			switch filepath.Ext(rel) {
+			case ".ts", ".tsx", ".js", ".mjs", ".cjs":
+			default:
+				return nil
+			}
+			p := "/" + rel
+			if strings.HasSuffix(rel, ".d.ts") || strings.Contains(p, "/dist/") || strings.Contains(p, "/build/") {
+				return nil
+			}
+		}
+		data, err := os.ReadFile(filepath.Join(repoPath, rel))
+		if err != nil || !pattern.Match(data) {
+			return nil
+		}
+		for i, line := range strings.Split(string(data), "\n") {
+			if pattern.MatchString(line) {
+				hits = append(hits, usageHit{file: rel, line: i + 1, text: strings.TrimSpace(line)})
+			}
+		}
+		return nil
+	})
+	return hits
+}
+
+// writeUsages groups one SDK's hits by kind: declarations, conversions,
+// other references, tests and generated code.
+func writeUsages(results *strings.Builder, repoName, repoPath string, hits []usageHit, limit int) (files, testFiles int) {
+	generated := map[string]bool{}
+	groups := map[string][]usageHit{}
+	seenFiles, seenTests := map[string]bool{}, map[string]bool{}
+	for _, h := range hits {
+		if _, ok := generated[h.file]; !ok {
+			data, _ := os.ReadFile(filepath.Join(repoPath, h.file))
+			generated[h.file] = isGenerated(data)
+		}
+		kind := "References"
+		switch {
+		case isTestPath(h.file):
+			kind = "Tests"
+			seenTests[h.file] = true
+		case generated[h.file]:
+			kind = "Generated"
+		case usageDeclaration.MatchString(h.text):
+			kind = "Declarations"
+		case usageConversion.MatchString(h.text):
+			kind = "Conversions"
+		}
+		seenFiles[h.file] = true
+		groups[kind] = append(groups[kind], h)
+	}
+
+	results.WriteString(fmt.Sprintf("## %s (%d line(s) in %d file(s))\n\n", repoName, len(hits), len(seenFiles)))
+	if len(hits) == 0 {
+		results.WriteString("No usages found\n\n")
+		return 0, 0
+	}
+	for _, kind := range []string{"Declarations", "Conversions", "References", "Tests", "Generated"} {
+		list := groups[kind]
+		if len(list) == 0 {
+			continue
+		}
+		results.WriteString(fmt.Sprintf("### %s (%d)\n\n", kind, len(list)))
+		for i, h := range list {
+			if i == limit {
+				results.WriteString(fmt.Sprintf("- … %d more\n", len(list)-limit))
+				break
+			}
+			text := h.text
+			if len(text) > 120 {
+				text = text[:117] + "..."
+			}
+			results.WriteString(fmt.Sprintf("- %s:%d `%s`\n", h.file, h.line, strings.ReplaceAll(text, "`", "'")))
+		}
+		results.WriteString("\n")
+	}
+	return len(seenFiles), len(seenTests)
+}
+
+func (s *QuickBasePersonalMCPServer) handleSchemaUsage(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	var params struct {
+		Name  string `json:"name"`
+		Limit int    `json:"limit"`
+	}
+	argsData, _ := json.Marshal(request.Params.Arguments)
+	if err := json.Unmarshal(argsData, &params); err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Invalid parameters: %v", err)), nil
+	}
+	params.Name = strings.TrimSpace(params.Name)
+	if params.Name == "" {
+		return mcp.NewToolResultError("name is required: a schema (QueryRequest), a field (mergeFieldId) or both (QueryRequest.where)"), nil
+	}
+	if params.Limit <= 0 {
+		params.Limit = 15
+	}
+	root, err := loadSpec()
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to load spec: %v", err)), nil
+	}
+	schemas := mappingValue(mappingValue(root, "components"), "schemas")
+	findSchema := func(name string) string {
+		for _, n := range mappingKeys(schemas) {
+			if normalizeTerm(n) == normalizeTerm(name) {
+				return n
+			}
+		}
+		return ""
+	}
+
+	// Work out what was asked for: a schema, a field of one, or a bare field
+	schema, field := params.Name, ""
+	if before, after, ok := strings.Cut(params.Name, "."); ok {
+		schema, field = before, after
+	}
+	if found := findSchema(schema); found != "" {
+		schema = found
+	} else if field == "" {
+		schema, field = "", params.Name
+	} else {
+		return mcp.NewToolResultError(fmt.Sprintf("Unknown schema: %q (available: %s)", schema, strings.Join(mappingKeys(schemas), ", "))), nil
+	}
+
+	var results strings.Builder
+	var users, owners []string
+	switch {
+	case field == "":
+		results.WriteString(fmt.Sprintf("# Usage: %s\n\n", schema))
+		users = schemaUsers(root, func(n *yaml.Node) bool { return refersTo(n, schema) })
+		for _, other := range mappingKeys(schemas) {
+			if other != schema && refersTo(expandRefs(root, mappingValue(schemas, other), nil), schema) {
+				owners = append(owners, other)
+			}
+		}
+	default:
+		title := field
+		if schema != "" {
+			title = schema + "." + field
+			if !hasProperty(expandRefs(root, mappingValue(schemas, schema), nil), field) {
+				return mcp.NewToolResultError(fmt.Sprintf("%s has no property %q", schema, field)), nil
+			}
+		}
+		results.WriteString(fmt.Sprintf("# Usage: %s\n\n", title))
+		for _, name := range mappingKeys(schemas) {
+			if mappingValue(mappingValue(mappingValue(schemas, name), "properties"), field) != nil {
+				owners = append(owners, name)
+			}
+		}
+		if schema != "" {
+			users = schemaUsers(root, func(n *yaml.Node) bool { return refersTo(n, schema) })
+		} else {
+			users = schemaUsers(root, func(n *yaml.Node) bool { return hasProperty(n, field) || scalarAt(n, "name") == field })
+		}
+		if schema == "" && len(owners)+len(users) == 0 {
+			return mcp.NewToolResultError(fmt.Sprintf("No schema or property named %q in the spec", field)), nil
+		}
+	}
+
+	results.WriteString("## In the spec\n\n")
+	if len(users) == 0 {
+		results.WriteString("- No operation uses it\n")
+	} else {
+		results.WriteString(fmt.Sprintf("- Operations (%d): %s\n", len(users), strings.Join(users, "; ")))
+	}
+	if len(owners) > 0 {
+		label := "Referenced by schemas"
+		if field != "" {
+			label = "Schemas with this property"
+		}
+		results.WriteString(fmt.Sprintf("- %s: %s\n", label, strings.Join(owners, ", ")))
+	}
+	results.WriteString("\n")
+
+	jsPattern, goPattern := usagePatterns(schema, field)
+	jsFiles, jsTests := writeUsages(&results, "quickbase-js", quickbaseJSPath, findUsages(quickbaseJSPath, false, jsPattern), params.Limit)
+	goFiles, goTests := writeUsages(&results, "quickbase-go", quickbaseGoPath, findUsages(quickbaseGoPath, true, goPattern), params.Limit)
+
+	results.WriteString(fmt.Sprintf("**Blast radius:** %d operation(s); quickbase-js %d file(s), %d of them tests; quickbase-go %d file(s), %d of them tests.", len(users), jsFiles, jsTests, goFiles, goTests))
+	if field != "" {
+		names := []string{"`" + field + "`"}
+		if g := goFieldName(field); g != field {
+			names = append(names, "`"+g+"`")
+		}
+		sort.Strings(names)
+		results.WriteString(fmt.Sprintf(" Fields are matched as %s in property position (keys, accesses, json tags), so look over short names for false positives.", strings.Join(names, "/")))
+	}
+	results.WriteString("\n")
+	return mcp.NewToolResultText(results.String()), nil
+}
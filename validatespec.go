@@ -0,0 +1,351 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"gopkg.in/yaml.v3"
+)
+
+// specFinding is one problem validate_spec found, with where it is.
+type specFinding struct {
+	severity string // error or warning
+	line     int
+	where    string // JSON pointer-ish location, e.g. paths./records.post
+	message  string
+}
+
+// Fields OpenAPI 3.x allows in a path item and an operation. Anything else
+// (other than an x- extension) is usually a typo that codegen ignores.
+var (
+	pathItemFields  = []string{"$ref", "summary", "description", "servers", "parameters"}
+	operationFields = []string{"tags", "summary", "description", "externalDocs", "operationId", "parameters", "requestBody", "responses", "callbacks", "deprecated", "security", "servers"}
+	parameterIns    = []string{"query", "header", "path", "cookie"}
+)
+
+var (
+	pathTemplate   = regexp.MustCompile(`\{([^}]+)\}`)
+	responseStatus = regexp.MustCompile(`^([1-5][0-9][0-9]|[1-5]XX|default)$`)
+)
+
+// refTarget follows a local $ref exactly, or returns nil when any step of
+// the pointer is missing.
+func refTarget(root *yaml.Node, ref string) *yaml.Node {
+	target := root
+	for _, part := range strings.Split(strings.TrimPrefix(ref, "#/"), "/") {
+		part = strings.NewReplacer("~1", "/", "~0", "~").Replace(part)
+		if target != nil && target.Kind == yaml.SequenceNode {
+			i, err := strconv.Atoi(part)
+			if err != nil || i < 0 || i >= len(target.Content) {
+				return nil
+			}
+			target = target.Content[i]
+			continue
+		}
+		target = mappingValue(target, part)
+	}
+	return target
+}
+
+// specValidator collects findings while walking the document.
+type specValidator struct {
+	root     *yaml.Node
+	findings []specFinding
+}
+
+func (v *specValidator) add(severity string, n *yaml.Node, where, format string, args ...interface{}) {
+	line := 0
+	if n != nil {
+		line = n.Line
+	}
+	v.findings = append(v.findings, specFinding{severity, line, strings.TrimPrefix(where, "."), fmt.Sprintf(format, args...)})
+}
+
+// refs checks every $ref under n resolves, and records the components
+// they name so unused ones can be reported.
+func (v *specValidator) refs(n *yaml.Node, where string, used map[string]bool) {
+	if n == nil {
+		return
+	}
+	if n.Kind == yaml.MappingNode {
+		for j := 0; j+1 < len(n.Content); j += 2 {
+			key, val := n.Content[j], n.Content[j+1]
+			if key.Value != "$ref" || val.Kind != yaml.ScalarNode {
+				v.refs(val, where+"."+key.Value, used)
+				continue
+			}
+			switch {
+			case !strings.HasPrefix(val.Value, "#/"):
+				v.add("warning", val, where, "external $ref %s is not checked", val.Value)
+			case refTarget(v.root, val.Value) == nil:
+				v.add("error", val, where, "unresolved $ref %s", val.Value)
+			default:
+				used[val.Value] = true
+			}
+		}
+		return
+	}
+	for i, c := range n.Content {
+		v.refs(c, fmt.Sprintf("%s[%d]", where, i), used)
+	}
+}
+
+// unknownFields flags keys of n that aren't in allowed or an extension.
+func (v *specValidator) unknownFields(n *yaml.Node, where string, allowed []string) {
+	for _, key := range mappingKeys(n) {
+		if !strings.HasPrefix(key, "x-") && !containsString(allowed, key) {
+			v.add("warning", mappingValue(n, key), where, "unknown field %q (a typo is ignored by codegen)", key)
+		}
+	}
+}
+
+// parameters checks a parameter list and returns the names of its path
+// parameters.
+func (v *specValidator) parameters(list *yaml.Node, where string) []string {
+	if list == nil {
+		return nil
+	}
+	if list.Kind != yaml.SequenceNode {
+		v.add("error", list, where, "parameters must be a list")
+		return nil
+	}
+	var pathParams []string
+	seen := map[string]bool{}
+	for i, raw := range list.Content {
+		at := fmt.Sprintf("%s.parameters[%d]", where, i)
+		p, _ := resolveRef(v.root, raw)
+		if mappingValue(raw, "$ref") != nil && p == raw {
+			continue // reported as unresolved
+		}
+		name, in := scalarAt(p, "name"), scalarAt(p, "in")
+		if name == "" {
+			v.add("error", raw, at, "parameter has no name")
+		}
+		if !containsString(parameterIns, in) {
+			v.add("error", raw, at, "parameter %s has in %q (use %s)", name, in, strings.Join(parameterIns, ", "))
+		}
+		if seen[in+" "+name] {
+			v.add("error", raw, at, "duplicate %s parameter %s", in, name)
+		}
+		seen[in+" "+name] = true
+		if in == "path" {
+			pathParams = append(pathParams, name)
+			if scalarAt(p, "required") != "true" {
+				v.add("error", raw, at, "path parameter %s must be required: true", name)
+			}
+		}
+		if mappingValue(p, "schema") == nil && mappingValue(p, "content") == nil {
+			v.add("error", raw, at, "parameter %s has neither schema nor content", name)
+		}
+		// A shared parameter's description is checked once, in components
+		if scalarAt(p, "description") == "" && p == raw {
+			v.add("warning", raw, at, "parameter %s has no description", name)
+		}
+	}
+	return pathParams
+}
+
+// validateSpec runs the structural checks over a parsed document.
+func validateSpec(root *yaml.Node) []specFinding {
+	v := &specValidator{root: root}
+	if root.Kind != yaml.MappingNode {
+		v.add("error", root, "", "document is not a mapping")
+		return v.findings
+	}
+
+	version := mappingValue(root, "openapi")
+	switch {
+	case version == nil && mappingValue(root, "swagger") != nil:
+		v.add("error", mappingValue(root, "swagger"), "swagger", "Swagger 2.0 document; only OpenAPI 3.x is supported")
+	case version == nil:
+		v.add("error", root, "openapi", "missing openapi version")
+	case !strings.HasPrefix(version.Value, "3."):
+		v.add("error", version, "openapi", "openapi %s is not 3.x", version.Value)
+	}
+	info := mappingValue(root, "info")
+	if info == nil {
+		v.add("error", root, "info", "missing info")
+	} else {
+		for _, field := range []string{"title", "version"} {
+			if scalarAt(info, field) == "" {
+				v.add("error", info, "info", "info has no %s", field)
+			}
+		}
+	}
+	paths := mappingValue(root, "paths")
+	if paths == nil || paths.Kind != yaml.MappingNode {
+		v.add("error", root, "paths", "missing paths")
+	}
+
+	used := map[string]bool{}
+	v.refs(root, "", used)
+
+	operationIDs := map[string][]specOperation{}
+	for j := 0; paths != nil && j+1 < len(paths.Content); j += 2 {
+		key, item := paths.Content[j], paths.Content[j+1]
+		where := "paths." + key.Value
+		if !strings.HasPrefix(key.Value, "/") {
+			v.add("error", key, where, "path does not start with /")
+		}
+		v.unknownFields(item, where, append(append([]string{}, httpMethods...), pathItemFields...))
+		shared := v.parameters(mappingValue(item, "parameters"), where)
+		var templated []string
+		for _, m := range pathTemplate.FindAllStringSubmatch(key.Value, -1) {
+			templated = append(templated, m[1])
+		}
+
+		for _, method := range httpMethods {
+			op := mappingValue(item, method)
+			if op == nil {
+				continue
+			}
+			at := where + "." + method
+			v.unknownFields(op, at, operationFields)
+			if id := scalarAt(op, "operationId"); id == "" {
+				v.add("warning", op, at, "%s %s has no operationId (codegen will invent a method name)", strings.ToUpper(method), key.Value)
+			} else {
+				operationIDs[id] = append(operationIDs[id], specOperation{Method: strings.ToUpper(method), Path: key.Value, Node: mappingValue(op, "operationId")})
+			}
+			if scalarAt(op, "summary") == "" && scalarAt(op, "description") == "" {
+				v.add("warning", op, at, "no summary or description")
+			}
+
+			declared := append(v.parameters(mappingValue(op, "parameters"), at), shared...)
+			for _, name := range templated {
+				if !containsString(declared, name) {
+					v.add("error", op, at, "path template {%s} has no path parameter", name)
+				}
+			}
+			for _, name := range declared {
+				if !containsString(templated, name) {
+					v.add("error", op, at, "path parameter %s is not in the path", name)
+				}
+			}
+
+			responses := mappingValue(op, "responses")
+			if responses == nil || len(responses.Content) == 0 {
+				v.add("error", op, at, "no responses")
+				continue
+			}
+			for k := 0; k+1 < len(responses.Content); k += 2 {
+				status := responses.Content[k]
+				if !responseStatus.MatchString(status.Value) {
+					v.add("error", status, at+".responses", "invalid status %q", status.Value)
+				}
+				raw := responses.Content[k+1]
+				resp, _ := resolveRef(root, raw)
+				if mappingValue(raw, "$ref") != nil && resp == raw {
+					continue // reported as unresolved
+				}
+				if scalarAt(resp, "description") == "" {
+					v.add("error", status, at+".responses."+status.Value, "response has no description (required by OpenAPI)")
+				}
+			}
+		}
+	}
+	for _, id := range sortedKeys(operationIDs) {
+		ops := operationIDs[id]
+		if len(ops) < 2 {
+			continue
+		}
+		var places []string
+		for _, op := range ops {
+			places = append(places, fmt.Sprintf("%s %s (line %d)", op.Method, op.Path, op.Node.Line))
+		}
+		for _, op := range ops {
+			v.add("error", op.Node, fmt.Sprintf("paths.%s.%s", op.Path, strings.ToLower(op.Method)), "duplicate operationId %s: %s", id, strings.Join(places, ", "))
+		}
+	}
+
+	shared := mappingValue(mappingValue(root, "components"), "parameters")
+	for j := 0; shared != nil && j+1 < len(shared.Content); j += 2 {
+		name, p := shared.Content[j], shared.Content[j+1]
+		if mappingValue(p, "$ref") == nil && scalarAt(p, "description") == "" {
+			v.add("warning", name, "components.parameters."+name.Value, "parameter %s has no description", scalarAt(p, "name"))
+		}
+	}
+
+	schemas := mappingValue(mappingValue(root, "components"), "schemas")
+	for j := 0; schemas != nil && j+1 < len(schemas.Content); j += 2 {
+		name, schema := schemas.Content[j], schemas.Content[j+1]
+		where := "components.schemas." + name.Value
+		if mappingValue(schema, "$ref") == nil && scalarAt(schema, "description") == "" {
+			v.add("warning", name, where, "schema has no description")
+		}
+		if !used["#/components/schemas/"+name.Value] {
+			v.add("warning", name, where, "schema is never referenced")
+		}
+	}
+
+	sort.SliceStable(v.findings, func(a, b int) bool { return v.findings[a].line < v.findings[b].line })
+	return v.findings
+}
+
+func (s *QuickBasePersonalMCPServer) handleValidateSpec(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	var params struct {
+		Ref        string `json:"ref"`
+		ErrorsOnly bool   `json:"errors_only"`
+	}
+	argsData, _ := json.Marshal(request.Params.Arguments)
+	if err := json.Unmarshal(argsData, &params); err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Invalid parameters: %v", err)), nil
+	}
+	path, err := findSpecFile()
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to find spec: %v", err)), nil
+	}
+	file, _ := filepath.Rel(quickbaseSpecPath, path)
+	file = filepath.ToSlash(file)
+	label := file
+	if params.Ref != "" {
+		label = fmt.Sprintf("%s at %s", file, params.Ref)
+	}
+
+	var results strings.Builder
+	results.WriteString(fmt.Sprintf("# Spec validation: %s\n\n", label))
+	root, err := loadSpecAt(params.Ref)
+	if err != nil {
+		// A parse error is the finding; yaml's message carries the line
+		results.WriteString(fmt.Sprintf("❌ The spec does not parse, so nothing else was checked.\n\n- %v\n", err))
+		return mcp.NewToolResultText(results.String()), nil
+	}
+
+	findings := validateSpec(root)
+	var errs, warnings []string
+	for _, f := range findings {
+		line := fmt.Sprintf("- %s:%d", file, f.line)
+		if f.where != "" {
+			line += fmt.Sprintf(" `%s`", f.where)
+		}
+		line += " — " + f.message
+		if f.severity == "error" {
+			errs = append(errs, line)
+		} else {
+			warnings = append(warnings, line)
+		}
+	}
+
+	switch {
+	case len(errs) > 0:
+		results.WriteString(fmt.Sprintf("❌ %d error(s), %d warning(s)\n\n", len(errs), len(warnings)))
+	case len(warnings) > 0:
+		results.WriteString(fmt.Sprintf("⚠️ Valid, with %d warning(s)\n\n", len(warnings)))
+	default:
+		results.WriteString("✅ No problems found\n")
+	}
+	if len(errs) > 0 {
+		results.WriteString(fmt.Sprintf("## Errors (%d)\n\n%s\n\n", len(errs), strings.Join(errs, "\n")))
+	}
+	if len(warnings) > 0 && !params.ErrorsOnly {
+		results.WriteString(fmt.Sprintf("## Warnings (%d)\n\n%s\n\n", len(warnings), strings.Join(warnings, "\n")))
+	}
+
+	return mcp.NewToolResultText(results.String()), nil
+}
@@ -0,0 +1,213 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// gitVersion is where a repo's checkout stands relative to its tags.
+type gitVersion struct {
+	describe  string // git describe --tags --always --dirty
+	latestTag string
+	commit    string
+	branch    string
+	date      string
+	err       error
+}
+
+// readGitVersion describes the checkout in repoPath. A repo without tags
+// still gets a commit; err is only set when git fails outright.
+func readGitVersion(repoPath string) gitVersion {
+	var v gitVersion
+	v.commit, v.err = runGit(repoPath, "rev-parse", "--short", "HEAD")
+	if v.err != nil {
+		return v
+	}
+	v.describe, _ = runGit(repoPath, "describe", "--tags", "--always", "--dirty")
+	v.latestTag, _ = runGit(repoPath, "describe", "--tags", "--abbrev=0")
+	v.branch, _ = runGit(repoPath, "rev-parse", "--abbrev-ref", "HEAD")
+	v.date, _ = runGit(repoPath, "log", "-1", "--format=%cs")
+	return v
+}
+
+// submodule is one entry of `git submodule status`.
+type submodule struct {
+	path, commit, state string
+}
+
+// listSubmodules reads a repo's submodules and whether each is checked out
+// at the recorded commit.
+func listSubmodules(repoPath string) []submodule {
+	out, err := runGit(repoPath, "submodule", "status", "--recursive")
+	if err != nil || out == "" {
+		return nil
+	}
+	var subs []submodule
+	for _, line := range strings.Split(out, "\n") {
+		if len(line) < 2 {
+			continue
+		}
+		state := "checked out"
+		switch line[0] {
+		case '-':
+			state = "not initialized"
+		case '+':
+			state = "checked out at a different commit than recorded"
+		case 'U':
+			state = "merge conflict"
+		}
+		fields := strings.Fields(line[1:])
+		if len(fields) < 2 {
+			continue
+		}
+		subs = append(subs, submodule{path: fields[1], commit: fields[0], state: state})
+	}
+	return subs
+}
+
+var (
+	goModuleLine  = regexp.MustCompile(`(?m)^module\s+(\S+)`)
+	goVersionLine = regexp.MustCompile(`(?m)^go\s+(\S+)`)
+	goMajorSuffix = regexp.MustCompile(`/v(\d+)$`)
+	semverMajor   = regexp.MustCompile(`^v?(\d+)\.`)
+)
+
+// majorVersion is the major number of a semver tag, or -1.
+func majorVersion(tag string) int {
+	m := semverMajor.FindStringSubmatch(tag)
+	if m == nil {
+		return -1
+	}
+	n, _ := strconv.Atoi(m[1])
+	return n
+}
+
+// writeGitVersion adds the git lines shared by every repo section.
+func writeGitVersion(results *strings.Builder, v gitVersion) {
+	if v.err != nil {
+		results.WriteString(fmt.Sprintf("- git: unavailable (%v)\n", v.err))
+		return
+	}
+	results.WriteString(fmt.Sprintf("- git describe: `%s`\n", v.describe))
+	tag := v.latestTag
+	if tag == "" {
+		tag = "(none)"
+	}
+	results.WriteString(fmt.Sprintf("- Latest tag: %s\n", tag))
+	results.WriteString(fmt.Sprintf("- HEAD: %s on %s (%s)\n", v.commit, v.branch, v.date))
+}
+
+func (s *QuickBasePersonalMCPServer) handleVersionReport(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	var results strings.Builder
+	var warnings []string
+	results.WriteString("# Version Report\n\n")
+
+	// quickbase-js: package.json is the published version
+	jsGit := readGitVersion(quickbaseJSPath)
+	results.WriteString("## quickbase-js\n\n")
+	var pkg struct {
+		Name    string `json:"name"`
+		Version string `json:"version"`
+	}
+	if data, err := os.ReadFile(filepath.Join(quickbaseJSPath, "package.json")); err != nil {
+		results.WriteString(fmt.Sprintf("- package.json: unreadable (%v)\n", err))
+	} else if err := json.Unmarshal(data, &pkg); err != nil {
+		results.WriteString(fmt.Sprintf("- package.json: invalid (%v)\n", err))
+	} else {
+		results.WriteString(fmt.Sprintf("- package.json: %s@%s\n", pkg.Name, pkg.Version))
+		if jsGit.latestTag != "" && strings.TrimPrefix(jsGit.latestTag, "v") != pkg.Version {
+			warnings = append(warnings, fmt.Sprintf("quickbase-js: package.json says %s but the latest tag is %s", pkg.Version, jsGit.latestTag))
+		}
+	}
+	writeGitVersion(&results, jsGit)
+	results.WriteString("\n")
+
+	// quickbase-go: the tag is the version; go.mod must agree on the major
+	goGit := readGitVersion(quickbaseGoPath)
+	results.WriteString("## quickbase-go\n\n")
+	if data, err := os.ReadFile(filepath.Join(quickbaseGoPath, "go.mod")); err != nil {
+		results.WriteString(fmt.Sprintf("- go.mod: unreadable (%v)\n", err))
+	} else {
+		module, goVersion := "(none)", "(none)"
+		if m := goModuleLine.FindSubmatch(data); m != nil {
+			module = string(m[1])
+		}
+		if m := goVersionLine.FindSubmatch(data); m != nil {
+			goVersion = string(m[1])
+		}
+		results.WriteString(fmt.Sprintf("- Module: %s (go %s)\n", module, goVersion))
+		if major := majorVersion(goGit.latestTag); major >= 2 {
+			if m := goMajorSuffix.FindStringSubmatch(module); m == nil || m[1] != strconv.Itoa(major) {
+				warnings = append(warnings, fmt.Sprintf("quickbase-go: tag %s needs the module path to end in /v%d", goGit.latestTag, major))
+			}
+		} else if m := goMajorSuffix.FindStringSubmatch(module); m != nil && major >= 0 {
+			warnings = append(warnings, fmt.Sprintf("quickbase-go: module path ends in /v%s but the latest tag is %s", m[1], goGit.latestTag))
+		}
+	}
+	writeGitVersion(&results, goGit)
+	results.WriteString("\n")
+
+	// quickbase-spec: the document's info.version and the repo's commit
+	specGit := readGitVersion(quickbaseSpecPath)
+	results.WriteString("## quickbase-spec\n\n")
+	if root, err := loadSpec(); err != nil {
+		results.WriteString(fmt.Sprintf("- Spec: unreadable (%v)\n", err))
+	} else if info := mappingValue(root, "info"); info != nil {
+		if v := mappingValue(info, "version"); v != nil {
+			results.WriteString(fmt.Sprintf("- info.version: %s\n", v.Value))
+		}
+	}
+	writeGitVersion(&results, specGit)
+	results.WriteString("\n")
+
+	// Submodules in the SDKs, usually a pinned copy of the spec
+	specHead, _ := runGit(quickbaseSpecPath, "rev-parse", "HEAD")
+	var subLines []string
+	for _, repo := range []struct{ name, path string }{{"quickbase-js", quickbaseJSPath}, {"quickbase-go", quickbaseGoPath}} {
+		for _, sub := range listSubmodules(repo.path) {
+			line := fmt.Sprintf("- %s/%s: %s (%s)", repo.name, sub.path, shortSHA(sub.commit), sub.state)
+			if strings.Contains(strings.ToLower(sub.path), "spec") && specHead != "" {
+				switch {
+				case sub.commit == specHead:
+					line += ", same as quickbase-spec HEAD"
+				default:
+					if behind, err := runGit(quickbaseSpecPath, "rev-list", "--count", sub.commit+"..HEAD"); err == nil {
+						line += fmt.Sprintf(", %s commit(s) behind quickbase-spec HEAD", behind)
+						warnings = append(warnings, fmt.Sprintf("%s pins the spec at %s, %s commit(s) behind quickbase-spec", repo.name, shortSHA(sub.commit), behind))
+					} else {
+						line += ", not in the local quickbase-spec history"
+					}
+				}
+			}
+			subLines = append(subLines, line)
+		}
+	}
+	if len(subLines) > 0 {
+		results.WriteString("## Submodules\n\n" + strings.Join(subLines, "\n") + "\n\n")
+	}
+
+	if len(warnings) > 0 {
+		results.WriteString("## ⚠️ Warnings\n\n")
+		for _, w := range warnings {
+			results.WriteString("- " + w + "\n")
+		}
+	}
+
+	return mcp.NewToolResultText(results.String()), nil
+}
+
+// shortSHA abbreviates a full commit hash for display.
+func shortSHA(sha string) string {
+	if len(sha) > 7 {
+		return sha[:7]
+	}
+	return sha
+}
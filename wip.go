@@ -0,0 +1,203 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// ageLabel says roughly how long ago t was.
+func ageLabel(t time.Time) string {
+	d := time.Since(t)
+	switch {
+	case d < time.Hour:
+		return "just now"
+	case d < 24*time.Hour:
+		return fmt.Sprintf("%dh ago", int(d.Hours()))
+	case d < 14*24*time.Hour:
+		return fmt.Sprintf("%dd ago", int(d.Hours()/24))
+	case d < 60*24*time.Hour:
+		return fmt.Sprintf("%dw ago", int(d.Hours()/24/7))
+	}
+	return fmt.Sprintf("%dmo ago", int(d.Hours()/24/30))
+}
+
+// stashEntry is one git stash with the files it holds.
+type stashEntry struct {
+	ref, message string
+	created      time.Time
+	files        []string
+}
+
+// listStashes reads a repo's stashes, newest first. Files include the
+// untracked ones stashed with -u where git can show them.
+func listStashes(repoPath string) ([]stashEntry, error) {
+	out, err := runGit(repoPath, "stash", "list", "--format=%gd%x1f%ct%x1f%gs")
+	if err != nil || out == "" {
+		return nil, err
+	}
+	var stashes []stashEntry
+	for _, line := range strings.Split(out, "\n") {
+		fields := strings.Split(line, "\x1f")
+		if len(fields) != 3 {
+			continue
+		}
+		sec, _ := strconv.ParseInt(fields[1], 10, 64)
+		st := stashEntry{ref: fields[0], message: fields[2], created: time.Unix(sec, 0)}
+		files, err := runGit(repoPath, "stash", "show", "--name-only", "--include-untracked", st.ref)
+		if err != nil {
+			files, _ = runGit(repoPath, "stash", "show", "--name-only", st.ref)
+		}
+		if files != "" {
+			st.files = strings.Split(files, "\n")
+		}
+		stashes = append(stashes, st)
+	}
+	return stashes, nil
+}
+
+func (s *QuickBasePersonalMCPServer) handleListWIP(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	var params struct {
+		Repo  string `json:"repo"`
+		Query string `json:"query"`
+	}
+	argsData, _ := json.Marshal(request.Params.Arguments)
+	if err := json.Unmarshal(argsData, &params); err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Invalid parameters: %v", err)), nil
+	}
+	repos, err := gitRepos(params.Repo)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	// temp-token matches temp_token.go, TempToken and "temp token work"
+	query := normalizeTerm(strings.ReplaceAll(params.Query, " ", ""))
+	matches := func(texts ...string) bool {
+		if query == "" {
+			return true
+		}
+		for _, t := range texts {
+			if strings.Contains(normalizeTerm(strings.ReplaceAll(t, " ", "")), query) {
+				return true
+			}
+		}
+		return false
+	}
+
+	var results strings.Builder
+	results.WriteString("# Work in progress\n\n")
+	if params.Query != "" {
+		results.WriteString(fmt.Sprintf("Matching: %s\n\n", params.Query))
+	}
+	var summary, details strings.Builder
+	summary.WriteString("| Repo | Branch | Uncommitted | Last touched | Stashes | Oldest stash |\n|---|---|---|---|---|---|\n")
+	found := 0
+	for _, repo := range repos {
+		st, err := readRepoStatus(repo.path)
+		if err != nil {
+			summary.WriteString(fmt.Sprintf("| %s | ⚠️ %v | | | | |\n", repo.name, err))
+			continue
+		}
+		type dirtyFile struct {
+			path, state string
+			modified    time.Time
+		}
+		var dirty []dirtyFile
+		seen := map[string]bool{}
+		for _, group := range []struct {
+			state string
+			files []string
+		}{{"conflict", st.conflicts}, {"staged", st.staged}, {"modified", st.modified}, {"untracked", st.untracked}} {
+			for _, f := range group.files {
+				if !matches(f) {
+					continue
+				}
+				state := group.state
+				if seen[f] {
+					// Staged, then changed again
+					for i := range dirty {
+						if dirty[i].path == f {
+							dirty[i].state += " + " + state
+						}
+					}
+					continue
+				}
+				seen[f] = true
+				d := dirtyFile{path: f, state: state}
+				if info, err := os.Stat(filepath.Join(repo.path, f)); err == nil {
+					d.modified = info.ModTime()
+				}
+				dirty = append(dirty, d)
+			}
+		}
+		var stashes []stashEntry
+		all, err := listStashes(repo.path)
+		if err != nil {
+			details.WriteString(fmt.Sprintf("⚠️ %s: couldn't list stashes: %v\n\n", repo.name, err))
+		}
+		for _, stash := range all {
+			if matches(append([]string{stash.message}, stash.files...)...) {
+				stashes = append(stashes, stash)
+			}
+		}
+
+		latest, oldestStash := "—", "—"
+		var latestTime time.Time
+		for _, d := range dirty {
+			if d.modified.After(latestTime) {
+				latestTime = d.modified
+				latest = ageLabel(d.modified)
+			}
+		}
+		if len(stashes) > 0 {
+			oldestStash = ageLabel(stashes[len(stashes)-1].created)
+		}
+		summary.WriteString(fmt.Sprintf("| %s | %s | %d | %s | %d | %s |\n", repo.name, st.branch, len(dirty), latest, len(stashes), oldestStash))
+		if len(dirty) == 0 && len(stashes) == 0 {
+			continue
+		}
+		found++
+
+		details.WriteString(fmt.Sprintf("## %s\n\n", repo.name))
+		if len(dirty) > 0 {
+			details.WriteString(fmt.Sprintf("Uncommitted on %s:\n\n| File | State | Last modified |\n|---|---|---|\n", st.branch))
+			for _, d := range dirty {
+				when := "deleted"
+				if !d.modified.IsZero() {
+					when = fmt.Sprintf("%s (%s)", d.modified.Format("2006-01-02 15:04"), ageLabel(d.modified))
+				}
+				details.WriteString(fmt.Sprintf("| `%s` | %s | %s |\n", d.path, d.state, when))
+			}
+			details.WriteString("\n")
+		}
+		if len(stashes) > 0 {
+			details.WriteString("Stashes:\n\n")
+			for _, stash := range stashes {
+				details.WriteString(fmt.Sprintf("- **%s** %s (%s) — %s\n", stash.ref, stash.created.Format("2006-01-02"), ageLabel(stash.created), stash.message))
+				for _, f := range stash.files {
+					details.WriteString(fmt.Sprintf("  - %s\n", f))
+				}
+			}
+			details.WriteString("\n")
+		}
+	}
+	results.WriteString(summary.String())
+	results.WriteString("\n")
+	if found == 0 {
+		if params.Query != "" {
+			results.WriteString(fmt.Sprintf("No uncommitted files or stashes match %q\n", params.Query))
+		} else {
+			results.WriteString("No uncommitted work or stashes anywhere\n")
+		}
+		return mcp.NewToolResultText(results.String()), nil
+	}
+	results.WriteString(details.String())
+	results.WriteString("Look inside a stash with `git stash show -p <ref>` in the repo; git_diff shows the uncommitted changes.\n")
+	return mcp.NewToolResultText(results.String()), nil
+}